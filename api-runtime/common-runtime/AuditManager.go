@@ -0,0 +1,200 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Audit Manager — audit trail of mutating API calls, stored in Spider MetaDB.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"github.com/rs/xid"
+)
+
+// Audit Result
+const (
+	AuditSucceeded = "Succeeded"
+	AuditFailed    = "Failed"
+)
+
+const (
+	defaultAuditListLimit = 100
+	maxAuditListLimit     = 10000
+	redactedValue         = "********"
+)
+
+// AuditInfo represents an audit record of a mutating API call.
+type AuditInfo struct {
+	AuditID        string    `gorm:"primaryKey" json:"AuditID" example:"cs1h7ms2k3q4cvp0l6dg"`
+	Time           time.Time `gorm:"index" json:"Time" example:"2026-10-01T12:00:00Z"`
+	UserName       string    `gorm:"index" json:"UserName" example:"alice"` // attempted user name or "anonymous" for a rejected call
+	ClientIP       string    `json:"ClientIP" example:"10.0.0.10"`
+	Method         string    `json:"Method" example:"POST"`
+	Path           string    `json:"Path" example:"/spider/vm"`
+	ConnectionName string    `gorm:"index" json:"ConnectionName" example:"aws-connection"`
+	ResourceType   string    `gorm:"index" json:"ResourceType" example:"vm"`
+	NameId         string    `gorm:"index" json:"NameId" example:"vm-01"`
+	SystemId       string    `json:"SystemId" example:"i-0bc7123b7e5cbf79d"`
+	RequestBody    string    `gorm:"type:text" json:"RequestBody,omitempty"` // secrets are redacted
+	StatusCode     int       `json:"StatusCode" example:"200"`
+	Result         string    `json:"Result" example:"Succeeded" enums:"Succeeded,Failed"`
+	Error          string    `gorm:"type:text" json:"Error,omitempty"`
+	DurationMs     int64     `json:"DurationMs" example:"15320"`
+}
+
+func (AuditInfo) TableName() string {
+	return "audit_infos"
+}
+
+// AuditFilter represents the conditions of ListAudit, empty fields are not used.
+type AuditFilter struct {
+	StartTime      time.Time
+	EndTime        time.Time
+	UserName       string
+	ConnectionName string
+	ResourceType   string
+	NameId         string
+	Limit          int // 0: 100
+}
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	defer infostore.Close(db)
+	infostore.AutoMigrateSecurity(db, &AuditInfo{})
+}
+
+// InsertAudit stores the audit record.
+func InsertAudit(auditInfo *AuditInfo) error {
+	if auditInfo.AuditID == "" {
+		auditInfo.AuditID = xid.New().String()
+	}
+	if auditInfo.Time.IsZero() {
+		auditInfo.Time = time.Now()
+	}
+	if err := infostore.Insert(auditInfo); err != nil {
+		cblog.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ListAudit returns the audit records matched with the filter, newest first.
+func ListAudit(filter AuditFilter) ([]*AuditInfo, error) {
+	cblog.Info("call ListAudit()")
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditListLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxAuditListLimit {
+		err := fmt.Errorf("limit must be between 1 and %d", maxAuditListLimit)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer infostore.Close(db)
+
+	query := db.Model(&AuditInfo{})
+	if !filter.StartTime.IsZero() {
+		query = query.Where("time >= ?", filter.StartTime)
+	}
+	if !filter.EndTime.IsZero() {
+		query = query.Where("time <= ?", filter.EndTime)
+	}
+	if filter.UserName != "" {
+		query = query.Where("user_name = ?", filter.UserName)
+	}
+	if filter.ConnectionName != "" {
+		query = query.Where("connection_name = ?", filter.ConnectionName)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.NameId != "" {
+		query = query.Where("name_id = ?", filter.NameId)
+	}
+
+	var auditList []*AuditInfo
+	if err := query.Order("time desc").Limit(filter.Limit).Find(&auditList).Error; err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return auditList, nil
+}
+
+// sensitiveKeys are the lower-case substrings of JSON keys whose values are redacted.
+var sensitiveKeys = []string{
+	"password", "passwd", "secret", "token", "privatekey", "private_key",
+	"apikey", "api_key", "accesskey", "access_key", "userdata",
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactSecrets returns the JSON body with the secret values replaced.
+// All values of {"Key": ..., "Value": ...} lists are redacted for the credential resource.
+// A body which is not JSON is not returned.
+func RedactSecrets(resourceType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Sprintf("(non-JSON body, %d bytes)", len(body))
+	}
+	redacted, err := json.Marshal(redactValue(data, resourceType == "credential"))
+	if err != nil {
+		return ""
+	}
+	return string(redacted)
+}
+
+func redactValue(data interface{}, redactAllKeyValues bool) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		// KeyValue, ex) {"Key": "ClientSecret", "Value": "..."}
+		if key, ok := v["Key"].(string); ok {
+			if _, hasValue := v["Value"]; hasValue && (redactAllKeyValues || isSensitiveKey(key)) {
+				v["Value"] = redactedValue
+			}
+		}
+		for key, value := range v {
+			if key != "Key" && key != "Value" && isSensitiveKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(value, redactAllKeyValues)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value, redactAllKeyValues)
+		}
+		return v
+	}
+	return data
+}
@@ -50,8 +50,8 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&ClusterIIDInfo{})
-	db.AutoMigrate(&NodeGroupIIDInfo{})
+	infostore.AutoMigrate(db, &ClusterIIDInfo{})
+	infostore.AutoMigrate(db, &NodeGroupIIDInfo{})
 	infostore.Close(db)
 }
 
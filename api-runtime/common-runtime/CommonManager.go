@@ -12,8 +12,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
-	"time"
 
 	"encoding/json"
 
@@ -48,6 +46,8 @@ const (
 	RDBMS      string = string(cres.RDBMS)
 	PUBLICIP   string = string(cres.PUBLICIP)
 	NIC        string = string(cres.NIC)
+
+	DISKSNAPSHOT string = string(cres.DISKSNAPSHOT)
 )
 
 func RSTypeString(rsType string) string {
@@ -55,22 +55,24 @@ func RSTypeString(rsType string) string {
 }
 
 // definition of SPLock for each Resource Ops
-var vpcSPLock = splock.New()
-var sgSPLock = splock.New()
-var keySPLock = splock.New()
-var vmSPLock = splock.New()
-var nlbSPLock = splock.New()
-var diskSPLock = splock.New()
-var myImageSPLock = splock.New()
-var clusterSPLock = splock.New()
-var fsSPLock = splock.New()
-var rdbmsSPLock = splock.New()
-var publicipSPLock = splock.New()
-var nicSPLock = splock.New()
+// On a PostgreSQL MetaDB, the locks are shared by all Spider instances on the MetaDB.
+var vpcSPLock = splock.New("VPC")
+var sgSPLock = splock.New("SG")
+var keySPLock = splock.New("Key")
+var vmSPLock = splock.New("VM")
+var nlbSPLock = splock.New("NLB")
+var diskSPLock = splock.New("Disk")
+var myImageSPLock = splock.New("MyImage")
+var clusterSPLock = splock.New("Cluster")
+var fsSPLock = splock.New("FileSystem")
+var rdbmsSPLock = splock.New("RDBMS")
+var publicipSPLock = splock.New("PublicIP")
+var nicSPLock = splock.New("NIC")
+var diskSnapshotSPLock = splock.New("DiskSnapshot")
 
 // vpcSharedResourceSPLock protects VPC-level shared resources (e.g., GCP Service Networking Peering, Azure Private DNS Zone)
 // that are created/deleted per VPC but shared by multiple RDBMS instances.
-var vpcSharedResourceSPLock = splock.New()
+var vpcSharedResourceSPLock = splock.New("VPCSharedResource")
 
 // ====================================================================
 // Common column name and struct for GORM
@@ -172,6 +174,8 @@ func GetID_MGMT(thisMode string) string {
 	return "OFF"
 }
 
+// GetAllSPLockInfo returns the status of all SPLocks.
+// On a PostgreSQL MetaDB, the locks held by all Spider instances are reported.
 func GetAllSPLockInfo() []string {
 	var results []string
 
@@ -179,6 +183,16 @@ func GetAllSPLockInfo() []string {
 	results = append(results, sgSPLock.GetSPLockMapStatus("SG SPLock"))
 	results = append(results, keySPLock.GetSPLockMapStatus("Key SPLock"))
 	results = append(results, vmSPLock.GetSPLockMapStatus("VM SPLock"))
+	results = append(results, nlbSPLock.GetSPLockMapStatus("NLB SPLock"))
+	results = append(results, diskSPLock.GetSPLockMapStatus("Disk SPLock"))
+	results = append(results, myImageSPLock.GetSPLockMapStatus("MyImage SPLock"))
+	results = append(results, clusterSPLock.GetSPLockMapStatus("Cluster SPLock"))
+	results = append(results, fsSPLock.GetSPLockMapStatus("FileSystem SPLock"))
+	results = append(results, rdbmsSPLock.GetSPLockMapStatus("RDBMS SPLock"))
+	results = append(results, publicipSPLock.GetSPLockMapStatus("PublicIP SPLock"))
+	results = append(results, nicSPLock.GetSPLockMapStatus("NIC SPLock"))
+	results = append(results, diskSnapshotSPLock.GetSPLockMapStatus("DiskSnapshot SPLock"))
+	results = append(results, vpcSharedResourceSPLock.GetSPLockMapStatus("VPCSharedResource SPLock"))
 
 	return results
 }
@@ -339,6 +353,9 @@ func UnregisterResource(connectionName string, rsType string, nameId string) (bo
 	case RDBMS:
 		rdbmsSPLock.Lock(connectionName, nameId)
 		defer rdbmsSPLock.Unlock(connectionName, nameId)
+	case DISKSNAPSHOT:
+		diskSnapshotSPLock.Lock(connectionName, nameId)
+		defer diskSnapshotSPLock.Unlock(connectionName, nameId)
 	default:
 		return false, fmt.Errorf(rsType + " is not supported Resource!!")
 	}
@@ -756,6 +773,46 @@ func UnregisterResource(connectionName string, rsType string, nameId string) (bo
 			}
 		}
 
+	case DISKSNAPSHOT:
+		var iidInfoList []*DiskSnapshotIIDInfo
+		if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+			err = getAuthIIDInfoList(connectionName, &iidInfoList)
+			if err != nil {
+				cblog.Error(err)
+				return false, err
+			}
+			_, err := getAuthIIDInfo(&iidInfoList, nameId)
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					return false, fmt.Errorf("The %s '%s' does not exist!", RSTypeString(rsType), nameId)
+				} else {
+					cblog.Error(err)
+					return false, err
+				}
+			}
+			_, err = infostore.DeleteByCondition(&DiskSnapshotIIDInfo{}, NAME_ID_COLUMN, nameId)
+			if err != nil {
+				cblog.Error(err)
+				return false, err
+			}
+		} else {
+			err := infostore.ListByConditions(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+			if err != nil {
+				cblog.Error(err)
+				return false, err
+			}
+			if len(iidInfoList) <= 0 {
+				return false, fmt.Errorf("The %s '%s' does not exist!", RSTypeString(rsType), nameId)
+			}
+
+			_, err = infostore.DeleteByConditions(&DiskSnapshotIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+			if err != nil {
+				cblog.Error(err)
+				return false, err
+			}
+		}
+		return true, nil
+
 	default:
 		return false, fmt.Errorf(rsType + " is not supported Resource!!")
 	}
@@ -1885,6 +1942,8 @@ func GetCSPResourceName(connectionName string, rsType string, nameID string) (st
 type DestroyedInfo struct {
 	IsAllDestroyed bool                       `json:"IsAllDestroyed" validate:"required" example:"true"` // true: all destroyed, false: some remained
 	DestroyedList  []*DeletedResourceInfoList `json:"DeletedAllListByResourceType" validate:"required"`  // List of resources deleted by type
+	DryRun         bool                       `json:"DryRun,omitempty" example:"false"`                  // true: nothing is deleted, only the Plan is returned
+	Plan           *DestroyPlanInfo           `json:"Plan,omitempty"`                                    // Ordered deletion plan of the destroy
 }
 
 // DeletedResourceInfoList represents information about deleted resources by type
@@ -1901,156 +1960,6 @@ type RemainedErrorInfo struct {
 	ErrorMsg string `json:"ErrorMsg" validate:"required" example:"delete error"` // Error message for the failed resource
 }
 
-// Destroy all Resources in a Connection
-func Destroy(connectionName string) (DestroyedInfo, error) {
-	// check empty and trim user inputs
-	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
-	if err != nil {
-		cblog.Println(err)
-		return DestroyedInfo{}, err
-	}
-
-	var destroyedInfo DestroyedInfo
-	destroyedInfo.IsAllDestroyed = true
-
-	// Define resource type groups
-	resourceTypeGroups := [][]string{
-		{CLUSTER, MYIMAGE, NLB, RDBMS},
-		{VM},
-		{DISK},
-		{KEY, SG},
-		{VPC},
-	}
-
-	for _, resourceTypes := range resourceTypeGroups {
-		var wg sync.WaitGroup
-		var mu sync.Mutex
-		var groupErr error
-
-		for _, resourceType := range resourceTypes {
-			wg.Add(1)
-			go func(resourceType string) {
-				defer wg.Done()
-
-				var finalDeletedResourceInfoList DeletedResourceInfoList
-				finalDeletedResourceInfoList.ResourceType = resourceType
-
-				for retry := 0; retry < 10; retry++ {
-					deletedResourceInfoList, err := deleteAllResourcesInResType(connectionName, resourceType)
-					mu.Lock()
-					if err != nil {
-						cblog.Println(err)
-						groupErr = err
-						mu.Unlock()
-						return
-					}
-					if deletedResourceInfoList == nil {
-						mu.Unlock()
-						return
-					}
-
-					// Append the deleted resource info list
-					finalDeletedResourceInfoList.DeletedIIDList = append(finalDeletedResourceInfoList.DeletedIIDList, deletedResourceInfoList.DeletedIIDList...)
-					finalDeletedResourceInfoList.IsAllDeleted = deletedResourceInfoList.IsAllDeleted
-					if !deletedResourceInfoList.IsAllDeleted {
-						finalDeletedResourceInfoList.RemainedErrorInfoList = deletedResourceInfoList.RemainedErrorInfoList
-					}
-
-					if deletedResourceInfoList.IsAllDeleted {
-						destroyedInfo.DestroyedList = append(destroyedInfo.DestroyedList, &finalDeletedResourceInfoList)
-						mu.Unlock()
-						return
-					}
-					mu.Unlock()
-					time.Sleep(3 * time.Second)
-				}
-
-				mu.Lock()
-				destroyedInfo.IsAllDestroyed = false
-				destroyedInfo.DestroyedList = append(destroyedInfo.DestroyedList, &finalDeletedResourceInfoList)
-				mu.Unlock()
-			}(resourceType)
-		}
-
-		wg.Wait()
-
-		if groupErr != nil {
-			return DestroyedInfo{}, groupErr
-		}
-	}
-
-	return destroyedInfo, nil
-}
-
-// deletes all resources of a specific resource type in a connection
-func deleteAllResourcesInResType(connectionName string, rsType string) (*DeletedResourceInfoList, error) {
-
-	nameList, err := ListResourceName(connectionName, rsType)
-	if err != nil {
-		cblog.Error(err)
-		return nil, err
-	}
-
-	if len(nameList) <= 0 {
-		return nil, nil
-	}
-
-	deletedResourceInfoList := &DeletedResourceInfoList{
-		ResourceType: rsType,
-		IsAllDeleted: true,
-	}
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, nameId := range nameList {
-		wg.Add(1)
-		go func(nameId string) {
-			defer wg.Done()
-			var err error
-
-			switch rsType {
-			case VPC:
-				_, err = DeleteVPC(connectionName, VPC, nameId, "false")
-			case SG:
-				_, err = DeleteSecurity(connectionName, SG, nameId, "false")
-			case KEY:
-				_, err = DeleteKey(connectionName, KEY, nameId, "false")
-			case VM:
-				_, _, err = DeleteVM(connectionName, VM, nameId, "false")
-			case NLB:
-				_, err = DeleteNLB(connectionName, NLB, nameId, "false")
-			case DISK:
-				_, err = DeleteDisk(connectionName, DISK, nameId, "false")
-			case MYIMAGE:
-				_, err = DeleteMyImage(connectionName, MYIMAGE, nameId, "false")
-			case CLUSTER:
-				_, err = DeleteCluster(connectionName, CLUSTER, nameId, "false")
-			case RDBMS:
-				_, err = DeleteRDBMS(connectionName, RDBMS, nameId, "false")
-			default:
-				err = fmt.Errorf("%s is not supported Resource!!", rsType)
-			}
-
-			mu.Lock()
-			defer mu.Unlock()
-			if err != nil {
-				deletedResourceInfoList.IsAllDeleted = false
-				deletedResourceInfoList.RemainedErrorInfoList = append(deletedResourceInfoList.RemainedErrorInfoList, &RemainedErrorInfo{
-					Name:     nameId,
-					ErrorMsg: err.Error(),
-				})
-			} else {
-				deletedResourceInfoList.DeletedIIDList = append(deletedResourceInfoList.DeletedIIDList, &cres.IID{NameId: nameId})
-			}
-		}(nameId)
-	}
-
-	wg.Wait()
-
-	return deletedResourceInfoList, nil
-}
-
 // ListResourceName lists resource names by connectionName and rsType
 func ListResourceName(connectionName, rsType string) ([]string, error) {
 	var info interface{}
@@ -2084,6 +1993,21 @@ func ListResourceName(connectionName, rsType string) ([]string, error) {
 	case RDBMS:
 		v := RDBMSIIDInfo{}
 		info = &v
+	case FILESYSTEM:
+		v := FileSystemIIDInfo{}
+		info = &v
+	case PUBLICIP:
+		v := PublicIPIIDInfo{}
+		info = &v
+	case NIC:
+		v := NICIIDInfo{}
+		info = &v
+	case DISKSNAPSHOT:
+		v := DiskSnapshotIIDInfo{}
+		info = &v
+	case S3BUCKET:
+		v := S3BucketIIDInfo{}
+		info = &v
 	default:
 		return nil, fmt.Errorf("%s is not a supported Resource!!", rsType)
 	}
@@ -2300,6 +2224,32 @@ func getAuthIIDInfoList(connectionName string, iidInfoList interface{}) error {
 			return fmt.Errorf("failed to list from MetaDB: %v", err)
 		}
 
+		for _, tmp := range tmpIIDInfoList {
+			for _, iid := range iidList {
+				if iid.SystemId == getDriverSystemId(cres.IID{NameId: tmp.NameId, SystemId: tmp.SystemId}) {
+					*v = append(*v, tmp)
+				}
+			}
+		}
+	case *[]*DiskSnapshotIIDInfo:
+		tmpIIDInfoList := []*DiskSnapshotIIDInfo{}
+		handler, err := cldConn.CreateDiskSnapshotHandler()
+		if err != nil {
+			cblog.Error(err)
+			return fmt.Errorf("failed to create DiskSnapshot handler: %v", err)
+		}
+		// Fetch granted ID list from CSP
+		iidList, err := handler.ListIID()
+		if err != nil {
+			cblog.Error(err)
+			return fmt.Errorf("failed to list IIDs from CSP: %v", err)
+		}
+		err = infostore.List(&tmpIIDInfoList)
+		if err != nil {
+			cblog.Error(err)
+			return fmt.Errorf("failed to list from MetaDB: %v", err)
+		}
+
 		for _, tmp := range tmpIIDInfoList {
 			for _, iid := range iidList {
 				if iid.SystemId == getDriverSystemId(cres.IID{NameId: tmp.NameId, SystemId: tmp.SystemId}) {
@@ -2452,6 +2402,13 @@ func getAuthIIDInfo(iidInfoList interface{}, nameId string) (interface{}, error)
 			}
 		}
 		return nil, fmt.Errorf("RDBMS '%s' does not exist", nameId)
+	case *[]*DiskSnapshotIIDInfo:
+		for _, iidInfo := range *v {
+			if iidInfo.NameId == nameId {
+				return iidInfo, nil // Return matching DiskSnapshotIIDInfo
+			}
+		}
+		return nil, fmt.Errorf("DiskSnapshot '%s' does not exist", nameId)
 	default:
 		return nil, fmt.Errorf("unsupported type for iidInfoList")
 	}
@@ -2535,12 +2492,15 @@ func getAuthIIDInfoBySystemIdContain(iidInfoList interface{}, systemId string) (
 type CapabilityType string
 
 const (
-	PRICE_INFO         CapabilityType = "PriceInfo"
-	CLUSTER_HANDLER    CapabilityType = "ClusterHandler"
-	TAG_HANDLER        CapabilityType = "TagHandler"
-	QUOTA_INFO_HANDLER CapabilityType = "QuotaInfoHandler"
+	PRICE_INFO            CapabilityType = "PriceInfo"
+	CLUSTER_HANDLER       CapabilityType = "ClusterHandler"
+	TAG_HANDLER           CapabilityType = "TagHandler"
+	QUOTA_INFO_HANDLER    CapabilityType = "QuotaInfoHandler"
+	DISK_SNAPSHOT_HANDLER CapabilityType = "DiskSnapshotHandler"
 
 	ZONE_BASED_CONTROL CapabilityType = "Zone-based Control"
+	VM_USERDATA        CapabilityType = "VM UserData"
+	SPOT_VM            CapabilityType = "Spot VM"
 )
 
 // checkCapability checks if the given connection supports specified capability
@@ -2561,8 +2521,14 @@ func checkCapability(connectionName string, capability CapabilityType) error {
 		supported = drvCapabilityInfo.TagHandler
 	case QUOTA_INFO_HANDLER:
 		supported = drvCapabilityInfo.QuotaInfoHandler
+	case DISK_SNAPSHOT_HANDLER:
+		supported = drvCapabilityInfo.DiskSnapshotHandler
 	case ZONE_BASED_CONTROL:
 		supported = drvCapabilityInfo.ZoneBasedControl
+	case VM_USERDATA:
+		supported = drvCapabilityInfo.VM_USERDATA
+	case SPOT_VM:
+		supported = drvCapabilityInfo.SPOT_VM
 	default:
 		return fmt.Errorf("unknown capability type: %s", capability)
 	}
@@ -0,0 +1,462 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Dependency Graph Manager — nodes and edges of the resources managed by Spider in a connection.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// relations of the dependency edges
+const (
+	RelationContains = "contains" // From owns To, ex) VPC -> Subnet, Cluster -> NodeGroup
+	RelationHosts    = "hosts"    // To is placed in From, ex) Subnet -> VM
+	RelationUses     = "uses"     // From uses To, ex) VM -> SG, NLB -> VM
+)
+
+// DependencyGraphInfo represents the resources in a connection and their dependencies.
+// In all relations, To depends on From for "contains" and "hosts", and From depends on To for "uses".
+type DependencyGraphInfo struct {
+	ConnectionName string                `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	NodeList       []*DependencyNodeInfo `json:"NodeList" validate:"required"`
+	EdgeList       []*DependencyEdgeInfo `json:"EdgeList" validate:"required"`
+	WarningList    []string              `json:"WarningList,omitempty"` // Lookups which failed and references to unmanaged resources
+}
+
+// DependencyNodeInfo represents a resource in the dependency graph.
+type DependencyNodeInfo struct {
+	ID           string `json:"ID" validate:"required" example:"subnet/vpc-01/subnet-01"` // type/name, type/owner/name for Subnet and NodeGroup
+	ResourceType string `json:"ResourceType" validate:"required" example:"subnet"`
+	NameId       string `json:"NameId" validate:"required" example:"subnet-01"`
+	SystemId     string `json:"SystemId" validate:"required" example:"subnet-0a1b2c3d"`
+	OwnerName    string `json:"OwnerName,omitempty" example:"vpc-01"` // owner VPC of a Subnet, owner Cluster of a NodeGroup
+}
+
+// DependencyEdgeInfo represents a dependency between two resources.
+type DependencyEdgeInfo struct {
+	From     string `json:"From" validate:"required" example:"vpc/vpc-01"`
+	To       string `json:"To" validate:"required" example:"subnet/vpc-01/subnet-01"`
+	Relation string `json:"Relation" validate:"required" example:"contains"` // contains, hosts or uses
+}
+
+// GetDependentList returns the IDs of the nodes which depend on the node, ex) the VMs in a Subnet,
+// so that the node can not be deleted without orphaning them.
+func (graph *DependencyGraphInfo) GetDependentList(nodeID string) []string {
+	dependentList := []string{}
+	for _, edge := range graph.EdgeList {
+		if edge.Relation == RelationUses && edge.To == nodeID {
+			dependentList = append(dependentList, edge.From)
+		} else if edge.Relation != RelationUses && edge.From == nodeID {
+			dependentList = append(dependentList, edge.To)
+		}
+	}
+	sort.Strings(dependentList)
+	return dependentList
+}
+
+// ToDOT returns the graph in the Graphviz DOT language.
+func (graph *DependencyGraphInfo) ToDOT() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %q {\n", graph.ConnectionName)
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, node := range graph.NodeList {
+		fmt.Fprintf(&sb, "  %q [label=%q];\n", node.ID, node.ResourceType+"\n"+node.NameId)
+	}
+	for _, edge := range graph.EdgeList {
+		style := ""
+		if edge.Relation == RelationUses {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q%s];\n", edge.From, edge.To, edge.Relation, style)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dependencyNodeID returns the ID of a node. Subnets and NodeGroups are unique in their owners only.
+func dependencyNodeID(rsType string, ownerName string, nameId string) string {
+	if rsType == SUBNET || rsType == NODEGROUP {
+		return rsType + "/" + ownerName + "/" + nameId
+	}
+	return rsType + "/" + nameId
+}
+
+// dependencyIIDRow is a row of the IID stores.
+type dependencyIIDRow struct {
+	nameId    string
+	systemId  string
+	ownerName string // owner of the resource in the IID store
+}
+
+// listDependencyIIDRows lists the rows of an IID store in the connection.
+func listDependencyIIDRows[T any](connectionName string, toRow func(*T) dependencyIIDRow) ([]dependencyIIDRow, error) {
+	var iidInfoList []*T
+	if err := infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName); err != nil {
+		return nil, err
+	}
+	rowList := []dependencyIIDRow{}
+	for _, iidInfo := range iidInfoList {
+		rowList = append(rowList, toRow(iidInfo))
+	}
+	return rowList, nil
+}
+
+// dependencyGraphBuilder collects the nodes and the edges of a graph.
+type dependencyGraphBuilder struct {
+	mu       sync.Mutex
+	graph    *DependencyGraphInfo
+	nodeMap  map[string]*DependencyNodeInfo
+	edgeMap  map[string]bool
+	ownerMap map[string]string // ID of a node -> owner VPC name
+}
+
+func (builder *dependencyGraphBuilder) addWarning(format string, args ...interface{}) {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.graph.WarningList = append(builder.graph.WarningList, fmt.Sprintf(format, args...))
+}
+
+// addEdge adds an edge between the managed resources. An edge to an unmanaged resource is reported as a warning.
+func (builder *dependencyGraphBuilder) addEdge(from string, to string, relation string) {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+
+	for _, id := range []string{from, to} {
+		if _, ok := builder.nodeMap[id]; !ok {
+			builder.graph.WarningList = append(builder.graph.WarningList,
+				fmt.Sprintf("'%s' %s '%s', but '%s' is not managed by Spider", from, relation, to, id))
+			return
+		}
+	}
+	key := from + " " + relation + " " + to
+	if builder.edgeMap[key] {
+		return
+	}
+	builder.edgeMap[key] = true
+	builder.graph.EdgeList = append(builder.graph.EdgeList, &DependencyEdgeInfo{From: from, To: to, Relation: relation})
+}
+
+// GetDependencyGraph returns the dependency graph of the resources managed by Spider in a connection.
+// The nodes and the owner relations are read from the IID stores, and the other relations from the CSP.
+// A failed lookup of a resource type is reported in the WarningList, and its relations are missed.
+func GetDependencyGraph(connectionName string) (*DependencyGraphInfo, error) {
+	cblog.Info("call GetDependencyGraph()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	builder := &dependencyGraphBuilder{
+		graph: &DependencyGraphInfo{
+			ConnectionName: connectionName,
+			NodeList:       []*DependencyNodeInfo{},
+			EdgeList:       []*DependencyEdgeInfo{},
+		},
+		nodeMap:  map[string]*DependencyNodeInfo{},
+		edgeMap:  map[string]bool{},
+		ownerMap: map[string]string{},
+	}
+
+	// (1) nodes from the IID stores
+	nameRow := func(nameId, systemId string) dependencyIIDRow {
+		return dependencyIIDRow{nameId: nameId, systemId: systemId}
+	}
+	ownerRow := func(nameId, systemId, ownerName string) dependencyIIDRow {
+		return dependencyIIDRow{nameId: nameId, systemId: systemId, ownerName: ownerName}
+	}
+	nodeSourceList := []struct {
+		rsType string
+		list   func() ([]dependencyIIDRow, error)
+	}{
+		{VPC, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *VPCIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{SUBNET, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *SubnetIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerVPCName) })
+		}},
+		{SG, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *SGIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerVPCName) })
+		}},
+		{KEY, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *KeyIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{VM, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *VMIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{NLB, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *NLBIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerVPCName) })
+		}},
+		{DISK, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *DiskIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{MYIMAGE, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *MyImageIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{CLUSTER, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *ClusterIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerVPCName) })
+		}},
+		{NODEGROUP, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *NodeGroupIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerClusterName) })
+		}},
+		{RDBMS, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *RDBMSIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerVPCName) })
+		}},
+		{FILESYSTEM, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *FileSystemIIDInfo) dependencyIIDRow { return ownerRow(i.NameId, i.SystemId, i.OwnerVPCName) })
+		}},
+		{PUBLICIP, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *PublicIPIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{NIC, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *NICIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{S3BUCKET, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *S3BucketIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+		{DISKSNAPSHOT, func() ([]dependencyIIDRow, error) {
+			return listDependencyIIDRows(connectionName, func(i *DiskSnapshotIIDInfo) dependencyIIDRow { return nameRow(i.NameId, i.SystemId) })
+		}},
+	}
+
+	type ownerEdge struct {
+		from, to, relation string
+	}
+	var ownerEdgeList []ownerEdge
+	for _, nodeSource := range nodeSourceList {
+		rowList, err := nodeSource.list()
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		for _, row := range rowList {
+			id := dependencyNodeID(nodeSource.rsType, row.ownerName, row.nameId)
+			node := &DependencyNodeInfo{
+				ID:           id,
+				ResourceType: nodeSource.rsType,
+				NameId:       row.nameId,
+				SystemId:     makeUserIID(row.nameId, row.systemId).SystemId,
+			}
+			if nodeSource.rsType == SUBNET || nodeSource.rsType == NODEGROUP {
+				node.OwnerName = row.ownerName
+			}
+			builder.nodeMap[id] = node
+			builder.graph.NodeList = append(builder.graph.NodeList, node)
+
+			// owner relations in the IID stores
+			switch {
+			case row.ownerName == "":
+			case nodeSource.rsType == NODEGROUP:
+				ownerEdgeList = append(ownerEdgeList, ownerEdge{dependencyNodeID(CLUSTER, "", row.ownerName), id, RelationContains})
+			default:
+				builder.ownerMap[id] = row.ownerName
+				ownerEdgeList = append(ownerEdgeList, ownerEdge{dependencyNodeID(VPC, "", row.ownerName), id, RelationContains})
+			}
+		}
+	}
+	for _, edge := range ownerEdgeList {
+		builder.addEdge(edge.from, edge.to, edge.relation)
+	}
+
+	// (2) the other relations from the CSP, only for the resource types in the connection
+	var wg sync.WaitGroup
+	for _, relationSource := range dependencyRelationSourceList {
+		hasNode := false
+		for _, node := range builder.graph.NodeList {
+			if node.ResourceType == relationSource.rsType {
+				hasNode = true
+				break
+			}
+		}
+		if !hasNode {
+			continue
+		}
+
+		wg.Add(1)
+		go func(rsType string, addRelations func(string, *dependencyGraphBuilder) error) {
+			defer wg.Done()
+			if err := addRelations(connectionName, builder); err != nil {
+				builder.addWarning("failed to get the relations of %s: %v", rsType, err)
+			}
+		}(relationSource.rsType, relationSource.addRelations)
+	}
+	wg.Wait()
+
+	graph := builder.graph
+	sort.Slice(graph.NodeList, func(i, j int) bool { return graph.NodeList[i].ID < graph.NodeList[j].ID })
+	sort.Slice(graph.EdgeList, func(i, j int) bool {
+		if graph.EdgeList[i].From != graph.EdgeList[j].From {
+			return graph.EdgeList[i].From < graph.EdgeList[j].From
+		}
+		return graph.EdgeList[i].To < graph.EdgeList[j].To
+	})
+	sort.Strings(graph.WarningList)
+	return graph, nil
+}
+
+// subnetNodeID returns the ID of a Subnet node in the VPC.
+func subnetNodeID(vpcIID cres.IID, subnetIID cres.IID) string {
+	return dependencyNodeID(SUBNET, vpcIID.NameId, subnetIID.NameId)
+}
+
+// dependencyRelationSourceList reads the relations of each resource type from the CSP.
+var dependencyRelationSourceList = []struct {
+	rsType       string
+	addRelations func(connectionName string, builder *dependencyGraphBuilder) error
+}{
+	{VM, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListVM(connectionName, VM)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			id := dependencyNodeID(VM, "", info.IId.NameId)
+			builder.addEdge(subnetNodeID(info.VpcIID, info.SubnetIID), id, RelationHosts)
+			for _, sgIID := range info.SecurityGroupIIds {
+				builder.addEdge(id, dependencyNodeID(SG, "", sgIID.NameId), RelationUses)
+			}
+			if info.KeyPairIId.NameId != "" {
+				builder.addEdge(id, dependencyNodeID(KEY, "", info.KeyPairIId.NameId), RelationUses)
+			}
+			for _, diskIID := range info.DataDiskIIDs {
+				builder.addEdge(id, dependencyNodeID(DISK, "", diskIID.NameId), RelationUses)
+			}
+		}
+		return nil
+	}},
+	{DISK, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListDisk(connectionName, DISK)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			if info.OwnerVM.NameId != "" {
+				builder.addEdge(dependencyNodeID(VM, "", info.OwnerVM.NameId), dependencyNodeID(DISK, "", info.IId.NameId), RelationUses)
+			}
+		}
+		return nil
+	}},
+	{DISKSNAPSHOT, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListDiskSnapshot(connectionName, DISKSNAPSHOT)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			if info.SourceDisk.NameId != "" {
+				builder.addEdge(dependencyNodeID(DISKSNAPSHOT, "", info.IId.NameId), dependencyNodeID(DISK, "", info.SourceDisk.NameId), RelationUses)
+			}
+		}
+		return nil
+	}},
+	{NIC, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListNIC(connectionName, NIC)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			id := dependencyNodeID(NIC, "", info.IId.NameId)
+			builder.addEdge(subnetNodeID(info.VpcIID, info.SubnetIID), id, RelationHosts)
+			for _, sgIID := range info.SecurityGroupIIDs {
+				builder.addEdge(id, dependencyNodeID(SG, "", sgIID.NameId), RelationUses)
+			}
+			if info.OwnerVM.NameId != "" {
+				builder.addEdge(dependencyNodeID(VM, "", info.OwnerVM.NameId), id, RelationUses)
+			}
+		}
+		return nil
+	}},
+	{PUBLICIP, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListPublicIP(connectionName, PUBLICIP)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			id := dependencyNodeID(PUBLICIP, "", info.IId.NameId)
+			if info.OwnedVM.NameId != "" {
+				builder.addEdge(dependencyNodeID(VM, "", info.OwnedVM.NameId), id, RelationUses)
+			}
+			if info.OwnedNIC.NameId != "" {
+				builder.addEdge(dependencyNodeID(NIC, "", info.OwnedNIC.NameId), id, RelationUses)
+			}
+		}
+		return nil
+	}},
+	{NLB, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListNLB(connectionName, NLB)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			if info.VMGroup.VMs == nil {
+				continue
+			}
+			for _, vmIID := range *info.VMGroup.VMs {
+				builder.addEdge(dependencyNodeID(NLB, "", info.IId.NameId), dependencyNodeID(VM, "", vmIID.NameId), RelationUses)
+			}
+		}
+		return nil
+	}},
+	{CLUSTER, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListCluster(connectionName, CLUSTER, "")
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			id := dependencyNodeID(CLUSTER, "", info.IId.NameId)
+			for _, subnetIID := range info.Network.SubnetIIDs {
+				builder.addEdge(id, subnetNodeID(info.Network.VpcIID, subnetIID), RelationUses)
+			}
+			for _, sgIID := range info.Network.SecurityGroupIIDs {
+				builder.addEdge(id, dependencyNodeID(SG, "", sgIID.NameId), RelationUses)
+			}
+		}
+		return nil
+	}},
+	{RDBMS, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListRDBMS(connectionName, RDBMS)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			id := dependencyNodeID(RDBMS, "", info.IId.NameId)
+			vpcIID := cres.IID{NameId: builder.ownerMap[id]}
+			for _, subnetIID := range info.SubnetIIDs {
+				builder.addEdge(id, subnetNodeID(vpcIID, subnetIID), RelationUses)
+			}
+			for _, sgIID := range info.SecurityGroupIIDs {
+				builder.addEdge(id, dependencyNodeID(SG, "", sgIID.NameId), RelationUses)
+			}
+		}
+		return nil
+	}},
+	{FILESYSTEM, func(connectionName string, builder *dependencyGraphBuilder) error {
+		infoList, err := ListFileSystem(connectionName)
+		if err != nil {
+			return err
+		}
+		for _, info := range infoList {
+			id := dependencyNodeID(FILESYSTEM, "", info.IId.NameId)
+			vpcIID := cres.IID{NameId: builder.ownerMap[id]}
+			for _, subnetIID := range info.AccessSubnetList {
+				builder.addEdge(id, subnetNodeID(vpcIID, subnetIID), RelationUses)
+			}
+		}
+		return nil
+	}},
+}
@@ -0,0 +1,538 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Destroy Manager — ordered deletion plan and destroy of the resources in a connection.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// S3BUCKET is the resource type of the S3 buckets in the destroy plan.
+const S3BUCKET string = "s3bucket"
+
+// destroyResourceTypes are the resource types deleted by the destroy, in the order of the resources in a step.
+// Subnets and NodeGroups are deleted with their VPCs and Clusters.
+var destroyResourceTypes = []string{CLUSTER, MYIMAGE, NLB, RDBMS, FILESYSTEM, S3BUCKET, DISKSNAPSHOT, VM, DISK, PUBLICIP, NIC, KEY, SG, VPC}
+
+// destroyRetryCount and destroyRetryInterval are for the resources which are not deleted yet,
+// ex) a Disk which is still attached to a terminating VM.
+const destroyRetryCount = 10
+const destroyRetryInterval = 3 * time.Second
+
+// destroyWorkerCount is the max number of the CSP calls of a destroy at the same time.
+const destroyWorkerCount = 10
+
+// DestroyFilter selects the resources to destroy. Empty fields select all resources.
+type DestroyFilter struct {
+	NamePrefix string // prefix of the NameId, ex) "test-"
+	TagKey     string // key of a tag, ex) "env"
+	TagValue   string // value of the tag, empty: any value of the TagKey
+}
+
+// DestroyPlanInfo represents the ordered deletion plan of the resources in a connection.
+type DestroyPlanInfo struct {
+	ConnectionName string             `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	NamePrefix     string             `json:"NamePrefix,omitempty" example:"test-"`
+	TagKey         string             `json:"TagKey,omitempty" example:"env"`
+	TagValue       string             `json:"TagValue,omitempty" example:"dev"`
+	ResourceCount  int                `json:"ResourceCount" validate:"required" example:"5"` // Number of resources to delete
+	StepList       []*DestroyStepInfo `json:"StepList" validate:"required"`                  // Steps in the deletion order
+	BlockedList    []string           `json:"BlockedList,omitempty"`                         // Resources in the plan used by resources which are not in the plan, the destroy is refused
+	WarningList    []string           `json:"WarningList,omitempty"`                         // Lookups which failed, the dependencies can be missed
+}
+
+// DestroyStepInfo represents the resources deleted in parallel in a step of the destroy plan.
+type DestroyStepInfo struct {
+	Step         int                    `json:"Step" validate:"required" example:"1"`
+	ResourceList []*DestroyResourceInfo `json:"ResourceList" validate:"required"`
+}
+
+// DestroyResourceInfo represents a resource in the destroy plan.
+type DestroyResourceInfo struct {
+	ResourceType string   `json:"ResourceType" validate:"required" example:"vpc"`
+	NameId       string   `json:"NameId" validate:"required" example:"vpc-01"`
+	UsedBy       []string `json:"UsedBy,omitempty" example:"vm/vm-01"` // Resources using this resource, deleted in the earlier steps
+}
+
+// destroyResource is a resource of the connection with the resources which use it.
+type destroyResource struct {
+	rsType   string
+	nameId   string
+	selected bool
+	usedBy   map[string]*destroyResource // by the node ID of the dependency graph
+	step     int
+}
+
+// runDestroyWorkers calls work for the indexes [0, count) by destroyWorkerCount workers.
+func runDestroyWorkers(count int, work func(i int)) {
+	indexCh := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < destroyWorkerCount && w < count; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexCh {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexCh <- i
+	}
+	close(indexCh)
+	wg.Wait()
+}
+
+// GetDestroyPlan returns the ordered deletion plan of the resources selected by the filter, without deleting them.
+func GetDestroyPlan(connectionName string, filter DestroyFilter) (*DestroyPlanInfo, error) {
+	cblog.Info("call GetDestroyPlan()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	plan, err := buildDestroyPlan(connectionName, filter)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Destroy all Resources selected by the filter in a Connection, in the order of the destroy plan.
+// The destroy is refused if a selected resource is used by a resource which is not selected.
+func Destroy(connectionName string, filter DestroyFilter) (DestroyedInfo, error) {
+	cblog.Info("call Destroy()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Println(err)
+		return DestroyedInfo{}, err
+	}
+
+	plan, err := buildDestroyPlan(connectionName, filter)
+	if err != nil {
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+	if len(plan.BlockedList) > 0 {
+		err := fmt.Errorf("destroy is refused, %d resources are used by resources which are not selected: %s",
+			len(plan.BlockedList), strings.Join(plan.BlockedList, "; "))
+		cblog.Error(err)
+		return DestroyedInfo{Plan: plan}, err
+	}
+
+	var destroyedInfo DestroyedInfo
+	destroyedInfo.IsAllDestroyed = true
+	destroyedInfo.Plan = plan
+
+	deletedListByType := map[string]*DeletedResourceInfoList{}
+	for _, step := range plan.StepList {
+		// resources of a step are deleted in parallel, and the remained ones are retried
+		remainedList := step.ResourceList
+		errorMap := map[*DestroyResourceInfo]error{}
+		for retry := 0; retry < destroyRetryCount && len(remainedList) > 0; retry++ {
+			if retry > 0 {
+				time.Sleep(destroyRetryInterval)
+			}
+			var mu sync.Mutex
+			var failedList []*DestroyResourceInfo
+			runDestroyWorkers(len(remainedList), func(i int) {
+				resource := remainedList[i]
+				err := deleteDestroyResource(connectionName, resource.ResourceType, resource.NameId)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errorMap[resource] = err
+					failedList = append(failedList, resource)
+				} else {
+					delete(errorMap, resource)
+				}
+			})
+			remainedList = failedList
+		}
+
+		for _, resource := range step.ResourceList {
+			deletedList, ok := deletedListByType[resource.ResourceType]
+			if !ok {
+				deletedList = &DeletedResourceInfoList{ResourceType: resource.ResourceType, IsAllDeleted: true}
+				deletedListByType[resource.ResourceType] = deletedList
+				destroyedInfo.DestroyedList = append(destroyedInfo.DestroyedList, deletedList)
+			}
+			if err, failed := errorMap[resource]; failed {
+				deletedList.IsAllDeleted = false
+				destroyedInfo.IsAllDestroyed = false
+				deletedList.RemainedErrorInfoList = append(deletedList.RemainedErrorInfoList, &RemainedErrorInfo{
+					Name:     resource.NameId,
+					ErrorMsg: err.Error(),
+				})
+			} else {
+				deletedList.DeletedIIDList = append(deletedList.DeletedIIDList, &cres.IID{NameId: resource.NameId})
+			}
+		}
+	}
+
+	return destroyedInfo, nil
+}
+
+// deleteResourcesWithRetry deletes the resources of a resource type, and retries the remained ones.
+func deleteResourcesWithRetry(connectionName string, rsType string, nameList []string) *DeletedResourceInfoList {
+	finalDeletedResourceInfoList := &DeletedResourceInfoList{ResourceType: rsType}
+
+	for retry := 0; retry < destroyRetryCount; retry++ {
+		deletedResourceInfoList := deleteResourcesInResType(connectionName, rsType, nameList)
+
+		finalDeletedResourceInfoList.DeletedIIDList = append(finalDeletedResourceInfoList.DeletedIIDList, deletedResourceInfoList.DeletedIIDList...)
+		finalDeletedResourceInfoList.IsAllDeleted = deletedResourceInfoList.IsAllDeleted
+		finalDeletedResourceInfoList.RemainedErrorInfoList = deletedResourceInfoList.RemainedErrorInfoList
+		if deletedResourceInfoList.IsAllDeleted {
+			return finalDeletedResourceInfoList
+		}
+
+		nameList = nil
+		for _, remained := range deletedResourceInfoList.RemainedErrorInfoList {
+			nameList = append(nameList, remained.Name)
+		}
+		if retry < destroyRetryCount-1 {
+			time.Sleep(destroyRetryInterval)
+		}
+	}
+	return finalDeletedResourceInfoList
+}
+
+// deletes the resources of a specific resource type in a connection
+func deleteResourcesInResType(connectionName string, rsType string, nameList []string) *DeletedResourceInfoList {
+	deletedResourceInfoList := &DeletedResourceInfoList{
+		ResourceType: rsType,
+		IsAllDeleted: true,
+	}
+
+	var mu sync.Mutex
+	runDestroyWorkers(len(nameList), func(i int) {
+		nameId := nameList[i]
+		err := deleteDestroyResource(connectionName, rsType, nameId)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			deletedResourceInfoList.IsAllDeleted = false
+			deletedResourceInfoList.RemainedErrorInfoList = append(deletedResourceInfoList.RemainedErrorInfoList, &RemainedErrorInfo{
+				Name:     nameId,
+				ErrorMsg: err.Error(),
+			})
+		} else {
+			deletedResourceInfoList.DeletedIIDList = append(deletedResourceInfoList.DeletedIIDList, &cres.IID{NameId: nameId})
+		}
+	})
+
+	return deletedResourceInfoList
+}
+
+// deleteDestroyResource deletes a resource of the connection.
+func deleteDestroyResource(connectionName string, rsType string, nameId string) error {
+	var err error
+	switch rsType {
+	case VPC:
+		_, err = DeleteVPC(connectionName, VPC, nameId, "false")
+	case SG:
+		_, err = DeleteSecurity(connectionName, SG, nameId, "false")
+	case KEY:
+		_, err = DeleteKey(connectionName, KEY, nameId, "false")
+	case VM:
+		_, _, err = DeleteVM(connectionName, VM, nameId, "false")
+	case NLB:
+		_, err = DeleteNLB(connectionName, NLB, nameId, "false")
+	case DISK:
+		_, err = DeleteDisk(connectionName, DISK, nameId, "false")
+	case MYIMAGE:
+		_, err = DeleteMyImage(connectionName, MYIMAGE, nameId, "false")
+	case CLUSTER:
+		_, err = DeleteCluster(connectionName, CLUSTER, nameId, "false")
+	case RDBMS:
+		_, err = DeleteRDBMS(connectionName, RDBMS, nameId, "false")
+	case FILESYSTEM:
+		_, err = DeleteFileSystem(connectionName, nameId)
+	case PUBLICIP:
+		_, err = DeletePublicIP(connectionName, PUBLICIP, nameId, "false")
+	case NIC:
+		_, err = DeleteNIC(connectionName, NIC, nameId, "false")
+	case S3BUCKET:
+		_, err = DeleteS3Bucket(connectionName, nameId, "false")
+	case DISKSNAPSHOT:
+		_, err = DeleteDiskSnapshot(connectionName, DISKSNAPSHOT, nameId, "false")
+	default:
+		err = fmt.Errorf("%s is not supported Resource!!", rsType)
+	}
+	return err
+}
+
+// destroyNodeID returns the node ID of the resource deleted with the node of the dependency graph,
+// a Subnet is deleted with its VPC, and a NodeGroup with its Cluster.
+func destroyNodeID(node *DependencyNodeInfo) string {
+	switch node.ResourceType {
+	case SUBNET:
+		return dependencyNodeID(VPC, "", node.OwnerName)
+	case NODEGROUP:
+		return dependencyNodeID(CLUSTER, "", node.OwnerName)
+	}
+	return node.ID
+}
+
+// buildDestroyPlan builds the deletion plan from the dependency graph of the connection.
+// A resource is planned in a step after all resources which use it. A selected resource used by
+// a resource which is not selected is reported in the BlockedList, and the destroy is refused.
+func buildDestroyPlan(connectionName string, filter DestroyFilter) (*DestroyPlanInfo, error) {
+	filter.NamePrefix = strings.TrimSpace(filter.NamePrefix)
+	filter.TagKey = strings.TrimSpace(filter.TagKey)
+	filter.TagValue = strings.TrimSpace(filter.TagValue)
+	if filter.TagKey == "" && filter.TagValue != "" {
+		return nil, fmt.Errorf("TagValue is given without TagKey")
+	}
+
+	plan := &DestroyPlanInfo{
+		ConnectionName: connectionName,
+		NamePrefix:     filter.NamePrefix,
+		TagKey:         filter.TagKey,
+		TagValue:       filter.TagValue,
+		StepList:       []*DestroyStepInfo{},
+	}
+
+	graph, err := GetDependencyGraph(connectionName)
+	if err != nil {
+		return nil, err
+	}
+	plan.WarningList = append(plan.WarningList, graph.WarningList...)
+
+	typeOrder := map[string]int{}
+	for i, rsType := range destroyResourceTypes {
+		typeOrder[rsType] = i
+	}
+	resourceMap := map[string]*destroyResource{} // by the node ID
+	destroyNodeIDMap := map[string]string{}      // node ID -> node ID of the resource deleted with it
+	var resourceList []*destroyResource
+	for _, node := range graph.NodeList {
+		destroyNodeIDMap[node.ID] = destroyNodeID(node)
+		if _, ok := typeOrder[node.ResourceType]; !ok {
+			continue
+		}
+		resource := &destroyResource{rsType: node.ResourceType, nameId: node.NameId, usedBy: map[string]*destroyResource{}}
+		resource.selected = strings.HasPrefix(node.NameId, filter.NamePrefix)
+		resourceMap[node.ID] = resource
+		resourceList = append(resourceList, resource)
+	}
+
+	// tags of the selected resources from the CSP
+	if filter.TagKey != "" {
+		var mu sync.Mutex
+		runDestroyWorkers(len(resourceList), func(i int) {
+			resource := resourceList[i]
+			if !resource.selected {
+				return
+			}
+			if resource.rsType == S3BUCKET {
+				resource.selected = false
+				return
+			}
+			tagList, err := getDestroyResourceTags(connectionName, resource.rsType, resource.nameId)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				resource.selected = false
+				plan.WarningList = append(plan.WarningList, fmt.Sprintf("%s '%s' is not in the plan, failed to get its tags: %v", resource.rsType, resource.nameId, err))
+				return
+			}
+			resource.selected = hasDestroyTag(tagList, filter.TagKey, filter.TagValue)
+		})
+		bucketCount := 0
+		for _, resource := range resourceList {
+			if resource.rsType == S3BUCKET && strings.HasPrefix(resource.nameId, filter.NamePrefix) {
+				bucketCount++
+			}
+		}
+		if bucketCount > 0 {
+			plan.WarningList = append(plan.WarningList, fmt.Sprintf("%d S3 buckets are not in the plan, the tag filter does not support S3 buckets", bucketCount))
+		}
+	}
+
+	// resources which use each resource
+	for _, edge := range graph.EdgeList {
+		user, used := edge.From, edge.To
+		if edge.Relation != RelationUses {
+			user, used = edge.To, edge.From
+		}
+		userResource, usedResource := resourceMap[destroyNodeIDMap[user]], resourceMap[destroyNodeIDMap[used]]
+		if userResource == nil || usedResource == nil || userResource == usedResource {
+			continue
+		}
+		usedResource.usedBy[destroyNodeIDMap[user]] = userResource
+	}
+
+	// steps of the selected resources, a resource is deleted after the resources which use it
+	var stepOf func(resource *destroyResource, visiting map[*destroyResource]bool) int
+	stepOf = func(resource *destroyResource, visiting map[*destroyResource]bool) int {
+		if resource.step > 0 {
+			return resource.step
+		}
+		if visiting[resource] {
+			plan.WarningList = append(plan.WarningList, fmt.Sprintf("%s '%s' is in a dependency cycle", resource.rsType, resource.nameId))
+			return 0
+		}
+		visiting[resource] = true
+		step := 1
+		for _, userResource := range resource.usedBy {
+			if userResource.selected {
+				if userStep := stepOf(userResource, visiting) + 1; userStep > step {
+					step = userStep
+				}
+			}
+		}
+		delete(visiting, resource)
+		resource.step = step
+		return step
+	}
+
+	sort.Slice(resourceList, func(i, j int) bool {
+		if resourceList[i].rsType != resourceList[j].rsType {
+			return typeOrder[resourceList[i].rsType] < typeOrder[resourceList[j].rsType]
+		}
+		return resourceList[i].nameId < resourceList[j].nameId
+	})
+	for _, resource := range resourceList {
+		if !resource.selected {
+			continue
+		}
+		step := stepOf(resource, map[*destroyResource]bool{})
+		for len(plan.StepList) < step {
+			plan.StepList = append(plan.StepList, &DestroyStepInfo{Step: len(plan.StepList) + 1})
+		}
+
+		planResource := &DestroyResourceInfo{ResourceType: resource.rsType, NameId: resource.nameId}
+		for id, userResource := range resource.usedBy {
+			planResource.UsedBy = append(planResource.UsedBy, id)
+			if !userResource.selected {
+				plan.BlockedList = append(plan.BlockedList, fmt.Sprintf("%s '%s' is used by %s '%s', which is not in the plan",
+					resource.rsType, resource.nameId, userResource.rsType, userResource.nameId))
+			}
+		}
+		sort.Strings(planResource.UsedBy)
+		plan.StepList[step-1].ResourceList = append(plan.StepList[step-1].ResourceList, planResource)
+		plan.ResourceCount++
+	}
+	sort.Strings(plan.BlockedList)
+	sort.Strings(plan.WarningList)
+
+	return plan, nil
+}
+
+// hasDestroyTag returns true if the tagList has the tag of the key, and of the value if the value is not empty.
+func hasDestroyTag(tagList []cres.KeyValue, key string, value string) bool {
+	for _, tag := range tagList {
+		if tag.Key == key && (value == "" || tag.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// getDestroyResourceTags returns the tags of the resource from the CSP.
+func getDestroyResourceTags(connectionName string, rsType string, nameId string) ([]cres.KeyValue, error) {
+	switch rsType {
+	case VM:
+		info, err := GetVM(connectionName, VM, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case NIC:
+		info, err := GetNIC(connectionName, NIC, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case PUBLICIP:
+		info, err := GetPublicIP(connectionName, PUBLICIP, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case VPC:
+		info, err := GetVPC(connectionName, VPC, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case SG:
+		info, err := GetSecurity(connectionName, SG, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case KEY:
+		info, err := GetKey(connectionName, KEY, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case NLB:
+		info, err := GetNLB(connectionName, NLB, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case DISK:
+		info, err := GetDisk(connectionName, DISK, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case MYIMAGE:
+		info, err := GetMyImage(connectionName, MYIMAGE, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case CLUSTER:
+		info, err := GetCluster(connectionName, CLUSTER, nameId, "")
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case RDBMS:
+		info, err := GetRDBMS(connectionName, RDBMS, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case FILESYSTEM:
+		info, err := GetFileSystem(connectionName, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	case DISKSNAPSHOT:
+		info, err := GetDiskSnapshot(connectionName, DISKSNAPSHOT, nameId)
+		if err != nil {
+			return nil, err
+		}
+		return info.TagList, nil
+	}
+	return nil, fmt.Errorf("%s does not support tags", rsType)
+}
@@ -36,7 +36,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&DiskIIDInfo{})
+	infostore.AutoMigrate(db, &DiskIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -0,0 +1,679 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+type DiskSnapshotIIDInfo FirstIIDInfo
+
+func (DiskSnapshotIIDInfo) TableName() string {
+	return "disk_snapshot_iid_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	infostore.AutoMigrate(db, &DiskSnapshotIIDInfo{})
+	infostore.Close(db)
+}
+
+//================ DiskSnapshot Handler
+
+// UserIID{UserID, CSP-ID} => SpiderIID{UserID, SP-XID:CSP-ID}
+// (1) check existence(UserID)
+// (2) get resource info(CSP-ID)
+// (3) create spiderIID: {UserID, SP-XID:CSP-ID}
+// (4) insert spiderIID
+func RegisterDiskSnapshot(connectionName string, userIID cres.IID) (*cres.DiskSnapshotInfo, error) {
+	cblog.Info("call RegisterDiskSnapshot()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := checkCapability(connectionName, DISK_SNAPSHOT_HANDLER); err != nil {
+		return nil, err
+	}
+
+	emptyPermissionList := []string{}
+	err = ValidateStruct(userIID, emptyPermissionList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	rsType := DISKSNAPSHOT
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDiskSnapshotHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	diskSnapshotSPLock.Lock(connectionName, userIID.NameId)
+	defer diskSnapshotSPLock.Unlock(connectionName, userIID.NameId)
+
+	// (1) check existence(UserID)
+	bool_ret := false
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		bool_ret, err = infostore.HasByCondition(&DiskSnapshotIIDInfo{}, NAME_ID_COLUMN, userIID.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		bool_ret, err = infostore.HasByConditions(&DiskSnapshotIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, userIID.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+	if bool_ret {
+		err := fmt.Errorf("%s '%s' already exists in connection '%s'", RSTypeString(rsType), userIID.NameId, connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get resource info(CSP-ID)
+	getInfo, err := handler.GetSnapshot(cres.IID{NameId: getMSShortID(userIID.SystemId), SystemId: userIID.SystemId})
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) create spiderIID: {UserID, SP-XID:CSP-ID}
+	systemId := getMSShortID(getInfo.IId.SystemId)
+	spiderIId := cres.IID{NameId: userIID.NameId, SystemId: systemId + ":" + getInfo.IId.SystemId}
+
+	// (4) insert spiderIID
+	err = infostore.Insert(&DiskSnapshotIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	getInfo.IId = userIID
+	setSourceDiskUserIID(connectionName, &getInfo)
+	return &getInfo, nil
+}
+
+// (1) check exist(NameID)
+// (2) get the source Disk's IID
+// (3) generate SP-XID and create reqIID, driverIID
+// (4) create Resource
+// (5) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+// (6) insert spiderIID
+// (7) create userIID
+func CreateDiskSnapshot(connectionName string, rsType string, reqInfo cres.DiskSnapshotInfo, IDTransformMode string) (*cres.DiskSnapshotInfo, error) {
+	cblog.Info("call CreateDiskSnapshot()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := checkCapability(connectionName, DISK_SNAPSHOT_HANDLER); err != nil {
+		return nil, err
+	}
+
+	if reqInfo.IId.NameId == "" {
+		err := fmt.Errorf("Disk Snapshot Name is empty!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sourceDiskName, err := EmptyCheckAndTrim("SourceDisk", reqInfo.SourceDisk.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDiskSnapshotHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	diskSnapshotSPLock.Lock(connectionName, reqInfo.IId.NameId)
+	defer diskSnapshotSPLock.Unlock(connectionName, reqInfo.IId.NameId)
+
+	// (1) check exist(NameID)
+	bool_ret := false
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		bool_ret, err = infostore.HasByCondition(&DiskSnapshotIIDInfo{}, NAME_ID_COLUMN, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		bool_ret, err = infostore.HasByConditions(&DiskSnapshotIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+	if bool_ret {
+		err := fmt.Errorf("%s '%s' already exists in connection '%s'", RSTypeString(DISKSNAPSHOT), reqInfo.IId.NameId, connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get the source Disk's IID
+	diskSPLock.RLock(connectionName, sourceDiskName)
+	defer diskSPLock.RUnlock(connectionName, sourceDiskName)
+
+	var diskIIDInfo DiskIIDInfo
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		var iidInfoList []*DiskIIDInfo
+		err = getAuthIIDInfoList(connectionName, &iidInfoList)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		castedIIDInfo, err := getAuthIIDInfo(&iidInfoList, sourceDiskName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		diskIIDInfo = *castedIIDInfo.(*DiskIIDInfo)
+	} else {
+		err = infostore.GetByConditions(&diskIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, sourceDiskName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+	reqInfo.SourceDisk = getDriverIID(cres.IID{NameId: diskIIDInfo.NameId, SystemId: diskIIDInfo.SystemId})
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" {
+		// (3) generate SP-XID
+		spUUID, err = iidm.New(connectionName, rsType, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		spUUID = reqInfo.IId.NameId
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: reqInfo.IId.NameId, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+	reqInfo.IId = driverIId
+
+	// (4) create Resource
+	info, err := handler.CreateSnapshot(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (5) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+
+	// (6) insert spiderIID
+	err = infostore.Insert(&DiskSnapshotIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		_, err2 := handler.DeleteSnapshot(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	// (7) create userIID: {reqNameID, driverSystemID}
+	info.IId = getUserIID(cres.IID{NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+	info.SourceDisk = getUserIID(cres.IID{NameId: diskIIDInfo.NameId, SystemId: diskIIDInfo.SystemId})
+
+	return &info, nil
+}
+
+// setSourceDiskUserIID sets the NameId of the source Disk with the Disk's SystemId.
+// The SystemId is used as the NameId if the Disk is not registered in Spider.
+func setSourceDiskUserIID(connectionName string, info *cres.DiskSnapshotInfo) {
+	if info.SourceDisk.SystemId == "" {
+		return
+	}
+	var diskIIDInfo DiskIIDInfo
+	err := infostore.GetByContain(&diskIIDInfo, CONNECTION_NAME_COLUMN, connectionName, SYSTEM_ID_COLUMN, info.SourceDisk.SystemId)
+	if err != nil {
+		info.SourceDisk.NameId = info.SourceDisk.SystemId
+		return
+	}
+	info.SourceDisk = getUserIID(cres.IID{NameId: diskIIDInfo.NameId, SystemId: diskIIDInfo.SystemId})
+}
+
+// (1) get IID:list
+// (2) get DiskSnapshotInfo:list
+func ListDiskSnapshot(connectionName string, rsType string) ([]*cres.DiskSnapshotInfo, error) {
+	cblog.Info("call ListDiskSnapshot()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := checkCapability(connectionName, DISK_SNAPSHOT_HANDLER); err != nil {
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDiskSnapshotHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get IID:list
+	var iidInfoList []*DiskSnapshotIIDInfo
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		err = getAuthIIDInfoList(connectionName, &iidInfoList)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	var infoList []*cres.DiskSnapshotInfo
+	if iidInfoList == nil || len(iidInfoList) <= 0 {
+		infoList = []*cres.DiskSnapshotInfo{}
+		return infoList, nil
+	}
+
+	// (2) get DiskSnapshotInfo:list
+	infoList2 := []*cres.DiskSnapshotInfo{}
+	for _, iidInfo := range iidInfoList {
+		diskSnapshotSPLock.RLock(connectionName, iidInfo.NameId)
+		info, err := handler.GetSnapshot(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		if err != nil {
+			diskSnapshotSPLock.RUnlock(connectionName, iidInfo.NameId)
+			if checkNotFoundError(err) {
+				cblog.Error(err)
+				info = cres.DiskSnapshotInfo{IId: cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}}
+				infoList2 = append(infoList2, &info)
+				continue
+			}
+			cblog.Error(err)
+			return nil, err
+		}
+		diskSnapshotSPLock.RUnlock(connectionName, iidInfo.NameId)
+
+		info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+		setSourceDiskUserIID(connectionName, &info)
+		infoList2 = append(infoList2, &info)
+	}
+
+	return infoList2, nil
+}
+
+// getDiskSnapshotIIDInfo gets the spiderIID of the snapshot with the NameId.
+func getDiskSnapshotIIDInfo(connectionName string, nameID string) (DiskSnapshotIIDInfo, error) {
+	var iidInfo DiskSnapshotIIDInfo
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		var iidInfoList []*DiskSnapshotIIDInfo
+		err := getAuthIIDInfoList(connectionName, &iidInfoList)
+		if err != nil {
+			return iidInfo, err
+		}
+		castedIIDInfo, err := getAuthIIDInfo(&iidInfoList, nameID)
+		if err != nil {
+			return iidInfo, err
+		}
+		iidInfo = *castedIIDInfo.(*DiskSnapshotIIDInfo)
+	} else {
+		err := infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+		if err != nil {
+			return iidInfo, err
+		}
+	}
+	return iidInfo, nil
+}
+
+// (1) get IID(NameId)
+// (2) get resource(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func GetDiskSnapshot(connectionName string, rsType string, nameID string) (*cres.DiskSnapshotInfo, error) {
+	cblog.Info("call GetDiskSnapshot()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := checkCapability(connectionName, DISK_SNAPSHOT_HANDLER); err != nil {
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDiskSnapshotHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	diskSnapshotSPLock.RLock(connectionName, nameID)
+	defer diskSnapshotSPLock.RUnlock(connectionName, nameID)
+
+	// (1) get IID(NameId)
+	iidInfo, err := getDiskSnapshotIIDInfo(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get resource(SystemId)
+	info, err := handler.GetSnapshot(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	setSourceDiskUserIID(connectionName, &info)
+
+	return &info, nil
+}
+
+// (1) get spiderIID
+// (2) delete Resource(SystemId)
+// (3) delete IID
+func DeleteDiskSnapshot(connectionName string, rsType string, nameID string, force string) (bool, error) {
+	cblog.Info("call DeleteDiskSnapshot()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	if err := checkCapability(connectionName, DISK_SNAPSHOT_HANDLER); err != nil {
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateDiskSnapshotHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	diskSnapshotSPLock.Lock(connectionName, nameID)
+	defer diskSnapshotSPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIID for creating driverIID
+	iidInfo, err := getDiskSnapshotIIDInfo(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (2) delete Resource(SystemId)
+	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	result, err := handler.DeleteSnapshot(driverIId)
+	if err != nil {
+		cblog.Error(err)
+		if checkNotFoundError(err) {
+			// if not found in CSP, continue
+			force = "true"
+		} else if force != "true" {
+			return false, err
+		}
+	}
+
+	if force != "true" && !result {
+		return false, nil
+	}
+
+	// (3) delete IID
+	_, err = infostore.DeleteByConditions(&DiskSnapshotIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CreateDiskFromSnapshot creates a new Disk with the data of the snapshot.
+// The new Disk is managed like a Disk created by CreateDisk().
+// (1) check exist(Disk NameID)
+// (2) get the snapshot's IID
+// (3) generate SP-XID and create reqIID, driverIID
+// (4) create Disk
+// (5) insert Disk's spiderIID
+// (6) create userIID
+func CreateDiskFromSnapshot(connectionName string, snapshotName string, reqInfo cres.DiskInfo, IDTransformMode string) (*cres.DiskInfo, error) {
+	cblog.Info("call CreateDiskFromSnapshot()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := checkCapability(connectionName, DISK_SNAPSHOT_HANDLER); err != nil {
+		return nil, err
+	}
+
+	snapshotName, err = EmptyCheckAndTrim("snapshotName", snapshotName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if reqInfo.IId.NameId == "" {
+		err := fmt.Errorf("Disk Name is empty!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// check the Zone info and the capability of ZONE_BASED_CONTROL
+	_, defaultZoneId, err := ccm.GetRegionNameByConnectionName(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if reqInfo.Zone != "" && reqInfo.Zone != defaultZoneId {
+		err := checkCapability(connectionName, ZONE_BASED_CONTROL)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDiskSnapshotHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	diskSPLock.Lock(connectionName, reqInfo.IId.NameId)
+	defer diskSPLock.Unlock(connectionName, reqInfo.IId.NameId)
+
+	// (1) check exist(Disk NameID)
+	bool_ret := false
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		bool_ret, err = infostore.HasByCondition(&DiskIIDInfo{}, NAME_ID_COLUMN, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		bool_ret, err = infostore.HasByConditions(&DiskIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+	if bool_ret {
+		err := fmt.Errorf("%s '%s' already exists in connection '%s'", RSTypeString(DISK), reqInfo.IId.NameId, connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get the snapshot's IID
+	diskSnapshotSPLock.RLock(connectionName, snapshotName)
+	defer diskSnapshotSPLock.RUnlock(connectionName, snapshotName)
+
+	snapshotIIDInfo, err := getDiskSnapshotIIDInfo(connectionName, snapshotName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" {
+		// (3) generate SP-XID
+		spUUID, err = iidm.New(connectionName, DISK, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		spUUID = reqInfo.IId.NameId
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: reqInfo.IId.NameId, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+	reqInfo.IId = driverIId
+	if strings.ToLower(reqInfo.DiskType) == "default" {
+		reqInfo.DiskType = ""
+	}
+
+	// (4) create Disk
+	snapshotDriverIID := getDriverIID(cres.IID{NameId: snapshotIIDInfo.NameId, SystemId: snapshotIIDInfo.SystemId})
+	info, err := handler.CreateDiskFromSnapshot(snapshotDriverIID, reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (5) insert Disk's spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+	err = infostore.Insert(&DiskIIDInfo{ConnectionName: connectionName, ZoneId: reqInfo.Zone, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		diskHandler, err2 := cldConn.CreateDiskHandler()
+		if err2 == nil {
+			_, err2 = diskHandler.DeleteDisk(info.IId)
+		}
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	// (6) create userIID: {reqNameID, driverSystemID}
+	info.IId = getUserIID(cres.IID{NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+
+	return &info, nil
+}
+
+func CountAllDiskSnapshots() (int64, error) {
+	var info DiskSnapshotIIDInfo
+	count, err := infostore.CountAllNameIDs(&info)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+	return count, nil
+}
+
+func CountDiskSnapshotsByConnection(connectionName string) (int64, error) {
+	var info DiskSnapshotIIDInfo
+	count, err := infostore.CountNameIDsByConnection(&info, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+	return count, nil
+}
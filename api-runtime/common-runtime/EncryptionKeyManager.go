@@ -0,0 +1,82 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Encryption Key Manager — online rotation of the key which encrypts the stored secrets.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	splock "github.com/cloud-barista/cb-spider/api-runtime/common-runtime/sp-lock"
+	drvcommon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+)
+
+// KeyRotationResult represents the result of an encryption key rotation.
+type KeyRotationResult struct {
+	ActiveVersion   string `json:"ActiveVersion" example:"2"`
+	CredentialCount int    `json:"CredentialCount" example:"5"` // number of the re-encrypted credentials
+	PrivateKeyCount int    `json:"PrivateKeyCount" example:"3"` // number of the re-encrypted local private keys
+	TokenS3KeyCount int    `json:"TokenS3KeyCount" example:"1"` // number of the re-encrypted S3 secret keys of API tokens
+}
+
+// keyRotationSPLock serializes the rotations of all Spider servers sharing the MetaDB.
+var keyRotationSPLock = splock.New("EncryptionKey")
+
+// GetEncryptionKeyInfo returns the status of the encryption keys.
+func GetEncryptionKeyInfo() (*cim.EncryptionKeyInfo, error) {
+	cblog.Info("call GetEncryptionKeyInfo()")
+
+	keyInfo, err := cim.GetEncryptionKeyInfo()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return keyInfo, nil
+}
+
+// RotateEncryptionKey re-encrypts all stored secrets with the active key.
+// If newVersion is true, a new key version is created and made active first.
+// Values of the old versions can be decrypted during the rotation, so the server keeps serving.
+func RotateEncryptionKey(newVersion bool) (*KeyRotationResult, error) {
+	cblog.Info("call RotateEncryptionKey()")
+
+	keyRotationSPLock.Lock("", "rotation")
+	defer keyRotationSPLock.Unlock("", "rotation")
+
+	if newVersion {
+		version, err := cim.NewKeyVersion()
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		cblog.Infof("a new encryption key version '%s' is activated", version)
+	}
+
+	keyInfo, err := cim.GetEncryptionKeyInfo()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	result := &KeyRotationResult{ActiveVersion: keyInfo.ActiveVersion}
+
+	if result.CredentialCount, err = cim.RotateCredentialEncryption(); err != nil {
+		return result, err
+	}
+	if result.PrivateKeyCount, err = drvcommon.ReEncryptKeys(); err != nil {
+		cblog.Error(err)
+		return result, err
+	}
+	if result.TokenS3KeyCount, err = reEncryptTokenS3Keys(); err != nil {
+		cblog.Error(err)
+		return result, err
+	}
+
+	cblog.Infof("stored secrets are re-encrypted with the key version '%s': %d credentials, %d private keys, %d token S3 keys",
+		result.ActiveVersion, result.CredentialCount, result.PrivateKeyCount, result.TokenS3KeyCount)
+	return result, nil
+}
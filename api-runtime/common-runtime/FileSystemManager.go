@@ -7,6 +7,7 @@ package commonruntime
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
 	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
@@ -27,13 +28,29 @@ func (FileSystemIIDInfo) TableName() string {
 	return "filesystem_iid_infos"
 }
 
+// -------- IID Info for FileSystem Backup
+
+const OWNER_FILESYSTEM_NAME_COLUMN = "owner_file_system_name"
+
+type FileSystemBackupIIDInfo struct {
+	ConnectionName      string `gorm:"primaryKey"` // ex) "aws-seoul-config"
+	NameId              string `gorm:"primaryKey"` // ex) "my_backup"
+	SystemId            string // BackupID in CSP, ex) "arn:aws:backup:ap-northeast-2:123456789012:recovery-point:..."
+	OwnerFileSystemName string `gorm:"primaryKey"` // ex) "my_filesystem"
+}
+
+func (FileSystemBackupIIDInfo) TableName() string {
+	return "filesystem_backup_iid_infos"
+}
+
 func init() {
 	db, err := infostore.Open()
 	if err != nil {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&FileSystemIIDInfo{})
+	infostore.AutoMigrate(db, &FileSystemIIDInfo{})
+	infostore.AutoMigrate(db, &FileSystemBackupIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -387,6 +404,11 @@ func DeleteFileSystem(connectionName string, nameID string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	// the backups of the FileSystem are not managed after the FileSystem is deleted
+	_, err = infostore.DeleteByConditions(&FileSystemBackupIIDInfo{}, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, OWNER_FILESYSTEM_NAME_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+	}
 	return result, nil
 }
 
@@ -502,3 +524,353 @@ func ListAccessSubnet(connectionName string, nameID string) ([]cres.IID, error)
 	}
 	return handler.ListAccessSubnet(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
 }
+
+// -------- FileSystem Backup
+
+// getFileSystemHandler returns the FileSystem's IID info and the handler of its zone.
+func getFileSystemHandler(connectionName string, nameID string) (*FileSystemIIDInfo, cres.FileSystemHandler, error) {
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var iidInfo FileSystemIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		return nil, nil, err
+	}
+	cldConn, err := ccm.GetZoneLevelCloudConnection(connectionName, iidInfo.ZoneId)
+	if err != nil {
+		return nil, nil, err
+	}
+	handler, err := cldConn.CreateFileSystemHandler()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &iidInfo, handler, nil
+}
+
+// setBackupUserIID replaces the FileSystem SystemId of the backup with the user's FileSystem NameId,
+// and sets the user's backup name.
+func setBackupUserIID(iidInfo *FileSystemIIDInfo, backupName string, backupInfo *cres.FileSystemBackupInfo) {
+	if backupInfo.FileSystemIID == "" || backupInfo.FileSystemIID == iidInfo.SystemId {
+		backupInfo.FileSystemIID = iidInfo.NameId
+	}
+	backupInfo.BackupName = backupName
+}
+
+// getBackupIIDInfo returns the registered backup of the FileSystem by the backup name or the CSP's BackupID.
+// An unregistered backup(ex: made by the schedule before it is listed) is returned with its BackupID as the name.
+func getBackupIIDInfo(iidInfo *FileSystemIIDInfo, backupID string) (*FileSystemBackupIIDInfo, bool, error) {
+	var backupIIDList []*FileSystemBackupIIDInfo
+	err := infostore.ListByConditions(&backupIIDList, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, OWNER_FILESYSTEM_NAME_COLUMN, iidInfo.NameId)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, backupIID := range backupIIDList {
+		if backupIID.NameId == backupID {
+			return backupIID, true, nil
+		}
+	}
+	for _, backupIID := range backupIIDList {
+		if backupIID.SystemId == backupID {
+			return backupIID, true, nil
+		}
+	}
+	return &FileSystemBackupIIDInfo{ConnectionName: iidInfo.ConnectionName, NameId: backupID, SystemId: backupID,
+		OwnerFileSystemName: iidInfo.NameId}, false, nil
+}
+
+func ScheduleFileSystemBackup(connectionName string, nameID string, schedule cres.CronSchedule) (*cres.FileSystemBackupInfo, error) {
+	cblog.Info("call ScheduleFileSystemBackup()")
+
+	iidInfo, handler, err := getFileSystemHandler(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	fsSPLock.RLock(iidInfo.ConnectionName, iidInfo.NameId)
+	defer fsSPLock.RUnlock(iidInfo.ConnectionName, iidInfo.NameId)
+
+	reqInfo := cres.FileSystemBackupInfo{
+		FileSystemIID: iidInfo.SystemId,
+		Schedule:      schedule,
+	}
+	info, err := handler.ScheduleBackup(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	setBackupUserIID(iidInfo, "", &info)
+	return &info, nil
+}
+
+// OnDemandFileSystemBackup creates a backup of the FileSystem and registers it with the backupName.
+// The CSP's BackupID is used as the name if the backupName is empty.
+func OnDemandFileSystemBackup(connectionName string, nameID string, backupName string) (*cres.FileSystemBackupInfo, error) {
+	cblog.Info("call OnDemandFileSystemBackup()")
+
+	iidInfo, handler, err := getFileSystemHandler(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// the backup IIDs are registered under the FileSystem's lock
+	fsSPLock.Lock(iidInfo.ConnectionName, iidInfo.NameId)
+	defer fsSPLock.Unlock(iidInfo.ConnectionName, iidInfo.NameId)
+
+	backupName = strings.TrimSpace(backupName)
+	if backupName != "" {
+		exist, err := infostore.HasByConditions(&FileSystemBackupIIDInfo{}, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, NAME_ID_COLUMN, backupName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if exist {
+			err := fmt.Errorf("FileSystem Backup '%s' already exists in connection '%s'", backupName, iidInfo.ConnectionName)
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	info, err := handler.OnDemandBackup(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if backupName == "" {
+		backupName = info.BackupID
+	}
+
+	err = infostore.Insert(&FileSystemBackupIIDInfo{ConnectionName: iidInfo.ConnectionName, NameId: backupName, SystemId: info.BackupID,
+		OwnerFileSystemName: iidInfo.NameId})
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		_, err2 := handler.DeleteBackup(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}, info.BackupID)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	setBackupUserIID(iidInfo, backupName, &info)
+	return &info, nil
+}
+
+func ListFileSystemBackup(connectionName string, nameID string) ([]cres.FileSystemBackupInfo, error) {
+	cblog.Info("call ListFileSystemBackup()")
+
+	iidInfo, handler, err := getFileSystemHandler(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	fsSPLock.Lock(iidInfo.ConnectionName, iidInfo.NameId)
+	defer fsSPLock.Unlock(iidInfo.ConnectionName, iidInfo.NameId)
+
+	infoList, err := handler.ListBackup(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if infoList == nil {
+		infoList = []cres.FileSystemBackupInfo{}
+	}
+
+	var backupIIDList []*FileSystemBackupIIDInfo
+	err = infostore.ListByConditions(&backupIIDList, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, OWNER_FILESYSTEM_NAME_COLUMN, iidInfo.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	nameMap := map[string]string{} // BackupID => backup name
+	for _, backupIID := range backupIIDList {
+		nameMap[backupIID.SystemId] = backupIID.NameId
+	}
+
+	backupIDMap := map[string]bool{}
+	for idx := range infoList {
+		backupID := infoList[idx].BackupID
+		backupIDMap[backupID] = true
+		backupName, ok := nameMap[backupID]
+		if !ok {
+			// register the backup made by the schedule with its BackupID as the name
+			backupName = backupID
+			err := infostore.Insert(&FileSystemBackupIIDInfo{ConnectionName: iidInfo.ConnectionName, NameId: backupName, SystemId: backupID,
+				OwnerFileSystemName: iidInfo.NameId})
+			if err != nil {
+				cblog.Error(err)
+			}
+		}
+		setBackupUserIID(iidInfo, backupName, &infoList[idx])
+	}
+
+	// unregister the backups removed in the CSP(ex: by the retention)
+	for _, backupIID := range backupIIDList {
+		if !backupIDMap[backupIID.SystemId] {
+			_, err := infostore.DeleteBy3Conditions(&FileSystemBackupIIDInfo{}, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName,
+				NAME_ID_COLUMN, backupIID.NameId, OWNER_FILESYSTEM_NAME_COLUMN, iidInfo.NameId)
+			if err != nil {
+				cblog.Error(err)
+			}
+		}
+	}
+	return infoList, nil
+}
+
+func GetFileSystemBackup(connectionName string, nameID string, backupID string) (*cres.FileSystemBackupInfo, error) {
+	cblog.Info("call GetFileSystemBackup()")
+
+	backupID, err := EmptyCheckAndTrim("backupID", backupID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	iidInfo, handler, err := getFileSystemHandler(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	backupIIDInfo, _, err := getBackupIIDInfo(iidInfo, backupID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	info, err := handler.GetBackup(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}, backupIIDInfo.SystemId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	setBackupUserIID(iidInfo, backupIIDInfo.NameId, &info)
+	return &info, nil
+}
+
+func DeleteFileSystemBackup(connectionName string, nameID string, backupID string) (bool, error) {
+	cblog.Info("call DeleteFileSystemBackup()")
+
+	backupID, err := EmptyCheckAndTrim("backupID", backupID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	iidInfo, handler, err := getFileSystemHandler(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	fsSPLock.Lock(iidInfo.ConnectionName, iidInfo.NameId)
+	defer fsSPLock.Unlock(iidInfo.ConnectionName, iidInfo.NameId)
+
+	backupIIDInfo, registered, err := getBackupIIDInfo(iidInfo, backupID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	result, err := handler.DeleteBackup(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}, backupIIDInfo.SystemId)
+	if err != nil {
+		cblog.Error(err)
+		if !checkNotFoundError(err) {
+			return false, err
+		}
+		// if not found in CSP, continue to delete metadb
+		result = true
+	}
+
+	if registered {
+		_, err = infostore.DeleteBy3Conditions(&FileSystemBackupIIDInfo{}, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName,
+			NAME_ID_COLUMN, backupIIDInfo.NameId, OWNER_FILESYSTEM_NAME_COLUMN, iidInfo.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+	}
+	return result, nil
+}
+
+// RestoreFileSystemBackup creates a new FileSystem(newNameID) from the backup of the FileSystem(nameID),
+// and registers it with the same zone and VPC as the source FileSystem.
+func RestoreFileSystemBackup(connectionName string, nameID string, backupID string, newNameID string) (*cres.FileSystemInfo, error) {
+	cblog.Info("call RestoreFileSystemBackup()")
+
+	backupID, err := EmptyCheckAndTrim("backupID", backupID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	newNameID, err = EmptyCheckAndTrim("newNameID", newNameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	iidInfo, handler, err := getFileSystemHandler(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	fsSPLock.RLock(iidInfo.ConnectionName, iidInfo.NameId)
+	defer fsSPLock.RUnlock(iidInfo.ConnectionName, iidInfo.NameId)
+
+	fsSPLock.Lock(iidInfo.ConnectionName, newNameID)
+	defer fsSPLock.Unlock(iidInfo.ConnectionName, newNameID)
+
+	exist, err := infostore.HasByConditions(&FileSystemIIDInfo{}, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, NAME_ID_COLUMN, newNameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if exist {
+		return nil, fmt.Errorf("FileSystem '%s' already exists in connection '%s'", newNameID, iidInfo.ConnectionName)
+	}
+
+	backupIIDInfo, _, err := getBackupIIDInfo(iidInfo, backupID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	info, err := handler.RestoreBackup(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId},
+		cres.IID{NameId: backupIIDInfo.NameId, SystemId: backupIIDInfo.SystemId}, cres.IID{NameId: newNameID})
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = infostore.Insert(&FileSystemIIDInfo{ConnectionName: iidInfo.ConnectionName, ZoneId: iidInfo.ZoneId, NameId: newNameID,
+		SystemId: info.IId.SystemId, OwnerVPCName: iidInfo.OwnerVPCName})
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		_, err2 := handler.DeleteFileSystem(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf("Failed to delete FileSystem %s: %v", info.IId.NameId, err2)
+		}
+		return nil, err
+	}
+
+	// get the restored FileSystem with the user's VPC and Subnet NameIds
+	restoredInfo, err := GetFileSystem(iidInfo.ConnectionName, newNameID)
+	if err != nil {
+		cblog.Error(err)
+		info.IId = cres.IID{NameId: newNameID, SystemId: info.IId.SystemId}
+		return &info, nil
+	}
+	return restoredInfo, nil
+}
@@ -0,0 +1,377 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Job Manager — asynchronous execution of long-running operations.
+// Job state is persisted in Spider MetaDB so that clients can poll it.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"github.com/rs/xid"
+)
+
+// Job Status
+const (
+	JobQueued      = "Queued"      // waiting for a job slot
+	JobRunning     = "Running"     // operation is in progress
+	JobSucceeded   = "Succeeded"   // operation is done, Result has the output
+	JobFailed      = "Failed"      // operation failed, Error has the reason
+	JobCanceled    = "Canceled"    // canceled before running
+	JobInterrupted = "Interrupted" // server was restarted while the job was not finished
+)
+
+// jobCancelCheckInterval is the interval to check the Canceled status of a queued job,
+// which can be set by CancelJob of another Spider server sharing the MetaDB.
+const jobCancelCheckInterval = 2 * time.Second
+
+// JobInfo represents the state of an asynchronous operation.
+type JobInfo struct {
+	JobID          string    `gorm:"primaryKey" json:"JobID" example:"cs1h7ms2k3q4cvp0l6dg"`
+	Operation      string    `json:"Operation" example:"CreateCluster"`
+	ConnectionName string    `gorm:"index" json:"ConnectionName" example:"aws-connection"`
+	OwnerID        string    `gorm:"index" json:"OwnerID" example:"spider-0"` // Spider server instance which runs the job
+	ResourceType   string    `json:"ResourceType" example:"cluster"`
+	ResourceName   string    `json:"ResourceName" example:"cluster-01"`
+	Status         string    `json:"Status" example:"Running" enums:"Queued,Running,Succeeded,Failed,Canceled,Interrupted"`
+	Progress       string    `json:"Progress" example:"running CreateCluster"`
+	Result         string    `gorm:"type:text" json:"Result,omitempty"` // JSON output of the operation
+	Error          string    `gorm:"type:text" json:"Error,omitempty"`
+	CreatedTime    time.Time `json:"CreatedTime" example:"2026-10-01T12:00:00Z"`
+	StartedTime    time.Time `json:"StartedTime,omitempty"`
+	FinishedTime   time.Time `json:"FinishedTime,omitempty"`
+}
+
+func (JobInfo) TableName() string {
+	return "job_infos"
+}
+
+// JobFunc is the operation executed by a job. The returned value is stored as JSON in JobInfo.Result.
+type JobFunc func() (interface{}, error)
+
+const defaultJobMaxConcurrency = 10
+
+var jobSlots chan struct{}
+
+// jobOwnerID identifies this Spider server among the servers sharing the MetaDB.
+var jobOwnerID string
+
+// jobLock guards the status transition of jobs and jobCancelMap.
+var jobLock = new(sync.Mutex)
+
+// jobCancelMap holds the cancel channels of the queued jobs of this server.
+// It only wakes up a local queued job early; the Canceled status in the MetaDB is authoritative.
+var jobCancelMap = map[string]chan struct{}{}
+
+func init() {
+	jobSlots = make(chan struct{}, getJobMaxConcurrency())
+	jobOwnerID = getJobOwnerID()
+
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	defer infostore.Close(db)
+	infostore.AutoMigrateSecurity(db, &JobInfo{})
+
+	// jobs of this server which were not finished before the restart cannot be resumed.
+	// jobs of other servers sharing the MetaDB are left as they are.
+	err = db.Model(&JobInfo{}).Where("owner_id = ? AND status IN ?", jobOwnerID, []string{JobQueued, JobRunning}).
+		Updates(map[string]interface{}{
+			"status":        JobInterrupted,
+			"error":         "the server was restarted before the job finished",
+			"finished_time": time.Now(),
+		}).Error
+	if err != nil {
+		cblog.Error(err)
+	}
+}
+
+// getJobMaxConcurrency returns the max number of running jobs, from SPIDER_JOB_MAX_CONCURRENCY.
+func getJobMaxConcurrency() int {
+	if envVal := os.Getenv("SPIDER_JOB_MAX_CONCURRENCY"); envVal != "" {
+		if n, err := strconv.Atoi(envVal); err == nil && n > 0 {
+			return n
+		}
+		cblog.Errorf("invalid SPIDER_JOB_MAX_CONCURRENCY: %q (use default: %d)", envVal, defaultJobMaxConcurrency)
+	}
+	return defaultJobMaxConcurrency
+}
+
+// getJobOwnerID returns the instance ID of this server, from SPIDER_INSTANCE_ID or the host name.
+// Each server sharing the MetaDB must have a distinct and stable ID(e.g., StatefulSet Pod name),
+// so that a restarted server interrupts only its own unfinished jobs.
+func getJobOwnerID() string {
+	if envVal := os.Getenv("SPIDER_INSTANCE_ID"); envVal != "" {
+		return envVal
+	}
+	hostName, err := os.Hostname()
+	if err != nil || hostName == "" {
+		cblog.Errorf("failed to get the host name: %v (use default: %s)", err, "localhost")
+		return "localhost"
+	}
+	return hostName
+}
+
+// GetJobMaxConcurrency returns the number of job slots, the max number of jobs running at once.
+func GetJobMaxConcurrency() int {
+	return cap(jobSlots)
+}
+
+// SubmitJob registers a job of the operation and runs it in background.
+// It returns the queued JobInfo right away.
+func SubmitJob(operation string, connectionName string, rsType string, resourceName string, run JobFunc) (*JobInfo, error) {
+	cblog.Info("call SubmitJob()")
+
+	operation, err := EmptyCheckAndTrim("operation", operation)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if run == nil {
+		err := fmt.Errorf("job function of %s is nil", operation)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	jobInfo := &JobInfo{
+		JobID:          xid.New().String(),
+		Operation:      operation,
+		ConnectionName: connectionName,
+		OwnerID:        jobOwnerID,
+		ResourceType:   rsType,
+		ResourceName:   resourceName,
+		Status:         JobQueued,
+		Progress:       "waiting for a job slot",
+		CreatedTime:    time.Now(),
+	}
+
+	cancelCh := make(chan struct{})
+	jobLock.Lock()
+	err = infostore.Insert(jobInfo)
+	if err == nil {
+		jobCancelMap[jobInfo.JobID] = cancelCh
+	}
+	jobLock.Unlock()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	queued := *jobInfo
+	go runJob(jobInfo, cancelCh, run)
+
+	return &queued, nil
+}
+
+func runJob(jobInfo *JobInfo, cancelCh chan struct{}, run JobFunc) {
+	defer func() {
+		jobLock.Lock()
+		delete(jobCancelMap, jobInfo.JobID)
+		jobLock.Unlock()
+	}()
+
+	ticker := time.NewTicker(jobCancelCheckInterval)
+	defer ticker.Stop()
+	for acquired := false; !acquired; {
+		select {
+		case jobSlots <- struct{}{}:
+			acquired = true
+		case <-cancelCh:
+			return // CancelJob already saved the Canceled status
+		case <-ticker.C:
+			if isJobCanceled(jobInfo.JobID) {
+				return // canceled by another server
+			}
+		}
+	}
+	defer func() { <-jobSlots }()
+
+	// Queued -> Running only if no server has canceled the job in the meantime.
+	startedTime := time.Now()
+	started, err := updateJobStatus(jobInfo.JobID, JobQueued, map[string]interface{}{
+		"status":       JobRunning,
+		"progress":     "running " + jobInfo.Operation,
+		"started_time": startedTime,
+	})
+	if err != nil {
+		cblog.Errorf("failed to start job %s: %v", jobInfo.JobID, err)
+		return
+	}
+	if !started {
+		return
+	}
+	jobInfo.Status = JobRunning
+	jobInfo.Progress = "running " + jobInfo.Operation
+	jobInfo.StartedTime = startedTime
+
+	result, err := runJobFunc(run)
+
+	jobInfo.FinishedTime = time.Now()
+	if err != nil {
+		jobInfo.Status = JobFailed
+		jobInfo.Progress = jobInfo.Operation + " failed"
+		jobInfo.Error = err.Error()
+	} else {
+		jobInfo.Status = JobSucceeded
+		jobInfo.Progress = jobInfo.Operation + " completed"
+		if result != nil {
+			jsonResult, err := json.Marshal(result)
+			if err != nil {
+				cblog.Error(err)
+			} else {
+				jobInfo.Result = string(jsonResult)
+			}
+		}
+	}
+
+	jobLock.Lock()
+	saveJob(jobInfo)
+	jobLock.Unlock()
+}
+
+// runJobFunc runs the job function and turns a panic into a job error.
+func runJobFunc(run JobFunc) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return run()
+}
+
+// updateJobStatus updates the job only if it is still in fromStatus.
+// It returns false if the job is not in fromStatus, e.g., it was canceled by another server.
+func updateJobStatus(jobID string, fromStatus string, updates map[string]interface{}) (bool, error) {
+	db, err := infostore.Open()
+	if err != nil {
+		return false, err
+	}
+	defer infostore.Close(db)
+
+	result := db.Model(&JobInfo{}).Where("job_id = ? AND status = ?", jobID, fromStatus).Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func isJobCanceled(jobID string) bool {
+	jobInfo, err := GetJob(jobID)
+	return err == nil && jobInfo.Status == JobCanceled
+}
+
+func saveJob(jobInfo *JobInfo) {
+	if err := infostore.Insert(jobInfo); err != nil {
+		cblog.Errorf("failed to save job %s: %v", jobInfo.JobID, err)
+	}
+}
+
+// GetJob returns the JobInfo of the jobID.
+func GetJob(jobID string) (*JobInfo, error) {
+	cblog.Info("call GetJob()")
+
+	jobID, err := EmptyCheckAndTrim("jobID", jobID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var jobInfo JobInfo
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer infostore.Close(db)
+
+	if err := db.Where("job_id = ?", jobID).First(&jobInfo).Error; err != nil {
+		err := fmt.Errorf("Job '%s' does not exist", jobID)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &jobInfo, nil
+}
+
+// ListJob returns the jobs of the connection, newest first.
+// All jobs are returned if connectionName is empty.
+func ListJob(connectionName string) ([]*JobInfo, error) {
+	cblog.Info("call ListJob()")
+
+	var jobList []*JobInfo
+	var err error
+	if connectionName == "" {
+		err = infostore.List(&jobList)
+	} else {
+		err = infostore.ListByCondition(&jobList, CONNECTION_NAME_COLUMN, connectionName)
+	}
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sort.Slice(jobList, func(i, j int) bool {
+		return jobList[i].CreatedTime.After(jobList[j].CreatedTime)
+	})
+
+	return jobList, nil
+}
+
+// CancelJob cancels the job. Only a queued job can be canceled,
+// because the running operation cannot be stopped safely in the middle.
+// The job can be canceled on any server sharing the MetaDB: the server running the job
+// checks the Canceled status before it starts the job.
+func CancelJob(jobID string) (bool, error) {
+	cblog.Info("call CancelJob()")
+
+	jobInfo, err := GetJob(jobID)
+	if err != nil {
+		return false, err
+	}
+
+	jobLock.Lock()
+	defer jobLock.Unlock()
+
+	canceled, err := updateJobStatus(jobInfo.JobID, JobQueued, map[string]interface{}{
+		"status":        JobCanceled,
+		"progress":      "canceled before running",
+		"finished_time": time.Now(),
+	})
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	if !canceled {
+		// re-read the status, it could be changed after GetJob
+		jobInfo, err = GetJob(jobID)
+		if err != nil {
+			return false, err
+		}
+		err := fmt.Errorf("Job '%s' cannot be canceled in %s status", jobInfo.JobID, jobInfo.Status)
+		cblog.Error(err)
+		return false, err
+	}
+
+	// wake up the queued job if it is in this server
+	if cancelCh, ok := jobCancelMap[jobInfo.JobID]; ok {
+		close(cancelCh)
+		delete(jobCancelMap, jobInfo.JobID)
+	}
+
+	return true, nil
+}
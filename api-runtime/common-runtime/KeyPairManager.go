@@ -13,6 +13,7 @@ import (
 	"os"
 
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	drvcommon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
 	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
 	infostore "github.com/cloud-barista/cb-spider/info-store"
@@ -27,6 +28,18 @@ func (KeyIIDInfo) TableName() string {
 	return "key_iid_infos"
 }
 
+// KeyFingerprintInfo keeps the fingerprint of an imported public key,
+// since some CSPs do not return it on GetKey/ListKey.
+type KeyFingerprintInfo struct {
+	ConnectionName string `gorm:"primaryKey"`
+	NameId         string `gorm:"primaryKey"`
+	Fingerprint    string
+}
+
+func (KeyFingerprintInfo) TableName() string {
+	return "key_fingerprint_infos"
+}
+
 //====================================================================
 
 func init() {
@@ -35,7 +48,8 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&KeyIIDInfo{})
+	infostore.AutoMigrate(db, &KeyIIDInfo{})
+	infostore.AutoMigrate(db, &KeyFingerprintInfo{})
 	infostore.Close(db)
 }
 
@@ -253,6 +267,136 @@ func CreateKey(connectionName string, rsType string, reqInfo cres.KeyPairReqInfo
 	return &info, nil
 }
 
+// (1) check exist(NameID) and validate the public key
+// (2) generate SP-XID and create reqIID, driverIID
+// (3) import the public key as a Resource
+// (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+// (5) insert spiderIID and the fingerprint
+// (6) create userIID
+func ImportKey(connectionName string, rsType string, nameID string, publicKey string, IDTransformMode string) (*cres.KeyPairInfo, error) {
+	cblog.Info("call ImportKey()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	publicKey, err = EmptyCheckAndTrim("publicKey", publicKey)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// the private key is never sent, so only the OpenSSH public key is accepted.
+	publicKey, fingerprint, err := drvcommon.ParsePublicKey(publicKey)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateKeyPairHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	keySPLock.Lock(connectionName, nameID)
+	defer keySPLock.Unlock(connectionName, nameID)
+
+	// (1) check exist(NameID)
+	bool_ret := false
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		bool_ret, err = infostore.HasByCondition(&KeyIIDInfo{}, NAME_ID_COLUMN, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else {
+		bool_ret, err = infostore.HasByConditions(&KeyIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	if bool_ret {
+		err := fmt.Errorf("%s '%s' already exists in connection '%s'", RSTypeString(KEY), nameID, connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		// (2) generate SP-XID and create reqIID, driverIID
+		spUUID, err = iidm.New(connectionName, rsType, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else { // No Use IID Management
+		spUUID = nameID
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: nameID, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+
+	// (3) import the public key as a Resource
+	info, err := handler.ImportKey(driverIId, publicKey)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if info.Fingerprint == "" {
+		info.Fingerprint = fingerprint
+	}
+	info.PrivateKey = ""
+
+	// (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+
+	// (5) insert spiderIID and the fingerprint
+	err = infostore.Insert(&KeyIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+	if err == nil {
+		infostore.DeleteByConditions(&KeyFingerprintInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, spiderIId.NameId)
+		err = infostore.Insert(&KeyFingerprintInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, Fingerprint: info.Fingerprint})
+		if err != nil {
+			infostore.DeleteByConditions(&KeyIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, spiderIId.NameId)
+		}
+	}
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		_, err2 := handler.DeleteKey(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (6) create userIID: {reqNameID, driverSystemID}
+	info.IId = getUserIID(cres.IID{NameId: spiderIId.NameId, SystemId: spiderIId.SystemId})
+
+	return &info, nil
+}
+
 // (1) get IID:list
 // (2) get KeyInfo:list
 func ListKey(connectionName string, rsType string) ([]*cres.KeyPairInfo, error) {
@@ -321,6 +465,7 @@ func ListKey(connectionName string, rsType string) ([]*cres.KeyPairInfo, error)
 		keySPLock.RUnlock(connectionName, iidInfo.NameId)
 
 		info.IId.NameId = iidInfo.NameId
+		setStoredFingerprint(connectionName, &info)
 		hideSecretInfo(&info)
 
 		infoList2 = append(infoList2, &info)
@@ -329,6 +474,19 @@ func ListKey(connectionName string, rsType string) ([]*cres.KeyPairInfo, error)
 	return infoList2, nil
 }
 
+// fill the Fingerprint of an imported key when the CSP does not return it
+func setStoredFingerprint(connectionName string, info *cres.KeyPairInfo) {
+	if info.Fingerprint != "" {
+		return
+	}
+	var fpInfo KeyFingerprintInfo
+	err := infostore.GetByConditions(&fpInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, info.IId.NameId)
+	if err != nil {
+		return
+	}
+	info.Fingerprint = fpInfo.Fingerprint
+}
+
 func hideSecretInfo(info *cres.KeyPairInfo) {
 	info.PublicKey = "Hidden for security."
 	info.PrivateKey = "Hidden for security."
@@ -400,6 +558,7 @@ func GetKey(connectionName string, rsType string, nameID string) (*cres.KeyPairI
 
 	// (3) set ResourceInfo(IID.NameId)
 	info.IId.NameId = iidInfo.NameId
+	setStoredFingerprint(iidInfo.ConnectionName, &info)
 	hideSecretInfo(&info)
 
 	return &info, nil
@@ -490,6 +649,10 @@ func DeleteKey(connectionName string, rsType string, nameID string, force string
 			return false, err
 		}
 	}
+	_, err = infostore.DeleteByConditions(&KeyFingerprintInfo{}, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+	}
 
 	return result, nil
 }
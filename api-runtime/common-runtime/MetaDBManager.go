@@ -0,0 +1,548 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// MetaDB Manager — backup, restore and migration of the MetaDB in maintenance mode.
+// The backups can be uploaded to a bucket of Spider S3, encrypted with an external Spider key.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"github.com/minio/minio-go/v7"
+	"gorm.io/gorm/clause"
+)
+
+// maintenanceDrainTimeout is the max time to wait for the running API calls before the maintenance.
+const maintenanceDrainTimeout = 60 * time.Second
+
+// maintenanceCheckInterval is the interval to check the maintenance mode in the MetaDB,
+// and to report the running API calls during the maintenance.
+const maintenanceCheckInterval = 2 * time.Second
+
+// maintenanceStateID is the ID of the only row of the maintenance mode in the MetaDB.
+const maintenanceStateID = "metadb"
+
+// remoteBackupSuffix is the suffix of the encrypted backup objects, ex) cb-spider_backup_20261018_120000.db.enc
+const remoteBackupSuffix = ".enc"
+
+// maxRemoteBackupSize limits the download of a remote backup.
+const maxRemoteBackupSize = 4 << 30 // 4GB
+
+func init() {
+	infostore.SetRemoteBackupHandler(uploadMetaDBBackup)
+
+	db, err := infostore.Open()
+	if err != nil {
+		panic("failed to connect database")
+	}
+	defer infostore.Close(db)
+	// not registered as MetaDB tables, the restore and the migration must not copy the maintenance mode
+	db.AutoMigrate(&MaintenanceStateInfo{}, &MaintenanceServerInfo{})
+
+	// the maintenance of this server was interrupted by a restart
+	maintenanceServerID = getJobOwnerID()
+	if err := db.Where("id = ? AND server_id = ?", maintenanceStateID, maintenanceServerID).Delete(&MaintenanceStateInfo{}).Error; err != nil {
+		cblog.Error(err)
+	}
+
+	refreshMaintenance()
+	go func() {
+		for range time.Tick(maintenanceCheckInterval) {
+			refreshMaintenance()
+		}
+	}()
+}
+
+// MaintenanceInfo represents the maintenance mode of the servers sharing the MetaDB.
+type MaintenanceInfo struct {
+	Enabled   bool      `json:"Enabled" example:"true"`
+	Reason    string    `json:"Reason,omitempty" example:"restoring the MetaDB backup 'cb-spider_backup_20261018_120000.db'"`
+	ServerID  string    `json:"ServerID,omitempty" example:"spider-01"` // server which started the maintenance
+	StartTime time.Time `json:"StartTime,omitempty"`
+}
+
+// MaintenanceStateInfo is the maintenance mode in the MetaDB, which all servers sharing the MetaDB follow.
+type MaintenanceStateInfo struct {
+	ID        string `gorm:"primaryKey"` // maintenanceStateID
+	Reason    string
+	ServerID  string
+	StartTime time.Time
+}
+
+// MaintenanceServerInfo is the number of the running API calls of a server, reported during the maintenance.
+type MaintenanceServerInfo struct {
+	ServerID     string `gorm:"primaryKey"`
+	RunningCalls int
+	UpdatedTime  time.Time
+}
+
+var maintenanceLock = new(sync.Mutex)
+var maintenance MaintenanceInfo // last seen in the MetaDB
+var runningCallCount int        // API calls in progress, except the MetaDB APIs
+var maintenanceServerID string  // identifies this server, same as the job owner ID
+
+// GetMaintenanceInfo returns the maintenance mode of the server.
+func GetMaintenanceInfo() MaintenanceInfo {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+	return maintenance
+}
+
+// BeginAPICall counts an API call in progress.
+// An error is returned in maintenance mode, and EndAPICall must not be called.
+func BeginAPICall() error {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+
+	if maintenance.Enabled {
+		return fmt.Errorf("CB-Spider is under maintenance: %s", maintenance.Reason)
+	}
+	runningCallCount++
+	return nil
+}
+
+// EndAPICall ends the API call counted by BeginAPICall.
+func EndAPICall() {
+	maintenanceLock.Lock()
+	runningCallCount--
+	maintenanceLock.Unlock()
+}
+
+// refreshMaintenance reads the maintenance mode of the MetaDB, and reports the running API calls of this server.
+// The server in maintenance reports too, a maintenance without the reports is left by a stopped server and is finished.
+func refreshMaintenance() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Warnf("failed to check the maintenance mode: %v", err)
+		return
+	}
+	defer infostore.Close(db)
+
+	var stateList []*MaintenanceStateInfo
+	if err := db.Where("id = ?", maintenanceStateID).Find(&stateList).Error; err != nil {
+		cblog.Warnf("failed to check the maintenance mode: %v", err)
+		return
+	}
+
+	maintenanceLock.Lock()
+	if len(stateList) == 0 {
+		maintenance = MaintenanceInfo{}
+		maintenanceLock.Unlock()
+		return
+	}
+	state := stateList[0]
+	maintenance = MaintenanceInfo{Enabled: true, Reason: state.Reason, ServerID: state.ServerID, StartTime: state.StartTime}
+	count := runningCallCount
+	maintenanceLock.Unlock()
+
+	report := &MaintenanceServerInfo{ServerID: maintenanceServerID, RunningCalls: count, UpdatedTime: time.Now()}
+	if err := db.Save(report).Error; err != nil {
+		cblog.Warnf("failed to report the running API calls: %v", err)
+	}
+	if state.ServerID == maintenanceServerID || time.Since(state.StartTime) < 3*maintenanceCheckInterval {
+		return
+	}
+
+	var ownerList []*MaintenanceServerInfo
+	staleTime := time.Now().Add(-3 * maintenanceCheckInterval)
+	if err := db.Where("server_id = ? AND updated_time >= ?", state.ServerID, staleTime).Find(&ownerList).Error; err != nil || len(ownerList) > 0 {
+		return
+	}
+	cblog.Warnf("the server '%s' in maintenance does not respond, the maintenance is finished: %s", state.ServerID, state.Reason)
+	db.Where("id = ? AND server_id = ? AND start_time = ?", maintenanceStateID, state.ServerID, state.StartTime).Delete(&MaintenanceStateInfo{})
+}
+
+// getOtherRunningCalls returns the running API calls reported by the other servers since the start of the maintenance.
+// A server which stops reporting is regarded as stopped.
+func getOtherRunningCalls(startTime time.Time) (int, error) {
+	db, err := infostore.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer infostore.Close(db)
+
+	var serverList []*MaintenanceServerInfo
+	err = db.Where("server_id <> ? AND updated_time >= ? AND updated_time >= ?",
+		maintenanceServerID, startTime, time.Now().Add(-3*maintenanceCheckInterval)).
+		Find(&serverList).Error
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, server := range serverList {
+		count += server.RunningCalls
+	}
+	return count, nil
+}
+
+// enterMaintenance starts the maintenance mode of all servers sharing the MetaDB,
+// and waits for the running API calls of the servers to finish.
+func enterMaintenance(reason string) error {
+	db, err := infostore.Open()
+	if err != nil {
+		return err
+	}
+	state := &MaintenanceStateInfo{ID: maintenanceStateID, Reason: reason, ServerID: maintenanceServerID, StartTime: time.Now()}
+	create := db.Clauses(clause.OnConflict{DoNothing: true}).Create(state)
+	if create.Error == nil && create.RowsAffected == 0 {
+		var current MaintenanceStateInfo
+		db.Where("id = ?", maintenanceStateID).First(&current)
+		create.Error = fmt.Errorf("CB-Spider is already under maintenance by the server '%s': %s", current.ServerID, current.Reason)
+	}
+	db.Where("1 = 1").Delete(&MaintenanceServerInfo{})
+	infostore.Close(db)
+	if create.Error != nil {
+		return create.Error
+	}
+
+	maintenanceLock.Lock()
+	maintenance = MaintenanceInfo{Enabled: true, Reason: reason, ServerID: maintenanceServerID, StartTime: state.StartTime}
+	maintenanceLock.Unlock()
+	cblog.Infof("maintenance mode is started: %s", reason)
+
+	deadline := time.Now().Add(maintenanceDrainTimeout)
+	for {
+		maintenanceLock.Lock()
+		count := runningCallCount
+		maintenanceLock.Unlock()
+
+		// the other servers see the maintenance and report their API calls within the check interval
+		if count == 0 && time.Since(state.StartTime) > 2*maintenanceCheckInterval {
+			otherCount, err := getOtherRunningCalls(state.StartTime)
+			if err != nil {
+				exitMaintenance()
+				return err
+			}
+			if otherCount == 0 {
+				return nil
+			}
+			count = otherCount
+		}
+		if time.Now().After(deadline) {
+			exitMaintenance()
+			return fmt.Errorf("%d API calls are still running after %v, try again later", count, maintenanceDrainTimeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// exitMaintenance finishes the maintenance mode of this server, the servers accept API calls again.
+func exitMaintenance() {
+	db, err := infostore.Open()
+	if err == nil {
+		err = db.Where("id = ? AND server_id = ?", maintenanceStateID, maintenanceServerID).Delete(&MaintenanceStateInfo{}).Error
+		db.Where("1 = 1").Delete(&MaintenanceServerInfo{})
+		infostore.Close(db)
+	}
+	if err != nil {
+		cblog.Errorf("failed to finish the maintenance mode in the MetaDB: %v", err)
+	}
+
+	maintenanceLock.Lock()
+	maintenance = MaintenanceInfo{}
+	maintenanceLock.Unlock()
+	cblog.Info("maintenance mode is finished")
+}
+
+// checkNoRunningJob returns an error if any asynchronous job is not finished.
+func checkNoRunningJob() error {
+	var jobList []*JobInfo
+	if err := infostore.ListByCondition(&jobList, "status", JobRunning); err != nil {
+		return err
+	}
+	var queuedList []*JobInfo
+	if err := infostore.ListByCondition(&queuedList, "status", JobQueued); err != nil {
+		return err
+	}
+	if count := len(jobList) + len(queuedList); count > 0 {
+		return fmt.Errorf("%d asynchronous jobs are not finished, try again later", count)
+	}
+	return nil
+}
+
+// getBackupDir returns the directory of the MetaDB backups.
+func getBackupDir() string {
+	return infostore.LoadBackupConfig().BackupDir
+}
+
+// ListMetaDBBackup returns the MetaDB backup files, newest first.
+func ListMetaDBBackup() ([]*infostore.BackupFileInfo, error) {
+	cblog.Info("call ListMetaDBBackup()")
+
+	backupList, err := infostore.ListBackups(getBackupDir())
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return backupList, nil
+}
+
+// CreateMetaDBBackup backs up the SQLite MetaDB now, and removes the oldest backups over the max count.
+// The backup is uploaded to the remote target too, if it is set.
+func CreateMetaDBBackup() (*infostore.BackupFileInfo, error) {
+	cblog.Info("call CreateMetaDBBackup()")
+
+	backupCfg := infostore.LoadBackupConfig()
+	backupPath, err := infostore.BackupMetaDB(backupCfg.BackupDir)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := infostore.RotateBackups(backupCfg.BackupDir, backupCfg.MaxCount); err != nil {
+		cblog.Error(err)
+	}
+	if backupCfg.RemoteEnabled() {
+		if err := infostore.UploadBackup(backupCfg, backupPath); err != nil {
+			err = fmt.Errorf("backup '%s' is created, but failed to upload it: %v", filepath.Base(backupPath), err)
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	backupInfo, err := infostore.GetBackupFileInfo(backupCfg.BackupDir, filepath.Base(backupPath))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return backupInfo, nil
+}
+
+// ValidateMetaDBBackup checks if the backup file can be restored.
+func ValidateMetaDBBackup(fileName string) (*infostore.BackupValidationInfo, error) {
+	cblog.Info("call ValidateMetaDBBackup()")
+
+	fileName, err := EmptyCheckAndTrim("fileName", fileName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	validation, err := infostore.ValidateBackup(getBackupDir(), fileName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return validation, nil
+}
+
+// RestoreMetaDBBackup restores the MetaDB from the backup file in maintenance mode.
+func RestoreMetaDBBackup(fileName string) (*infostore.MetaDBCopyResult, error) {
+	cblog.Info("call RestoreMetaDBBackup()")
+
+	fileName, err := EmptyCheckAndTrim("fileName", fileName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := enterMaintenance(fmt.Sprintf("restoring the MetaDB backup '%s'", fileName)); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer exitMaintenance()
+
+	if err := checkNoRunningJob(); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	result, err := infostore.RestoreMetaDB(getBackupDir(), fileName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// MigrateMetaDB copies all MetaDB tables from the source to the target in maintenance mode.
+// source and target: "" for the MetaDB of this server, a PostgreSQL URL, or a SQLite file path.
+func MigrateMetaDB(source string, target string, overwrite bool) (*infostore.MetaDBCopyResult, error) {
+	cblog.Info("call MigrateMetaDB()")
+
+	if err := enterMaintenance("migrating the MetaDB"); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer exitMaintenance()
+
+	if err := checkNoRunningJob(); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	result, err := infostore.MigrateMetaDB(source, target, overwrite)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// ================ Remote backups in a bucket of Spider S3
+
+// getRemoteBackupObjectName returns the object name of the backup file in the remote target.
+func getRemoteBackupObjectName(cfg infostore.BackupConfig, fileName string) string {
+	return cfg.S3Prefix + fileName + remoteBackupSuffix
+}
+
+// uploadMetaDBBackup encrypts the backup file with the Spider key as a stream, uploads it,
+// and removes the oldest remote backups over cfg.MaxCount.
+// Only an external key is used, a backup encrypted with the key ring of this server is lost with the server.
+func uploadMetaDBBackup(cfg infostore.BackupConfig, backupPath string) error {
+	if err := cim.CheckExternalKey(); err != nil {
+		return fmt.Errorf("remote backup is not allowed: %v", err)
+	}
+
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	encReader, encSize, err := cim.NewEncryptReader(file, stat.Size())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the backup: %v", err)
+	}
+
+	objectName := getRemoteBackupObjectName(cfg, filepath.Base(backupPath))
+	_, err = PutS3ObjectFromReader(cfg.S3ConnectionName, cfg.S3BucketName, objectName, encReader, encSize)
+	if err != nil {
+		return fmt.Errorf("failed to upload the backup to '%s': %v", objectName, err)
+	}
+
+	return rotateRemoteBackups(cfg)
+}
+
+// listRemoteBackupObjects returns the backup objects in the remote target, newest first.
+func listRemoteBackupObjects(cfg infostore.BackupConfig) ([]minio.ObjectInfo, error) {
+	objectList, err := ListS3Objects(cfg.S3ConnectionName, cfg.S3BucketName, cfg.S3Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var backupObjectList []minio.ObjectInfo
+	for _, object := range objectList {
+		fileName := strings.TrimSuffix(strings.TrimPrefix(object.Key, cfg.S3Prefix), remoteBackupSuffix)
+		if !strings.HasSuffix(object.Key, remoteBackupSuffix) || infostore.CheckBackupFileName(fileName) != nil {
+			continue
+		}
+		backupObjectList = append(backupObjectList, object)
+	}
+	sort.Slice(backupObjectList, func(i, j int) bool {
+		return backupObjectList[i].Key > backupObjectList[j].Key
+	})
+	return backupObjectList, nil
+}
+
+// rotateRemoteBackups removes the oldest remote backups over cfg.MaxCount.
+func rotateRemoteBackups(cfg infostore.BackupConfig) error {
+	backupObjectList, err := listRemoteBackupObjects(cfg)
+	if err != nil {
+		return err
+	}
+	for i := cfg.MaxCount; i < len(backupObjectList); i++ {
+		if _, err := DeleteS3Object(cfg.S3ConnectionName, cfg.S3BucketName, backupObjectList[i].Key); err != nil {
+			cblog.Warnf("[MSB] Failed to delete old remote backup '%s': %v", backupObjectList[i].Key, err)
+		} else {
+			cblog.Infof("[MSB] Deleted old remote backup: %s", backupObjectList[i].Key)
+		}
+	}
+	return nil
+}
+
+// getRemoteBackupConfig returns the backup config with the remote target.
+func getRemoteBackupConfig() (infostore.BackupConfig, error) {
+	cfg := infostore.LoadBackupConfig()
+	if !cfg.RemoteEnabled() {
+		return cfg, fmt.Errorf("remote backup target is not set (SPIDER_BACKUP_S3_CONNECTION, SPIDER_BACKUP_S3_BUCKET)")
+	}
+	return cfg, nil
+}
+
+// ListMetaDBRemoteBackup returns the backups in the remote target, newest first.
+// FileName is the name of the backup file, and Size is the size of the encrypted object.
+func ListMetaDBRemoteBackup() ([]*infostore.BackupFileInfo, error) {
+	cblog.Info("call ListMetaDBRemoteBackup()")
+
+	cfg, err := getRemoteBackupConfig()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	backupObjectList, err := listRemoteBackupObjects(cfg)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	backupList := []*infostore.BackupFileInfo{}
+	for _, object := range backupObjectList {
+		backupList = append(backupList, &infostore.BackupFileInfo{
+			FileName:    strings.TrimSuffix(strings.TrimPrefix(object.Key, cfg.S3Prefix), remoteBackupSuffix),
+			Size:        object.Size,
+			CreatedTime: object.LastModified,
+		})
+	}
+	return backupList, nil
+}
+
+// RestoreMetaDBRemoteBackup downloads and decrypts the remote backup into the local backup directory,
+// and restores the MetaDB from it in maintenance mode.
+func RestoreMetaDBRemoteBackup(fileName string) (*infostore.MetaDBCopyResult, error) {
+	cblog.Info("call RestoreMetaDBRemoteBackup()")
+
+	fileName, err := EmptyCheckAndTrim("fileName", fileName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := infostore.CheckBackupFileName(fileName); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	cfg, err := getRemoteBackupConfig()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	objectName := getRemoteBackupObjectName(cfg, fileName)
+	reader, err := GetS3ObjectStream(cfg.S3ConnectionName, cfg.S3BucketName, objectName)
+	if err != nil {
+		err = fmt.Errorf("failed to download the remote backup '%s': %v", objectName, err)
+		cblog.Error(err)
+		return nil, err
+	}
+	defer reader.Close()
+	decReader, err := cim.NewDecryptReader(io.LimitReader(reader, maxRemoteBackupSize))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := infostore.SaveBackupFile(cfg.BackupDir, fileName, decReader); err != nil {
+		err = fmt.Errorf("failed to download the remote backup '%s': %v", objectName, err)
+		cblog.Error(err)
+		return nil, err
+	}
+	cblog.Infof("[MSB] Remote backup is downloaded: %s", objectName)
+
+	return RestoreMetaDBBackup(fileName)
+}
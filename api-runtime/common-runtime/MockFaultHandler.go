@@ -0,0 +1,64 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	mkrs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
+)
+
+// ================ Mock Driver Fault Injection Handler
+
+// GetMockFault returns the fault injection spec of the Mock Driver's MockName.
+func GetMockFault(mockName string) (string, error) {
+	cblog.Info("call GetMockFault()")
+
+	// check empty and trim user input
+	mockName, err := EmptyCheckAndTrim("mockName", mockName)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	return mkrs.GetFaultSpec(mockName), nil
+}
+
+// SetMockFault replaces the fault injection rules of the Mock Driver's MockName.
+// ex) spec = "StartVM:delay=2s,error=0.3;CreateRDBMS:stuck=true;ListVM:lag=5s"
+func SetMockFault(mockName string, spec string) (string, error) {
+	cblog.Info("call SetMockFault()")
+
+	// check empty and trim user input
+	mockName, err := EmptyCheckAndTrim("mockName", mockName)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	if err := mkrs.SetFaultSpec(mockName, spec); err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	return mkrs.GetFaultSpec(mockName), nil
+}
+
+// ClearMockFault removes the fault injection rules of the Mock Driver's MockName.
+func ClearMockFault(mockName string) (bool, error) {
+	cblog.Info("call ClearMockFault()")
+
+	// check empty and trim user input
+	mockName, err := EmptyCheckAndTrim("mockName", mockName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	mkrs.ClearFault(mockName)
+	return true, nil
+}
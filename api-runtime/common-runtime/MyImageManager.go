@@ -35,7 +35,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&MyImageIIDInfo{})
+	infostore.AutoMigrate(db, &MyImageIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -35,7 +35,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&NICIIDInfo{})
+	infostore.AutoMigrate(db, &NICIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -37,7 +37,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&NLBIIDInfo{})
+	infostore.AutoMigrate(db, &NLBIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -35,7 +35,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&PublicIPIIDInfo{})
+	infostore.AutoMigrate(db, &PublicIPIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -46,7 +47,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&RDBMSIIDInfo{})
+	infostore.AutoMigrate(db, &RDBMSIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -899,6 +900,236 @@ func DeleteRDBMS(connectionName string, rsType string, nameID string, force stri
 	return result, nil
 }
 
+// -------- RDBMS Instance Control
+
+// getRDBMSIIDInfo returns the IID info of the RDBMS. Caller must hold rdbmsSPLock.
+func getRDBMSIIDInfo(connectionName string, rsType string, nameID string) (*RDBMSIIDInfo, error) {
+	var iidInfoList []*RDBMSIIDInfo
+	var err error
+	if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+		err = getAuthIIDInfoList(connectionName, &iidInfoList)
+	} else {
+		err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, OneIIdInfo := range iidInfoList {
+		if OneIIdInfo.NameId == nameID {
+			return OneIIdInfo, nil
+		}
+	}
+	return nil, fmt.Errorf("%s '%s' does not exist in connection '%s'", RSTypeString(rsType), nameID, connectionName)
+}
+
+// findDBSpec returns the orderable DBSpec of the engine from DBSpecHandler.ListDBSpec.
+func findDBSpec(connectionName string, dbEngine string, specName string) (*cres.DBSpecInfo, error) {
+	specList, err := ListDBSpec(connectionName, dbEngine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate DBSpec '%s': %v", specName, err)
+	}
+	for _, specInfo := range specList {
+		if specInfo.Name == specName {
+			return specInfo, nil
+		}
+	}
+	return nil, fmt.Errorf("DBSpec '%s' is not an orderable %s spec in connection '%s'", specName, dbEngine, connectionName)
+}
+
+// waitRDBMSChange waits until the CSP finishes applying the change:
+// the RDBMS is Available and done(info) is true.
+func waitRDBMSChange(connectionName string, handler cres.RDBMSHandler, driverIId cres.IID, done func(info cres.RDBMSInfo) bool) error {
+	waiter := NewWaiter(10, 1800) // (sleep, timeout)
+	for {
+		info, err := handler.GetRDBMS(driverIId)
+		if err != nil {
+			cblog.Error(err)
+			if !checkNotFoundError(err) {
+				return err
+			}
+		} else if info.Status == cres.RDBMSAvailable && done(info) {
+			return nil
+		} else if info.Status == cres.RDBMSError {
+			return fmt.Errorf("[%s] RDBMS %s is in Error status while applying the change", connectionName, driverIId.NameId)
+		}
+
+		if !waiter.Wait() {
+			return fmt.Errorf("[%s] Failed to wait for the change of RDBMS %s. (Timeout=%v)", connectionName, driverIId.NameId, waiter.Timeout)
+		}
+	}
+}
+
+// ChangeRDBMSSpec changes the DBSpec of the RDBMS and waits until the CSP applies it.
+// The new spec must be one of the orderable specs of DBSpecHandler.ListDBSpec.
+func ChangeRDBMSSpec(connectionName string, rsType string, nameID string, newSpec string) (*cres.RDBMSInfo, error) {
+	cblog.Info("call ChangeRDBMSSpec()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	newSpec, err = EmptyCheckAndTrim("newSpec", newSpec)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = func() error {
+		cldConn, err := ccm.GetCloudConnection(connectionName)
+		if err != nil {
+			return err
+		}
+		handler, err := cldConn.CreateRDBMSHandler()
+		if err != nil {
+			return err
+		}
+
+		rdbmsSPLock.Lock(connectionName, nameID)
+		defer rdbmsSPLock.Unlock(connectionName, nameID)
+
+		iidInfo, err := getRDBMSIIDInfo(connectionName, rsType, nameID)
+		if err != nil {
+			return err
+		}
+		driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+		info, err := handler.GetRDBMS(driverIId)
+		if err != nil {
+			return err
+		}
+		if info.Status != cres.RDBMSAvailable {
+			return fmt.Errorf("%s '%s' is not Available. Current status: %s", RSTypeString(rsType), nameID, info.Status)
+		}
+		if info.DBSpec == newSpec {
+			return fmt.Errorf("%s '%s' already has DBSpec '%s'", RSTypeString(rsType), nameID, newSpec)
+		}
+		if metaInfo, err := handler.GetMetaInfo(info.DBEngine); err == nil && !metaInfo.SupportsSpecChange {
+			return fmt.Errorf("changing the DBSpec of %s is not supported in this CSP. See SupportsSpecChange in GetMetaInfo", RSTypeString(rsType))
+		}
+
+		// validate the new spec
+		specInfo, err := findDBSpec(connectionName, info.DBEngine, newSpec)
+		if err != nil {
+			return err
+		}
+		if size, err := strconv.ParseInt(info.StorageSize, 10, 64); err == nil && specInfo.StorageSizeRangeGB.Max > 0 {
+			if size < specInfo.StorageSizeRangeGB.Min || size > specInfo.StorageSizeRangeGB.Max {
+				return fmt.Errorf("StorageSize %dGB of %s '%s' is out of range(%d-%dGB) for DBSpec '%s'", size, RSTypeString(rsType), nameID,
+					specInfo.StorageSizeRangeGB.Min, specInfo.StorageSizeRangeGB.Max, newSpec)
+			}
+		}
+
+		_, err = handler.ChangeSpec(driverIId, newSpec)
+		if err != nil {
+			return err
+		}
+
+		return waitRDBMSChange(connectionName, handler, driverIId, func(info cres.RDBMSInfo) bool {
+			return info.DBSpec == newSpec
+		})
+	}()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return GetRDBMS(connectionName, rsType, nameID)
+}
+
+// ChangeRDBMSStorageSize expands the storage(GB) of the RDBMS and waits until the CSP applies it.
+func ChangeRDBMSStorageSize(connectionName string, rsType string, nameID string, newSize string) (*cres.RDBMSInfo, error) {
+	cblog.Info("call ChangeRDBMSStorageSize()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	newSize, err = EmptyCheckAndTrim("newSize", newSize)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	size, err := strconv.ParseInt(newSize, 10, 64)
+	if err != nil || size <= 0 {
+		err = fmt.Errorf("invalid StorageSize '%s': it should be a positive integer in GB", newSize)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = func() error {
+		cldConn, err := ccm.GetCloudConnection(connectionName)
+		if err != nil {
+			return err
+		}
+		handler, err := cldConn.CreateRDBMSHandler()
+		if err != nil {
+			return err
+		}
+
+		rdbmsSPLock.Lock(connectionName, nameID)
+		defer rdbmsSPLock.Unlock(connectionName, nameID)
+
+		iidInfo, err := getRDBMSIIDInfo(connectionName, rsType, nameID)
+		if err != nil {
+			return err
+		}
+		driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+		info, err := handler.GetRDBMS(driverIId)
+		if err != nil {
+			return err
+		}
+		if info.Status != cres.RDBMSAvailable {
+			return fmt.Errorf("%s '%s' is not Available. Current status: %s", RSTypeString(rsType), nameID, info.Status)
+		}
+		if curSize, err := strconv.ParseInt(info.StorageSize, 10, 64); err == nil && size <= curSize {
+			return fmt.Errorf("StorageSize can only be expanded. Current: %dGB, Requested: %dGB", curSize, size)
+		}
+		if metaInfo, err := handler.GetMetaInfo(info.DBEngine); err == nil && !metaInfo.SupportsStorageSizeChange {
+			return fmt.Errorf("changing the StorageSize of %s is not supported in this CSP. See SupportsStorageSizeChange in GetMetaInfo", RSTypeString(rsType))
+		}
+
+		// validate the size with the range of the current spec
+		specInfo, err := findDBSpec(connectionName, info.DBEngine, info.DBSpec)
+		if err != nil {
+			return err
+		}
+		if specInfo.StorageSizeRangeGB.Max > 0 && size > specInfo.StorageSizeRangeGB.Max {
+			return fmt.Errorf("StorageSize %dGB is out of range(%d-%dGB) for DBSpec '%s'", size,
+				specInfo.StorageSizeRangeGB.Min, specInfo.StorageSizeRangeGB.Max, info.DBSpec)
+		}
+
+		_, err = handler.ChangeStorageSize(driverIId, newSize)
+		if err != nil {
+			return err
+		}
+
+		return waitRDBMSChange(connectionName, handler, driverIId, func(info cres.RDBMSInfo) bool {
+			curSize, err := strconv.ParseInt(info.StorageSize, 10, 64)
+			return err != nil || curSize >= size
+		})
+	}()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return GetRDBMS(connectionName, rsType, nameID)
+}
+
 func CountAllRDBMS() (int64, error) {
 	var info RDBMSIIDInfo
 	count, err := infostore.CountAllNameIDs(&info)
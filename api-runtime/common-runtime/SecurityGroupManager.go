@@ -36,7 +36,7 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&SGIIDInfo{})
+	infostore.AutoMigrate(db, &SGIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -0,0 +1,1504 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Stack Manager — declarative plan, apply and destroy of a group of resources in a connection.
+// The document and the resources owned by a stack are persisted in Spider MetaDB.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	splock "github.com/cloud-barista/cb-spider/api-runtime/common-runtime/sp-lock"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"gopkg.in/yaml.v3"
+)
+
+// Stack Status
+const (
+	StackApplied = "Applied" // all resources of the document are applied
+	StackFailed  = "Failed"  // the last apply or destroy is not finished, Error has the reason
+)
+
+// Stack Plan Actions
+const (
+	StackActionCreate   = "Create"   // the resource will be created
+	StackActionAttach   = "Attach"   // the disk will be attached to the VM of the document
+	StackActionDelete   = "Delete"   // the resource is removed from the document and will be deleted
+	StackActionUpdate   = "Update"   // the resource differs from the document, it is updated in place
+	StackActionReplace  = "Replace"  // the resource differs in a field which cannot be updated, it is deleted and created again
+	StackActionNoChange = "NoChange" // the resource is the same as the document
+)
+
+// stackResourceTypes is the apply order of the resource types, a resource type is created after
+// the resource types which it refers to. Resources are deleted in the reverse order.
+var stackResourceTypes = []string{VPC, SG, KEY, VM, DISK, NLB}
+
+// StackDocument describes the resources of a stack and the references between them.
+// References are the names of other resources in the document or in the connection.
+// All values of a document are strings, ex) "22" for a port.
+type StackDocument struct {
+	Name           string               `json:"Name" validate:"required" example:"web-stack"`
+	ConnectionName string               `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ResourceList   []*StackResourceInfo `json:"ResourceList" validate:"required"`
+}
+
+// StackResourceInfo is a resource of a stack document.
+// The Spec is StackVPCSpec, StackSGSpec, StackKeyPairSpec, StackVMSpec, StackDiskSpec or StackNLBSpec by the Type.
+type StackResourceInfo struct {
+	Type string          `json:"Type" validate:"required" example:"vpc" enums:"vpc,sg,keypair,vm,disk,nlb"`
+	Name string          `json:"Name" validate:"required" example:"vpc-01"`
+	Spec json.RawMessage `json:"Spec,omitempty" swaggertype:"object"`
+
+	spec interface{} // decoded Spec
+}
+
+// StackVPCSpec is the Spec of a VPC in a stack document.
+type StackVPCSpec struct {
+	IPv4_CIDR      string             `json:"IPv4_CIDR,omitempty" example:"10.0.0.0/16"`
+	SubnetInfoList []*StackSubnetSpec `json:"SubnetInfoList" validate:"required"`
+	TagList        []cres.KeyValue    `json:"TagList,omitempty"`
+}
+
+// StackSubnetSpec is a subnet of StackVPCSpec.
+type StackSubnetSpec struct {
+	Name      string          `json:"Name" validate:"required" example:"subnet-01"`
+	Zone      string          `json:"Zone,omitempty" example:"us-east-1b"`
+	IPv4_CIDR string          `json:"IPv4_CIDR" validate:"required" example:"10.0.1.0/24"`
+	TagList   []cres.KeyValue `json:"TagList,omitempty"`
+}
+
+// StackSGSpec is the Spec of a SecurityGroup in a stack document.
+type StackSGSpec struct {
+	VPCName       string                  `json:"VPCName" validate:"required" example:"vpc-01"`
+	SecurityRules []cres.SecurityRuleInfo `json:"SecurityRules,omitempty"`
+	TagList       []cres.KeyValue         `json:"TagList,omitempty"`
+}
+
+// StackKeyPairSpec is the Spec of a KeyPair in a stack document.
+// The public key is imported, a stack does not create a private key which it cannot return again.
+type StackKeyPairSpec struct {
+	PublicKey string          `json:"PublicKey" validate:"required" example:"ssh-rsa AAAAB3..."`
+	TagList   []cres.KeyValue `json:"TagList,omitempty"`
+}
+
+// StackVMSpec is the Spec of a VM in a stack document.
+type StackVMSpec struct {
+	ImageType          string                `json:"ImageType,omitempty" example:"PublicImage"` // PublicImage or MyImage
+	ImageName          string                `json:"ImageName" validate:"required" example:"ami-0c55b159cbfafe1f0"`
+	VMSpecName         string                `json:"VMSpecName" validate:"required" example:"t2.micro"`
+	VPCName            string                `json:"VPCName" validate:"required" example:"vpc-01"`
+	SubnetName         string                `json:"SubnetName" validate:"required" example:"subnet-01"`
+	SecurityGroupNames []string              `json:"SecurityGroupNames" validate:"required" example:"sg-01"`
+	KeyPairName        string                `json:"KeyPairName,omitempty" example:"keypair-01"`
+	RootDiskType       string                `json:"RootDiskType,omitempty" example:"gp2"`
+	RootDiskSize       string                `json:"RootDiskSize,omitempty" example:"50"`
+	VMUserId           string                `json:"VMUserId,omitempty" example:"Administrator"`
+	VMUserPasswd       string                `json:"VMUserPasswd,omitempty" example:"password"`
+	UserData           string                `json:"UserData,omitempty" example:"#cloud-config"`
+	UserDataEncoding   string                `json:"UserDataEncoding,omitempty" example:"plain"` // plain(default) or base64
+	PurchaseOption     cres.VMPurchaseOption `json:"PurchaseOption,omitempty"`                   // OnDemand(default) or Spot
+	TagList            []cres.KeyValue       `json:"TagList,omitempty"`
+}
+
+// StackDiskSpec is the Spec of a Disk in a stack document.
+type StackDiskSpec struct {
+	Zone         string          `json:"Zone,omitempty" example:"us-east-1b"`
+	DiskType     string          `json:"DiskType,omitempty" example:"gp2"`       // if not specified, default is used
+	DiskSize     string          `json:"DiskSize,omitempty" example:"100"`       // if not specified, default is used (unit is GB)
+	AttachVMName string          `json:"AttachVMName,omitempty" example:"vm-01"` // VM to attach the disk to
+	TagList      []cres.KeyValue `json:"TagList,omitempty"`
+}
+
+// StackNLBSpec is the Spec of a NLB in a stack document.
+type StackNLBSpec struct {
+	VPCName       string                    `json:"VPCName" validate:"required" example:"vpc-01"`
+	Type          string                    `json:"Type,omitempty" example:"PUBLIC"`  // PUBLIC(V) | INTERNAL
+	Scope         string                    `json:"Scope,omitempty" example:"REGION"` // REGION(V) | GLOBAL
+	Listener      StackNLBListenerSpec      `json:"Listener" validate:"required"`
+	VMGroup       StackNLBVMGroupSpec       `json:"VMGroup,omitempty"`
+	HealthChecker StackNLBHealthCheckerSpec `json:"HealthChecker" validate:"required"`
+	TagList       []cres.KeyValue           `json:"TagList,omitempty"`
+}
+
+// StackNLBListenerSpec is the listener of StackNLBSpec.
+type StackNLBListenerSpec struct {
+	Protocol string `json:"Protocol" validate:"required" example:"TCP"` // TCP|UDP
+	Port     string `json:"Port" validate:"required" example:"22"`
+}
+
+// StackNLBVMGroupSpec is the VM group of StackNLBSpec.
+type StackNLBVMGroupSpec struct {
+	Protocol string   `json:"Protocol,omitempty" example:"TCP"` // if not specified, the listener's is used
+	Port     string   `json:"Port,omitempty" example:"22"`      // if not specified, the listener's is used
+	VMs      []string `json:"VMs,omitempty" example:"vm-01"`
+}
+
+// StackNLBHealthCheckerSpec is the health checker of StackNLBSpec.
+type StackNLBHealthCheckerSpec struct {
+	Protocol  string `json:"Protocol" validate:"required" example:"TCP"` // TCP|HTTP
+	Port      string `json:"Port" validate:"required" example:"22"`
+	Interval  string `json:"Interval,omitempty" example:"default"`  // secs, if not specified, determined by CSP
+	Timeout   string `json:"Timeout,omitempty" example:"default"`   // secs, if not specified, determined by CSP
+	Threshold string `json:"Threshold,omitempty" example:"default"` // num, if not specified, determined by CSP
+}
+
+// StackInfo represents a stack applied to a connection, with the resources owned by the stack.
+// Primary key is "connectionName::stackName" like TopologyLayoutInfo.
+type StackInfo struct {
+	ID             string                    `gorm:"primaryKey" json:"-"`
+	ConnectionName string                    `gorm:"index;not null" json:"ConnectionName" example:"aws-connection"`
+	Name           string                    `gorm:"not null" json:"Name" example:"web-stack"`
+	Status         string                    `json:"Status" example:"Applied" enums:"Applied,Failed"`
+	Error          string                    `gorm:"type:text" json:"Error,omitempty"`
+	DocumentJSON   string                    `gorm:"type:text" json:"-"`
+	StateJSON      string                    `gorm:"type:text" json:"-"`
+	Document       *StackDocument            `gorm:"-" json:"Document"`     // Last applied document, VMUserPasswd is redacted
+	ResourceList   []*StackResourceStateInfo `gorm:"-" json:"ResourceList"` // Resources owned by the stack
+	CreatedTime    time.Time                 `json:"CreatedTime" example:"2026-10-01T12:00:00Z"`
+	UpdatedTime    time.Time                 `json:"UpdatedTime" example:"2026-10-01T12:00:00Z"`
+}
+
+func (StackInfo) TableName() string {
+	return "stack_infos"
+}
+
+// StackResourceStateInfo is a resource created and owned by a stack.
+type StackResourceStateInfo struct {
+	ResourceType string `json:"ResourceType" validate:"required" example:"vm"`
+	Name         string `json:"Name" validate:"required" example:"vm-01"`
+	SystemId     string `json:"SystemId,omitempty" example:"i-0bc7123b7e5cbf79d"`
+}
+
+// StackPlanInfo represents the actions to apply a stack document, in the order of apply.
+type StackPlanInfo struct {
+	Name           string             `json:"Name" validate:"required" example:"web-stack"`
+	ConnectionName string             `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ActionList     []*StackActionInfo `json:"ActionList" validate:"required"`
+	WarningList    []string           `json:"WarningList,omitempty"` // Lookups which failed during the plan
+}
+
+// StackActionInfo represents the action of a resource in the stack plan.
+type StackActionInfo struct {
+	ResourceType string   `json:"ResourceType" validate:"required" example:"vm"`
+	Name         string   `json:"Name" validate:"required" example:"vm-01"`
+	Action       string   `json:"Action" validate:"required" example:"Create" enums:"Create,Attach,Delete,Update,Replace,NoChange"`
+	DiffList     []string `json:"DiffList,omitempty" example:"VMSpecName: t2.small => t2.micro"` // live value => document value
+	BlockedBy    []string `json:"BlockedBy,omitempty" example:"disk/disk-01"`                    // resources of the stack which use the resource to replace, the apply is refused
+}
+
+// stackSPLock serializes the applies and destroys of a stack across all Spider servers sharing the MetaDB.
+var stackSPLock = splock.New("Stack")
+
+// stackLockTimeout is the wait for the lock of a stack, a concurrent apply or destroy fails after it.
+const stackLockTimeout = time.Second
+
+// stackDiff is a field of a resource which differs from the document.
+type stackDiff struct {
+	field     string
+	liveValue string
+	docValue  string
+	inPlace   bool // the field is corrected without replacing the resource
+}
+
+func (diff stackDiff) String() string {
+	return fmt.Sprintf("%s: %s => %s", diff.field, diff.liveValue, diff.docValue)
+}
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	defer infostore.Close(db)
+	infostore.AutoMigrate(db, &StackInfo{})
+}
+
+func makeStackID(connectionName, stackName string) string {
+	return connectionName + "::" + stackName
+}
+
+func stackResourceKey(rsType string, name string) string {
+	return rsType + "/" + name
+}
+
+// ParseStackDocument parses a stack document in YAML or JSON.
+func ParseStackDocument(data []byte) (*StackDocument, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid stack document: %v", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("stack document is empty")
+	}
+	jsonData, err := json.Marshal(stackValueToString(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid stack document: %v", err)
+	}
+
+	doc := &StackDocument{}
+	if err := decodeStrictJSON(jsonData, doc); err != nil {
+		return nil, fmt.Errorf("invalid stack document: %v", err)
+	}
+	return doc, nil
+}
+
+// stackValueToString converts the scalars of a parsed document to strings, ex) 22 => "22".
+func stackValueToString(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = stackValueToString(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stackValueToString(item)
+		}
+		return v
+	case nil, string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// decodeStrictJSON decodes JSON and rejects unknown fields, which are typos in a document.
+func decodeStrictJSON(data []byte, out interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
+
+// validateStackDocument checks the document and decodes the Spec of the resources.
+func validateStackDocument(doc *StackDocument) error {
+	if doc == nil {
+		return fmt.Errorf("stack document is empty")
+	}
+	var err error
+	if doc.Name, err = EmptyCheckAndTrim("Name of the stack", strings.TrimSpace(doc.Name)); err != nil {
+		return err
+	}
+	if doc.ConnectionName, err = EmptyCheckAndTrim("ConnectionName of the stack", strings.TrimSpace(doc.ConnectionName)); err != nil {
+		return err
+	}
+	if len(doc.ResourceList) == 0 {
+		return fmt.Errorf("stack '%s' has no resources", doc.Name)
+	}
+
+	resourceMap := map[string]bool{}
+	for _, resource := range doc.ResourceList {
+		if resource == nil {
+			return fmt.Errorf("stack '%s' has an empty resource", doc.Name)
+		}
+		resource.Type = strings.ToLower(strings.TrimSpace(resource.Type))
+		if resource.Name, err = EmptyCheckAndTrim("Name of the "+resource.Type, strings.TrimSpace(resource.Name)); err != nil {
+			return err
+		}
+		key := stackResourceKey(resource.Type, resource.Name)
+		if resourceMap[key] {
+			return fmt.Errorf("%s '%s' is duplicated in the stack", resource.Type, resource.Name)
+		}
+		resourceMap[key] = true
+
+		if err := decodeStackResourceSpec(resource); err != nil {
+			return fmt.Errorf("%s '%s': %v", resource.Type, resource.Name, err)
+		}
+	}
+	return nil
+}
+
+// decodeStackResourceSpec decodes the Spec by the Type and checks the required fields.
+func decodeStackResourceSpec(resource *StackResourceInfo) error {
+	spec := resource.Spec
+	if len(spec) == 0 || string(spec) == "null" {
+		spec = []byte("{}")
+	}
+
+	var required map[string]string
+	switch resource.Type {
+	case VPC:
+		vpcSpec := &StackVPCSpec{}
+		if err := decodeStrictJSON(spec, vpcSpec); err != nil {
+			return err
+		}
+		if len(vpcSpec.SubnetInfoList) == 0 {
+			return fmt.Errorf("SubnetInfoList is empty")
+		}
+		for _, subnet := range vpcSpec.SubnetInfoList {
+			if subnet == nil || strings.TrimSpace(subnet.Name) == "" {
+				return fmt.Errorf("Name of a subnet is empty")
+			}
+			subnet.Name = strings.TrimSpace(subnet.Name)
+		}
+		resource.spec = vpcSpec
+	case SG:
+		sgSpec := &StackSGSpec{}
+		if err := decodeStrictJSON(spec, sgSpec); err != nil {
+			return err
+		}
+		required = map[string]string{"VPCName": sgSpec.VPCName}
+		resource.spec = sgSpec
+	case KEY:
+		keySpec := &StackKeyPairSpec{}
+		if err := decodeStrictJSON(spec, keySpec); err != nil {
+			return err
+		}
+		required = map[string]string{"PublicKey": keySpec.PublicKey}
+		resource.spec = keySpec
+	case VM:
+		vmSpec := &StackVMSpec{}
+		if err := decodeStrictJSON(spec, vmSpec); err != nil {
+			return err
+		}
+		if len(vmSpec.SecurityGroupNames) == 0 {
+			return fmt.Errorf("SecurityGroupNames is empty")
+		}
+		required = map[string]string{"ImageName": vmSpec.ImageName, "VMSpecName": vmSpec.VMSpecName,
+			"VPCName": vmSpec.VPCName, "SubnetName": vmSpec.SubnetName}
+		resource.spec = vmSpec
+	case DISK:
+		diskSpec := &StackDiskSpec{}
+		if err := decodeStrictJSON(spec, diskSpec); err != nil {
+			return err
+		}
+		resource.spec = diskSpec
+	case NLB:
+		nlbSpec := &StackNLBSpec{}
+		if err := decodeStrictJSON(spec, nlbSpec); err != nil {
+			return err
+		}
+		required = map[string]string{"VPCName": nlbSpec.VPCName, "Listener.Protocol": nlbSpec.Listener.Protocol,
+			"Listener.Port": nlbSpec.Listener.Port, "HealthChecker.Protocol": nlbSpec.HealthChecker.Protocol,
+			"HealthChecker.Port": nlbSpec.HealthChecker.Port}
+		resource.spec = nlbSpec
+	default:
+		return fmt.Errorf("resource type '%s' is not supported in a stack, use one of %s",
+			resource.Type, strings.Join(stackResourceTypes, ", "))
+	}
+
+	var emptyList []string
+	for name, value := range required {
+		if strings.TrimSpace(value) == "" {
+			emptyList = append(emptyList, name)
+		}
+	}
+	if len(emptyList) > 0 {
+		sort.Strings(emptyList)
+		return fmt.Errorf("%s is empty", strings.Join(emptyList, ", "))
+	}
+	return nil
+}
+
+// stackReference is a resource referred to by a resource of the document.
+type stackReference struct {
+	rsType    string
+	name      string
+	ownerName string // owner VPC of a subnet
+}
+
+// getStackReferences returns the resources which the resource refers to.
+func getStackReferences(resource *StackResourceInfo) []stackReference {
+	var refList []stackReference
+	switch spec := resource.spec.(type) {
+	case *StackSGSpec:
+		refList = append(refList, stackReference{rsType: VPC, name: spec.VPCName})
+	case *StackVMSpec:
+		refList = append(refList, stackReference{rsType: VPC, name: spec.VPCName})
+		refList = append(refList, stackReference{rsType: SUBNET, name: spec.SubnetName, ownerName: spec.VPCName})
+		for _, sgName := range spec.SecurityGroupNames {
+			refList = append(refList, stackReference{rsType: SG, name: sgName})
+		}
+		if spec.KeyPairName != "" {
+			refList = append(refList, stackReference{rsType: KEY, name: spec.KeyPairName})
+		}
+	case *StackDiskSpec:
+		if spec.AttachVMName != "" {
+			refList = append(refList, stackReference{rsType: VM, name: spec.AttachVMName})
+		}
+	case *StackNLBSpec:
+		refList = append(refList, stackReference{rsType: VPC, name: spec.VPCName})
+		for _, vmName := range spec.VMGroup.VMs {
+			refList = append(refList, stackReference{rsType: VM, name: vmName})
+		}
+	}
+	return refList
+}
+
+// stackPlanContext has the document, the state and the resources of the connection for a plan.
+type stackPlanContext struct {
+	doc          *StackDocument
+	resourceMap  map[string]*StackResourceInfo      // "type/name" of the document
+	stateMap     map[string]*StackResourceStateInfo // "type/name" owned by the stack
+	existNameMap map[string]map[string]bool         // type => names in the IID store
+}
+
+func newStackPlanContext(doc *StackDocument, stateList []*StackResourceStateInfo) (*stackPlanContext, error) {
+	ctx := &stackPlanContext{
+		doc:          doc,
+		resourceMap:  map[string]*StackResourceInfo{},
+		stateMap:     map[string]*StackResourceStateInfo{},
+		existNameMap: map[string]map[string]bool{},
+	}
+	for _, resource := range doc.ResourceList {
+		ctx.resourceMap[stackResourceKey(resource.Type, resource.Name)] = resource
+	}
+	for _, state := range stateList {
+		ctx.stateMap[stackResourceKey(state.ResourceType, state.Name)] = state
+	}
+	for _, rsType := range stackResourceTypes {
+		nameList, err := ListResourceName(doc.ConnectionName, rsType)
+		if err != nil {
+			return nil, err
+		}
+		ctx.existNameMap[rsType] = map[string]bool{}
+		for _, name := range nameList {
+			ctx.existNameMap[rsType][name] = true
+		}
+	}
+	return ctx, nil
+}
+
+// checkReferences checks that the references are in the document, or in the connection and not owned by the stack.
+func (ctx *stackPlanContext) checkReferences() error {
+	for _, resource := range ctx.doc.ResourceList {
+		for _, ref := range getStackReferences(resource) {
+			if ref.rsType == SUBNET {
+				if err := ctx.checkSubnetReference(resource, ref); err != nil {
+					return err
+				}
+				continue
+			}
+			key := stackResourceKey(ref.rsType, ref.name)
+			if _, ok := ctx.resourceMap[key]; ok {
+				continue
+			}
+			if _, ok := ctx.stateMap[key]; ok {
+				return fmt.Errorf("%s '%s' referred to by %s '%s' is removed from the stack and will be deleted",
+					ref.rsType, ref.name, resource.Type, resource.Name)
+			}
+			if !ctx.existNameMap[ref.rsType][ref.name] {
+				return fmt.Errorf("%s '%s' referred to by %s '%s' is not in the stack nor in the connection",
+					ref.rsType, ref.name, resource.Type, resource.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (ctx *stackPlanContext) checkSubnetReference(resource *StackResourceInfo, ref stackReference) error {
+	if vpc, ok := ctx.resourceMap[stackResourceKey(VPC, ref.ownerName)]; ok {
+		for _, subnet := range vpc.spec.(*StackVPCSpec).SubnetInfoList {
+			if subnet.Name == ref.name {
+				return nil
+			}
+		}
+	} else {
+		has, err := infostore.HasBy3Conditions(&SubnetIIDInfo{}, CONNECTION_NAME_COLUMN, ctx.doc.ConnectionName,
+			NAME_ID_COLUMN, ref.name, OWNER_VPC_NAME_COLUMN, ref.ownerName)
+		if err != nil {
+			return err
+		}
+		if has {
+			return nil
+		}
+	}
+	return fmt.Errorf("subnet '%s' referred to by %s '%s' is not in the VPC '%s'",
+		ref.name, resource.Type, resource.Name, ref.ownerName)
+}
+
+// GetStackPlan returns the actions to apply the stack document, without changing any resource.
+func GetStackPlan(doc *StackDocument) (*StackPlanInfo, error) {
+	cblog.Info("call GetStackPlan()")
+
+	if err := validateStackDocument(doc); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	stackInfo, err := getStackInfo(doc.ConnectionName, doc.Name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	var stateList []*StackResourceStateInfo
+	if stackInfo != nil {
+		stateList = stackInfo.ResourceList
+	}
+
+	plan, err := buildStackPlan(doc, stateList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return plan, nil
+}
+
+// buildStackPlan diffs the document with the state of the stack, the IID stores and the CSP.
+func buildStackPlan(doc *StackDocument, stateList []*StackResourceStateInfo) (*StackPlanInfo, error) {
+	ctx, err := newStackPlanContext(doc, stateList)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.checkReferences(); err != nil {
+		return nil, err
+	}
+	for _, resource := range doc.ResourceList {
+		_, owned := ctx.stateMap[stackResourceKey(resource.Type, resource.Name)]
+		if !owned && ctx.existNameMap[resource.Type][resource.Name] {
+			return nil, fmt.Errorf("%s '%s' already exists in the connection and is not owned by the stack '%s'",
+				resource.Type, resource.Name, doc.Name)
+		}
+	}
+
+	plan := &StackPlanInfo{
+		Name:           doc.Name,
+		ConnectionName: doc.ConnectionName,
+		ActionList:     []*StackActionInfo{},
+	}
+
+	// owned resources removed from the document, in the reverse order of the resource types
+	for i := len(stackResourceTypes) - 1; i >= 0; i-- {
+		for _, state := range stateList {
+			key := stackResourceKey(state.ResourceType, state.Name)
+			if state.ResourceType != stackResourceTypes[i] || ctx.resourceMap[key] != nil {
+				continue
+			}
+			if !ctx.existNameMap[state.ResourceType][state.Name] {
+				plan.WarningList = append(plan.WarningList, fmt.Sprintf("%s '%s' is removed from the stack and is already deleted",
+					state.ResourceType, state.Name))
+				continue
+			}
+			plan.ActionList = append(plan.ActionList, &StackActionInfo{ResourceType: state.ResourceType, Name: state.Name, Action: StackActionDelete})
+		}
+	}
+
+	// resources of the document, in the order of the resource types
+	for _, rsType := range stackResourceTypes {
+		var actionList []*StackActionInfo
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		for _, resource := range doc.ResourceList {
+			if resource.Type != rsType {
+				continue
+			}
+			action := &StackActionInfo{ResourceType: resource.Type, Name: resource.Name}
+			actionList = append(actionList, action)
+
+			if !ctx.existNameMap[resource.Type][resource.Name] {
+				action.Action = StackActionCreate
+				if _, owned := ctx.stateMap[stackResourceKey(resource.Type, resource.Name)]; owned {
+					action.DiffList = []string{"deleted outside of the stack"}
+				}
+			} else {
+				wg.Add(1)
+				go func(resource *StackResourceInfo, action *StackActionInfo) {
+					defer wg.Done()
+					diffList, err := getStackResourceDiff(doc.ConnectionName, resource)
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						action.Action = StackActionNoChange
+						plan.WarningList = append(plan.WarningList, fmt.Sprintf("drift of %s '%s' is unknown: %v", resource.Type, resource.Name, err))
+						return
+					}
+					action.Action = StackActionNoChange
+					for _, diff := range diffList {
+						action.DiffList = append(action.DiffList, diff.String())
+						if !diff.inPlace {
+							action.Action = StackActionReplace
+						} else if action.Action == StackActionNoChange {
+							action.Action = StackActionUpdate
+						}
+					}
+					// a disk which is only not attached to its VM
+					if len(diffList) == 1 && diffList[0].field == "AttachVMName" && diffList[0].liveValue == "" {
+						action.Action = StackActionAttach
+					}
+				}(resource, action)
+			}
+		}
+		wg.Wait()
+		plan.ActionList = append(plan.ActionList, actionList...)
+	}
+	setStackReplaceBlocks(doc, plan)
+	sort.Strings(plan.WarningList)
+
+	return plan, nil
+}
+
+// setStackReplaceBlocks sets the resources of the stack which use a resource to replace.
+// The resource cannot be deleted while they use it, so the apply of the plan is refused.
+func setStackReplaceBlocks(doc *StackDocument, plan *StackPlanInfo) {
+	actionMap := map[string]*StackActionInfo{}
+	for _, action := range plan.ActionList {
+		actionMap[stackResourceKey(action.ResourceType, action.Name)] = action
+	}
+	for _, resource := range doc.ResourceList {
+		if action := actionMap[stackResourceKey(resource.Type, resource.Name)]; action != nil && action.Action == StackActionCreate {
+			continue
+		}
+		for _, ref := range getStackReferences(resource) {
+			refAction := actionMap[stackResourceKey(ref.rsType, ref.name)]
+			if refAction == nil || refAction.Action != StackActionReplace {
+				continue
+			}
+			refAction.BlockedBy = append(refAction.BlockedBy, resource.Type+"/"+resource.Name)
+		}
+	}
+}
+
+// getStackResourceDiff compares the resource in the CSP with the document.
+func getStackResourceDiff(connectionName string, resource *StackResourceInfo) ([]stackDiff, error) {
+	var diffList []stackDiff
+	addDiff := func(field string, liveValue string, docValue string, inPlace bool) {
+		if docValue != "" && !strings.EqualFold(liveValue, docValue) {
+			diffList = append(diffList, stackDiff{field: field, liveValue: liveValue, docValue: docValue, inPlace: inPlace})
+		}
+	}
+
+	switch spec := resource.spec.(type) {
+	case *StackVPCSpec:
+		vpcInfo, err := GetVPC(connectionName, VPC, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		addDiff("IPv4_CIDR", vpcInfo.IPv4_CIDR, spec.IPv4_CIDR, false)
+		var liveSubnetList, docSubnetList []string
+		for _, subnetInfo := range vpcInfo.SubnetInfoList {
+			liveSubnetList = append(liveSubnetList, subnetInfo.IId.NameId)
+		}
+		for _, subnet := range spec.SubnetInfoList {
+			docSubnetList = append(docSubnetList, subnet.Name)
+		}
+		addDiff("SubnetInfoList", joinSortedNames(liveSubnetList), joinSortedNames(docSubnetList), true)
+	case *StackSGSpec:
+		sgInfo, err := GetSecurity(connectionName, SG, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		addDiff("VPCName", sgInfo.VpcIID.NameId, spec.VPCName, false)
+		var liveRuleList []cres.SecurityRuleInfo
+		if sgInfo.SecurityRules != nil {
+			liveRuleList = *sgInfo.SecurityRules
+		}
+		for _, rule := range spec.SecurityRules {
+			if !hasStackSecurityRule(liveRuleList, rule) {
+				diffList = append(diffList, stackDiff{field: "SecurityRules", liveValue: "missing",
+					docValue: fmt.Sprintf("%s %s %s-%s %s", rule.Direction, rule.IPProtocol, rule.FromPort, rule.ToPort, rule.CIDR), inPlace: true})
+			}
+		}
+	case *StackKeyPairSpec:
+		keyInfo, err := GetKey(connectionName, KEY, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		// some CSPs do not return the public key
+		if keyInfo.PublicKey != "" {
+			addDiff("PublicKey", trimPublicKeyComment(keyInfo.PublicKey), trimPublicKeyComment(spec.PublicKey), false)
+		}
+	case *StackVMSpec:
+		vmInfo, err := GetVM(connectionName, VM, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		addDiff("VMSpecName", vmInfo.VMSpecName, spec.VMSpecName, true)
+		addDiff("VPCName", vmInfo.VpcIID.NameId, spec.VPCName, false)
+		addDiff("SubnetName", vmInfo.SubnetIID.NameId, spec.SubnetName, false)
+		addDiff("KeyPairName", vmInfo.KeyPairIId.NameId, spec.KeyPairName, false)
+		var liveSGList []string
+		for _, sgIID := range vmInfo.SecurityGroupIIds {
+			liveSGList = append(liveSGList, sgIID.NameId)
+		}
+		addDiff("SecurityGroupNames", joinSortedNames(liveSGList), joinSortedNames(spec.SecurityGroupNames), false)
+	case *StackDiskSpec:
+		diskInfo, err := GetDisk(connectionName, DISK, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(spec.DiskSize, "default") {
+			addDiff("DiskSize", diskInfo.DiskSize, spec.DiskSize, true)
+		}
+		if !strings.EqualFold(spec.DiskType, "default") {
+			addDiff("DiskType", diskInfo.DiskType, spec.DiskType, false)
+		}
+		addDiff("AttachVMName", diskInfo.OwnerVM.NameId, spec.AttachVMName, true)
+	case *StackNLBSpec:
+		nlbInfo, err := GetNLB(connectionName, NLB, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		addDiff("VPCName", nlbInfo.VpcIID.NameId, spec.VPCName, false)
+		addDiff("Type", nlbInfo.Type, spec.Type, false)
+		addDiff("Scope", nlbInfo.Scope, spec.Scope, false)
+		addDiff("Listener.Protocol", nlbInfo.Listener.Protocol, spec.Listener.Protocol, true)
+		addDiff("Listener.Port", nlbInfo.Listener.Port, spec.Listener.Port, true)
+		var liveVMList []string
+		if nlbInfo.VMGroup.VMs != nil {
+			for _, vmIID := range *nlbInfo.VMGroup.VMs {
+				liveVMList = append(liveVMList, vmIID.NameId)
+			}
+		}
+		addDiff("VMGroup.VMs", joinSortedNames(liveVMList), joinSortedNames(spec.VMGroup.VMs), true)
+	}
+	return diffList, nil
+}
+
+// trimPublicKeyComment returns the type and the key of an OpenSSH public key, without the comment.
+func trimPublicKeyComment(publicKey string) string {
+	fields := strings.Fields(publicKey)
+	if len(fields) > 2 {
+		fields = fields[:2]
+	}
+	return strings.Join(fields, " ")
+}
+
+func joinSortedNames(nameList []string) string {
+	sortedList := append([]string{}, nameList...)
+	sort.Strings(sortedList)
+	return strings.Join(sortedList, ",")
+}
+
+func hasStackSecurityRule(ruleList []cres.SecurityRuleInfo, rule cres.SecurityRuleInfo) bool {
+	cidr := rule.CIDR
+	if cidr == "" {
+		cidr = "0.0.0.0/0"
+	}
+	for _, liveRule := range ruleList {
+		if strings.EqualFold(liveRule.Direction, rule.Direction) && strings.EqualFold(liveRule.IPProtocol, rule.IPProtocol) &&
+			liveRule.FromPort == rule.FromPort && liveRule.ToPort == rule.ToPort && liveRule.CIDR == cidr {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyStack applies the stack document in the order of the plan, and persists the state of the stack.
+// Resources created before a failure are owned by the stack, so the stack can be applied again.
+// Drifted resources are updated in place, or replaced if a drifted field cannot be updated.
+// The apply is refused if a resource to replace is used by other resources of the stack.
+func ApplyStack(doc *StackDocument) (*StackInfo, error) {
+	cblog.Info("call ApplyStack()")
+
+	if err := validateStackDocument(doc); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	id := makeStackID(doc.ConnectionName, doc.Name)
+	if err := lockStack(doc.ConnectionName, doc.Name); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer unlockStack(doc.ConnectionName, doc.Name)
+
+	stackInfo, err := getStackInfo(doc.ConnectionName, doc.Name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if stackInfo == nil {
+		stackInfo = &StackInfo{
+			ID:             id,
+			ConnectionName: doc.ConnectionName,
+			Name:           doc.Name,
+			ResourceList:   []*StackResourceStateInfo{},
+			CreatedTime:    time.Now(),
+		}
+	}
+
+	plan, err := buildStackPlan(doc, stackInfo.ResourceList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	var blockedList []string
+	for _, action := range plan.ActionList {
+		if len(action.BlockedBy) > 0 {
+			blockedList = append(blockedList, fmt.Sprintf("%s '%s' is used by %s", action.ResourceType, action.Name,
+				strings.Join(action.BlockedBy, ", ")))
+		}
+	}
+	if len(blockedList) > 0 {
+		err := fmt.Errorf("apply of the stack '%s' is refused, resources to replace are in use: %s", doc.Name,
+			strings.Join(blockedList, "; "))
+		cblog.Error(err)
+		return nil, err
+	}
+
+	stackInfo.Document = redactStackDocument(doc)
+	resourceMap := map[string]*StackResourceInfo{}
+	for _, resource := range doc.ResourceList {
+		resourceMap[stackResourceKey(resource.Type, resource.Name)] = resource
+	}
+
+	for _, action := range plan.ActionList {
+		switch action.Action {
+		case StackActionDelete:
+			err = deleteStackResource(doc.ConnectionName, action.ResourceType, action.Name)
+			if err == nil {
+				stackInfo.removeResource(action.ResourceType, action.Name)
+			}
+		case StackActionCreate:
+			resource := resourceMap[stackResourceKey(action.ResourceType, action.Name)]
+			var systemId string
+			systemId, err = createStackResource(doc.ConnectionName, resource)
+			// a resource created with an error, ex) a failed tag, is owned by the stack too
+			if err == nil || systemId != "" {
+				stackInfo.removeResource(action.ResourceType, action.Name)
+				stackInfo.ResourceList = append(stackInfo.ResourceList, &StackResourceStateInfo{
+					ResourceType: action.ResourceType, Name: action.Name, SystemId: systemId})
+			}
+			if err == nil {
+				err = attachStackDisk(doc.ConnectionName, resource)
+			}
+		case StackActionAttach:
+			err = attachStackDisk(doc.ConnectionName, resourceMap[stackResourceKey(action.ResourceType, action.Name)])
+		case StackActionUpdate:
+			err = updateStackResource(doc.ConnectionName, resourceMap[stackResourceKey(action.ResourceType, action.Name)])
+		case StackActionReplace:
+			err = deleteStackResource(doc.ConnectionName, action.ResourceType, action.Name)
+			if err != nil {
+				break
+			}
+			stackInfo.removeResource(action.ResourceType, action.Name)
+			if err = saveStackInfo(stackInfo); err != nil {
+				break
+			}
+			resource := resourceMap[stackResourceKey(action.ResourceType, action.Name)]
+			var systemId string
+			systemId, err = createStackResource(doc.ConnectionName, resource)
+			if err == nil || systemId != "" {
+				stackInfo.ResourceList = append(stackInfo.ResourceList, &StackResourceStateInfo{
+					ResourceType: action.ResourceType, Name: action.Name, SystemId: systemId})
+			}
+			if err == nil {
+				err = attachStackDisk(doc.ConnectionName, resource)
+			}
+		}
+
+		if err != nil {
+			err = fmt.Errorf("failed to %s %s '%s' of the stack '%s': %v", strings.ToLower(action.Action),
+				action.ResourceType, action.Name, doc.Name, err)
+			cblog.Error(err)
+			stackInfo.Status = StackFailed
+			stackInfo.Error = err.Error()
+			if saveErr := saveStackInfo(stackInfo); saveErr != nil {
+				cblog.Error(saveErr)
+			}
+			return nil, err
+		}
+		// the state is saved after each change, for the next apply after a failure or a restart
+		if action.Action == StackActionDelete || action.Action == StackActionCreate || action.Action == StackActionReplace {
+			if err := saveStackInfo(stackInfo); err != nil {
+				cblog.Error(err)
+				return nil, err
+			}
+		}
+	}
+
+	stackInfo.Status = StackApplied
+	stackInfo.Error = ""
+	if err := saveStackInfo(stackInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return stackInfo, nil
+}
+
+// createStackResource creates the resource through the resource manager, and returns its SystemId.
+// The SystemId is returned with an error if the resource is created but not completed.
+func createStackResource(connectionName string, resource *StackResourceInfo) (string, error) {
+	switch spec := resource.spec.(type) {
+	case *StackVPCSpec:
+		subnetInfoList := []cres.SubnetInfo{}
+		for _, subnet := range spec.SubnetInfoList {
+			subnetInfoList = append(subnetInfoList, cres.SubnetInfo{
+				IId:       cres.IID{subnet.Name, ""},
+				IPv4_CIDR: subnet.IPv4_CIDR,
+				Zone:      subnet.Zone,
+				TagList:   subnet.TagList,
+			})
+		}
+		reqInfo := cres.VPCReqInfo{
+			IId:            cres.IID{resource.Name, ""},
+			IPv4_CIDR:      spec.IPv4_CIDR,
+			SubnetInfoList: subnetInfoList,
+			TagList:        spec.TagList,
+		}
+		info, err := CreateVPC(connectionName, VPC, reqInfo, "")
+		if err != nil {
+			return "", err
+		}
+		return info.IId.SystemId, nil
+	case *StackSGSpec:
+		ruleList := append([]cres.SecurityRuleInfo{}, spec.SecurityRules...)
+		reqInfo := cres.SecurityReqInfo{
+			IId:           cres.IID{resource.Name, ""},
+			VpcIID:        cres.IID{spec.VPCName, ""},
+			SecurityRules: &ruleList,
+			TagList:       spec.TagList,
+		}
+		info, err := CreateSecurity(connectionName, SG, reqInfo, "")
+		if err != nil {
+			return "", err
+		}
+		return info.IId.SystemId, nil
+	case *StackKeyPairSpec:
+		info, err := ImportKey(connectionName, KEY, resource.Name, spec.PublicKey, "")
+		if err != nil {
+			return "", err
+		}
+		// ImportKey has no tags, they are added after the import
+		for _, tag := range spec.TagList {
+			if _, err := AddTag(connectionName, cres.KEY, resource.Name, tag); err != nil {
+				return info.IId.SystemId, err
+			}
+		}
+		return info.IId.SystemId, nil
+	case *StackVMSpec:
+		sgIIDList := []cres.IID{}
+		for _, sgName := range spec.SecurityGroupNames {
+			sgIIDList = append(sgIIDList, cres.IID{sgName, ""})
+		}
+		reqInfo := cres.VMReqInfo{
+			IId:               cres.IID{resource.Name, ""},
+			ImageType:         cres.ImageType(spec.ImageType),
+			ImageIID:          cres.IID{spec.ImageName, spec.ImageName},
+			VpcIID:            cres.IID{spec.VPCName, ""},
+			SubnetIID:         cres.IID{spec.SubnetName, ""},
+			SecurityGroupIIDs: sgIIDList,
+			VMSpecName:        spec.VMSpecName,
+			KeyPairIID:        cres.IID{spec.KeyPairName, ""},
+			RootDiskType:      spec.RootDiskType,
+			RootDiskSize:      spec.RootDiskSize,
+			DataDiskIIDs:      []cres.IID{},
+			VMUserId:          spec.VMUserId,
+			VMUserPasswd:      spec.VMUserPasswd,
+			UserData:          spec.UserData,
+			UserDataEncoding:  spec.UserDataEncoding,
+			PurchaseOption:    spec.PurchaseOption,
+			TagList:           spec.TagList,
+		}
+		info, err := StartVM(connectionName, VM, reqInfo, "")
+		if err != nil {
+			return "", err
+		}
+		return info.IId.SystemId, nil
+	case *StackDiskSpec:
+		reqInfo := cres.DiskInfo{
+			IId:      cres.IID{resource.Name, resource.Name},
+			Zone:     spec.Zone,
+			DiskType: spec.DiskType,
+			DiskSize: spec.DiskSize,
+			TagList:  spec.TagList,
+		}
+		info, err := CreateDisk(connectionName, DISK, reqInfo, "")
+		if err != nil {
+			return "", err
+		}
+		return info.IId.SystemId, nil
+	case *StackNLBSpec:
+		reqInfo, err := convertStackNLBSpec(resource.Name, spec)
+		if err != nil {
+			return "", err
+		}
+		info, err := CreateNLB(connectionName, NLB, reqInfo, "")
+		if err != nil {
+			return "", err
+		}
+		return info.IId.SystemId, nil
+	}
+	return "", fmt.Errorf("%s is not supported Resource!!", resource.Type)
+}
+
+func convertStackNLBSpec(name string, spec *StackNLBSpec) (cres.NLBInfo, error) {
+	vmIIDList := []cres.IID{}
+	for _, vmName := range spec.VMGroup.VMs {
+		vmIIDList = append(vmIIDList, cres.IID{vmName, ""})
+	}
+	vmGroup := cres.VMGroupInfo{Protocol: spec.VMGroup.Protocol, Port: spec.VMGroup.Port, VMs: &vmIIDList}
+	if vmGroup.Protocol == "" {
+		vmGroup.Protocol = spec.Listener.Protocol
+	}
+	if vmGroup.Port == "" {
+		vmGroup.Port = spec.Listener.Port
+	}
+
+	healthChecker := cres.HealthCheckerInfo{Protocol: spec.HealthChecker.Protocol, Port: spec.HealthChecker.Port}
+	for _, field := range []struct {
+		name  string
+		value string
+		out   *int
+	}{
+		{"Interval", spec.HealthChecker.Interval, &healthChecker.Interval},
+		{"Timeout", spec.HealthChecker.Timeout, &healthChecker.Timeout},
+		{"Threshold", spec.HealthChecker.Threshold, &healthChecker.Threshold},
+	} {
+		// default: "default" or "" or "-1" => -1
+		switch strings.ToLower(field.value) {
+		case "default", "", "-1":
+			*field.out = -1
+		default:
+			n, err := strconv.Atoi(field.value)
+			if err != nil {
+				return cres.NLBInfo{}, fmt.Errorf("HealthChecker.%s '%s' is not a number", field.name, field.value)
+			}
+			*field.out = n
+		}
+	}
+
+	return cres.NLBInfo{
+		IId:           cres.IID{name, name},
+		VpcIID:        cres.IID{spec.VPCName, ""},
+		Type:          spec.Type,
+		Scope:         spec.Scope,
+		Listener:      cres.ListenerInfo{Protocol: spec.Listener.Protocol, Port: spec.Listener.Port},
+		VMGroup:       vmGroup,
+		HealthChecker: healthChecker,
+		TagList:       spec.TagList,
+	}, nil
+}
+
+// updateStackResource corrects the drifted fields of the resource which can be updated in place.
+func updateStackResource(connectionName string, resource *StackResourceInfo) error {
+	switch spec := resource.spec.(type) {
+	case *StackVPCSpec:
+		vpcInfo, err := GetVPC(connectionName, VPC, resource.Name)
+		if err != nil {
+			return err
+		}
+		liveSubnetMap := map[string]bool{}
+		for _, subnetInfo := range vpcInfo.SubnetInfoList {
+			liveSubnetMap[subnetInfo.IId.NameId] = true
+		}
+		docSubnetMap := map[string]bool{}
+		for _, subnet := range spec.SubnetInfoList {
+			docSubnetMap[subnet.Name] = true
+			if liveSubnetMap[subnet.Name] {
+				continue
+			}
+			reqInfo := cres.SubnetInfo{
+				IId:       cres.IID{subnet.Name, ""},
+				IPv4_CIDR: subnet.IPv4_CIDR,
+				Zone:      subnet.Zone,
+				TagList:   subnet.TagList,
+			}
+			if _, err := AddSubnet(connectionName, SUBNET, resource.Name, reqInfo, ""); err != nil {
+				return err
+			}
+		}
+		for name := range liveSubnetMap {
+			if docSubnetMap[name] {
+				continue
+			}
+			if _, err := RemoveSubnet(connectionName, resource.Name, name, "false"); err != nil {
+				return err
+			}
+		}
+	case *StackSGSpec:
+		sgInfo, err := GetSecurity(connectionName, SG, resource.Name)
+		if err != nil {
+			return err
+		}
+		var liveRuleList []cres.SecurityRuleInfo
+		if sgInfo.SecurityRules != nil {
+			liveRuleList = *sgInfo.SecurityRules
+		}
+		var missingRuleList []cres.SecurityRuleInfo
+		for _, rule := range spec.SecurityRules {
+			if !hasStackSecurityRule(liveRuleList, rule) {
+				missingRuleList = append(missingRuleList, rule)
+			}
+		}
+		if len(missingRuleList) > 0 {
+			if _, err := AddRules(connectionName, resource.Name, missingRuleList); err != nil {
+				return err
+			}
+		}
+	case *StackVMSpec:
+		vmInfo, err := GetVM(connectionName, VM, resource.Name)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(vmInfo.VMSpecName, spec.VMSpecName) {
+			if _, err := ChangeVMSpec(connectionName, VM, resource.Name, spec.VMSpecName); err != nil {
+				return err
+			}
+		}
+	case *StackDiskSpec:
+		diskInfo, err := GetDisk(connectionName, DISK, resource.Name)
+		if err != nil {
+			return err
+		}
+		if spec.DiskSize != "" && !strings.EqualFold(spec.DiskSize, "default") && diskInfo.DiskSize != spec.DiskSize {
+			if _, err := ChangeDiskSize(connectionName, resource.Name, spec.DiskSize); err != nil {
+				return err
+			}
+		}
+		if spec.AttachVMName != "" && diskInfo.OwnerVM.NameId != spec.AttachVMName {
+			if diskInfo.OwnerVM.NameId != "" {
+				if _, err := DetachDisk(connectionName, resource.Name, diskInfo.OwnerVM.NameId); err != nil {
+					return err
+				}
+			}
+			return attachStackDisk(connectionName, resource)
+		}
+	case *StackNLBSpec:
+		nlbInfo, err := GetNLB(connectionName, NLB, resource.Name)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(nlbInfo.Listener.Protocol, spec.Listener.Protocol) || nlbInfo.Listener.Port != spec.Listener.Port {
+			listener := cres.ListenerInfo{Protocol: spec.Listener.Protocol, Port: spec.Listener.Port}
+			if _, err := ChangeListener(connectionName, resource.Name, listener); err != nil {
+				return err
+			}
+		}
+		liveVMMap := map[string]bool{}
+		if nlbInfo.VMGroup.VMs != nil {
+			for _, vmIID := range *nlbInfo.VMGroup.VMs {
+				liveVMMap[vmIID.NameId] = true
+			}
+		}
+		docVMMap := map[string]bool{}
+		var addVMList, removeVMList []string
+		for _, vmName := range spec.VMGroup.VMs {
+			docVMMap[vmName] = true
+			if !liveVMMap[vmName] {
+				addVMList = append(addVMList, vmName)
+			}
+		}
+		// an empty VM list of the document keeps the VMs, like the other fields which are not specified
+		for vmName := range liveVMMap {
+			if len(spec.VMGroup.VMs) > 0 && !docVMMap[vmName] {
+				removeVMList = append(removeVMList, vmName)
+			}
+		}
+		if len(addVMList) > 0 {
+			if _, err := AddNLBVMs(connectionName, resource.Name, addVMList); err != nil {
+				return err
+			}
+		}
+		if len(removeVMList) > 0 {
+			sort.Strings(removeVMList)
+			if _, err := RemoveNLBVMs(connectionName, resource.Name, removeVMList); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// attachStackDisk attaches a disk of the document to its VM, other resources are skipped.
+func attachStackDisk(connectionName string, resource *StackResourceInfo) error {
+	diskSpec, ok := resource.spec.(*StackDiskSpec)
+	if !ok || diskSpec.AttachVMName == "" {
+		return nil
+	}
+	_, err := AttachDisk(connectionName, resource.Name, diskSpec.AttachVMName)
+	return err
+}
+
+// deleteStackResource deletes a resource of the stack, a disk is detached from its VM before.
+func deleteStackResource(connectionName string, rsType string, name string) error {
+	if rsType == DISK {
+		diskInfo, err := GetDisk(connectionName, DISK, name)
+		if err != nil {
+			return err
+		}
+		if diskInfo.OwnerVM.NameId != "" {
+			if _, err := DetachDisk(connectionName, name, diskInfo.OwnerVM.NameId); err != nil {
+				return err
+			}
+		}
+	}
+
+	deletedResourceInfoList := deleteResourcesWithRetry(connectionName, rsType, []string{name})
+	if !deletedResourceInfoList.IsAllDeleted {
+		for _, remained := range deletedResourceInfoList.RemainedErrorInfoList {
+			return fmt.Errorf("%s", remained.ErrorMsg)
+		}
+	}
+	return nil
+}
+
+// DestroyStack deletes all resources owned by the stack in the reverse order of the resource types,
+// and deletes the stack. If some resources are not deleted, the stack keeps them.
+func DestroyStack(connectionName string, stackName string) (DestroyedInfo, error) {
+	cblog.Info("call DestroyStack()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+	stackName, err = EmptyCheckAndTrim("stackName", stackName)
+	if err != nil {
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+
+	id := makeStackID(connectionName, stackName)
+	if err := lockStack(connectionName, stackName); err != nil {
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+	defer unlockStack(connectionName, stackName)
+
+	stackInfo, err := getStackInfo(connectionName, stackName)
+	if err != nil {
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+	if stackInfo == nil {
+		err := fmt.Errorf("stack '%s' does not exist in the connection '%s'", stackName, connectionName)
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+
+	destroyedInfo := DestroyedInfo{IsAllDestroyed: true, DestroyedList: []*DeletedResourceInfoList{}}
+	for i := len(stackResourceTypes) - 1; i >= 0; i-- {
+		rsType := stackResourceTypes[i]
+		nameList, err := ListResourceName(connectionName, rsType)
+		if err != nil {
+			cblog.Error(err)
+			return DestroyedInfo{}, err
+		}
+		existNameMap := map[string]bool{}
+		for _, name := range nameList {
+			existNameMap[name] = true
+		}
+
+		deletedResourceInfoList := &DeletedResourceInfoList{ResourceType: rsType, IsAllDeleted: true}
+		for _, state := range append([]*StackResourceStateInfo{}, stackInfo.ResourceList...) {
+			if state.ResourceType != rsType {
+				continue
+			}
+			// a resource deleted outside of the stack is just removed from the stack
+			if existNameMap[state.Name] {
+				if err := deleteStackResource(connectionName, rsType, state.Name); err != nil {
+					deletedResourceInfoList.IsAllDeleted = false
+					deletedResourceInfoList.RemainedErrorInfoList = append(deletedResourceInfoList.RemainedErrorInfoList,
+						&RemainedErrorInfo{Name: state.Name, ErrorMsg: err.Error()})
+					continue
+				}
+			}
+			deletedResourceInfoList.DeletedIIDList = append(deletedResourceInfoList.DeletedIIDList,
+				&cres.IID{NameId: state.Name, SystemId: state.SystemId})
+			stackInfo.removeResource(rsType, state.Name)
+		}
+		if len(deletedResourceInfoList.DeletedIIDList) == 0 && deletedResourceInfoList.IsAllDeleted {
+			continue
+		}
+		destroyedInfo.DestroyedList = append(destroyedInfo.DestroyedList, deletedResourceInfoList)
+		if !deletedResourceInfoList.IsAllDeleted {
+			// resources of the next types can be used by the remained ones
+			destroyedInfo.IsAllDestroyed = false
+			break
+		}
+	}
+
+	if !destroyedInfo.IsAllDestroyed {
+		stackInfo.Status = StackFailed
+		stackInfo.Error = fmt.Sprintf("failed to destroy the stack '%s', %d resources remain", stackName, len(stackInfo.ResourceList))
+		if err := saveStackInfo(stackInfo); err != nil {
+			cblog.Error(err)
+			return DestroyedInfo{}, err
+		}
+		return destroyedInfo, nil
+	}
+
+	if _, err := infostore.Delete(&StackInfo{}, "id", id); err != nil {
+		cblog.Error(err)
+		return DestroyedInfo{}, err
+	}
+	return destroyedInfo, nil
+}
+
+// GetStackDrift returns the plan of the last applied document, the actions other than NoChange are drifts.
+func GetStackDrift(connectionName string, stackName string) (*StackPlanInfo, error) {
+	cblog.Info("call GetStackDrift()")
+
+	stackInfo, err := GetStack(connectionName, stackName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateStackDocument(stackInfo.Document); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	plan, err := buildStackPlan(stackInfo.Document, stackInfo.ResourceList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ListStack returns the stacks of a connection.
+func ListStack(connectionName string) ([]*StackInfo, error) {
+	cblog.Info("call ListStack()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var stackInfoList []*StackInfo
+	if err := infostore.ListByCondition(&stackInfoList, CONNECTION_NAME_COLUMN, connectionName); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, stackInfo := range stackInfoList {
+		if err := stackInfo.unmarshal(); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+	sort.Slice(stackInfoList, func(i, j int) bool {
+		return stackInfoList[i].Name < stackInfoList[j].Name
+	})
+	return stackInfoList, nil
+}
+
+// GetStack returns a stack of a connection.
+func GetStack(connectionName string, stackName string) (*StackInfo, error) {
+	cblog.Info("call GetStack()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	stackName, err = EmptyCheckAndTrim("stackName", stackName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	stackInfo, err := getStackInfo(connectionName, stackName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if stackInfo == nil {
+		err := fmt.Errorf("stack '%s' does not exist in the connection '%s'", stackName, connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+	return stackInfo, nil
+}
+
+// getStackInfo returns the stack, or nil if it does not exist.
+func getStackInfo(connectionName string, stackName string) (*StackInfo, error) {
+	id := makeStackID(connectionName, stackName)
+	has, err := infostore.Has(&StackInfo{}, "id", id)
+	if err != nil || !has {
+		return nil, err
+	}
+
+	stackInfo := &StackInfo{}
+	if err := infostore.Get(stackInfo, "id", id); err != nil {
+		return nil, err
+	}
+	if err := stackInfo.unmarshal(); err != nil {
+		return nil, err
+	}
+	return stackInfo, nil
+}
+
+// saveStackInfo upserts the stack with its document and state.
+func saveStackInfo(stackInfo *StackInfo) error {
+	documentJSON, err := json.Marshal(stackInfo.Document)
+	if err != nil {
+		return err
+	}
+	stateJSON, err := json.Marshal(stackInfo.ResourceList)
+	if err != nil {
+		return err
+	}
+	stackInfo.DocumentJSON = string(documentJSON)
+	stackInfo.StateJSON = string(stateJSON)
+	stackInfo.UpdatedTime = time.Now()
+
+	db, err := infostore.Open()
+	if err != nil {
+		return err
+	}
+	defer infostore.Close(db)
+	return db.Save(stackInfo).Error
+}
+
+// redactStackDocument returns a copy of the document without the VM passwords, which is persisted and responded.
+func redactStackDocument(doc *StackDocument) *StackDocument {
+	redactedDoc := &StackDocument{Name: doc.Name, ConnectionName: doc.ConnectionName}
+	for _, resource := range doc.ResourceList {
+		redactedResource := &StackResourceInfo{Type: resource.Type, Name: resource.Name, Spec: resource.Spec}
+		var spec map[string]interface{}
+		if resource.Type == VM && json.Unmarshal(resource.Spec, &spec) == nil {
+			if passwd, ok := spec["VMUserPasswd"].(string); ok && passwd != "" {
+				spec["VMUserPasswd"] = redactedValue
+				if redactedSpec, err := json.Marshal(spec); err == nil {
+					redactedResource.Spec = redactedSpec
+				}
+			}
+		}
+		redactedDoc.ResourceList = append(redactedDoc.ResourceList, redactedResource)
+	}
+	return redactedDoc
+}
+
+func (stackInfo *StackInfo) unmarshal() error {
+	stackInfo.Document = &StackDocument{}
+	if stackInfo.DocumentJSON != "" {
+		if err := json.Unmarshal([]byte(stackInfo.DocumentJSON), stackInfo.Document); err != nil {
+			return fmt.Errorf("invalid document of the stack '%s': %v", stackInfo.Name, err)
+		}
+	}
+	stackInfo.ResourceList = []*StackResourceStateInfo{}
+	if stackInfo.StateJSON != "" {
+		if err := json.Unmarshal([]byte(stackInfo.StateJSON), &stackInfo.ResourceList); err != nil {
+			return fmt.Errorf("invalid state of the stack '%s': %v", stackInfo.Name, err)
+		}
+	}
+	return nil
+}
+
+func (stackInfo *StackInfo) removeResource(rsType string, name string) {
+	for i, state := range stackInfo.ResourceList {
+		if state.ResourceType == rsType && state.Name == name {
+			stackInfo.ResourceList = append(stackInfo.ResourceList[:i], stackInfo.ResourceList[i+1:]...)
+			return
+		}
+	}
+}
+
+// lockStack takes the lock of the stack in all Spider servers sharing the MetaDB,
+// a concurrent apply or destroy of the stack fails.
+func lockStack(connectionName string, stackName string) error {
+	if err := stackSPLock.TryLock(connectionName, stackName, stackLockTimeout); err != nil {
+		return fmt.Errorf("stack '%s' is being applied or destroyed: %v", makeStackID(connectionName, stackName), err)
+	}
+	return nil
+}
+
+func unlockStack(connectionName string, stackName string) {
+	stackSPLock.Unlock(connectionName, stackName)
+}
@@ -0,0 +1,376 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Token Manager — API bearer tokens and S3 access key pairs of API users.
+// Only the SHA-256 hash of a token is stored, the token itself is shown once when issued.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"github.com/rs/xid"
+)
+
+const (
+	TokenPrefix             = "spt_" // prefix of API bearer tokens
+	defaultTokenExpiryDays  = 90
+	maxTokenExpiryDays      = 3650
+	s3AccessKeyPrefix       = "SPDR"
+	TOKEN_HASH_COLUMN       = "token_hash"
+	S3_ACCESS_KEY_ID_COLUMN = "s3_access_key_id"
+)
+
+// APITokenInfo represents an API token of a user.
+// Scope limits the role of the token owner, empty: the owner's roles as they are.
+type APITokenInfo struct {
+	TokenID       string    `gorm:"primaryKey" json:"TokenID" example:"cs1h7ms2k3q4cvp0l6dg"`
+	Name          string    `json:"Name" example:"ci-pipeline"`
+	UserName      string    `gorm:"index" json:"UserName" example:"alice"`
+	TokenHash     string    `gorm:"uniqueIndex" json:"-"`
+	Scope         string    `json:"Scope,omitempty" example:"read-only" enums:"admin,operator,read-only,"`
+	S3AccessKeyID string    `gorm:"index" json:"S3AccessKeyID,omitempty" example:"SPDR6F1C0A9E2B7D4C3A"`
+	S3SecretKey   string    `json:"-"` // encrypted with the active Spider key
+	ExpiresAt     time.Time `json:"ExpiresAt" example:"2026-12-31T00:00:00Z"`
+	CreatedTime   time.Time `json:"CreatedTime"`
+	Revoked       bool      `json:"Revoked"`
+	RevokedTime   time.Time `json:"RevokedTime,omitempty"`
+}
+
+func (APITokenInfo) TableName() string {
+	return "api_token_infos"
+}
+
+// IssuedTokenInfo is the result of IssueToken, with the secrets which are not shown again.
+type IssuedTokenInfo struct {
+	APITokenInfo
+	Token           string `json:"Token" example:"spt_4f0c8e7d..."`
+	S3SecretKeyText string `json:"S3SecretKey,omitempty" example:"b3JpZ2luYWwtc2VjcmV0LWtleS1leGFtcGxl"`
+}
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	defer infostore.Close(db)
+	infostore.AutoMigrateSecurity(db, &APITokenInfo{})
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// roleRank orders roles by their permissions, 0 for no access.
+func roleRank(role string) int {
+	switch role {
+	case RoleReadOnly:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// limitRole returns the lower role of role and scope.
+func limitRole(role string, scope string) string {
+	if scope == "" || roleRank(role) <= roleRank(scope) {
+		return role
+	}
+	return scope
+}
+
+// getTokenOwner returns the user of the userName, including the built-in admin.
+func getTokenOwner(userName string) (*UserInfo, error) {
+	if isBuiltinAdmin(userName) {
+		return GetBuiltinAdminUser(), nil
+	}
+	return GetUser(userName)
+}
+
+// IssueToken issues an API token of the user, valid for expiryDays (0: 90 days).
+// If withS3Key is true, an S3 access key pair is issued together for the S3 API.
+func IssueToken(name string, userName string, scope string, expiryDays int, withS3Key bool) (*IssuedTokenInfo, error) {
+	cblog.Info("call IssueToken()")
+
+	name, err := EmptyCheckAndTrim("name", name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	userName, err = EmptyCheckAndTrim("userName", userName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := validateRole(scope, true); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if expiryDays == 0 {
+		expiryDays = defaultTokenExpiryDays
+	}
+	if expiryDays < 0 || expiryDays > maxTokenExpiryDays {
+		err := fmt.Errorf("ExpiryDays must be between 1 and %d", maxTokenExpiryDays)
+		cblog.Error(err)
+		return nil, err
+	}
+	if _, err := getTokenOwner(userName); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	secret, err := randomBytes(32)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	token := TokenPrefix + hex.EncodeToString(secret)
+
+	now := time.Now()
+	issued := &IssuedTokenInfo{
+		APITokenInfo: APITokenInfo{
+			TokenID:     xid.New().String(),
+			Name:        name,
+			UserName:    userName,
+			TokenHash:   hashToken(token),
+			Scope:       scope,
+			ExpiresAt:   now.AddDate(0, 0, expiryDays),
+			CreatedTime: now,
+		},
+		Token: token,
+	}
+
+	if withS3Key {
+		keyID, err := randomBytes(8)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		s3Secret, err := randomBytes(30)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		issued.S3AccessKeyID = s3AccessKeyPrefix + strings.ToUpper(hex.EncodeToString(keyID))
+		issued.S3SecretKeyText = base64.RawURLEncoding.EncodeToString(s3Secret)
+		issued.S3SecretKey, err = cim.EncryptValue(issued.S3SecretKeyText)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	if err := infostore.Insert(&issued.APITokenInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+// ListToken returns the tokens of the user, newest first.
+// All tokens are returned if userName is empty.
+func ListToken(userName string) ([]*APITokenInfo, error) {
+	cblog.Info("call ListToken()")
+
+	var tokenList []*APITokenInfo
+	var err error
+	if userName == "" {
+		err = infostore.List(&tokenList)
+	} else {
+		err = infostore.ListByCondition(&tokenList, "user_name", userName)
+	}
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sort.Slice(tokenList, func(i, j int) bool {
+		return tokenList[i].CreatedTime.After(tokenList[j].CreatedTime)
+	})
+
+	return tokenList, nil
+}
+
+// GetToken returns the APITokenInfo of the tokenID.
+func GetToken(tokenID string) (*APITokenInfo, error) {
+	cblog.Info("call GetToken()")
+
+	tokenID, err := EmptyCheckAndTrim("tokenID", tokenID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var tokenInfo APITokenInfo
+	if err := infostore.Get(&tokenInfo, "token_id", tokenID); err != nil {
+		err := fmt.Errorf("Token '%s' does not exist", tokenID)
+		cblog.Error(err)
+		return nil, err
+	}
+	return &tokenInfo, nil
+}
+
+// RevokeToken revokes the token. The revoked token is kept to be listed.
+func RevokeToken(tokenID string) (bool, error) {
+	cblog.Info("call RevokeToken()")
+
+	tokenInfo, err := GetToken(tokenID)
+	if err != nil {
+		return false, err
+	}
+	if tokenInfo.Revoked {
+		return true, nil
+	}
+
+	tokenInfo.Revoked = true
+	tokenInfo.RevokedTime = time.Now()
+	if err := infostore.Insert(tokenInfo); err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// checkTokenValid returns an error if the token is revoked or expired.
+func (tokenInfo *APITokenInfo) checkTokenValid() error {
+	if tokenInfo.Revoked {
+		return fmt.Errorf("Token '%s' is revoked", tokenInfo.TokenID)
+	}
+	if time.Now().After(tokenInfo.ExpiresAt) {
+		return fmt.Errorf("Token '%s' is expired", tokenInfo.TokenID)
+	}
+	return nil
+}
+
+// tokenUser returns the owner of the token whose roles are limited by the token scope.
+func (tokenInfo *APITokenInfo) tokenUser() (*UserInfo, error) {
+	userInfo, err := getTokenOwner(tokenInfo.UserName)
+	if err != nil {
+		return nil, fmt.Errorf("owner of Token '%s' does not exist", tokenInfo.TokenID)
+	}
+	if tokenInfo.Scope == "" {
+		return userInfo, nil
+	}
+
+	limited := *userInfo
+	limited.Role = limitRole(userInfo.Role, tokenInfo.Scope)
+	limited.GrantList = make(GrantList, len(userInfo.GrantList))
+	for i, grant := range userInfo.GrantList {
+		grant.Role = limitRole(grant.Role, tokenInfo.Scope)
+		limited.GrantList[i] = grant
+	}
+	return &limited, nil
+}
+
+// AuthenticateToken checks the bearer token and returns its owner limited by the token scope.
+func AuthenticateToken(token string) (*UserInfo, error) {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	var tokenInfo APITokenInfo
+	if err := infostore.Get(&tokenInfo, TOKEN_HASH_COLUMN, hashToken(token)); err != nil {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if err := tokenInfo.checkTokenValid(); err != nil {
+		return nil, err
+	}
+	return tokenInfo.tokenUser()
+}
+
+// GetTokenUserName returns the owner name of the token, also of a revoked or expired token.
+// It returns "" if the token does not exist.
+func GetTokenUserName(token string) string {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return ""
+	}
+
+	var tokenInfo APITokenInfo
+	if err := infostore.Get(&tokenInfo, TOKEN_HASH_COLUMN, hashToken(token)); err != nil {
+		return ""
+	}
+	return tokenInfo.UserName
+}
+
+// GetS3KeyPair returns the S3 secret key and the owner of the S3 access key ID.
+// The owner is limited by the token scope.
+func GetS3KeyPair(accessKeyID string) (string, *UserInfo, error) {
+	if !strings.HasPrefix(accessKeyID, s3AccessKeyPrefix) {
+		return "", nil, fmt.Errorf("invalid S3 access key")
+	}
+
+	var tokenInfo APITokenInfo
+	if err := infostore.Get(&tokenInfo, S3_ACCESS_KEY_ID_COLUMN, accessKeyID); err != nil {
+		return "", nil, fmt.Errorf("invalid S3 access key")
+	}
+	if err := tokenInfo.checkTokenValid(); err != nil {
+		return "", nil, err
+	}
+	secretKey, err := cim.DecryptValue(tokenInfo.S3SecretKey)
+	if err != nil {
+		return "", nil, err
+	}
+	userInfo, err := tokenInfo.tokenUser()
+	if err != nil {
+		return "", nil, err
+	}
+	return secretKey, userInfo, nil
+}
+
+// reEncryptTokenS3Keys re-encrypts the S3 secret keys of the tokens with the active Spider key.
+func reEncryptTokenS3Keys() (int, error) {
+	var tokenList []*APITokenInfo
+	if err := infostore.List(&tokenList); err != nil {
+		cblog.Error(err)
+		return 0, err
+	}
+
+	count := 0
+	for _, tokenInfo := range tokenList {
+		if tokenInfo.S3SecretKey == "" {
+			continue
+		}
+		encSecretKey, reEncrypted, err := cim.ReEncryptValue(tokenInfo.S3SecretKey)
+		if err != nil {
+			return count, fmt.Errorf("failed to re-encrypt the S3 secret key of Token '%s': %v", tokenInfo.TokenID, err)
+		}
+		if !reEncrypted {
+			continue
+		}
+		tokenInfo.S3SecretKey = encSecretKey
+		if err := infostore.Insert(tokenInfo); err != nil {
+			cblog.Error(err)
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
@@ -50,7 +50,7 @@ func init() {
 			db.Migrator().DropTable(&TopologyLayoutInfo{})
 		}
 	}
-	db.AutoMigrate(&TopologyLayoutInfo{})
+	infostore.AutoMigrate(db, &TopologyLayoutInfo{})
 }
 
 // SaveTopologyLayout upserts a named layout version for a connection.
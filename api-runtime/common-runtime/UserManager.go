@@ -0,0 +1,428 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// User Manager — API user accounts and role-based access control.
+// The account of SPIDER_USERNAME/SPIDER_PASSWORD is the built-in admin and is not stored.
+//
+// by CB-Spider Team, 2026.10.
+
+package commonruntime
+
+import (
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User Roles
+const (
+	RoleAdmin    = "admin"     // all APIs, including user management
+	RoleOperator = "operator"  // all resource APIs, but not admin-only APIs
+	RoleReadOnly = "read-only" // only read APIs
+)
+
+// GrantInfo gives a role on a connection config or on all connection configs of a provider.
+type GrantInfo struct {
+	ConnectionName string `json:"ConnectionName,omitempty" example:"aws-seoul-config"` // set ConnectionName or ProviderName
+	ProviderName   string `json:"ProviderName,omitempty" example:"AWS"`
+	Role           string `json:"Role" validate:"required" example:"read-only" enums:"operator,read-only"`
+}
+
+// GrantList represents a list of grants, stored as JSON in MetaDB.
+type GrantList []GrantInfo
+
+func (o *GrantList) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(v), o)
+	case []byte:
+		if len(v) == 0 {
+			return nil
+		}
+		return json.Unmarshal(v, o)
+	default:
+		return fmt.Errorf("unsupported GrantList source type: %T", src)
+	}
+}
+
+func (o GrantList) Value() (driver.Value, error) {
+	if len(o) == 0 {
+		return nil, nil
+	}
+	jsonData, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return string(jsonData), nil
+}
+
+// UserInfo represents an API user.
+// Role is applied to requests which do not match any grant in GrantList.
+type UserInfo struct {
+	UserName     string    `gorm:"primaryKey" json:"UserName" validate:"required" example:"alice"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"Role" example:"read-only" enums:"admin,operator,read-only,"` // empty: no access without a grant
+	GrantList    GrantList `gorm:"type:text" json:"GrantList"`
+	CreatedTime  time.Time `json:"CreatedTime"`
+	UpdatedTime  time.Time `json:"UpdatedTime"`
+}
+
+func (UserInfo) TableName() string {
+	return "user_infos"
+}
+
+// adminOnlyResources are admin-only for mutating calls, "*" means for all calls.
+var adminOnlyResources = map[string]string{
+	"user":             "*",
+	"token":            "*",
+	"audit":            "*",
+	"encryptionkey":    "*",
+	"metadb":           "*",
+	"destroy":          "mutate",
+	"driver":           "mutate",
+	"credential":       "mutate",
+	"region":           "mutate",
+	"connectionconfig": "mutate",
+	"mockfault":        "mutate",
+}
+
+// readOnlyPostResources are query APIs called with POST.
+var readOnlyPostResources = map[string]bool{
+	"priceinfo":             true,
+	"getvmusingresources":   true,
+	"getsecuritygroupowner": true,
+	"getnlbowner":           true,
+	"getclusterowner":       true,
+	"getrdbmsowner":         true,
+}
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	defer infostore.Close(db)
+	infostore.AutoMigrateSecurity(db, &UserInfo{})
+}
+
+func validateRole(role string, allowEmpty bool) error {
+	switch role {
+	case RoleAdmin, RoleOperator, RoleReadOnly:
+		return nil
+	case "":
+		if allowEmpty {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid Role '%s': it should be one of %s, %s and %s", role, RoleAdmin, RoleOperator, RoleReadOnly)
+}
+
+func validateGrantList(grantList GrantList) error {
+	for i, grant := range grantList {
+		if (grant.ConnectionName == "") == (grant.ProviderName == "") {
+			return fmt.Errorf("grant #%d should have either ConnectionName or ProviderName", i+1)
+		}
+		// admin-only APIs are not connection resources, so a grant can not give admin
+		if grant.Role != RoleOperator && grant.Role != RoleReadOnly {
+			return fmt.Errorf("grant #%d: invalid Role '%s': it should be %s or %s", i+1, grant.Role, RoleOperator, RoleReadOnly)
+		}
+	}
+	return nil
+}
+
+func normalizeGrantList(grantList GrantList) GrantList {
+	for i := range grantList {
+		grantList[i].ConnectionName = strings.TrimSpace(grantList[i].ConnectionName)
+		grantList[i].ProviderName = strings.ToUpper(strings.TrimSpace(grantList[i].ProviderName))
+	}
+	return grantList
+}
+
+func isBuiltinAdmin(userName string) bool {
+	return userName != "" && userName == os.Getenv("SPIDER_USERNAME")
+}
+
+// CreateUser creates an API user.
+func CreateUser(userName string, password string, role string, grantList GrantList) (*UserInfo, error) {
+	cblog.Info("call CreateUser()")
+
+	userName, err := EmptyCheckAndTrim("userName", userName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if password == "" {
+		err := fmt.Errorf("password is empty")
+		cblog.Error(err)
+		return nil, err
+	}
+	if isBuiltinAdmin(userName) {
+		err := fmt.Errorf("User '%s' is the built-in admin of SPIDER_USERNAME", userName)
+		cblog.Error(err)
+		return nil, err
+	}
+	grantList = normalizeGrantList(grantList)
+	if err := validateRole(role, true); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := validateGrantList(grantList); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var userInfo UserInfo
+	bool_ret, err := infostore.Has(&userInfo, "user_name", userName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if bool_ret {
+		err := fmt.Errorf("User '%s' already exists", userName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	userInfo = UserInfo{
+		UserName:     userName,
+		PasswordHash: string(hash),
+		Role:         role,
+		GrantList:    grantList,
+		CreatedTime:  now,
+		UpdatedTime:  now,
+	}
+	if err := infostore.Insert(&userInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &userInfo, nil
+}
+
+// ListUser returns all API users, not including the built-in admin.
+func ListUser() ([]*UserInfo, error) {
+	cblog.Info("call ListUser()")
+
+	var userList []*UserInfo
+	if err := infostore.List(&userList); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return userList, nil
+}
+
+// GetUser returns the API user.
+func GetUser(userName string) (*UserInfo, error) {
+	cblog.Info("call GetUser()")
+
+	userName, err := EmptyCheckAndTrim("userName", userName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var userInfo UserInfo
+	if err := infostore.Get(&userInfo, "user_name", userName); err != nil {
+		err := fmt.Errorf("User '%s' does not exist", userName)
+		cblog.Error(err)
+		return nil, err
+	}
+	return &userInfo, nil
+}
+
+// UpdateUser changes the password, role and grants of the API user.
+// An empty password keeps the current password.
+func UpdateUser(userName string, password string, role string, grantList GrantList) (*UserInfo, error) {
+	cblog.Info("call UpdateUser()")
+
+	userInfo, err := GetUser(userName)
+	if err != nil {
+		return nil, err
+	}
+	grantList = normalizeGrantList(grantList)
+	if err := validateRole(role, true); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := validateGrantList(grantList); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		userInfo.PasswordHash = string(hash)
+	}
+	userInfo.Role = role
+	userInfo.GrantList = grantList
+	userInfo.UpdatedTime = time.Now()
+	if err := infostore.Insert(userInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return userInfo, nil
+}
+
+// DeleteUser deletes the API user.
+func DeleteUser(userName string) (bool, error) {
+	cblog.Info("call DeleteUser()")
+
+	userInfo, err := GetUser(userName)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := infostore.Delete(&UserInfo{}, "user_name", userInfo.UserName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// the API tokens of the user cannot be used any more
+	if _, err := infostore.Delete(&APITokenInfo{}, "user_name", userInfo.UserName); err != nil {
+		cblog.Error(err)
+	}
+	return result, nil
+}
+
+// AuthenticateUser checks the password and returns the user.
+// The built-in admin of SPIDER_USERNAME/SPIDER_PASSWORD is returned as an admin user.
+func AuthenticateUser(userName string, password string) (*UserInfo, error) {
+	if userName == "" || password == "" {
+		return nil, fmt.Errorf("invalid username or password")
+	}
+
+	if isBuiltinAdmin(userName) {
+		if subtle.ConstantTimeCompare([]byte(password), []byte(os.Getenv("SPIDER_PASSWORD"))) != 1 {
+			return nil, fmt.Errorf("invalid username or password")
+		}
+		return &UserInfo{UserName: userName, Role: RoleAdmin}, nil
+	}
+
+	var userInfo UserInfo
+	if err := infostore.Get(&userInfo, "user_name", userName); err != nil {
+		// compare with a dummy hash to take the same time as an existing user
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return nil, fmt.Errorf("invalid username or password")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(userInfo.PasswordHash), []byte(password)); err != nil {
+		return nil, fmt.Errorf("invalid username or password")
+	}
+	return &userInfo, nil
+}
+
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("cb-spider-dummy-password"), bcrypt.MinCost)
+
+// GetBuiltinAdminUser returns the built-in admin user of SPIDER_USERNAME.
+func GetBuiltinAdminUser() *UserInfo {
+	return &UserInfo{UserName: os.Getenv("SPIDER_USERNAME"), Role: RoleAdmin}
+}
+
+// getEffectiveRole returns the role of the user for the connection.
+// A grant on the connection takes precedence over a grant on its provider.
+func (userInfo *UserInfo) getEffectiveRole(connectionName string) string {
+	if userInfo.Role == RoleAdmin || connectionName == "" || len(userInfo.GrantList) == 0 {
+		return userInfo.Role
+	}
+
+	for _, grant := range userInfo.GrantList {
+		if grant.ConnectionName == connectionName {
+			return grant.Role
+		}
+	}
+
+	providerName := ""
+	for _, grant := range userInfo.GrantList {
+		if grant.ProviderName == "" {
+			continue
+		}
+		if providerName == "" {
+			cccInfo, err := ccim.GetConnectionConfig(connectionName)
+			if err != nil {
+				break
+			}
+			providerName = strings.ToUpper(cccInfo.ProviderName)
+		}
+		if grant.ProviderName == providerName {
+			return grant.Role
+		}
+	}
+
+	return userInfo.Role
+}
+
+// GetRouteResource returns the resource of the route path, ex) "vm" of "/vm/:Name".
+func GetRouteResource(routePath string) string {
+	return strings.SplitN(strings.TrimPrefix(routePath, "/"), "/", 2)[0]
+}
+
+// IsReadRequest returns true if the API call does not change any resource.
+func IsReadRequest(method string, routePath string) bool {
+	return method == "GET" || method == "HEAD" || (method == "POST" && readOnlyPostResources[GetRouteResource(routePath)])
+}
+
+// CheckPermission checks if the user can call the API.
+// routePath is the route path without '/spider', ex) "/vm/:Name".
+func CheckPermission(userInfo *UserInfo, method string, routePath string, connectionName string) error {
+	if userInfo == nil {
+		return fmt.Errorf("no user")
+	}
+
+	resource := GetRouteResource(routePath)
+	isRead := IsReadRequest(method, routePath)
+
+	denied := func() error {
+		if connectionName != "" {
+			return fmt.Errorf("User '%s' is not allowed to call %s %s on connection '%s'", userInfo.UserName, method, routePath, connectionName)
+		}
+		return fmt.Errorf("User '%s' is not allowed to call %s %s", userInfo.UserName, method, routePath)
+	}
+
+	// admin-only APIs are checked with the user's Role only, a grant is never applied
+	if scope, ok := adminOnlyResources[resource]; ok && (scope == "*" || !isRead) {
+		if userInfo.Role == RoleAdmin {
+			return nil
+		}
+		return denied()
+	}
+
+	switch userInfo.getEffectiveRole(connectionName) {
+	case RoleAdmin, RoleOperator:
+		return nil
+	case RoleReadOnly:
+		if !isRead {
+			return denied()
+		}
+		return nil
+	}
+	return denied()
+}
@@ -9,6 +9,7 @@
 package commonruntime
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 	"time"
 
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	drvcommon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
 	ccon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/connect"
 	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
@@ -230,9 +232,9 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&VMIIDInfo{})
-	db.AutoMigrate(&VMRecentInfo{})
-	db.AutoMigrate(&VMFavoriteInfo{})
+	infostore.AutoMigrate(db, &VMIIDInfo{})
+	infostore.AutoMigrate(db, &VMRecentInfo{})
+	infostore.AutoMigrate(db, &VMFavoriteInfo{})
 	infostore.Close(db)
 }
 
@@ -578,6 +580,104 @@ func RegisterVM(connectionName string, userIID cres.IID) (*cres.VMInfo, error) {
 	return &getInfo, nil
 }
 
+// VM UserData Encodings
+const (
+	VMUserDataPlain  = "plain"
+	VMUserDataBase64 = "base64"
+)
+
+// DecodeVMUserData returns the plain text of a UserData by its encoding, "" is plain.
+func DecodeVMUserData(userData string, encoding string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", VMUserDataPlain:
+		return userData, nil
+	case VMUserDataBase64:
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(userData))
+		if err != nil {
+			return "", fmt.Errorf("UserData is not valid base64: %v", err)
+		}
+		return string(decoded), nil
+	default:
+		return "", fmt.Errorf("UserDataEncoding should be %s or %s: %s", VMUserDataPlain, VMUserDataBase64, encoding)
+	}
+}
+
+// ValidateVMUserData checks the size of a plain text UserData with the limit of a CSP.
+// This is an early check of the UserData only, the drivers check the size again
+// with their init scripts and the MIME headers of the merged user data.
+func ValidateVMUserData(providerName string, userData string) error {
+	return drvcommon.CheckUserDataSize(providerName, userData)
+}
+
+// checkVMUserData decodes the UserData of the request and checks the capability and the size limit.
+func checkVMUserData(connectionName string, reqInfo *cres.VMReqInfo) error {
+	if reqInfo.UserData == "" {
+		return nil
+	}
+
+	if err := checkCapability(connectionName, VM_USERDATA); err != nil {
+		return err
+	}
+
+	providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
+	if err != nil {
+		return err
+	}
+
+	// drivers get the plain text
+	if reqInfo.UserData, err = DecodeVMUserData(reqInfo.UserData, reqInfo.UserDataEncoding); err != nil {
+		return err
+	}
+	reqInfo.UserDataEncoding = ""
+	return ValidateVMUserData(providerName, reqInfo.UserData)
+}
+
+// ValidateVMPurchaseOption normalizes the case of the PurchaseOption and sets the defaults:
+// OnDemand for the LifecycleType, Terminate for the InterruptionBehavior of a Spot VM.
+func ValidateVMPurchaseOption(option *cres.VMPurchaseOption) error {
+	switch {
+	case option.LifecycleType == "", strings.EqualFold(string(option.LifecycleType), string(cres.OnDemandVM)):
+		if option.MaxPrice != "" || option.InterruptionBehavior != "" {
+			return fmt.Errorf("MaxPrice and InterruptionBehavior of PurchaseOption are only for a Spot VM")
+		}
+		option.LifecycleType = cres.OnDemandVM
+		return nil
+	case strings.EqualFold(string(option.LifecycleType), string(cres.SpotVM)):
+		option.LifecycleType = cres.SpotVM
+	default:
+		return fmt.Errorf("LifecycleType of PurchaseOption should be %s or %s: %s", cres.OnDemandVM, cres.SpotVM, option.LifecycleType)
+	}
+
+	if option.MaxPrice != "" {
+		maxPrice, err := strconv.ParseFloat(option.MaxPrice, 64)
+		if err != nil || maxPrice <= 0 {
+			return fmt.Errorf("MaxPrice of PurchaseOption should be a positive number: %s", option.MaxPrice)
+		}
+	}
+
+	switch {
+	case option.InterruptionBehavior == "", strings.EqualFold(string(option.InterruptionBehavior), string(cres.SpotTerminate)):
+		option.InterruptionBehavior = cres.SpotTerminate
+	case strings.EqualFold(string(option.InterruptionBehavior), string(cres.SpotStop)):
+		option.InterruptionBehavior = cres.SpotStop
+	default:
+		return fmt.Errorf("InterruptionBehavior of PurchaseOption should be %s or %s: %s", cres.SpotTerminate, cres.SpotStop, option.InterruptionBehavior)
+	}
+	return nil
+}
+
+// checkVMPurchaseOption validates the PurchaseOption of the request and checks the capability for a Spot VM.
+func checkVMPurchaseOption(connectionName string, reqInfo *cres.VMReqInfo) error {
+	if err := ValidateVMPurchaseOption(&reqInfo.PurchaseOption); err != nil {
+		return err
+	}
+
+	if reqInfo.PurchaseOption.LifecycleType == cres.SpotVM {
+		return checkCapability(connectionName, SPOT_VM)
+	}
+	return nil
+}
+
 // (1) check exist(NameID)
 // (2) generate SP-XID and create reqIID, driverIID
 // (3) clone the reqInfo with DriverIID
@@ -609,6 +709,11 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 		//	"resources.IID:NameId",
 		"resources.VMReqInfo:VMUserId",     // because can be set without VM User
 		"resources.VMReqInfo:VMUserPasswd", // because can be set without VM PW
+		"resources.VMReqInfo:UserData",     // because can be set without UserData
+
+		"resources.VMPurchaseOption:LifecycleType",        // default: OnDemand
+		"resources.VMPurchaseOption:MaxPrice",             // default: up to the OnDemand price
+		"resources.VMPurchaseOption:InterruptionBehavior", // default: Terminate
 	}
 
 	err = ValidateStruct(reqInfo, emptyPermissionList)
@@ -617,6 +722,18 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 		return nil, err
 	}
 
+	err = checkVMUserData(connectionName, &reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = checkVMPurchaseOption(connectionName, &reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
 	err = checkImageType(&reqInfo)
 	if err != nil {
 		cblog.Error(err)
@@ -2262,6 +2379,93 @@ func ControlVM(connectionName string, rsType string, nameID string, action strin
 	return info, nil
 }
 
+// (1) get IID(NameId)
+// (2) validate the new VMSpec with the VMSpecHandler
+// (3) change CSP:VM(SystemId) spec, the driver stops and starts the VM if the CSP requires it
+func ChangeVMSpec(connectionName string, rsType string, nameID string, vmSpecName string) (*cres.VMInfo, error) {
+	cblog.Info("call ChangeVMSpec()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	vmSpecName, err = EmptyCheckAndTrim("vmSpecName", vmSpecName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = func() error {
+		vmSPLock.Lock(connectionName, nameID)
+		defer vmSPLock.Unlock(connectionName, nameID)
+
+		// (1) get IID(NameId)
+		var iidInfo VMIIDInfo
+		if os.Getenv("PERMISSION_BASED_CONTROL_MODE") != "" {
+			var iidInfoList []*VMIIDInfo
+			err := getAuthIIDInfoList(connectionName, &iidInfoList)
+			if err != nil {
+				return err
+			}
+			castedIIDInfo, err := getAuthIIDInfo(&iidInfoList, nameID)
+			if err != nil {
+				return err
+			}
+			iidInfo = *castedIIDInfo.(*VMIIDInfo)
+		} else {
+			err := infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+			if err != nil {
+				return err
+			}
+		}
+
+		cldConn, err := ccm.GetZoneLevelCloudConnection(connectionName, iidInfo.ZoneId)
+		if err != nil {
+			return err
+		}
+		handler, err := cldConn.CreateVMHandler()
+		if err != nil {
+			return err
+		}
+		vmIID := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+		info, err := handler.GetVM(vmIID)
+		if err != nil {
+			return err
+		}
+		if info.VMSpecName == vmSpecName {
+			return fmt.Errorf("%s '%s' already has VMSpec '%s'", RSTypeString(rsType), nameID, vmSpecName)
+		}
+
+		// (2) validate the new VMSpec with the VMSpecHandler
+		specHandler, err := cldConn.CreateVMSpecHandler()
+		if err != nil {
+			return err
+		}
+		_, err = specHandler.GetVMSpec(vmSpecName)
+		if err != nil {
+			return fmt.Errorf("VMSpec '%s' is not available: %v", vmSpecName, err)
+		}
+
+		// (3) change CSP:VM(SystemId) spec
+		_, err = handler.ChangeVMSpec(vmIID, vmSpecName)
+		return err
+	}()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return GetVM(connectionName, rsType, nameID)
+}
+
 func DeleteVM(connectionName string, rsType string, nameID string, force string) (bool, cres.VMStatus, error) {
 	cblog.Info("call DeleteVM()")
 
@@ -44,8 +44,8 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&VPCIIDInfo{})
-	db.AutoMigrate(&SubnetIIDInfo{})
+	infostore.AutoMigrate(db, &VPCIIDInfo{})
+	infostore.AutoMigrate(db, &SubnetIIDInfo{})
 	infostore.Close(db)
 }
 
@@ -0,0 +1,104 @@
+// SPLock Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Memory backend — id-based locks in the Spider process.
+//
+// by CB-Spider Team, 2026.10.
+
+package splock
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type memoryBackend struct {
+	rwMutex sync.RWMutex // lock for handling lockMap
+	lockMap map[LockKey]*LockValue
+}
+
+type LockValue struct {
+	lock  sync.RWMutex // for id-based locking
+	count int          // reference counter for this lock
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{lockMap: make(map[LockKey]*LockValue)}
+}
+
+// getLockValue returns the LockValue of the key with its reference counted.
+func (backend *memoryBackend) getLockValue(key LockKey) *LockValue {
+	backend.rwMutex.Lock()
+	defer backend.rwMutex.Unlock()
+
+	lockValue := backend.lockMap[key]
+	if lockValue == nil {
+		lockValue = &LockValue{}
+		backend.lockMap[key] = lockValue
+	}
+	lockValue.count++
+	return lockValue
+}
+
+// releaseLockValue returns the LockValue of the key and removes it when it is not referenced.
+func (backend *memoryBackend) releaseLockValue(key LockKey) *LockValue {
+	backend.rwMutex.Lock()
+	defer backend.rwMutex.Unlock()
+
+	lockValue := backend.lockMap[key]
+	lockValue.count--
+	if lockValue.count == 0 {
+		delete(backend.lockMap, key)
+	}
+	return lockValue
+}
+
+func (backend *memoryBackend) Lock(key LockKey) {
+	backend.getLockValue(key).lock.Lock()
+}
+
+// TryLock takes the lock of the key within the timeout, nothing is held if it fails.
+func (backend *memoryBackend) TryLock(key LockKey, timeout time.Duration) error {
+	lockValue := backend.getLockValue(key)
+	deadline := time.Now().Add(timeout)
+	interval := minLockPollInterval
+	for !lockValue.lock.TryLock() {
+		if time.Now().After(deadline) {
+			backend.releaseLockValue(key)
+			return fmt.Errorf("timeout(%v) to get the lock of %s:%s", timeout, key.connectionName, key.resourceId)
+		}
+		time.Sleep(interval)
+		if interval *= 2; interval > maxLockPollInterval {
+			interval = maxLockPollInterval
+		}
+	}
+	return nil
+}
+
+func (backend *memoryBackend) Unlock(key LockKey) {
+	backend.releaseLockValue(key).lock.Unlock()
+}
+
+func (backend *memoryBackend) RLock(key LockKey) {
+	backend.getLockValue(key).lock.RLock()
+}
+
+func (backend *memoryBackend) RUnlock(key LockKey) {
+	backend.releaseLockValue(key).lock.RUnlock()
+}
+
+func (backend *memoryBackend) Status() []string {
+	var statusList []string
+
+	backend.rwMutex.RLock()
+	for k, v := range backend.lockMap {
+		statusList = append(statusList, fmt.Sprintf("(%s:%s, %p:%d)", k.connectionName, k.resourceId, &v.lock, v.count))
+	}
+	backend.rwMutex.RUnlock()
+
+	return statusList
+}
@@ -0,0 +1,415 @@
+// SPLock Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// PostgreSQL backend — id-based locks shared by the Spider instances on a PostgreSQL MetaDB.
+// A lock is taken in the memory first and then as a session-level advisory lock of PostgreSQL.
+// Each held lock has its own DB session, so the advisory locks are released by PostgreSQL if the
+// process dies, and a broken session drops only its own lock. A lost session is detected by a
+// keep-alive and its lock is taken again. The lock is never degraded to a lock in this process:
+// Lock waits until the MetaDB is back, TryLock returns an error.
+//
+// by CB-Spider Team, 2026.10.
+
+package splock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sync"
+	"time"
+
+	cblogger "github.com/cloud-barista/cb-log"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	minLockPollInterval = 10 * time.Millisecond
+	maxLockPollInterval = 500 * time.Millisecond
+)
+
+var cblog *logrus.Logger
+
+func init() {
+	cblog = cblogger.GetLogger("CLOUD-BARISTA")
+}
+
+// SPLockKeyInfo maps an advisory lock key to its SPLock key, to show the locks of the cluster.
+type SPLockKeyInfo struct {
+	LockKey        int64  `gorm:"primaryKey;autoIncrement:false"`
+	LockName       string `gorm:"index"`
+	ConnectionName string
+	ResourceId     string
+}
+
+func (SPLockKeyInfo) TableName() string {
+	return "splock_keys"
+}
+
+// ====================================================================
+const (
+	maxDBRetryInterval    = 5 * time.Second
+	lockKeepAliveInterval = 10 * time.Second
+	lockConnectTimeout    = 10 * time.Second
+	lockKeepAliveTimeout  = 5 * time.Second
+	lockSessionAppNameFmt = "cb-spider-splock@%s:%d"
+)
+
+var migrateOnce sync.Once
+
+var (
+	lockDBOnce sync.Once
+	lockDB     *sql.DB
+	lockDBErr  error
+)
+
+// getLockDB returns the connection pool of the lock sessions, separated from the MetaDB pool
+// because a held lock keeps its connection. Idle connections are not kept, so a session returned
+// to the pool is closed and cannot carry an advisory lock to another user.
+func getLockDB() (*sql.DB, error) {
+	lockDBOnce.Do(func() {
+		var db *gorm.DB
+		db, lockDBErr = gorm.Open(postgres.Open(infostore.DB_DSN), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if lockDBErr != nil {
+			return
+		}
+		if lockDB, lockDBErr = db.DB(); lockDBErr != nil {
+			return
+		}
+		lockDB.SetMaxIdleConns(0)
+	})
+	return lockDB, lockDBErr
+}
+
+// openLockSession opens a new DB session for a lock.
+func openLockSession() (*sql.Conn, error) {
+	db, err := getLockDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lockConnectTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hostName, _ := os.Hostname()
+	appName := fmt.Sprintf(lockSessionAppNameFmt, hostName, os.Getpid())
+	if _, err := conn.ExecContext(ctx, "SELECT set_config('application_name', $1, false)", appName); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// pgLock is an advisory lock held on its own DB session.
+type pgLock struct {
+	name   string // ex) "VM(aws-seoul-config:VM-01)", for the logs
+	keyId  int64
+	shared bool
+
+	mutex sync.Mutex
+	conn  *sql.Conn
+	lost  int // number of the lost sessions while held
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// acquireAdvisory takes the advisory lock of the key on a new session.
+// It retries until the deadline, or until canceled with a zero deadline. DB errors are retried, not ignored.
+func acquireAdvisory(name string, keyId int64, shared bool, deadline time.Time, cancel <-chan struct{}) (*sql.Conn, error) {
+	query := "SELECT pg_try_advisory_lock($1)"
+	if shared {
+		query = "SELECT pg_try_advisory_lock_shared($1)"
+	}
+
+	var conn *sql.Conn
+	interval := minLockPollInterval
+	dbInterval := minLockPollInterval
+	for {
+		var err error
+		if conn == nil {
+			conn, err = openLockSession()
+		}
+		if err == nil {
+			var locked bool
+			if err = conn.QueryRowContext(context.Background(), query, keyId).Scan(&locked); err == nil {
+				if locked {
+					return conn, nil
+				}
+			} else {
+				conn.Close()
+				conn = nil
+			}
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			if conn != nil {
+				conn.Close()
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the distributed SPLock %s: %v", name, err)
+			}
+			return nil, fmt.Errorf("timeout to get the distributed SPLock %s, it is held by another one", name)
+		}
+
+		wait := interval
+		if err != nil {
+			cblog.Errorf("failed to get the distributed SPLock %s, retry in %v: %v", name, dbInterval, err)
+			wait = dbInterval
+			if dbInterval *= 2; dbInterval > maxDBRetryInterval {
+				dbInterval = maxDBRetryInterval
+			}
+		} else {
+			dbInterval = minLockPollInterval
+			if interval *= 2; interval > maxLockPollInterval {
+				interval = maxLockPollInterval
+			}
+		}
+		select {
+		case <-cancel:
+			if conn != nil {
+				conn.Close()
+			}
+			return nil, fmt.Errorf("getting the distributed SPLock %s is canceled", name)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// keepAlive checks the session of the lock, and takes the lock again on a new session if it is lost.
+// PostgreSQL releases the advisory lock of a lost session, so another instance could have taken it
+// in the meantime; it is logged as an error.
+func (lock *pgLock) keepAlive() {
+	defer close(lock.done)
+	ticker := time.NewTicker(lockKeepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lock.stop:
+			return
+		case <-ticker.C:
+		}
+
+		lock.mutex.Lock()
+		ctx, cancel := context.WithTimeout(context.Background(), lockKeepAliveTimeout)
+		err := lock.conn.PingContext(ctx)
+		cancel()
+		if err == nil {
+			lock.mutex.Unlock()
+			continue
+		}
+
+		lock.lost++
+		cblog.Errorf("the DB session of the distributed SPLock %s is lost, the lock is taken again: %v", lock.name, err)
+		lock.conn.Close()
+		lock.conn = nil
+		lock.mutex.Unlock()
+
+		// canceled by release() if the holder unlocks it in the meantime
+		conn, err := acquireAdvisory(lock.name, lock.keyId, lock.shared, time.Time{}, lock.stop)
+		if err != nil {
+			return
+		}
+		lock.mutex.Lock()
+		lock.conn = conn
+		lock.mutex.Unlock()
+	}
+}
+
+// release stops the keep-alive and releases the advisory lock by closing its session.
+func (lock *pgLock) release() {
+	close(lock.stop)
+	<-lock.done
+
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	if lock.lost > 0 {
+		cblog.Errorf("the distributed SPLock %s was taken again after %d lost DB session(s), another instance could have held it", lock.name, lock.lost)
+	}
+	if lock.conn == nil {
+		return
+	}
+
+	query := "SELECT pg_advisory_unlock($1)"
+	if lock.shared {
+		query = "SELECT pg_advisory_unlock_shared($1)"
+	}
+	var unlocked bool
+	if err := lock.conn.QueryRowContext(context.Background(), query, lock.keyId).Scan(&unlocked); err != nil || !unlocked {
+		cblog.Errorf("failed to release the distributed SPLock %s, its session is closed: %v", lock.name, err)
+	}
+	lock.conn.Close()
+	lock.conn = nil
+}
+
+// ====================================================================
+type postgresBackend struct {
+	name   string
+	memory *memoryBackend // locks in this process, taken before the advisory locks
+
+	mutex  sync.Mutex
+	held   map[LockKey][]*pgLock // held advisory locks of the key
+	keyIds sync.Map              // LockKey => int64, registered in splock_keys
+}
+
+func newPostgresBackend(name string) *postgresBackend {
+	return &postgresBackend{
+		name:   name,
+		memory: newMemoryBackend(),
+		held:   make(map[LockKey][]*pgLock),
+	}
+}
+
+// advisoryKey returns the advisory lock key of the key, and registers the key to be shown in the status.
+func (backend *postgresBackend) advisoryKey(key LockKey) int64 {
+	if keyId, ok := backend.keyIds.Load(key); ok {
+		return keyId.(int64)
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(backend.name + "\x00" + key.connectionName + "\x00" + key.resourceId))
+	keyId := int64(hash.Sum64())
+
+	db, err := infostore.Open()
+	if err == nil {
+		migrateOnce.Do(func() {
+			db.AutoMigrate(&SPLockKeyInfo{})
+		})
+		err = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&SPLockKeyInfo{
+			LockKey:        keyId,
+			LockName:       backend.name,
+			ConnectionName: key.connectionName,
+			ResourceId:     key.resourceId,
+		}).Error
+	}
+	if err != nil {
+		cblog.Warnf("failed to register the SPLock key %s(%s:%s): %v", backend.name, key.connectionName, key.resourceId, err)
+	} else {
+		backend.keyIds.Store(key, keyId)
+	}
+	return keyId
+}
+
+// lockAdvisory takes the advisory lock of the key until the deadline, or waits for it with a zero deadline.
+func (backend *postgresBackend) lockAdvisory(key LockKey, shared bool, deadline time.Time) error {
+	lock := &pgLock{
+		name:   fmt.Sprintf("%s(%s:%s)", backend.name, key.connectionName, key.resourceId),
+		keyId:  backend.advisoryKey(key),
+		shared: shared,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	conn, err := acquireAdvisory(lock.name, lock.keyId, shared, deadline, nil)
+	if err != nil {
+		return err
+	}
+	lock.conn = conn
+	go lock.keepAlive()
+
+	backend.mutex.Lock()
+	backend.held[key] = append(backend.held[key], lock)
+	backend.mutex.Unlock()
+	return nil
+}
+
+func (backend *postgresBackend) unlockAdvisory(key LockKey) {
+	backend.mutex.Lock()
+	locks := backend.held[key]
+	if len(locks) == 0 {
+		backend.mutex.Unlock()
+		cblog.Errorf("the distributed SPLock %s(%s:%s) is not held", backend.name, key.connectionName, key.resourceId)
+		return
+	}
+	lock := locks[len(locks)-1]
+	if len(locks) == 1 {
+		delete(backend.held, key)
+	} else {
+		backend.held[key] = locks[:len(locks)-1]
+	}
+	backend.mutex.Unlock()
+
+	lock.release()
+}
+
+func (backend *postgresBackend) Lock(key LockKey) {
+	backend.memory.Lock(key)
+	backend.lockAdvisory(key, false, time.Time{})
+}
+
+// TryLock takes the lock within the timeout. Nothing is held if it fails.
+func (backend *postgresBackend) TryLock(key LockKey, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	if err := backend.memory.TryLock(key, timeout); err != nil {
+		return err
+	}
+	if err := backend.lockAdvisory(key, false, deadline); err != nil {
+		backend.memory.Unlock(key)
+		return err
+	}
+	return nil
+}
+
+func (backend *postgresBackend) Unlock(key LockKey) {
+	backend.unlockAdvisory(key)
+	backend.memory.Unlock(key)
+}
+
+func (backend *postgresBackend) RLock(key LockKey) {
+	backend.memory.RLock(key)
+	backend.lockAdvisory(key, true, time.Time{})
+}
+
+func (backend *postgresBackend) RUnlock(key LockKey) {
+	backend.unlockAdvisory(key)
+	backend.memory.RUnlock(key)
+}
+
+// Status returns the advisory locks held in the cluster with their holders.
+// ex) "(aws-seoul-config:VM-01, cb-spider-splock@host-01:1234 ExclusiveLock:1)"
+func (backend *postgresBackend) Status() []string {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return backend.memory.Status()
+	}
+
+	type lockHolder struct {
+		ConnectionName  string
+		ResourceId      string
+		ApplicationName string
+		Mode            string
+		Count           int
+	}
+	var holderList []lockHolder
+	err = db.Raw(`SELECT k.connection_name, k.resource_id, a.application_name, l.mode, count(*) AS count
+		FROM pg_locks l
+		JOIN splock_keys k ON ((l.classid::bigint << 32) | l.objid::bigint) = k.lock_key
+		LEFT JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory' AND l.objsubid = 1 AND l.granted AND k.lock_name = ?
+		GROUP BY k.connection_name, k.resource_id, a.application_name, l.mode
+		ORDER BY k.connection_name, k.resource_id`, backend.name).Scan(&holderList).Error
+	if err != nil {
+		cblog.Error(err)
+		return backend.memory.Status()
+	}
+
+	var statusList []string
+	for _, holder := range holderList {
+		statusList = append(statusList, fmt.Sprintf("(%s:%s, %s %s:%d)",
+			holder.ConnectionName, holder.ResourceId, holder.ApplicationName, holder.Mode, holder.Count))
+	}
+	return statusList
+}
@@ -10,15 +10,16 @@ package splock
 
 import (
 	"bytes"
-	"fmt"
 	"os"
-	"sync"
+	"time"
+
+	infostore "github.com/cloud-barista/cb-spider/info-store"
 )
 
 // ====================================================================
 type SPLOCK struct {
-	rwMutex sync.RWMutex // lock for handling lockMap
-	lockMap map[LockKey]*LockValue
+	name    string // ex) "VPC", namespace of the lock keys
+	backend LockBackend
 }
 
 type LockKey struct {
@@ -26,17 +27,32 @@ type LockKey struct {
 	resourceId     string // ex) "VM-01"
 }
 
-type LockValue struct {
-	lock  sync.RWMutex // for id-based locking
-	count int          // reference counter for this lock
+// LockBackend is the implementation of the id-based locking.
+//   - memory: locks in the Spider process (default)
+//   - postgres: locks in the memory and PostgreSQL advisory locks shared by the Spider instances on a MetaDB
+type LockBackend interface {
+	Lock(key LockKey)
+	TryLock(key LockKey, timeout time.Duration) error // nothing is held if it fails
+	Unlock(key LockKey)
+	RLock(key LockKey)
+	RUnlock(key LockKey)
+	Status() []string // ex) "(aws-seoul-config:VM-01, 0xc000123456:1)"
 }
 
 //====================================================================
 
-func New() *SPLOCK {
-	var spLock = new(SPLOCK)
-	spLock.lockMap = make(map[LockKey]*LockValue)
-	return spLock
+// New returns the SPLOCK of the name.
+// The PostgreSQL backend is used if the MetaDB is PostgreSQL, so the locks are shared across the Spider instances.
+func New(name string) *SPLOCK {
+	if infostore.IsPostgres() {
+		return NewWithBackend(name, newPostgresBackend(name))
+	}
+	return NewWithBackend(name, newMemoryBackend())
+}
+
+// NewWithBackend returns the SPLOCK of the name with the backend.
+func NewWithBackend(name string, backend LockBackend) *SPLOCK {
+	return &SPLOCK{name: name, backend: backend}
 }
 
 func overrideConnection(conn string) string {
@@ -48,72 +64,34 @@ func overrideConnection(conn string) string {
 }
 
 func (spLock *SPLOCK) Lock(conn string, id string) {
-	conn = overrideConnection(conn)
-
-	spLock.rwMutex.Lock()
-	lockValue := spLock.lockMap[LockKey{conn, id}]
-	if lockValue == nil {
-		spLock.lockMap[LockKey{conn, id}] = &LockValue{}
-		lockValue = spLock.lockMap[LockKey{conn, id}]
-	}
-	lockValue.count++
-	spLock.rwMutex.Unlock()
+	spLock.backend.Lock(LockKey{overrideConnection(conn), id})
+}
 
-	lockValue.lock.Lock()
+// TryLock takes the lock within the timeout. If it returns an error, the lock is not held
+// and Unlock must not be called, ex) the lock is held by another one or the MetaDB is not available.
+func (spLock *SPLOCK) TryLock(conn string, id string, timeout time.Duration) error {
+	return spLock.backend.TryLock(LockKey{overrideConnection(conn), id}, timeout)
 }
 
 func (spLock *SPLOCK) Unlock(conn string, id string) {
-	conn = overrideConnection(conn)
-
-	spLock.rwMutex.Lock()
-	lockValue := spLock.lockMap[LockKey{conn, id}]
-	lockValue.count--
-	if lockValue.count == 0 {
-		delete(spLock.lockMap, LockKey{conn, id})
-	}
-	spLock.rwMutex.Unlock()
-
-	lockValue.lock.Unlock()
+	spLock.backend.Unlock(LockKey{overrideConnection(conn), id})
 }
 
 func (spLock *SPLOCK) RLock(conn string, id string) {
-	conn = overrideConnection(conn)
-
-	spLock.rwMutex.Lock()
-	lockValue := spLock.lockMap[LockKey{conn, id}]
-	if lockValue == nil {
-		spLock.lockMap[LockKey{conn, id}] = &LockValue{}
-		lockValue = spLock.lockMap[LockKey{conn, id}]
-	}
-	lockValue.count++
-	spLock.rwMutex.Unlock()
-
-	lockValue.lock.RLock()
+	spLock.backend.RLock(LockKey{overrideConnection(conn), id})
 }
 
 func (spLock *SPLOCK) RUnlock(conn string, id string) {
-	conn = overrideConnection(conn)
-
-	spLock.rwMutex.Lock()
-	lockValue := spLock.lockMap[LockKey{conn, id}]
-	lockValue.count--
-	if lockValue.count == 0 {
-		delete(spLock.lockMap, LockKey{conn, id})
-	}
-	spLock.rwMutex.Unlock()
-
-	lockValue.lock.RUnlock()
+	spLock.backend.RUnlock(LockKey{overrideConnection(conn), id})
 }
 
 func (spLock *SPLOCK) GetSPLockMapStatus(lockName string) string {
 	var buff bytes.Buffer
 	buff.WriteString("<" + lockName + "> ")
 
-	spLock.rwMutex.RLock()
-	for k, v := range spLock.lockMap {
-		buff.WriteString(fmt.Sprintf("(%s:%s, %p:%d) ", k.connectionName, k.resourceId, &v.lock, v.count))
+	for _, status := range spLock.backend.Status() {
+		buff.WriteString(status + " ")
 	}
-	spLock.rwMutex.RUnlock()
 
 	return buff.String()
 }
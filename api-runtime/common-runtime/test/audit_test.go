@@ -0,0 +1,96 @@
+// Audit Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+func TestAuditRedactSecrets(t *testing.T) {
+	credBody := `{"CredentialName":"aws-cred","ProviderName":"AWS","KeyValueInfoList":[{"Key":"ClientId","Value":"AKIA-ID"},{"Key":"ClientSecret","Value":"aws-secret"}]}`
+	redacted := cmrt.RedactSecrets("credential", []byte(credBody))
+	if strings.Contains(redacted, "AKIA-ID") || strings.Contains(redacted, "aws-secret") {
+		t.Errorf("credential values should be redacted: %s", redacted)
+	}
+	if !strings.Contains(redacted, "aws-cred") || !strings.Contains(redacted, "ClientSecret") {
+		t.Errorf("names and keys should be kept: %s", redacted)
+	}
+
+	userBody := `{"UserName":"alice","Password":"alice-password","ReqInfo":{"Name":"vm-01","VMUserPasswd":"vm-pw","KeyValueList":[{"Key":"ApiToken","Value":"tk"},{"Key":"Tag","Value":"dev"}]}}`
+	redacted = cmrt.RedactSecrets("user", []byte(userBody))
+	for _, secret := range []string{"alice-password", "vm-pw", `"tk"`} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("%s should be redacted: %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, "vm-01") || !strings.Contains(redacted, "dev") {
+		t.Errorf("non-secret values should be kept: %s", redacted)
+	}
+
+	if redacted := cmrt.RedactSecrets("s3", []byte("binary-data")); strings.Contains(redacted, "binary-data") {
+		t.Errorf("non-JSON body should not be kept: %s", redacted)
+	}
+}
+
+func TestAuditListFilter(t *testing.T) {
+	now := time.Now()
+	// records are kept in MetaDB, use unique names for each run
+	suffix := strconv.FormatInt(now.UnixNano(), 36)
+	alice, bob := "audit-alice-"+suffix, "audit-bob-"+suffix
+	aws, gcp := "audit-aws-"+suffix, "audit-gcp-"+suffix
+	records := []*cmrt.AuditInfo{
+		{Time: now.Add(-2 * time.Hour), UserName: alice, Method: "POST", ConnectionName: aws, ResourceType: "vm", NameId: "vm-01", Result: cmrt.AuditSucceeded},
+		{Time: now.Add(-1 * time.Hour), UserName: bob, Method: "DELETE", ConnectionName: aws, ResourceType: "vm", NameId: "vm-01", Result: cmrt.AuditFailed},
+		{Time: now, UserName: alice, Method: "POST", ConnectionName: gcp, ResourceType: "vpc", NameId: "vpc-01", Result: cmrt.AuditSucceeded},
+	}
+	for _, record := range records {
+		if err := cmrt.InsertAudit(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		filter cmrt.AuditFilter
+		count  int
+	}{
+		{cmrt.AuditFilter{UserName: alice}, 2},
+		{cmrt.AuditFilter{ConnectionName: aws}, 2},
+		{cmrt.AuditFilter{ConnectionName: aws, ResourceType: "vm", NameId: "vm-01"}, 2},
+		{cmrt.AuditFilter{ConnectionName: aws, StartTime: now.Add(-90 * time.Minute)}, 1},
+		{cmrt.AuditFilter{UserName: alice, EndTime: now.Add(-time.Minute)}, 1},
+		{cmrt.AuditFilter{UserName: alice, Limit: 1}, 1},
+	}
+	for _, tc := range testCases {
+		auditList, err := cmrt.ListAudit(tc.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(auditList) != tc.count {
+			t.Errorf("filter %+v: expected %d records, got %d", tc.filter, tc.count, len(auditList))
+		}
+	}
+
+	// newest first
+	auditList, err := cmrt.ListAudit(cmrt.AuditFilter{UserName: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auditList) == 2 && auditList[0].ResourceType != "vpc" {
+		t.Errorf("audit records should be listed newest first")
+	}
+
+	if _, err := cmrt.ListAudit(cmrt.AuditFilter{Limit: -1}); err == nil {
+		t.Error("negative limit should be rejected")
+	}
+}
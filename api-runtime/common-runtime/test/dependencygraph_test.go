@@ -0,0 +1,61 @@
+// Dependency Graph Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	// the owner relations are in the IID stores, the connection has no CSP
+	connectionName := setupTestConnection(t, "graph-test-conn",
+		&cmrt.VPCIIDInfo{NameId: "vpc-01", SystemId: "vpc-sys-01"},
+		&cmrt.SubnetIIDInfo{NameId: "subnet-01", SystemId: "subnet-sys-01", OwnerVPCName: "vpc-01"},
+		&cmrt.SGIIDInfo{NameId: "sg-01", SystemId: "sg-sys-01", OwnerVPCName: "vpc-01"},
+		&cmrt.ClusterIIDInfo{NameId: "cluster-01", SystemId: "cluster-sys-01", OwnerVPCName: "vpc-01"},
+		&cmrt.NodeGroupIIDInfo{NameId: "ng-01", SystemId: "ng-sys-01", OwnerClusterName: "cluster-01"},
+	)
+
+	graph, err := cmrt.GetDependencyGraph(connectionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.NodeList) != 5 {
+		t.Errorf("expected 5 nodes, got %d", len(graph.NodeList))
+	}
+	var edges []string
+	for _, edge := range graph.EdgeList {
+		edges = append(edges, edge.From+" "+edge.Relation+" "+edge.To)
+	}
+	expected := "cluster/cluster-01 contains nodegroup/cluster-01/ng-01, vpc/vpc-01 contains cluster/cluster-01, " +
+		"vpc/vpc-01 contains sg/sg-01, vpc/vpc-01 contains subnet/vpc-01/subnet-01"
+	if strings.Join(edges, ", ") != expected {
+		t.Errorf("expected edges %s, got %s", expected, strings.Join(edges, ", "))
+	}
+
+	// the relations of the cluster are read from the CSP, which is not available
+	if len(graph.WarningList) != 1 || !strings.Contains(graph.WarningList[0], "cluster") {
+		t.Errorf("failed lookup of the clusters should be warned: %v", graph.WarningList)
+	}
+
+	if dependents := graph.GetDependentList("vpc/vpc-01"); len(dependents) != 3 {
+		t.Errorf("the cluster, the SG and the subnet should depend on the VPC: %v", dependents)
+	}
+	if dependents := graph.GetDependentList("sg/sg-01"); len(dependents) != 0 {
+		t.Errorf("nothing should depend on the SG: %v", dependents)
+	}
+
+	dot := graph.ToDOT()
+	if !strings.HasPrefix(dot, "digraph ") || !strings.Contains(dot, `"vpc/vpc-01" -> "subnet/vpc-01/subnet-01" [label="contains"];`) {
+		t.Errorf("unexpected DOT: %s", dot)
+	}
+}
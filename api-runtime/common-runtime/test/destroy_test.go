@@ -0,0 +1,80 @@
+// Destroy Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+func TestDestroyPlan(t *testing.T) {
+	// resources in the IID stores only, the relations of the file system are not read without the CSP
+	connectionName := setupTestConnection(t, "destroy-test-conn",
+		&cmrt.VPCIIDInfo{NameId: "vpc-01", SystemId: "vpc-sys-01"},
+		&cmrt.SGIIDInfo{NameId: "test-sg-01", SystemId: "sg-sys-01", OwnerVPCName: "vpc-01"},
+		&cmrt.KeyIIDInfo{NameId: "test-key-01", SystemId: "key-sys-01"},
+		&cmrt.FileSystemIIDInfo{NameId: "fs-01", SystemId: "fs-sys-01", OwnerVPCName: "vpc-01"},
+	)
+
+	plan, err := cmrt.GetDestroyPlan(connectionName, cmrt.DestroyFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var steps []string
+	for _, step := range plan.StepList {
+		var names []string
+		for _, resource := range step.ResourceList {
+			names = append(names, resource.ResourceType+"/"+resource.NameId)
+		}
+		steps = append(steps, strings.Join(names, ","))
+	}
+	expected := "filesystem/fs-01,keypair/test-key-01,sg/test-sg-01 | vpc/vpc-01"
+	if strings.Join(steps, " | ") != expected || plan.ResourceCount != 4 {
+		t.Errorf("expected steps %s, got %s", expected, strings.Join(steps, " | "))
+	}
+	vpc := plan.StepList[len(plan.StepList)-1].ResourceList[0]
+	if strings.Join(vpc.UsedBy, ",") != "filesystem/fs-01,sg/test-sg-01" {
+		t.Errorf("VPC should be used by the file system and the SG: %v", vpc.UsedBy)
+	}
+	if len(plan.WarningList) != 1 || !strings.Contains(plan.WarningList[0], "filesystem") || len(plan.BlockedList) != 0 {
+		t.Errorf("only the relations of the file system should be unknown: %v, %v", plan.WarningList, plan.BlockedList)
+	}
+
+	// only the resources of the prefix
+	plan, err = cmrt.GetDestroyPlan(connectionName, cmrt.DestroyFilter{NamePrefix: "test-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.ResourceCount != 2 || len(plan.StepList) != 1 {
+		t.Errorf("only the SG and the key should be in the plan: %+v", plan.StepList)
+	}
+
+	// the VPC is still used by the SG and the file system, which are not selected
+	plan, err = cmrt.GetDestroyPlan(connectionName, cmrt.DestroyFilter{NamePrefix: "vpc-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.ResourceCount != 1 || len(plan.BlockedList) != 2 {
+		t.Errorf("the VPC should be blocked by the SG and the file system: %+v", plan.BlockedList)
+	}
+	// the destroy is refused before any deletion
+	if _, err := cmrt.Destroy(connectionName, cmrt.DestroyFilter{NamePrefix: "vpc-"}); err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Errorf("destroy of a VPC used by the resources which are not selected should be refused: %v", err)
+	}
+	if has, _ := infostore.HasByConditions(&cmrt.VPCIIDInfo{}, "connection_name", connectionName, "name_id", "vpc-01"); !has {
+		t.Error("the VPC should not be deleted by the refused destroy")
+	}
+
+	if _, err := cmrt.GetDestroyPlan(connectionName, cmrt.DestroyFilter{TagValue: "dev"}); err == nil {
+		t.Error("TagValue without TagKey should be rejected")
+	}
+}
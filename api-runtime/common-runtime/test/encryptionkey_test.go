@@ -0,0 +1,312 @@
+// Encryption Key Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// clearKeyVersions removes the key versions registered in the MetaDB by the other tests
+// and the tokens encrypted with them.
+func clearKeyVersions(t *testing.T) {
+	db, err := infostore.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer infostore.Close(db)
+	if err := db.Where("1 = 1").Delete(&cim.EncryptionKeyVersionInfo{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Where("1 = 1").Delete(&cmrt.APITokenInfo{}).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setKeyEnv sets the key env for the test and restores the built-in key after the test.
+func setKeyEnv(t *testing.T, env map[string]string) {
+	for _, name := range []string{"SPIDER_KEY_PROVIDER", "SPIDER_KEY", "SPIDER_KEY_VERSION", "SPIDER_KEY_PREVIOUS", "SPIDER_KEY_FILE"} {
+		os.Unsetenv(name)
+	}
+	for name, value := range env {
+		os.Setenv(name, value)
+	}
+	cim.ReloadKeyProvider()
+	t.Cleanup(func() {
+		for name := range env {
+			os.Unsetenv(name)
+		}
+		cim.ReloadKeyProvider()
+	})
+}
+
+func TestEncryptionKeyFileRotation(t *testing.T) {
+	clearKeyVersions(t)
+
+	// a value of the built-in key, stored before the key versions
+	legacyValue, err := cim.Encrypt(cim.SPIDER_KEY, []byte("legacy-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "spider-keyring.json")
+	setKeyEnv(t, map[string]string{"SPIDER_KEY_FILE": keyFile})
+
+	keyInfo, err := cim.GetEncryptionKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyInfo.Provider != "file" || keyInfo.ActiveVersion != "1" || !keyInfo.Rotatable {
+		t.Errorf("unexpected key info of a new key ring: %+v", keyInfo)
+	}
+	if stat, err := os.Stat(keyFile); err != nil || stat.Mode().Perm() != 0600 {
+		t.Errorf("key ring file should be created with 0600: %v", err)
+	}
+
+	v1Value, err := cim.EncryptValue("v1-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(v1Value, "spk:1:") || cim.GetValueKeyVersion(v1Value) != "1" {
+		t.Errorf("encrypted value should record the key version: %s", v1Value)
+	}
+
+	// secrets of a user, to be re-encrypted by the rotation
+	if _, err := cmrt.CreateUser("key-user", "key-password", cmrt.RoleReadOnly, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.DeleteUser("key-user")
+	issued, err := cmrt.IssueToken("s3", "key-user", "", 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cmrt.RotateEncryptionKey(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ActiveVersion != "2" || result.TokenS3KeyCount < 1 {
+		t.Errorf("unexpected rotation result: %+v", result)
+	}
+
+	// mixed versions are decrypted during and after the rotation
+	for value, plain := range map[string]string{legacyValue: "legacy-secret", v1Value: "v1-secret"} {
+		decrypted, err := cim.DecryptValue(value)
+		if err != nil || decrypted != plain {
+			t.Errorf("%s: expected %s, got %s, %v", value, plain, decrypted, err)
+		}
+	}
+	reEncrypted, changed, err := cim.ReEncryptValue(v1Value)
+	if err != nil || !changed || cim.GetValueKeyVersion(reEncrypted) != "2" {
+		t.Errorf("v1 value should be re-encrypted with v2: %s, %v", reEncrypted, err)
+	}
+
+	secretKey, _, err := cmrt.GetS3KeyPair(issued.S3AccessKeyID)
+	if err != nil || secretKey != issued.S3SecretKeyText {
+		t.Errorf("S3 secret key should be kept after the rotation: %v", err)
+	}
+
+	// the key ring is loaded again from the file
+	cim.ReloadKeyProvider()
+	keyInfo, err = cim.GetEncryptionKeyInfo()
+	if err != nil || keyInfo.ActiveVersion != "2" || len(keyInfo.Versions) != 3 {
+		t.Errorf("unexpected key info of the reloaded key ring: %+v, %v", keyInfo, err)
+	}
+}
+
+func TestEncryptionKeyEnv(t *testing.T) {
+	clearKeyVersions(t)
+
+	oldKey := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	setKeyEnv(t, map[string]string{"SPIDER_KEY": oldKey, "SPIDER_KEY_VERSION": "2026a"})
+
+	oldValue, err := cim.EncryptValue("env-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cim.GetValueKeyVersion(oldValue) != "2026a" {
+		t.Errorf("encrypted value should record the key version: %s", oldValue)
+	}
+	if _, err := cmrt.RotateEncryptionKey(true); err == nil {
+		t.Error("env key provider should not create a new key version")
+	}
+
+	// new key with the old key as a previous one
+	setKeyEnv(t, map[string]string{
+		"SPIDER_KEY":          "fedcba9876543210fedcba9876543210",
+		"SPIDER_KEY_VERSION":  "2026b",
+		"SPIDER_KEY_PREVIOUS": "2026a:" + oldKey,
+	})
+	if decrypted, err := cim.DecryptValue(oldValue); err != nil || decrypted != "env-secret" {
+		t.Errorf("previous key should decrypt the old value: %v", err)
+	}
+
+	// old key is not given
+	setKeyEnv(t, map[string]string{"SPIDER_KEY": "fedcba9876543210fedcba9876543210", "SPIDER_KEY_VERSION": "2026b"})
+	if _, err := cim.DecryptValue(oldValue); err == nil {
+		t.Error("value of an unknown key version should not be decrypted")
+	}
+
+	setKeyEnv(t, map[string]string{"SPIDER_KEY": "short-key"})
+	if _, err := cim.EncryptValue("env-secret"); err == nil {
+		t.Error("invalid SPIDER_KEY should be rejected")
+	}
+}
+
+func TestEncryptStream(t *testing.T) {
+	clearKeyVersions(t)
+
+	keyFile := filepath.Join(t.TempDir(), "spider-keyring.json")
+	setKeyEnv(t, map[string]string{"SPIDER_KEY_FILE": keyFile})
+	if err := cim.CheckExternalKey(); err == nil {
+		t.Error("key ring file of this server should not be an external key")
+	}
+
+	encrypt := func(data []byte) []byte {
+		encReader, encSize, err := cim.NewEncryptReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encData, err := io.ReadAll(encReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(encData)) != encSize {
+			t.Errorf("encrypted size is %d, but %d is given", len(encData), encSize)
+		}
+		return encData
+	}
+	decrypt := func(encData []byte) ([]byte, error) {
+		decReader, err := cim.NewDecryptReader(bytes.NewReader(encData))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(decReader)
+	}
+
+	// 0 byte, a full chunk and chunks with a remainder
+	for _, size := range []int{0, 64 * 1024, 200 * 1024} {
+		data := []byte(strings.Repeat("SQLite format 3\x00", size/16+1))[:size]
+		encData := encrypt(data)
+		if !strings.HasPrefix(string(encData), "spkd:1:") || (size > 0 && strings.Contains(string(encData), "SQLite format 3")) {
+			t.Errorf("encrypted data should record the key version and hide the data: %q", encData[:16])
+		}
+		if decrypted, err := decrypt(encData); err != nil || !bytes.Equal(decrypted, data) {
+			t.Errorf("%d bytes should be decrypted: %v", size, err)
+		}
+	}
+
+	data := []byte(strings.Repeat("SQLite format 3\x00", 10000))
+	encData := encrypt(data)
+
+	// data of the old key version is decrypted after the rotation
+	if _, err := cmrt.RotateEncryptionKey(true); err != nil {
+		t.Fatal(err)
+	}
+	if decrypted, err := decrypt(encData); err != nil || !bytes.Equal(decrypted, data) {
+		t.Errorf("data of the previous key version should be decrypted: %v", err)
+	}
+
+	tampered := append([]byte{}, encData...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, err := decrypt(tampered); err == nil {
+		t.Error("tampered data should not be decrypted")
+	}
+	if _, err := decrypt(encData[:len(encData)-70*1024]); err == nil {
+		t.Error("truncated data should not be decrypted")
+	}
+	if _, err := decrypt(append(append([]byte{}, encData...), 0)); err == nil {
+		t.Error("data with trailing bytes should not be decrypted")
+	}
+	if _, err := decrypt(data); err == nil {
+		t.Error("plain data should not be decrypted")
+	}
+
+	setKeyEnv(t, map[string]string{"SPIDER_KEY": "fedcba9876543210fedcba9876543210", "SPIDER_KEY_VERSION": "2027"})
+	if err := cim.CheckExternalKey(); err != nil {
+		t.Errorf("SPIDER_KEY should be an external key: %v", err)
+	}
+}
+
+func TestEncryptionKeyVersionsInMetaDB(t *testing.T) {
+	clearKeyVersions(t)
+	keyFile := filepath.Join(t.TempDir(), "spider-keyring.json")
+	setKeyEnv(t, map[string]string{"SPIDER_KEY_FILE": keyFile})
+
+	if _, err := cim.EncryptValue("db-secret"); err != nil {
+		t.Fatal(err)
+	}
+	var infoList []*cim.EncryptionKeyVersionInfo
+	if err := infostore.List(&infoList); err != nil {
+		t.Fatal(err)
+	}
+	if len(infoList) != 1 || infoList[0].Version != "1" || !infoList[0].Active || infoList[0].KeyCheck == "" {
+		t.Fatalf("key version 1 should be registered as active: %+v", infoList)
+	}
+
+	// another server activates a new version which this server does not have
+	if err := infostore.Insert(&cim.EncryptionKeyVersionInfo{Version: "7", Provider: "file", KeyCheck: "other", CreatedTime: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	db, err := infostore.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Model(&cim.EncryptionKeyVersionInfo{}).Where("version = ?", "1").Update("active", false)
+	db.Model(&cim.EncryptionKeyVersionInfo{}).Where("version = ?", "7").Update("active", true)
+	infostore.Close(db)
+
+	if _, err := cim.EncryptValue("db-secret"); err == nil {
+		t.Error("value should not be encrypted without the active key version of the MetaDB")
+	}
+	keyInfo, err := cim.GetEncryptionKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyInfo.ActiveVersion != "7" || len(keyInfo.MissingVersions) != 1 || keyInfo.MissingVersions[0] != "7" {
+		t.Errorf("key info should show the active and missing version of the MetaDB: %+v", keyInfo)
+	}
+
+	// a new version is created after the versions of the MetaDB
+	result, err := cmrt.RotateEncryptionKey(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ActiveVersion != "8" {
+		t.Errorf("new key version should be 8, got %s", result.ActiveVersion)
+	}
+
+	// a key ring with a different key of a registered version is rejected
+	otherKeyFile := filepath.Join(t.TempDir(), "spider-keyring.json")
+	if err := os.WriteFile(otherKeyFile, []byte(`{"ActiveVersion":"1","Keys":{"1":"`+
+		base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))+`"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setKeyEnv(t, map[string]string{"SPIDER_KEY_FILE": otherKeyFile})
+	if _, err := cim.EncryptValue("db-secret"); err == nil {
+		t.Error("key ring with a different key of the version 1 should be rejected")
+	}
+
+	// a new key ring is not created for a MetaDB with key versions
+	setKeyEnv(t, map[string]string{"SPIDER_KEY_FILE": filepath.Join(t.TempDir(), "spider-keyring.json")})
+	if _, err := cim.EncryptValue("db-secret"); err == nil {
+		t.Error("new key ring should not be created when the MetaDB has key versions")
+	}
+	clearKeyVersions(t)
+}
@@ -0,0 +1,39 @@
+// Test Fixtures of CB-Spider Common Runtime.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// setupTestConnection returns a new connection name with the prefix, which has no CSP,
+// and inserts the IID infos on it. The ConnectionName of each IID info is set here.
+// The IID infos of the connection are deleted at the end of the test.
+func setupTestConnection(t *testing.T, prefix string, iidInfoList ...interface{}) string {
+	connectionName := prefix + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	t.Cleanup(func() {
+		for _, iidInfo := range iidInfoList {
+			emptyInfo := reflect.New(reflect.TypeOf(iidInfo).Elem()).Interface()
+			infostore.DeleteByCondition(emptyInfo, "connection_name", connectionName)
+		}
+	})
+	for _, iidInfo := range iidInfoList {
+		reflect.ValueOf(iidInfo).Elem().FieldByName("ConnectionName").SetString(connectionName)
+		if err := infostore.Insert(iidInfo); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return connectionName
+}
@@ -0,0 +1,180 @@
+// Job Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+func waitJobDone(t *testing.T, jobID string) *cmrt.JobInfo {
+	for i := 0; i < 100; i++ {
+		jobInfo, err := cmrt.GetJob(jobID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if jobInfo.Status != cmrt.JobQueued && jobInfo.Status != cmrt.JobRunning {
+			return jobInfo
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Job %s is not finished", jobID)
+	return nil
+}
+
+func TestJobSucceededAndFailed(t *testing.T) {
+	jobInfo, err := cmrt.SubmitJob("TestOp", "job-test-conn", "vm", "vm-01", func() (interface{}, error) {
+		return map[string]string{"Name": "vm-01"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobInfo.Status != cmrt.JobQueued {
+		t.Errorf("submitted job status is not %s. It is %s.", cmrt.JobQueued, jobInfo.Status)
+	}
+	doneInfo := waitJobDone(t, jobInfo.JobID)
+	if doneInfo.Status != cmrt.JobSucceeded || doneInfo.Result != `{"Name":"vm-01"}` {
+		t.Errorf("unexpected job: %#v", doneInfo)
+	}
+
+	jobInfo, err = cmrt.SubmitJob("TestOp", "job-test-conn", "vm", "vm-02", func() (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doneInfo = waitJobDone(t, jobInfo.JobID)
+	if doneInfo.Status != cmrt.JobFailed || doneInfo.Error != "boom" {
+		t.Errorf("unexpected job: %#v", doneInfo)
+	}
+
+	jobList, err := cmrt.ListJob("job-test-conn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobList) < 2 || jobList[0].JobID != jobInfo.JobID {
+		t.Errorf("jobs are not listed newest first")
+	}
+}
+
+// blockJobSlots occupies all job slots, so that the next job stays Queued.
+// The returned function releases the slots and waits for the blocker jobs.
+func blockJobSlots(t *testing.T) func() {
+	release := make(chan struct{})
+	var blockers []string
+	for i := 0; i < cmrt.GetJobMaxConcurrency(); i++ {
+		jobInfo, err := cmrt.SubmitJob("Block", "job-test-conn", "vm", "blocker", func() (interface{}, error) {
+			<-release
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		blockers = append(blockers, jobInfo.JobID)
+	}
+	for _, jobID := range blockers {
+		for i := 0; i < 100; i++ {
+			if jobInfo, err := cmrt.GetJob(jobID); err == nil && jobInfo.Status == cmrt.JobRunning {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	return func() {
+		close(release)
+		for _, jobID := range blockers {
+			waitJobDone(t, jobID)
+		}
+	}
+}
+
+func TestJobCancel(t *testing.T) {
+	releaseBlockers := blockJobSlots(t)
+	defer releaseBlockers()
+	blockers, err := cmrt.ListJob("job-test-conn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blockers) == 0 || blockers[0].Status != cmrt.JobRunning {
+		t.Fatal("blocker jobs are not running")
+	}
+
+	if _, err := cmrt.CancelJob(blockers[0].JobID); err == nil {
+		t.Error("running job should not be canceled")
+	}
+
+	jobInfo, err := cmrt.SubmitJob("TestOp", "job-test-conn", "vm", "vm-03", func() (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := cmrt.CancelJob(jobInfo.JobID)
+	if err != nil || !ok {
+		t.Fatalf("queued job should be canceled: %v", err)
+	}
+	canceledInfo, err := cmrt.GetJob(jobInfo.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceledInfo.Status != cmrt.JobCanceled {
+		t.Errorf("job status is not %s. It is %s.", cmrt.JobCanceled, canceledInfo.Status)
+	}
+	if _, err := cmrt.CancelJob(jobInfo.JobID); err == nil {
+		t.Error("canceled job should not be canceled again")
+	}
+}
+
+func TestJobCanceledByAnotherServer(t *testing.T) {
+	releaseBlockers := blockJobSlots(t)
+
+	ran := make(chan struct{}, 1)
+	jobInfo, err := cmrt.SubmitJob("TestOp", "job-test-conn", "vm", "vm-04", func() (interface{}, error) {
+		ran <- struct{}{}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobInfo.OwnerID == "" {
+		t.Error("job has no OwnerID")
+	}
+
+	// another server sharing the MetaDB cancels the job: only the MetaDB is changed
+	db, err := infostore.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Model(&cmrt.JobInfo{}).Where("job_id = ? AND status = ?", jobInfo.JobID, cmrt.JobQueued).
+		Updates(map[string]interface{}{"status": cmrt.JobCanceled}).Error
+	infostore.Close(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releaseBlockers()
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case <-ran:
+		t.Error("job canceled by another server should not run")
+	default:
+	}
+	canceledInfo, err := cmrt.GetJob(jobInfo.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceledInfo.Status != cmrt.JobCanceled {
+		t.Errorf("job status is not %s. It is %s.", cmrt.JobCanceled, canceledInfo.Status)
+	}
+}
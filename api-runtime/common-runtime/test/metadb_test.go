@@ -0,0 +1,221 @@
+// MetaDB Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+func getTableRows(tables []infostore.TableRowInfo, table string) int64 {
+	for _, tableInfo := range tables {
+		if tableInfo.Table == table {
+			return tableInfo.Rows
+		}
+	}
+	return -1
+}
+
+func TestMetaDBBackupRestore(t *testing.T) {
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	userName := "metadb-user-" + suffix
+	if _, err := cmrt.CreateUser(userName, "metadb-password", cmrt.RoleReadOnly, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.DeleteUser(userName)
+	layout := &cmrt.TopologyLayoutInfo{ID: "metadb-conn::" + suffix, ConnectionName: "metadb-conn", VersionName: suffix}
+	if err := infostore.Insert(layout); err != nil {
+		t.Fatal(err)
+	}
+	defer infostore.Delete(&cmrt.TopologyLayoutInfo{}, "id", layout.ID)
+
+	backupInfo, err := cmrt.CreateMetaDBBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	backupList, err := cmrt.ListMetaDBBackup()
+	if err != nil || len(backupList) == 0 || backupList[0].FileName != backupInfo.FileName {
+		t.Fatalf("the new backup should be listed first: %v", err)
+	}
+
+	validation, err := cmrt.ValidateMetaDBBackup(backupInfo.FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !validation.Valid || validation.IntegrityCheck != "ok" || getTableRows(validation.Tables, "user_infos") < 1 {
+		t.Errorf("unexpected validation result: %+v", validation)
+	}
+	if _, err := cmrt.ValidateMetaDBBackup("../meta_db/cb-spider.db"); err == nil {
+		t.Error("a file out of the backup directory should be rejected")
+	}
+
+	// the deleted layout comes back with the restore, but the deleted user does not
+	if _, err := infostore.Delete(&cmrt.TopologyLayoutInfo{}, "id", layout.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cmrt.DeleteUser(userName); err != nil {
+		t.Fatal(err)
+	}
+	result, err := cmrt.RestoreMetaDBBackup(backupInfo.FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SafetyBackup == "" || result.SafetyBackup == backupInfo.FileName {
+		t.Errorf("the current MetaDB should be backed up before the restore: %+v", result)
+	}
+	if has, err := infostore.Has(&cmrt.TopologyLayoutInfo{}, "id", layout.ID); err != nil || !has {
+		t.Errorf("layout should be restored: %v", err)
+	}
+	if _, err := cmrt.GetUser(userName); err == nil {
+		t.Error("deleted user should not be restored")
+	}
+	for _, table := range []string{"user_infos", "api_token_infos", "audit_infos", "job_infos", "encryption_key_version_infos"} {
+		if !slices.Contains(result.KeptTables, table) {
+			t.Errorf("table '%s' should be kept by the restore: %v", table, result.KeptTables)
+		}
+	}
+
+	// API calls are accepted after the restore
+	if err := cmrt.BeginAPICall(); err != nil {
+		t.Errorf("maintenance mode should be finished: %v", err)
+	} else {
+		cmrt.EndAPICall()
+	}
+}
+
+func TestMetaDBMigration(t *testing.T) {
+	backupDir := infostore.LoadBackupConfig().BackupDir
+	target, err := filepath.Abs(filepath.Join(backupDir, "migrated-"+strconv.FormatInt(time.Now().UnixNano(), 36)+".db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(target)
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	userName := "migrate-user-" + suffix
+	if _, err := cmrt.CreateUser(userName, "migrate-password", cmrt.RoleReadOnly, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.DeleteUser(userName)
+	layout := &cmrt.TopologyLayoutInfo{ID: "migrate-conn::" + suffix, ConnectionName: "migrate-conn", VersionName: suffix}
+	if err := infostore.Insert(layout); err != nil {
+		t.Fatal(err)
+	}
+	defer infostore.Delete(&cmrt.TopologyLayoutInfo{}, "id", layout.ID)
+
+	if _, err := cmrt.MigrateMetaDB("", filepath.Join(t.TempDir(), "migrated.db"), false); err == nil {
+		t.Error("SQLite file out of the backup directory should be rejected")
+	}
+
+	result, err := cmrt.MigrateMetaDB("", target, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Target != target || getTableRows(result.Tables, "topology_layout_infos") < 1 ||
+		getTableRows(result.MergedTables, "user_infos") < 1 {
+		t.Errorf("unexpected migration result: %+v", result)
+	}
+
+	// the target has the rows now
+	if _, err := cmrt.MigrateMetaDB("", target, false); err == nil {
+		t.Error("migration into a non-empty MetaDB should fail without overwrite")
+	}
+	result, err = cmrt.MigrateMetaDB("", target, true)
+	if err != nil {
+		t.Errorf("migration with overwrite should succeed: %v", err)
+	} else if getTableRows(result.MergedTables, "user_infos") != 0 {
+		t.Errorf("merged users should not be added again: %+v", result.MergedTables)
+	}
+	if _, err := cmrt.MigrateMetaDB(target, target, true); err == nil {
+		t.Error("migration into the source itself should fail")
+	}
+}
+
+func TestMetaDBMaintenanceOfAnotherServer(t *testing.T) {
+	db, err := infostore.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer infostore.Close(db)
+
+	// another server is restoring the MetaDB, and reports that it is alive
+	state := &cmrt.MaintenanceStateInfo{ID: "metadb", Reason: "test maintenance", ServerID: "other-server", StartTime: time.Now()}
+	if err := db.Create(state).Error; err != nil {
+		t.Fatal(err)
+	}
+	defer db.Where("server_id = ?", "other-server").Delete(&cmrt.MaintenanceStateInfo{})
+	defer db.Where("server_id = ?", "other-server").Delete(&cmrt.MaintenanceServerInfo{})
+
+	if _, err := cmrt.RestoreMetaDBBackup("cb-spider_backup_20261018_120000.db"); err == nil || !strings.Contains(err.Error(), "other-server") {
+		t.Errorf("maintenance should not be started during the maintenance of another server: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !cmrt.GetMaintenanceInfo().Enabled && time.Now().Before(deadline) {
+		db.Save(&cmrt.MaintenanceServerInfo{ServerID: "other-server", UpdatedTime: time.Now()})
+		time.Sleep(200 * time.Millisecond)
+	}
+	if maintenance := cmrt.GetMaintenanceInfo(); !maintenance.Enabled || maintenance.ServerID != "other-server" {
+		t.Fatalf("maintenance of another server should be followed: %+v", maintenance)
+	}
+	if err := cmrt.BeginAPICall(); err == nil {
+		cmrt.EndAPICall()
+		t.Error("API call should be rejected during the maintenance of another server")
+	}
+
+	// the server stops reporting, its maintenance is finished
+	deadline = time.Now().Add(20 * time.Second)
+	for cmrt.GetMaintenanceInfo().Enabled && time.Now().Before(deadline) {
+		time.Sleep(200 * time.Millisecond)
+	}
+	if err := cmrt.BeginAPICall(); err != nil {
+		t.Errorf("maintenance of a stopped server should be finished: %v", err)
+	} else {
+		cmrt.EndAPICall()
+	}
+}
+
+func TestMetaDBRemoteBackupConfig(t *testing.T) {
+	// only the connection is given, the remote target is disabled
+	os.Setenv("SPIDER_BACKUP_S3_CONNECTION", "backup-conn")
+	defer os.Unsetenv("SPIDER_BACKUP_S3_CONNECTION")
+	if infostore.LoadBackupConfig().RemoteEnabled() {
+		t.Error("remote target should be disabled without the bucket")
+	}
+
+	if _, err := cmrt.ListMetaDBRemoteBackup(); err == nil {
+		t.Error("remote backups should not be listed without the remote target")
+	}
+	if _, err := cmrt.RestoreMetaDBRemoteBackup("cb-spider_backup_20261018_120000.db"); err == nil {
+		t.Error("remote backup should not be restored without the remote target")
+	}
+
+	os.Setenv("SPIDER_BACKUP_S3_BUCKET", "backup-bucket")
+	defer os.Unsetenv("SPIDER_BACKUP_S3_BUCKET")
+	backupCfg := infostore.LoadBackupConfig()
+	if !backupCfg.RemoteEnabled() || backupCfg.S3Prefix != infostore.DEFAULT_BACKUP_S3_PREFIX {
+		t.Errorf("unexpected remote target: %+v", backupCfg)
+	}
+	if _, err := cmrt.RestoreMetaDBRemoteBackup("../cb-spider.db"); err == nil {
+		t.Error("invalid backup file name should be rejected")
+	}
+
+	// the built-in key is not an external key
+	if _, err := cmrt.CreateMetaDBBackup(); err == nil || !strings.Contains(err.Error(), "external key") {
+		t.Errorf("backup should not be uploaded without an external key: %v", err)
+	}
+}
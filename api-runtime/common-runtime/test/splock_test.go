@@ -0,0 +1,117 @@
+// SPLock Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// The PostgreSQL backend is tested only with a PostgreSQL MetaDB(SPIDER_METADB_URL).
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	splock "github.com/cloud-barista/cb-spider/api-runtime/common-runtime/sp-lock"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+func TestSPLockMemoryBackend(t *testing.T) {
+	if infostore.IsPostgres() {
+		t.Skip("the MetaDB is PostgreSQL, the memory backend is not used")
+	}
+	spLock := splock.New("TestMemory")
+
+	// exclusive lock
+	spLock.Lock("lock-conn", "res-01")
+	if err := spLock.TryLock("lock-conn", "res-01", 50*time.Millisecond); err == nil {
+		t.Error("locked key should not be locked again")
+	}
+	if err := spLock.TryLock("lock-conn", "res-02", 50*time.Millisecond); err != nil {
+		t.Errorf("another key should be locked: %v", err)
+	} else {
+		spLock.Unlock("lock-conn", "res-02")
+	}
+	if status := spLock.GetSPLockMapStatus("TestMemory"); !strings.Contains(status, "lock-conn:res-01") {
+		t.Errorf("status should show the held lock: %s", status)
+	}
+
+	var released atomic.Bool
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		released.Store(true)
+		spLock.Unlock("lock-conn", "res-01")
+	}()
+	if err := spLock.TryLock("lock-conn", "res-01", 2*time.Second); err != nil {
+		t.Fatalf("lock should be taken after the release: %v", err)
+	}
+	if !released.Load() {
+		t.Error("lock was taken before the release")
+	}
+	spLock.Unlock("lock-conn", "res-01")
+
+	// shared locks
+	spLock.RLock("lock-conn", "res-01")
+	spLock.RLock("lock-conn", "res-01")
+	if err := spLock.TryLock("lock-conn", "res-01", 50*time.Millisecond); err == nil {
+		t.Error("shared locked key should not be locked exclusively")
+	}
+	spLock.RUnlock("lock-conn", "res-01")
+	spLock.RUnlock("lock-conn", "res-01")
+	if err := spLock.TryLock("lock-conn", "res-01", 50*time.Millisecond); err != nil {
+		t.Errorf("released key should be locked: %v", err)
+	} else {
+		spLock.Unlock("lock-conn", "res-01")
+	}
+
+	if status := spLock.GetSPLockMapStatus("TestMemory"); strings.Contains(status, "lock-conn") {
+		t.Errorf("status should not show the released locks: %s", status)
+	}
+}
+
+func TestSPLockPostgresBackend(t *testing.T) {
+	if !infostore.IsPostgres() {
+		t.Skip("set SPIDER_METADB_URL to a PostgreSQL MetaDB to test the PostgreSQL backend")
+	}
+
+	// two SPLOCKs of a name have their own locks in the memory, like two Spider servers
+	server1 := splock.New("TestPostgres")
+	server2 := splock.New("TestPostgres")
+
+	server1.Lock("lock-conn", "res-01")
+	if err := server2.TryLock("lock-conn", "res-01", 200*time.Millisecond); err == nil {
+		t.Fatal("key locked by another server should not be locked")
+	}
+	server1.Unlock("lock-conn", "res-01")
+	if err := server2.TryLock("lock-conn", "res-01", 2*time.Second); err != nil {
+		t.Fatalf("released key should be locked by another server: %v", err)
+	}
+	server2.Unlock("lock-conn", "res-01")
+
+	// a lost DB session drops only its own lock
+	server1.Lock("lock-conn", "res-01")
+	server1.Lock("lock-conn", "res-02")
+	defer server1.Unlock("lock-conn", "res-02")
+	defer server1.Unlock("lock-conn", "res-01")
+
+	db, err := infostore.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var terminated []bool
+	err = db.Raw(`SELECT pg_terminate_backend(l.pid) FROM pg_locks l
+		JOIN splock_keys k ON ((l.classid::bigint << 32) | l.objid::bigint) = k.lock_key
+		WHERE l.locktype = 'advisory' AND l.objsubid = 1 AND l.granted
+		AND k.lock_name = ? AND k.connection_name = ? AND k.resource_id = ?`,
+		"TestPostgres", "lock-conn", "res-01").Scan(&terminated).Error
+	if err != nil || len(terminated) != 1 {
+		t.Fatalf("failed to terminate the session of the lock: %v, %v", terminated, err)
+	}
+	if err := server2.TryLock("lock-conn", "res-02", 200*time.Millisecond); err == nil {
+		t.Error("lock of another session should be kept when a session is lost")
+	}
+}
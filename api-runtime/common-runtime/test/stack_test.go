@@ -0,0 +1,151 @@
+// Stack Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+const stackTestDocument = `
+Name: web-stack
+ConnectionName: CONNECTION
+ResourceList:
+  - Type: vpc
+    Name: vpc-01
+    Spec:
+      IPv4_CIDR: 10.0.0.0/16
+      SubnetInfoList:
+        - Name: subnet-01
+          IPv4_CIDR: 10.0.1.0/24
+  - Type: nlb
+    Name: nlb-01
+    Spec:
+      VPCName: vpc-01
+      Listener: {Protocol: TCP, Port: 22}
+      VMGroup: {VMs: [vm-01]}
+      HealthChecker: {Protocol: TCP, Port: 22}
+  - Type: disk
+    Name: disk-01
+    Spec:
+      DiskSize: 100
+      AttachVMName: vm-01
+  - Type: vm
+    Name: vm-01
+    Spec:
+      ImageName: ami-01
+      VMSpecName: t2.micro
+      VPCName: vpc-01
+      SubnetName: subnet-01
+      SecurityGroupNames: [sg-01]
+      KeyPairName: keypair-01
+  - Type: keypair
+    Name: keypair-01
+    Spec:
+      PublicKey: ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFk5kqdBvl5ld0s2Yw9JmKc3z0kq5PpQ5fJbq8m3Kx7C stack-test
+  - Type: sg
+    Name: sg-01
+    Spec:
+      VPCName: vpc-01
+      SecurityRules:
+        - {Direction: inbound, IPProtocol: TCP, FromPort: 22, ToPort: 22}
+`
+
+func TestStackPlan(t *testing.T) {
+	// the connection has no CSP, so only the resources to create are planned
+	// vpc-02 is in the connection, but not in the stack
+	connectionName := setupTestConnection(t, "stack-test-conn",
+		&cmrt.VPCIIDInfo{NameId: "vpc-02", SystemId: "vpc-sys-02"},
+	)
+	parseDocument := func(document string) *cmrt.StackDocument {
+		doc, err := cmrt.ParseStackDocument([]byte(strings.Replace(document, "CONNECTION", connectionName, 1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+
+	plan, err := cmrt.GetStackPlan(parseDocument(stackTestDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actions []string
+	for _, action := range plan.ActionList {
+		actions = append(actions, action.Action+" "+action.ResourceType+"/"+action.Name)
+	}
+	expected := "Create vpc/vpc-01, Create sg/sg-01, Create keypair/keypair-01, Create vm/vm-01, Create disk/disk-01, Create nlb/nlb-01"
+	if strings.Join(actions, ", ") != expected {
+		t.Errorf("expected actions %s, got %s", expected, strings.Join(actions, ", "))
+	}
+
+	// references to the resources which are neither in the document nor in the connection
+	for _, replace := range [][2]string{
+		{"SubnetName: subnet-01", "SubnetName: subnet-02"},
+		{"KeyPairName: keypair-01", "KeyPairName: keypair-02"},
+		{"AttachVMName: vm-01", "AttachVMName: vm-02"},
+	} {
+		doc := parseDocument(strings.Replace(stackTestDocument, replace[0], replace[1], 1))
+		if _, err := cmrt.GetStackPlan(doc); err == nil || !strings.Contains(err.Error(), "02") {
+			t.Errorf("reference of %s should be rejected: %v", replace[1], err)
+		}
+	}
+
+	// a reference to a resource in the connection
+	doc := parseDocument(strings.Replace(stackTestDocument, "    Spec:\n      VPCName: vpc-01\n      SecurityRules", "    Spec:\n      VPCName: vpc-02\n      SecurityRules", 1))
+	if _, err := cmrt.GetStackPlan(doc); err != nil {
+		t.Errorf("reference to a VPC in the connection should be allowed: %v", err)
+	}
+
+	// a resource in the connection which is not owned by the stack
+	doc = parseDocument(strings.ReplaceAll(stackTestDocument, "vpc-01", "vpc-02"))
+	if _, err := cmrt.GetStackPlan(doc); err == nil || !strings.Contains(err.Error(), "not owned") {
+		t.Errorf("existing VPC should conflict: %v", err)
+	}
+
+	if _, err := cmrt.GetStack(connectionName, "web-stack"); err == nil {
+		t.Error("planned stack should not be saved")
+	}
+}
+
+func TestStackDocument(t *testing.T) {
+	yamlDoc, err := cmrt.ParseStackDocument([]byte(stackTestDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonDoc, err := cmrt.ParseStackDocument([]byte(`{"Name": "web-stack", "ConnectionName": "CONNECTION",
+		"ResourceList": [{"Type": "disk", "Name": "disk-01", "Spec": {"DiskSize": 100}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// numbers are parsed as strings in both formats
+	for _, spec := range []string{string(yamlDoc.ResourceList[2].Spec), string(jsonDoc.ResourceList[0].Spec)} {
+		if !strings.Contains(spec, `"DiskSize":"100"`) {
+			t.Errorf("unexpected disk spec: %s", spec)
+		}
+	}
+
+	for _, invalid := range []string{
+		"Name: web-stack\nConnectionName: conn\nResourceList:\n  - {Type: keypair, Name: key-01, Spec: {Tags: []}}",
+		"Name: web-stack\nConnectionName: conn\nResourceList:\n  - {Type: keypair, Name: key-01, Spec: {PublicKey: ssh-rsa AAAA}}\n  - {Type: keypair, Name: key-01, Spec: {PublicKey: ssh-rsa AAAA}}",
+		"Name: web-stack\nConnectionName: conn\nResourceList:\n  - {Type: keypair, Name: key-01}",
+		"Name: web-stack\nConnectionName: conn\nResourceList:\n  - {Type: cluster, Name: cluster-01}",
+		"Name: web-stack\nConnectionName: conn\nResourceList:\n  - {Type: sg, Name: sg-01}",
+		"Name: web-stack\nConnectionName: conn\nResourceList: []",
+	} {
+		doc, err := cmrt.ParseStackDocument([]byte(invalid))
+		if err == nil {
+			_, err = cmrt.GetStackPlan(doc)
+		}
+		if err == nil {
+			t.Errorf("invalid document should be rejected: %s", invalid)
+		}
+	}
+}
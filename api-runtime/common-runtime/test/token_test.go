@@ -0,0 +1,107 @@
+// API Token Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+func TestTokenIssueAndRevoke(t *testing.T) {
+	os.Setenv("SPIDER_USERNAME", "admin")
+	os.Setenv("SPIDER_PASSWORD", "admin-password")
+
+	if _, err := cmrt.CreateUser("token-user", "token-password", cmrt.RoleOperator, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.DeleteUser("token-user")
+
+	if _, err := cmrt.IssueToken("ci", "no-user", "", 0, false); err == nil {
+		t.Error("token of unknown user should be rejected")
+	}
+	if _, err := cmrt.IssueToken("ci", "token-user", "root", 0, false); err == nil {
+		t.Error("unknown scope should be rejected")
+	}
+	if _, err := cmrt.IssueToken("ci", "token-user", "", -1, false); err == nil {
+		t.Error("negative ExpiryDays should be rejected")
+	}
+
+	issued, err := cmrt.IssueToken("ci", "token-user", "", 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(issued.Token, cmrt.TokenPrefix) || issued.S3AccessKeyID != "" {
+		t.Errorf("unexpected issued token: %+v", issued)
+	}
+	if issued.TokenHash == issued.Token {
+		t.Error("token should be stored hashed")
+	}
+
+	userInfo, err := cmrt.AuthenticateToken(issued.Token)
+	if err != nil || userInfo.UserName != "token-user" || userInfo.Role != cmrt.RoleOperator {
+		t.Errorf("token is not authenticated as the owner: %+v, %v", userInfo, err)
+	}
+	if _, err := cmrt.AuthenticateToken(issued.Token + "0"); err == nil {
+		t.Error("wrong token should be rejected")
+	}
+
+	tokenList, err := cmrt.ListToken("token-user")
+	if err != nil || len(tokenList) != 1 || tokenList[0].TokenID != issued.TokenID {
+		t.Errorf("issued token is not listed: %v", err)
+	}
+
+	if _, err := cmrt.RevokeToken(issued.TokenID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cmrt.AuthenticateToken(issued.Token); err == nil {
+		t.Error("revoked token should be rejected")
+	}
+}
+
+func TestTokenScopeAndS3Key(t *testing.T) {
+	os.Setenv("SPIDER_USERNAME", "admin")
+	os.Setenv("SPIDER_PASSWORD", "admin-password")
+
+	issued, err := cmrt.IssueToken("s3-client", "admin", cmrt.RoleReadOnly, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.RevokeToken(issued.TokenID)
+
+	// the built-in admin is limited to read-only by the token scope
+	userInfo, err := cmrt.AuthenticateToken(issued.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmrt.CheckPermission(userInfo, "GET", "/vm", "aws-config"); err != nil {
+		t.Error(err)
+	}
+	if err := cmrt.CheckPermission(userInfo, "POST", "/vm", "aws-config"); err == nil {
+		t.Error("read-only token should not create a VM")
+	}
+
+	if issued.S3AccessKeyID == "" || issued.S3SecretKeyText == "" {
+		t.Fatalf("S3 key pair is not issued: %+v", issued)
+	}
+	if issued.S3SecretKey == issued.S3SecretKeyText {
+		t.Error("S3 secret key should be stored encrypted")
+	}
+	secretKey, s3User, err := cmrt.GetS3KeyPair(issued.S3AccessKeyID)
+	if err != nil || secretKey != issued.S3SecretKeyText || s3User.Role != cmrt.RoleReadOnly {
+		t.Errorf("S3 key pair mismatch: %v", err)
+	}
+
+	cmrt.RevokeToken(issued.TokenID)
+	if _, _, err := cmrt.GetS3KeyPair(issued.S3AccessKeyID); err == nil {
+		t.Error("S3 key of revoked token should be rejected")
+	}
+}
@@ -0,0 +1,133 @@
+// User Manager Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"os"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+func TestUserAuthenticate(t *testing.T) {
+	os.Setenv("SPIDER_USERNAME", "admin")
+	os.Setenv("SPIDER_PASSWORD", "admin-password")
+
+	if _, err := cmrt.CreateUser("admin", "pw", cmrt.RoleReadOnly, nil); err == nil {
+		t.Error("the built-in admin name should be rejected")
+	}
+	if _, err := cmrt.CreateUser("bad-role", "pw", "root", nil); err == nil {
+		t.Error("unknown role should be rejected")
+	}
+	if _, err := cmrt.CreateUser("bad-grant", "pw", "", cmrt.GrantList{{Role: cmrt.RoleOperator}}); err == nil {
+		t.Error("grant without ConnectionName and ProviderName should be rejected")
+	}
+	if _, err := cmrt.CreateUser("bad-grant", "pw", "", cmrt.GrantList{{ConnectionName: "aws-seoul-config", Role: cmrt.RoleAdmin}}); err == nil {
+		t.Error("grant with admin role should be rejected")
+	}
+
+	userInfo, err := cmrt.CreateUser("auth-user", "auth-password", cmrt.RoleReadOnly, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.DeleteUser("auth-user")
+	if userInfo.PasswordHash == "auth-password" {
+		t.Error("password should be stored hashed")
+	}
+	if _, err := cmrt.CreateUser("auth-user", "pw", cmrt.RoleReadOnly, nil); err == nil {
+		t.Error("duplicated user should be rejected")
+	}
+
+	if _, err := cmrt.AuthenticateUser("auth-user", "auth-password"); err != nil {
+		t.Error(err)
+	}
+	if _, err := cmrt.AuthenticateUser("auth-user", "wrong"); err == nil {
+		t.Error("wrong password should be rejected")
+	}
+	if _, err := cmrt.AuthenticateUser("no-user", "auth-password"); err == nil {
+		t.Error("unknown user should be rejected")
+	}
+	adminInfo, err := cmrt.AuthenticateUser("admin", "admin-password")
+	if err != nil || adminInfo.Role != cmrt.RoleAdmin {
+		t.Errorf("built-in admin should be authenticated as admin: %v", err)
+	}
+
+	// password change
+	if _, err := cmrt.UpdateUser("auth-user", "new-password", cmrt.RoleOperator, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cmrt.AuthenticateUser("auth-user", "auth-password"); err == nil {
+		t.Error("old password should be rejected")
+	}
+	if userInfo, err := cmrt.AuthenticateUser("auth-user", "new-password"); err != nil || userInfo.Role != cmrt.RoleOperator {
+		t.Errorf("updated user is not authenticated with new role: %v", err)
+	}
+}
+
+func TestUserPermission(t *testing.T) {
+	userInfo := &cmrt.UserInfo{
+		UserName: "perm-user",
+		Role:     "",
+		GrantList: cmrt.GrantList{
+			{ConnectionName: "aws-seoul-config", Role: cmrt.RoleReadOnly},
+			{ConnectionName: "aws-tokyo-config", Role: cmrt.RoleOperator},
+		},
+	}
+
+	testCases := []struct {
+		method         string
+		path           string
+		connectionName string
+		allowed        bool
+	}{
+		{"GET", "/vm", "aws-seoul-config", true},
+		{"POST", "/priceinfo/:ProductFamily/:RegionName", "aws-seoul-config", true},
+		{"POST", "/vm", "aws-seoul-config", false},
+		{"DELETE", "/destroy", "aws-seoul-config", false},
+		{"POST", "/vm", "aws-tokyo-config", true},
+		{"DELETE", "/destroy", "aws-tokyo-config", false},
+		{"DELETE", "/credential/:CredentialName", "aws-tokyo-config", false},
+		{"GET", "/user", "aws-tokyo-config", false},
+		{"PUT", "/mockfault/:MockName", "aws-tokyo-config", false},
+		{"GET", "/mockfault/:MockName", "aws-tokyo-config", true},
+		{"GET", "/vm", "gcp-config", false}, // no grant, no default role
+		{"GET", "/connectionconfig", "", false},
+	}
+	for _, tc := range testCases {
+		err := cmrt.CheckPermission(userInfo, tc.method, tc.path, tc.connectionName)
+		if (err == nil) != tc.allowed {
+			t.Errorf("%s %s on '%s': allowed should be %v, err: %v", tc.method, tc.path, tc.connectionName, tc.allowed, err)
+		}
+	}
+
+	// default role is applied without a matching grant
+	userInfo.Role = cmrt.RoleReadOnly
+	if err := cmrt.CheckPermission(userInfo, "GET", "/connectionconfig", ""); err != nil {
+		t.Error(err)
+	}
+	userInfo.Role = cmrt.RoleAdmin
+	if err := cmrt.CheckPermission(userInfo, "DELETE", "/destroy", "aws-seoul-config"); err != nil {
+		t.Error(err)
+	}
+
+	// admin-only APIs are checked with the user's Role only, even with an admin grant
+	grantAdmin := &cmrt.UserInfo{
+		UserName:  "grant-admin-user",
+		Role:      cmrt.RoleOperator,
+		GrantList: cmrt.GrantList{{ConnectionName: "aws-seoul-config", Role: cmrt.RoleAdmin}},
+	}
+	for _, path := range []string{"/user", "/token", "/metadb", "/encryptionkey", "/audit"} {
+		if err := cmrt.CheckPermission(grantAdmin, "GET", path, "aws-seoul-config"); err == nil {
+			t.Errorf("GET %s should be denied by an admin grant", path)
+		}
+	}
+	if err := cmrt.CheckPermission(grantAdmin, "POST", "/credential", "aws-seoul-config"); err == nil {
+		t.Error("POST /credential should be denied by an admin grant")
+	}
+}
@@ -0,0 +1,50 @@
+// VM PurchaseOption Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+func TestVMPurchaseOption(t *testing.T) {
+	option := cres.VMPurchaseOption{}
+	if err := cmrt.ValidateVMPurchaseOption(&option); err != nil || option.LifecycleType != cres.OnDemandVM {
+		t.Errorf("empty option should be OnDemand: %v, %v", option, err)
+	}
+
+	option = cres.VMPurchaseOption{LifecycleType: "spot", MaxPrice: "0.05"}
+	if err := cmrt.ValidateVMPurchaseOption(&option); err != nil {
+		t.Fatal(err)
+	}
+	if option.LifecycleType != cres.SpotVM || option.InterruptionBehavior != cres.SpotTerminate {
+		t.Errorf("spot option should be normalized with Terminate: %v", option)
+	}
+
+	option = cres.VMPurchaseOption{LifecycleType: "Spot", InterruptionBehavior: "STOP"}
+	if err := cmrt.ValidateVMPurchaseOption(&option); err != nil || option.InterruptionBehavior != cres.SpotStop {
+		t.Errorf("STOP should be normalized to Stop: %v, %v", option, err)
+	}
+
+	for _, invalid := range []cres.VMPurchaseOption{
+		{LifecycleType: "Reserved"},
+		{LifecycleType: "OnDemand", MaxPrice: "0.05"},
+		{InterruptionBehavior: "Stop"},
+		{LifecycleType: "Spot", MaxPrice: "cheap"},
+		{LifecycleType: "Spot", MaxPrice: "0"},
+		{LifecycleType: "Spot", InterruptionBehavior: "Hibernate"},
+	} {
+		option := invalid
+		if err := cmrt.ValidateVMPurchaseOption(&option); err == nil {
+			t.Errorf("invalid option should be rejected: %v", invalid)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// VM UserData Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+func TestVMUserData(t *testing.T) {
+	script := "#!/bin/bash\necho hello > /tmp/hello.txt\n"
+
+	if decoded, err := cmrt.DecodeVMUserData(script, ""); err != nil || decoded != script {
+		t.Errorf("plain text should be kept: %s, %v", decoded, err)
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(script))
+	if decoded, err := cmrt.DecodeVMUserData(encoded, "BASE64"); err != nil || decoded != script {
+		t.Errorf("base64 should be decoded: %s, %v", decoded, err)
+	}
+	// a plain text which is valid base64 is not decoded
+	if decoded, err := cmrt.DecodeVMUserData("abcd", "plain"); err != nil || decoded != "abcd" {
+		t.Errorf("plain text like base64 should be kept: %s, %v", decoded, err)
+	}
+	if _, err := cmrt.DecodeVMUserData(script, "base64"); err == nil {
+		t.Error("invalid base64 should be rejected")
+	}
+	if _, err := cmrt.DecodeVMUserData(script, "gzip"); err == nil {
+		t.Error("unknown encoding should be rejected")
+	}
+
+	// AWS limits the plain text, Tencent limits the base64 encoded text
+	userData := strings.Repeat("a", 16*1024)
+	if err := cmrt.ValidateVMUserData("AWS", userData); err != nil {
+		t.Errorf("16KB should be allowed for AWS: %v", err)
+	}
+	if err := cmrt.ValidateVMUserData("AWS", userData+"a"); err == nil {
+		t.Error("larger than 16KB should be rejected for AWS")
+	}
+	if err := cmrt.ValidateVMUserData("TENCENT", userData); err == nil || !strings.Contains(err.Error(), "base64") {
+		t.Errorf("16KB should be rejected for Tencent after base64 encoding: %v", err)
+	}
+	if err := cmrt.ValidateVMUserData("MOCK", userData+userData); err != nil {
+		t.Errorf("no limit should be checked for Mock: %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	aw "github.com/cloud-barista/cb-spider/api-runtime/rest-runtime/admin-web"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ Audit Log
+
+// AuditListResponse represents the response body for listing audit records.
+type AuditListResponse struct {
+	Result []*cmrt.AuditInfo `json:"audit" validate:"required" description:"A list of audit records, newest first"`
+}
+
+// listAudit godoc
+// @ID list-audit
+// @Summary List Audit Records
+// @Description Retrieve the audit records of mutating API calls(POST/PUT/DELETE), newest first. 🕷️ Secrets in the request bodies are redacted. Calls rejected by the authentication or the permission check are recorded with the attempted user name, or 'anonymous'. <br> The time filters are in RFC3339 format, ex) 2026-10-01T00:00:00Z.
+// @Tags [Audit Log]
+// @Accept  json
+// @Produce  json
+// @Param StartTime query string false "Start of the time range (RFC3339)"
+// @Param EndTime query string false "End of the time range (RFC3339)"
+// @Param UserName query string false "The name of the User who called the API"
+// @Param ConnectionName query string false "The name of the Connection"
+// @Param ResourceType query string false "The type of the resource, ex) vm, vpc, credential"
+// @Param NameId query string false "The NameId of the resource"
+// @Param Limit query int false "Max number of records, default: 100"
+// @Success 200 {object} AuditListResponse "List of Audit Records"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameters"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /audit [get]
+func ListAudit(c echo.Context) error {
+	cblog.Info("call ListAudit()")
+
+	filter := cmrt.AuditFilter{
+		UserName:       c.QueryParam("UserName"),
+		ConnectionName: c.QueryParam("ConnectionName"),
+		ResourceType:   c.QueryParam("ResourceType"),
+		NameId:         c.QueryParam("NameId"),
+	}
+	var err error
+	if startTime := c.QueryParam("StartTime"); startTime != "" {
+		if filter.StartTime, err = time.Parse(time.RFC3339, startTime); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid StartTime: "+err.Error())
+		}
+	}
+	if endTime := c.QueryParam("EndTime"); endTime != "" {
+		if filter.EndTime, err = time.Parse(time.RFC3339, endTime); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid EndTime: "+err.Error())
+		}
+	}
+	if limit := c.QueryParam("Limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid Limit: "+err.Error())
+		}
+	}
+
+	result, err := cmrt.ListAudit(filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := AuditListResponse{Result: result}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// maxAuditBodySize is the max size of a request or response body kept for the audit record.
+const maxAuditBodySize = 64 << 10
+
+// auditBodyWriter keeps the head of the response body.
+type auditBodyWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditBodyWriter) Write(b []byte) (int, error) {
+	if remain := maxAuditBodySize - w.body.Len(); remain > 0 {
+		if len(b) < remain {
+			remain = len(b)
+		}
+		w.body.Write(b[:remain])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditBodyWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// auditSkipPrefixes are the paths which are not audited.
+var auditSkipPrefixes = []string{
+	"/spider/adminweb", // AdminWeb pages call the REST APIs, which are audited
+	"/spider/api",
+}
+
+// auditAnonymousUser is the UserName of a rejected call without any user name.
+const auditAnonymousUser = "anonymous"
+
+// AuditMiddleware records the mutating API calls(POST/PUT/DELETE) into the audit log.
+// It should be used before the authentication middleware, so that the calls rejected
+// by the authentication or the permission check are also recorded.
+func AuditMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		routePath := strings.TrimPrefix(c.Path(), "/spider")
+		if cmrt.IsReadRequest(req.Method, routePath) {
+			return next(c)
+		}
+		for _, prefix := range auditSkipPrefixes {
+			if strings.HasPrefix(req.URL.Path, prefix) {
+				return next(c)
+			}
+		}
+
+		start := time.Now()
+		reqBody := peekAuditBody(req)
+		writer := &auditBodyWriter{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = writer
+
+		err := next(c)
+		if err != nil {
+			c.Error(err) // write the error response to get the status code
+		}
+
+		auditInfo := &cmrt.AuditInfo{
+			Time:         start,
+			ClientIP:     c.RealIP(),
+			Method:       req.Method,
+			Path:         req.URL.Path,
+			ResourceType: cmrt.GetRouteResource(routePath),
+			StatusCode:   c.Response().Status,
+			DurationMs:   time.Since(start).Milliseconds(),
+		}
+		auditInfo.UserName = getAuditUserName(c)
+		auditInfo.RequestBody = cmrt.RedactSecrets(auditInfo.ResourceType, reqBody)
+		fillAuditResource(c, auditInfo, reqBody, writer.body.Bytes())
+
+		if auditInfo.StatusCode < 400 {
+			auditInfo.Result = cmrt.AuditSucceeded
+		} else {
+			auditInfo.Result = cmrt.AuditFailed
+			auditInfo.Error = getAuditErrorMessage(err, auditInfo.StatusCode, writer.body.Bytes())
+		}
+
+		if insertErr := cmrt.InsertAudit(auditInfo); insertErr != nil {
+			cblog.Errorf("failed to record the audit of %s %s: %v", req.Method, req.URL.Path, insertErr)
+		}
+		return nil
+	}
+}
+
+// getAuditUserName returns the caller of the request.
+// If the authentication failed, it returns the user name the caller attempted, or "anonymous".
+func getAuditUserName(c echo.Context) string {
+	if userInfo := getAuthUser(c); userInfo != nil {
+		return userInfo.UserName
+	}
+	if userName, ok := aw.GetSessionUser(c); ok {
+		return userName
+	}
+
+	userName := ""
+	auth := c.Request().Header.Get(echo.HeaderAuthorization)
+	switch {
+	case strings.HasPrefix(auth, "Basic "):
+		if decoded, err := base64.StdEncoding.DecodeString(auth[len("Basic "):]); err == nil {
+			userName, _, _ = strings.Cut(string(decoded), ":")
+		}
+	case strings.HasPrefix(auth, "Bearer "):
+		userName = cmrt.GetTokenUserName(strings.TrimSpace(auth[len("Bearer "):]))
+	case strings.HasPrefix(auth, "AWS4-HMAC-SHA256"):
+		if info, err := parseAWS4AuthInfo(auth); err == nil {
+			userName, _ = splitAccessKey(info.AccessKey)
+		}
+	}
+	if userName == "" {
+		return auditAnonymousUser
+	}
+	return userName
+}
+
+// peekAuditBody returns the head of the JSON request body and restores the body for the handler.
+func peekAuditBody(req *http.Request) []byte {
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+	head, err := io.ReadAll(io.LimitReader(req.Body, maxAuditBodySize+1))
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), req.Body), req.Body}
+	if err != nil || len(head) > maxAuditBodySize {
+		return nil
+	}
+	return head
+}
+
+// auditResourceInfo is the part of a request or response body for the audit record.
+type auditResourceInfo struct {
+	ConnectionName string `json:"ConnectionName"`
+	ReqInfo        struct {
+		Name  string `json:"Name"`
+		CSPId string `json:"CSPId"`
+	} `json:"ReqInfo"`
+	IId struct {
+		NameId   string `json:"NameId"`
+		SystemId string `json:"SystemId"`
+	} `json:"IId"`
+	Name           string `json:"Name"`
+	CredentialName string `json:"CredentialName"`
+	DriverName     string `json:"DriverName"`
+	RegionName     string `json:"RegionName"`
+	ConfigName     string `json:"ConfigName"`
+	UserName       string `json:"UserName"`
+}
+
+// fillAuditResource sets the ConnectionName, NameId and SystemId from the path, the query,
+// the request body and the response body, in that order.
+func fillAuditResource(c echo.Context, auditInfo *cmrt.AuditInfo, reqBody []byte, respBody []byte) {
+	var reqInfo, respInfo auditResourceInfo
+	json.Unmarshal(reqBody, &reqInfo)
+	json.Unmarshal(respBody, &respInfo)
+
+	firstOf := func(values ...string) string {
+		for _, v := range values {
+			if v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	auditInfo.ConnectionName = firstOf(c.Param("ConnectionName"), c.QueryParam("ConnectionName"), reqInfo.ConnectionName)
+	if auditInfo.ConnectionName == "" && strings.HasPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "AWS4-HMAC-SHA256") {
+		if info, err := parseAWS4AuthInfo(c.Request().Header.Get(echo.HeaderAuthorization)); err == nil {
+			_, auditInfo.ConnectionName = splitAccessKey(info.AccessKey)
+		}
+	}
+
+	pathName := ""
+	for _, paramName := range c.ParamNames() {
+		if paramName != "ConnectionName" && paramName != "Id" {
+			pathName = c.Param(paramName)
+			break
+		}
+	}
+	auditInfo.NameId = firstOf(pathName, reqInfo.ReqInfo.Name, reqInfo.Name, reqInfo.CredentialName,
+		reqInfo.DriverName, reqInfo.RegionName, reqInfo.ConfigName, reqInfo.UserName, respInfo.IId.NameId)
+	auditInfo.SystemId = firstOf(c.Param("Id"), reqInfo.ReqInfo.CSPId, respInfo.IId.SystemId)
+}
+
+// getAuditErrorMessage returns the error message of the failed call.
+func getAuditErrorMessage(err error, statusCode int, respBody []byte) string {
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		if msg, ok := httpErr.Message.(string); ok {
+			return msg
+		}
+	}
+	var msg SimpleMsg
+	if json.Unmarshal(respBody, &msg) == nil && msg.Message != "" {
+		return msg.Message
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return http.StatusText(statusCode)
+}
@@ -13,7 +13,6 @@ import (
 	"crypto/subtle"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -347,6 +346,7 @@ func getRoutes() []route {
 		{"DELETE", "/regkeypair/:Name", UnregisterKey},
 
 		{"POST", "/keypair", CreateKey},
+		{"POST", "/keypair/import", ImportKey},
 		{"GET", "/keypair", ListKey},
 		{"GET", "/keypair/:Name", GetKey},
 		{"DELETE", "/keypair/:Name", DeleteKey},
@@ -417,6 +417,7 @@ func getRoutes() []route {
 		{"GET", "/controlvm/:Name", ControlVM}, // suspend, resume, reboot
 		// only for AdminWeb
 		{"PUT", "/controlvm/:Name", ControlVM}, // suspend, resume, reboot
+		{"PUT", "/vm/:Name/spec", ChangeVMSpec},
 
 		//-- for management
 		{"GET", "/allvm", ListAllVM},
@@ -482,6 +483,21 @@ func getRoutes() []route {
 		{"GET", "/countdisk", CountAllDisks},
 		{"GET", "/countdisk/:ConnectionName", CountDisksByConnection},
 
+		//----------DiskSnapshot Handler
+		{"POST", "/regdisksnapshot", RegisterDiskSnapshot},
+		{"DELETE", "/regdisksnapshot/:Name", UnregisterDiskSnapshot},
+
+		{"POST", "/disksnapshot", CreateDiskSnapshot},
+		{"GET", "/disksnapshot", ListDiskSnapshot},
+		{"GET", "/disksnapshot/:Name", GetDiskSnapshot},
+		{"DELETE", "/disksnapshot/:Name", DeleteDiskSnapshot},
+		//-- restore to a new Disk
+		{"POST", "/disksnapshot/:Name/disk", CreateDiskFromSnapshot},
+
+		//-- for dashboard
+		{"GET", "/countdisksnapshot", CountAllDiskSnapshots},
+		{"GET", "/countdisksnapshot/:ConnectionName", CountDiskSnapshotsByConnection},
+
 		//----------MyImage Handler
 		{"POST", "/regmyimage", RegisterMyImage},
 		{"DELETE", "/regmyimage/:Name", UnregisterMyImage},
@@ -544,6 +560,13 @@ func getRoutes() []route {
 		{"POST", "/filesystem/:Name/accesssubnet", AddAccessSubnet},
 		{"GET", "/filesystem/:Name/accesssubnet", ListAccessSubnet},
 		{"DELETE", "/filesystem/:Name/accesssubnet", RemoveAccessSubnet},
+		// -- for Backup
+		{"POST", "/filesystem/:Name/backup/schedule", ScheduleFileSystemBackup},
+		{"POST", "/filesystem/:Name/backup", OnDemandFileSystemBackup},
+		{"GET", "/filesystem/:Name/backup", ListFileSystemBackup},
+		{"GET", "/filesystem/:Name/backup/:BackupID", GetFileSystemBackup},
+		{"DELETE", "/filesystem/:Name/backup/:BackupID", DeleteFileSystemBackup},
+		{"POST", "/filesystem/:Name/backup/:BackupID/restore", RestoreFileSystemBackup},
 
 		//----------Monitoring Handler
 		{"GET", "/monitoring/vm/:VMName/:MetricType", GetVMMetricData},
@@ -552,6 +575,17 @@ func getRoutes() []route {
 		//----------Destory All Resources in a Connection
 		{"DELETE", "/destroy", Destroy},
 
+		//----------Dependency Graph of the Resources in a Connection
+		{"GET", "/dependencygraph", GetDependencyGraph},
+
+		//----------Stack Handler
+		{"POST", "/stack/plan", PlanStack},
+		{"POST", "/stack/apply", ApplyStack},
+		{"GET", "/stack", ListStack},
+		{"GET", "/stack/:Name", GetStack},
+		{"GET", "/stack/:Name/drift", GetStackDrift},
+		{"DELETE", "/stack/:Name", DestroyStack},
+
 		//----------RDBMS Handler
 		{"GET", "/getrdbmsowner", GetRDBMSOwnerVPC},
 		{"POST", "/getrdbmsowner", GetRDBMSOwnerVPC},
@@ -562,6 +596,8 @@ func getRoutes() []route {
 		{"GET", "/rdbms", ListRDBMS},
 		{"GET", "/rdbms/:Name", GetRDBMS},
 		{"DELETE", "/rdbms/:Name", DeleteRDBMS},
+		{"PUT", "/rdbms/:Name/spec", ChangeRDBMSSpec},
+		{"PUT", "/rdbms/:Name/storage", ChangeRDBMSStorageSize},
 
 		//-- RDBMS database management (CSP-native API; drivers that support RDBMSDatabaseManager)
 		{"POST", "/rdbms/:Name/databases", CreateRDBMSDatabase},
@@ -611,6 +647,38 @@ func getRoutes() []route {
 		//-------------------------------------------------------------------//
 		//----------SPLock Info
 		{"GET", "/splockinfo", GetAllSPLockInfo},
+		//----------Mock Driver Fault Injection
+		{"GET", "/mockfault/:MockName", GetMockFault},
+		{"PUT", "/mockfault/:MockName", SetMockFault},
+		{"DELETE", "/mockfault/:MockName", ClearMockFault},
+		//----------User Management (admin only)
+		{"POST", "/user", CreateUser},
+		{"GET", "/user", ListUser},
+		{"GET", "/user/:UserName", GetUser},
+		{"PUT", "/user/:UserName", UpdateUser},
+		{"DELETE", "/user/:UserName", DeleteUser},
+		//----------API Token (admin only)
+		{"POST", "/token", IssueToken},
+		{"GET", "/token", ListToken},
+		{"DELETE", "/token/:TokenID", RevokeToken},
+		//----------Audit Log (admin only)
+		{"GET", "/audit", ListAudit},
+		//----------Encryption Key (admin only)
+		{"GET", "/encryptionkey", GetEncryptionKey},
+		{"POST", "/encryptionkey/rotate", RotateEncryptionKey},
+		//----------MetaDB (admin only)
+		{"GET", "/metadb/maintenance", GetMaintenance},
+		{"GET", "/metadb/backup", ListMetaDBBackup},
+		{"POST", "/metadb/backup", CreateMetaDBBackup},
+		{"GET", "/metadb/backup/:FileName/validate", ValidateMetaDBBackup},
+		{"POST", "/metadb/backup/:FileName/restore", RestoreMetaDBBackup},
+		{"GET", "/metadb/remotebackup", ListMetaDBRemoteBackup},
+		{"POST", "/metadb/remotebackup/:FileName/restore", RestoreMetaDBRemoteBackup},
+		{"POST", "/metadb/migrate", MigrateMetaDB},
+		//----------Asynchronous Job
+		{"GET", "/job", ListJob},
+		{"GET", "/job/:JobID", GetJob},
+		{"DELETE", "/job/:JobID", CancelJob},
 		//----------SSH RUN
 		{"POST", "/sshrun", SSHRun},
 
@@ -897,6 +965,9 @@ func ApiServer(routes []route) {
 		log.Fatal("[AUTH ERROR] SPIDER_USERNAME and SPIDER_PASSWORD must both be set in setup.env. Server cannot start without authentication.")
 	}
 
+	// Audit log of mutating API calls, before the auth middleware to record the rejected calls, too
+	e.Use(AuditMiddleware)
+
 	cblog.Info("**** Rest Auth Enabled ****")
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -913,19 +984,20 @@ func ApiServer(routes []route) {
 			}
 			// Check Basic Auth header
 			auth := c.Request().Header.Get(echo.HeaderAuthorization)
-			if auth == "" {
+			if auth == "" && !aw.HasSessionCookie(c) {
 				return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Unauthorized"})
 			}
 			// S3 API paths use AWS4-HMAC-SHA256 authentication (S3-compatible)
 			if strings.HasPrefix(reqPath, "/spider/s3") && strings.HasPrefix(auth, "AWS4-HMAC-SHA256") {
-				// JSON format requests: no signature verification (CB-Spider internal clients)
+				// JSON format requests are authenticated like the other JSON calls, with Basic or Bearer
 				accept := c.Request().Header.Get("Accept")
 				// application/xml in Accept takes priority over application/json
 				isJSON := !strings.Contains(accept, "application/xml") &&
 					(strings.Contains(accept, "application/json") ||
 						strings.ToLower(c.QueryParam("format")) == "json")
 				if isJSON {
-					return next(c)
+					cblog.Warnf("S3 AWS4 auth of a JSON format request rejected [%s %s]", c.Request().Method, reqPath)
+					return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Unauthorized"})
 				}
 				// XML (S3-compatible) format: require full AWS4 signature
 				s3authForbidden := func(code, msg string) error {
@@ -942,45 +1014,62 @@ func ApiServer(routes []route) {
 					cblog.Warnf("S3 AWS4 auth parse failed [%s %s]: %v", c.Request().Method, reqPath, err)
 					return s3authForbidden("InvalidAccessKeyId", "Unable to parse Authorization header.")
 				}
-				username, _ := splitAccessKey(info.AccessKey)
-				// Always verify username: access key must be in "username@connectionName" format
+				username, connName := splitAccessKey(info.AccessKey)
+				// Always verify username: access key must be in "username@connectionName" format.
+				// The username is SPIDER_USERNAME, or the S3 access key ID of an API token.
+				secretKey := SPIDER_PASSWORD
+				var tokenUser *cr.UserInfo
 				if subtle.ConstantTimeCompare([]byte(username), []byte(SPIDER_USERNAME)) != 1 {
-					cblog.Warnf("S3 AWS4 username mismatch [%s %s]: got %q", c.Request().Method, reqPath, username)
-					return s3authForbidden("InvalidAccessKeyId", "The Access Key Id you provided does not exist.")
+					secretKey, tokenUser, err = cr.GetS3KeyPair(username)
+					if err != nil {
+						cblog.Warnf("S3 AWS4 access key rejected [%s %s]: got %q: %v", c.Request().Method, reqPath, username, err)
+						return s3authForbidden("InvalidAccessKeyId", "The Access Key Id you provided does not exist.")
+					}
 				}
-				// Verify AWS4 signature using SPIDER_PASSWORD as the secret key
-				if err := verifyAWS4Signature(c.Request(), auth, SPIDER_PASSWORD); err != nil {
+				// Verify AWS4 signature using SPIDER_PASSWORD or the token's S3 secret key as the secret key
+				if err := verifyAWS4Signature(c.Request(), auth, secretKey); err != nil {
 					cblog.Warnf("S3 AWS4 signature mismatch [%s %s]: %v", c.Request().Method, reqPath, err)
 					return s3authForbidden("SignatureDoesNotMatch",
 						"The request signature we calculated does not match the signature you provided. "+
 							"Check your Secret Access Key and signing method.")
 				}
+				if tokenUser != nil {
+					if err := authorizeConnectionRequest(c, tokenUser, connName); err != nil {
+						return s3authForbidden("AccessDenied", "Access Denied")
+					}
+				} else {
+					c.Set(authUserKey, cr.GetBuiltinAdminUser())
+				}
 				return next(c)
 			}
-			const prefix = "Basic "
-			if !strings.HasPrefix(auth, prefix) {
-				return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Unauthorized"})
-			}
-			decoded, err := base64.StdEncoding.DecodeString(auth[len(prefix):])
-			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Unauthorized"})
+			// Bearer API token, or Basic Auth of the built-in admin or a user in the user store (or AdminWeb session)
+			var userInfo *cr.UserInfo
+			var ok bool
+			if strings.HasPrefix(auth, "Bearer ") {
+				userInfo, ok = authenticateBearer(auth)
+			} else {
+				userInfo, ok = authenticateBasic(c, auth)
 			}
-			parts := strings.SplitN(string(decoded), ":", 2)
-			if len(parts) != 2 {
+			if !ok {
 				return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Unauthorized"})
 			}
-			// Be careful to use constant time comparison to prevent timing attacks
-			if subtle.ConstantTimeCompare([]byte(parts[0]), []byte(SPIDER_USERNAME)) == 1 &&
-				subtle.ConstantTimeCompare([]byte(parts[1]), []byte(SPIDER_PASSWORD)) == 1 {
-				return next(c)
+			// Role-based access control by the user's role and grants
+			if err := authorizeRequest(c, userInfo); err != nil {
+				if httpErr, ok := err.(*echo.HTTPError); ok {
+					return c.JSON(httpErr.Code, map[string]string{"message": fmt.Sprint(httpErr.Message)})
+				}
+				return c.JSON(http.StatusForbidden, map[string]string{"message": err.Error()})
 			}
-			return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Unauthorized"})
+			return next(c)
 		}
 	})
 
 	// AdminWeb session-based auth middleware (protects adminweb pages after BasicAuth skip)
 	e.Use(aw.AdminWebSessionMiddleware)
 
+	// Maintenance mode during the MetaDB restore and migration
+	e.Use(MaintenanceMiddleware)
+
 	for _, route := range routes {
 		spiderPath := "/spider" + route.path
 		switch route.method {
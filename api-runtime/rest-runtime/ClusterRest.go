@@ -178,7 +178,9 @@ type ClusterNodeGroupRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param ClusterCreateRequest body restruntime.ClusterCreateRequest true "Request body for creating a Cluster"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
 // @Success 200 {object} cres.ClusterInfo "Details of the created Cluster"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
 // @Failure 500 {object} SimpleMsg "Internal Server Error"
@@ -206,6 +208,11 @@ func CreateCluster(c echo.Context) error {
 	}
 
 	// Call common-runtime API
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "CreateCluster", req.ConnectionName, CLUSTER, req.ReqInfo.Name, func() (interface{}, error) {
+			return cmrt.CreateCluster(req.ConnectionName, CLUSTER, reqInfo, req.IDTransformMode)
+		})
+	}
 	result, err := cmrt.CreateCluster(req.ConnectionName, CLUSTER, reqInfo, req.IDTransformMode)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -556,7 +563,9 @@ func ChangeNodeGroupScaling(c echo.Context) error {
 // @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a Cluster"
 // @Param Name path string true "The name of the Cluster to delete"
 // @Param force query string false "Force delete the Cluster. ex) true or false(default: false)"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
 // @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
 // @Failure 500 {object} SimpleMsg "Internal Server Error"
@@ -573,6 +582,13 @@ func DeleteCluster(c echo.Context) error {
 	clusterName := c.Param("Name")
 
 	// Call common-runtime API
+	if isAsyncRequest(c) {
+		force := c.QueryParam("force")
+		return submitAsyncJob(c, "DeleteCluster", req.ConnectionName, CLUSTER, clusterName, func() (interface{}, error) {
+			result, err := cmrt.DeleteCluster(req.ConnectionName, CLUSTER, clusterName, force)
+			return BooleanInfo{Result: strconv.FormatBool(result)}, err
+		})
+	}
 	result, err := cmrt.DeleteCluster(req.ConnectionName, CLUSTER, clusterName, c.QueryParam("force"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
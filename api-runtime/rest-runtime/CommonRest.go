@@ -11,6 +11,7 @@ package restruntime
 import (
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
@@ -41,6 +42,8 @@ const (
 	RDBMS     string = string(cres.RDBMS)
 	PUBLICIP  string = string(cres.PUBLICIP)
 	NIC       string = string(cres.NIC)
+
+	DISKSNAPSHOT string = string(cres.DISKSNAPSHOT)
 )
 
 //================ Common Request & Response
@@ -187,12 +190,16 @@ func GetCSPResourceInfo(c echo.Context) error {
 // Destroy godoc
 // @ID destroy-all-resource
 // @Summary Destroy all resources in a connection
-// @Description Deletes all resources associated with a specific cloud connection. This action is irreversible.
+// @Description Deletes all resources associated with a specific cloud connection. This action is irreversible. <br> The resources are deleted in the order of a deletion plan built from the dependency graph of the resources(/dependencygraph): a resource is deleted after all resources which use it, ex) VM before Disk, NIC and SecurityGroup. <br> The destroy is refused before any deletion if a selected resource is used by a resource which is not selected(BlockedList of the plan). <br> 🕷️ With dryrun=true, nothing is deleted and only the plan is returned in the Plan field. <br> NamePrefix, TagKey and TagValue select a subset of the resources. S3 buckets are not selected by the tag filter.
 // @Tags [Utility]
 // @Accept  json
 // @Produce  json
 // @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting all resources"
-// @Success 200 {object} cmrt.DestroyedInfo "Details of the destroyed resources"
+// @Param dryrun query bool false "true: return the deletion plan without deleting the resources"
+// @Param NamePrefix query string false "Select the resources whose NameId starts with the prefix"
+// @Param TagKey query string false "Select the resources which have a tag of the key"
+// @Param TagValue query string false "Select the resources which have the TagKey tag of the value, empty: any value"
+// @Success 200 {object} cmrt.DestroyedInfo "Details of the destroyed resources, or the plan of the dry run"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to missing parameters"
 // @Failure 500 {object} SimpleMsg "Internal Server Error"
 // @Router /destroy [delete]
@@ -205,8 +212,22 @@ func Destroy(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	filter := cmrt.DestroyFilter{
+		NamePrefix: c.QueryParam("NamePrefix"),
+		TagKey:     c.QueryParam("TagKey"),
+		TagValue:   c.QueryParam("TagValue"),
+	}
+
+	if strings.EqualFold(c.QueryParam("dryrun"), "true") {
+		plan, err := cmrt.GetDestroyPlan(req.ConnectionName, filter)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, &cmrt.DestroyedInfo{DestroyedList: []*cmrt.DeletedResourceInfoList{}, DryRun: true, Plan: plan})
+	}
+
 	// Call common-runtime API
-	result, err := cmrt.Destroy(req.ConnectionName)
+	result, err := cmrt.Destroy(req.ConnectionName, filter)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -214,6 +235,43 @@ func Destroy(c echo.Context) error {
 	return c.JSON(http.StatusOK, &result)
 }
 
+// getDependencyGraph godoc
+// @ID get-dependencygraph
+// @Summary Get Resource Dependency Graph
+// @Description Retrieve the nodes and the edges of all resources managed by Spider in a connection, ex) VPC contains Subnet, Subnet hosts VM, VM uses SG/KeyPair/Disk/NIC/PublicIP, NLB uses VM, Cluster contains NodeGroup, RDBMS/FileSystem uses Subnet. <br> For "contains" and "hosts", To depends on From. For "uses", From depends on To. <br> 🕷️ With format=dot, the graph is returned in the Graphviz DOT language, ex) curl ... | dot -Tsvg > graph.svg
+// @Tags [Utility]
+// @Accept  json
+// @Produce  json
+// @Produce  text/vnd.graphviz
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param format query string false "Response format: json(default) or dot"
+// @Success 200 {object} cmrt.DependencyGraphInfo "Dependency graph of the resources"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to missing parameters"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dependencygraph [get]
+func GetDependencyGraph(c echo.Context) error {
+	cblog.Info("call GetDependencyGraph()")
+
+	connectionName := c.QueryParam("ConnectionName")
+	if connectionName == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "ConnectionName query parameter is required")
+	}
+	format := strings.ToLower(c.QueryParam("format"))
+	if format != "" && format != "json" && format != "dot" {
+		return echo.NewHTTPError(http.StatusBadRequest, "format should be json or dot")
+	}
+
+	result, err := cmrt.GetDependencyGraph(connectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	if format == "dot" {
+		return c.Blob(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(result.ToDOT()))
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
 // CheckTCPPort godoc
 // @ID check-tcp-port
 // @Summary Check if a specific TCP port is open
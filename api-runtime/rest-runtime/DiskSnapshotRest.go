@@ -0,0 +1,373 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ DiskSnapshot Handler
+
+// DiskSnapshotRegisterRequest represents the request body for registering a Disk Snapshot.
+type DiskSnapshotRegisterRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		Name  string `json:"Name" validate:"required" example:"disksnapshot-01"`
+		CSPId string `json:"CSPId" validate:"required" example:"snap-0abc1234"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// registerDiskSnapshot godoc
+// @ID register-disksnapshot
+// @Summary Register Disk Snapshot
+// @Description Register a new Disk Snapshot with the specified name and CSP ID.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param DiskSnapshotRegisterRequest body restruntime.DiskSnapshotRegisterRequest true "Request body for registering a Disk Snapshot"
+// @Success 200 {object} cres.DiskSnapshotInfo "Details of the registered Disk Snapshot"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /regdisksnapshot [post]
+func RegisterDiskSnapshot(c echo.Context) error {
+	cblog.Info("call RegisterDiskSnapshot()")
+
+	req := DiskSnapshotRegisterRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// create UserIID
+	userIId := cres.IID{req.ReqInfo.Name, req.ReqInfo.CSPId}
+
+	// Call common-runtime API
+	result, err := cmrt.RegisterDiskSnapshot(req.ConnectionName, userIId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// unregisterDiskSnapshot godoc
+// @ID unregister-disksnapshot
+// @Summary Unregister Disk Snapshot
+// @Description Unregister a Disk Snapshot with the specified name.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for unregistering a Disk Snapshot"
+// @Param Name path string true "The name of the Disk Snapshot to unregister"
+// @Success 200 {object} BooleanInfo "Result of the unregister operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /regdisksnapshot/{Name} [delete]
+func UnregisterDiskSnapshot(c echo.Context) error {
+	cblog.Info("call UnregisterDiskSnapshot()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.UnregisterResource(req.ConnectionName, DISKSNAPSHOT, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// DiskSnapshotCreateRequest represents the request body for creating a Disk Snapshot.
+type DiskSnapshotCreateRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name       string          `json:"Name" validate:"required" example:"disksnapshot-01"`
+		SourceDisk string          `json:"SourceDisk" validate:"required" example:"disk-01"` // name of the Disk to snapshot
+		TagList    []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// createDiskSnapshot godoc
+// @ID create-disksnapshot
+// @Summary Create Disk Snapshot
+// @Description Create a new snapshot of a Disk.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param DiskSnapshotCreateRequest body restruntime.DiskSnapshotCreateRequest true "Request body for creating a Disk Snapshot"
+// @Success 200 {object} cres.DiskSnapshotInfo "Details of the created Disk Snapshot"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /disksnapshot [post]
+func CreateDiskSnapshot(c echo.Context) error {
+	cblog.Info("call CreateDiskSnapshot()")
+
+	req := DiskSnapshotCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	reqInfo := cres.DiskSnapshotInfo{
+		IId:        cres.IID{req.ReqInfo.Name, req.ReqInfo.Name},
+		SourceDisk: cres.IID{req.ReqInfo.SourceDisk, ""},
+		TagList:    req.ReqInfo.TagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateDiskSnapshot(req.ConnectionName, DISKSNAPSHOT, reqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DiskSnapshotListResponse represents the response body for listing Disk Snapshots.
+type DiskSnapshotListResponse struct {
+	Result []*cres.DiskSnapshotInfo `json:"disksnapshot" validate:"required" description:"A list of Disk Snapshot information"`
+}
+
+// listDiskSnapshot godoc
+// @ID list-disksnapshot
+// @Summary List Disk Snapshots
+// @Description Retrieve a list of Disk Snapshots associated with a specific connection.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to list Disk Snapshots for"
+// @Success 200 {object} DiskSnapshotListResponse "List of Disk Snapshots"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /disksnapshot [get]
+func ListDiskSnapshot(c echo.Context) error {
+	cblog.Info("call ListDiskSnapshot()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListDiskSnapshot(req.ConnectionName, DISKSNAPSHOT)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := DiskSnapshotListResponse{
+		Result: result,
+	}
+
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getDiskSnapshot godoc
+// @ID get-disksnapshot
+// @Summary Get Disk Snapshot
+// @Description Retrieve details of a specific Disk Snapshot.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a Disk Snapshot for"
+// @Param Name path string true "The name of the Disk Snapshot to retrieve"
+// @Success 200 {object} cres.DiskSnapshotInfo "Details of the Disk Snapshot"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /disksnapshot/{Name} [get]
+func GetDiskSnapshot(c echo.Context) error {
+	cblog.Info("call GetDiskSnapshot()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetDiskSnapshot(req.ConnectionName, DISKSNAPSHOT, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteDiskSnapshot godoc
+// @ID delete-disksnapshot
+// @Summary Delete Disk Snapshot
+// @Description Delete a specified Disk Snapshot.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a Disk Snapshot"
+// @Param Name path string true "The name of the Disk Snapshot to delete"
+// @Param force query string false "Force delete the Disk Snapshot. ex) true or false(default: false)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /disksnapshot/{Name} [delete]
+func DeleteDiskSnapshot(c echo.Context) error {
+	cblog.Info("call DeleteDiskSnapshot()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteDiskSnapshot(req.ConnectionName, DISKSNAPSHOT, c.Param("Name"), c.QueryParam("force"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// DiskFromSnapshotCreateRequest represents the request body for creating a Disk from a Disk Snapshot.
+type DiskFromSnapshotCreateRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name     string          `json:"Name" validate:"required" example:"disk-02"`
+		Zone     string          `json:"Zone,omitempty" validate:"omitempty" example:"us-east-1b"` // target zone for the disk, if not specified, it will be created in the same zone as the Connection.
+		DiskType string          `json:"DiskType,omitempty" validate:"omitempty" example:"gp2"`    // gp2 or default, if not specified, default is used
+		DiskSize string          `json:"DiskSize,omitempty" validate:"omitempty" example:"100"`    // 100 or default(size of the snapshot), can not be smaller than the snapshot (unit is GB)
+		TagList  []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// createDiskFromSnapshot godoc
+// @ID create-disk-from-snapshot
+// @Summary Create Disk from Disk Snapshot
+// @Description Create a new Disk with the data of a Disk Snapshot. The new Disk is managed as a Disk.
+// @Tags [DiskSnapshot Management]
+// @Accept  json
+// @Produce  json
+// @Param DiskFromSnapshotCreateRequest body restruntime.DiskFromSnapshotCreateRequest true "Request body for creating a Disk from a Disk Snapshot"
+// @Param Name path string true "The name of the Disk Snapshot"
+// @Success 200 {object} cres.DiskInfo "Details of the created Disk"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /disksnapshot/{Name}/disk [post]
+func CreateDiskFromSnapshot(c echo.Context) error {
+	cblog.Info("call CreateDiskFromSnapshot()")
+
+	req := DiskFromSnapshotCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	reqInfo := cres.DiskInfo{
+		IId:      cres.IID{req.ReqInfo.Name, req.ReqInfo.Name},
+		Zone:     req.ReqInfo.Zone,
+		DiskType: req.ReqInfo.DiskType,
+		DiskSize: req.ReqInfo.DiskSize,
+		TagList:  req.ReqInfo.TagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateDiskFromSnapshot(req.ConnectionName, c.Param("Name"), reqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// countAllDiskSnapshots godoc
+// @ID count-all-disksnapshots
+// @Summary Count All Disk Snapshots
+// @Description Get the total number of Disk Snapshots across all connections.
+// @Tags [DiskSnapshot Management]
+// @Produce  json
+// @Success 200 {object} CountResponse "Total count of Disk Snapshots"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countdisksnapshot [get]
+func CountAllDiskSnapshots(c echo.Context) error {
+	// Call common-runtime API
+	count, err := cmrt.CountAllDiskSnapshots()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON Result
+	jsonResult := CountResponse{
+		Count: int(count),
+	}
+
+	return c.JSON(http.StatusOK, jsonResult)
+}
+
+// countDiskSnapshotsByConnection godoc
+// @ID count-disksnapshots-by-connection
+// @Summary Count Disk Snapshots by Connection
+// @Description Get the total number of Disk Snapshots for a specific connection.
+// @Tags [DiskSnapshot Management]
+// @Produce  json
+// @Param ConnectionName path string true "The name of the Connection"
+// @Success 200 {object} CountResponse "Total count of Disk Snapshots for the connection"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countdisksnapshot/{ConnectionName} [get]
+func CountDiskSnapshotsByConnection(c echo.Context) error {
+	// Call common-runtime API
+	count, err := cmrt.CountDiskSnapshotsByConnection(c.Param("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON Result
+	jsonResult := CountResponse{
+		Count: int(count),
+	}
+
+	return c.JSON(http.StatusOK, jsonResult)
+}
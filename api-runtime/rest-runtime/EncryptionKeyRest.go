@@ -0,0 +1,74 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"net/http"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ Encryption Key Management (admin only)
+
+// KeyRotationRequest represents the request body for rotating the encryption key.
+type KeyRotationRequest struct {
+	NewVersion bool `json:"NewVersion" example:"true"` // create a new key version first, only for a rotatable provider
+}
+
+// getEncryptionKey godoc
+// @ID get-encryptionkey
+// @Summary Get Encryption Key Info
+// @Description Retrieve the provider and the versions of the key which encrypts the stored credentials and secrets. The keys themselves are not shown. <br> The provider is selected by SPIDER_KEY_PROVIDER, or by SPIDER_KEY(env) and SPIDER_KEY_FILE(file) which is set.
+// @Tags [Encryption Key Management]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} cim.EncryptionKeyInfo "Details of the Encryption Key"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /encryptionkey [get]
+func GetEncryptionKey(c echo.Context) error {
+	cblog.Info("call GetEncryptionKey()")
+
+	result, err := cmrt.GetEncryptionKeyInfo()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// rotateEncryptionKey godoc
+// @ID rotate-encryptionkey
+// @Summary Rotate Encryption Key
+// @Description Re-encrypt all stored credentials and secrets with the active key version while the server is running. 🕷️ With NewVersion, a file key provider creates a new key version and activates it first. <br> For the env key provider, set the new key in SPIDER_KEY and SPIDER_KEY_VERSION, move the old one to SPIDER_KEY_PREVIOUS, restart the server and call this API.
+// @Tags [Encryption Key Management]
+// @Accept  json
+// @Produce  json
+// @Param KeyRotationRequest body restruntime.KeyRotationRequest false "Request body for rotating the Encryption Key"
+// @Success 200 {object} cmrt.KeyRotationResult "Result of the rotation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /encryptionkey/rotate [post]
+func RotateEncryptionKey(c echo.Context) error {
+	cblog.Info("call RotateEncryptionKey()")
+
+	req := KeyRotationRequest{}
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	result, err := cmrt.RotateEncryptionKey(req.NewVersion)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
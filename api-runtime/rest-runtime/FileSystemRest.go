@@ -208,3 +208,183 @@ func ListAccessSubnet(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, result)
 }
+
+// -------- FileSystem Backup
+
+type FileSystemBackupScheduleRequest struct {
+	ConnectionName string            `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	Schedule       cres.CronSchedule `json:"Schedule"` // default: "0 5 * * *" (Every day at 5 AM)
+}
+
+// ScheduleFileSystemBackup godoc
+// @ID schedule-filesystem-backup
+// @Summary Schedule FileSystem Backup
+// @Description Set the cron schedule of the FileSystem's backups. Empty fields of the Schedule use the default "0 5 * * *" (Every day at 5 AM).
+// @Tags [FileSystem Management]
+// @Accept json
+// @Produce json
+// @Param Name path string true "FileSystem Name"
+// @Param FileSystemBackupScheduleRequest body restruntime.FileSystemBackupScheduleRequest true "Backup Schedule Info"
+// @Success 200 {object} cres.FileSystemBackupInfo
+// @Failure 400 {object} SimpleMsg "Bad Request"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /filesystem/{Name}/backup/schedule [post]
+func ScheduleFileSystemBackup(c echo.Context) error {
+	cblog.Info("call ScheduleFileSystemBackup()")
+
+	name := c.Param("Name")
+	var req FileSystemBackupScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	result, err := cmrt.ScheduleFileSystemBackup(req.ConnectionName, name, req.Schedule)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+type FileSystemBackupCreateRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	Name           string `json:"Name,omitempty" validate:"omitempty" example:"efs-01-backup-01"` // Name of the backup, default: the CSP's BackupID
+}
+
+// OnDemandFileSystemBackup godoc
+// @ID ondemand-filesystem-backup
+// @Summary Create On-Demand FileSystem Backup
+// @Description Create a backup of the FileSystem now. The backup is registered with the Name, or with the CSP's BackupID if the Name is empty.
+// @Tags [FileSystem Management]
+// @Accept json
+// @Produce json
+// @Param Name path string true "FileSystem Name"
+// @Param FileSystemBackupCreateRequest body restruntime.FileSystemBackupCreateRequest true "Backup Info"
+// @Success 200 {object} cres.FileSystemBackupInfo
+// @Failure 400 {object} SimpleMsg "Bad Request"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /filesystem/{Name}/backup [post]
+func OnDemandFileSystemBackup(c echo.Context) error {
+	cblog.Info("call OnDemandFileSystemBackup()")
+
+	name := c.Param("Name")
+	var req FileSystemBackupCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	result, err := cmrt.OnDemandFileSystemBackup(req.ConnectionName, name, req.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// ListFileSystemBackup godoc
+// @ID list-filesystem-backup
+// @Summary List FileSystem Backups
+// @Tags [FileSystem Management]
+// @Produce json
+// @Param ConnectionName query string true "Connection Name"
+// @Param Name path string true "FileSystem Name"
+// @Success 200 {array} cres.FileSystemBackupInfo
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /filesystem/{Name}/backup [get]
+func ListFileSystemBackup(c echo.Context) error {
+	cblog.Info("call ListFileSystemBackup()")
+
+	conn := c.QueryParam("ConnectionName")
+	name := c.Param("Name")
+	result, err := cmrt.ListFileSystemBackup(conn, name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetFileSystemBackup godoc
+// @ID get-filesystem-backup
+// @Summary Get FileSystem Backup
+// @Tags [FileSystem Management]
+// @Produce json
+// @Param ConnectionName query string true "Connection Name"
+// @Param Name path string true "FileSystem Name"
+// @Param BackupID path string true "Backup Name or the CSP's BackupID"
+// @Success 200 {object} cres.FileSystemBackupInfo
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /filesystem/{Name}/backup/{BackupID} [get]
+func GetFileSystemBackup(c echo.Context) error {
+	cblog.Info("call GetFileSystemBackup()")
+
+	conn := c.QueryParam("ConnectionName")
+	name := c.Param("Name")
+	result, err := cmrt.GetFileSystemBackup(conn, name, c.Param("BackupID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteFileSystemBackup godoc
+// @ID delete-filesystem-backup
+// @Summary Delete FileSystem Backup
+// @Tags [FileSystem Management]
+// @Accept json
+// @Produce json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Connection Name"
+// @Param Name path string true "FileSystem Name"
+// @Param BackupID path string true "Backup Name or the CSP's BackupID"
+// @Success 200 {object} BooleanInfo
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /filesystem/{Name}/backup/{BackupID} [delete]
+func DeleteFileSystemBackup(c echo.Context) error {
+	cblog.Info("call DeleteFileSystemBackup()")
+
+	var req ConnectionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	name := c.Param("Name")
+	result, err := cmrt.DeleteFileSystemBackup(req.ConnectionName, name, c.Param("BackupID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, BooleanInfo{Result: strconv.FormatBool(result)})
+}
+
+type FileSystemBackupRestoreRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	NewName        string `json:"NewName" validate:"required" example:"efs-01-restored"` // Name of the FileSystem to be created from the backup
+}
+
+// RestoreFileSystemBackup godoc
+// @ID restore-filesystem-backup
+// @Summary Restore FileSystem Backup
+// @Description Create a new FileSystem from the backup. The new FileSystem is in the same zone and VPC as the source FileSystem.
+// @Tags [FileSystem Management]
+// @Accept json
+// @Produce json
+// @Param Name path string true "FileSystem Name"
+// @Param BackupID path string true "Backup Name or the CSP's BackupID"
+// @Param FileSystemBackupRestoreRequest body restruntime.FileSystemBackupRestoreRequest true "Restore Info"
+// @Success 200 {object} cres.FileSystemInfo "Details of the restored FileSystem"
+// @Failure 400 {object} SimpleMsg "Bad Request"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /filesystem/{Name}/backup/{BackupID}/restore [post]
+func RestoreFileSystemBackup(c echo.Context) error {
+	cblog.Info("call RestoreFileSystemBackup()")
+
+	var req FileSystemBackupRestoreRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	name := c.Param("Name")
+	result, err := cmrt.RestoreFileSystemBackup(req.ConnectionName, name, c.Param("BackupID"), req.NewName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,148 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ Asynchronous Job Handler
+
+// JobListResponse represents the response body for listing jobs.
+type JobListResponse struct {
+	Result []*cmrt.JobInfo `json:"job" validate:"required" description:"A list of jobs, newest first"`
+}
+
+// isAsyncRequest returns true if the request has 'async=true' query parameter.
+func isAsyncRequest(c echo.Context) bool {
+	async, err := strconv.ParseBool(c.QueryParam("async"))
+	return err == nil && async
+}
+
+// submitAsyncJob runs the operation as a job and responds 202 Accepted with the queued JobInfo.
+func submitAsyncJob(c echo.Context, operation string, connectionName string, rsType string, resourceName string, run cmrt.JobFunc) error {
+	result, err := cmrt.SubmitJob(operation, connectionName, rsType, resourceName, run)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, result)
+}
+
+// authorizeJob checks the permission of the caller on the connection of the job.
+// The job routes have no ConnectionName, so the auth middleware checked them with the caller's role only.
+func authorizeJob(c echo.Context, jobInfo *cmrt.JobInfo) error {
+	userInfo := getAuthUser(c)
+	if userInfo == nil {
+		return fmt.Errorf("no authenticated user")
+	}
+	return authorizeConnectionRequest(c, userInfo, jobInfo.ConnectionName)
+}
+
+// getJob godoc
+// @ID get-job
+// @Summary Get Job
+// @Description Retrieve the status, progress and result of an asynchronous job. 🕷️ A job is started by calling a long-running API with 'async=true'. The caller needs the permission on the connection of the job.
+// @Tags [Job Management]
+// @Accept  json
+// @Produce  json
+// @Param JobID path string true "The ID of the Job"
+// @Success 200 {object} cmrt.JobInfo "Details of the Job"
+// @Failure 403 {object} SimpleMsg "Forbidden, no permission on the connection of the Job"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Router /job/{JobID} [get]
+func GetJob(c echo.Context) error {
+	cblog.Info("call GetJob()")
+
+	result, err := cmrt.GetJob(c.Param("JobID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err := authorizeJob(c, result); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listJob godoc
+// @ID list-job
+// @Summary List Jobs
+// @Description Retrieve a list of asynchronous jobs, newest first. All jobs are listed if ConnectionName is not given. Only the jobs on the connections the caller has the permission on are listed.
+// @Tags [Job Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string false "The name of the Connection to filter the jobs"
+// @Success 200 {object} JobListResponse "List of Jobs"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /job [get]
+func ListJob(c echo.Context) error {
+	cblog.Info("call ListJob()")
+
+	result, err := cmrt.ListJob(c.QueryParam("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// filter the jobs on the connections without the permission
+	userInfo := getAuthUser(c)
+	routePath := strings.TrimPrefix(c.Path(), "/spider")
+	jobList := []*cmrt.JobInfo{}
+	for _, jobInfo := range result {
+		if userInfo == nil || cmrt.CheckPermission(userInfo, c.Request().Method, routePath, jobInfo.ConnectionName) != nil {
+			continue
+		}
+		jobList = append(jobList, jobInfo)
+	}
+
+	jsonResult := JobListResponse{Result: jobList}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// cancelJob godoc
+// @ID cancel-job
+// @Summary Cancel Job
+// @Description Cancel an asynchronous job. Only a Queued job can be canceled, a Running job cannot be stopped in the middle. The caller needs the permission on the connection of the job.
+// @Tags [Job Management]
+// @Accept  json
+// @Produce  json
+// @Param JobID path string true "The ID of the Job"
+// @Success 200 {object} BooleanInfo "Result of the cancel operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, the Job is not in Queued status"
+// @Failure 403 {object} SimpleMsg "Forbidden, no permission on the connection of the Job"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Router /job/{JobID} [delete]
+func CancelJob(c echo.Context) error {
+	cblog.Info("call CancelJob()")
+
+	jobInfo, err := cmrt.GetJob(c.Param("JobID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err := authorizeJob(c, jobInfo); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	result, err := cmrt.CancelJob(c.Param("JobID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
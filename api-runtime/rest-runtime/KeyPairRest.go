@@ -148,6 +148,47 @@ func CreateKey(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+// KeyPairImportRequest represents the request body for importing a KeyPair.
+type KeyPairImportRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name      string `json:"Name" validate:"required" example:"keypair-01"`
+		PublicKey string `json:"PublicKey" validate:"required" example:"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... user@laptop"` // OpenSSH public key
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// importKey godoc
+// @ID import-keypair
+// @Summary Import KeyPair
+// @Description Import an existing SSH public key(OpenSSH format) as a KeyPair. The PrivateKey is never sent to CB-Spider, so it is empty in the result.
+// @Tags [KeyPair Management]
+// @Accept  json
+// @Produce  json
+// @Param KeyPairImportRequest body restruntime.KeyPairImportRequest true "Request body for importing a KeyPair"
+// @Success 200 {object} cres.KeyPairInfo "Details of the imported KeyPair"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /keypair/import [post]
+func ImportKey(c echo.Context) error {
+	cblog.Info("call ImportKey()")
+
+	var req KeyPairImportRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ImportKey(req.ConnectionName, KEY, req.ReqInfo.Name, req.ReqInfo.PublicKey, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 // KeyPairListResponse represents the response body for listing KeyPairs.
 type KeyPairListResponse struct {
 	Result []*cres.KeyPairInfo `json:"keypair"`
@@ -0,0 +1,110 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"net/http"
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ Mock Driver Fault Injection Handler
+
+// MockFaultInfo represents the fault injection rules of a MockName of the Mock Driver.
+type MockFaultInfo struct {
+	MockName  string `json:"MockName" validate:"required" example:"mock_name00"`
+	FaultSpec string `json:"FaultSpec" validate:"required" example:"StartVM:delay=2s,error=0.3;CreateRDBMS:stuck=true;ListVM:lag=5s"`
+}
+
+// MockFaultRequest represents the request body for setting the fault injection rules.
+type MockFaultRequest struct {
+	// Rules separated by ';', each rule is '<operation>:<key>=<value>,...'. The operation '*' is for all operations.
+	// keys: delay=<duration>, error=<0~1>, partial=<0~1>, stuck=<true|duration>, lag=<duration>
+	FaultSpec string `json:"FaultSpec" validate:"required" example:"StartVM:delay=2s,error=0.3;CreateRDBMS:stuck=true;ListVM:lag=5s"`
+}
+
+// getMockFault godoc
+// @ID get-mock-fault
+// @Summary Get Mock Driver Fault Injection
+// @Description Retrieve the fault injection rules of a MockName of the Mock Driver.
+// @Tags [Test] Mock Driver
+// @Accept  json
+// @Produce  json
+// @Param MockName path string true "The MockName of the Mock Driver credential"
+// @Success 200 {object} MockFaultInfo "Fault injection rules of the MockName"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid MockName"
+// @Router /mockfault/{MockName} [get]
+func GetMockFault(c echo.Context) error {
+	cblog.Info("call GetMockFault()")
+
+	result, err := cmrt.GetMockFault(c.Param("MockName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, &MockFaultInfo{MockName: c.Param("MockName"), FaultSpec: result})
+}
+
+// setMockFault godoc
+// @ID set-mock-fault
+// @Summary Set Mock Driver Fault Injection
+// @Description Replace the fault injection rules of a MockName of the Mock Driver. An empty FaultSpec clears the rules.
+// @Description The MockFault key-value of a Mock credential overrides these rules whenever its connection is used.
+// @Tags [Test] Mock Driver
+// @Accept  json
+// @Produce  json
+// @Param MockName path string true "The MockName of the Mock Driver credential"
+// @Param MockFaultRequest body restruntime.MockFaultRequest true "Request body for setting the fault injection rules"
+// @Success 200 {object} MockFaultInfo "Fault injection rules of the MockName"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid FaultSpec"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /mockfault/{MockName} [put]
+func SetMockFault(c echo.Context) error {
+	cblog.Info("call SetMockFault()")
+
+	req := MockFaultRequest{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	result, err := cmrt.SetMockFault(c.Param("MockName"), req.FaultSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, &MockFaultInfo{MockName: c.Param("MockName"), FaultSpec: result})
+}
+
+// clearMockFault godoc
+// @ID clear-mock-fault
+// @Summary Clear Mock Driver Fault Injection
+// @Description Remove the fault injection rules of a MockName of the Mock Driver and release its resources stuck in Creating.
+// @Tags [Test] Mock Driver
+// @Accept  json
+// @Produce  json
+// @Param MockName path string true "The MockName of the Mock Driver credential"
+// @Success 200 {object} BooleanInfo "Result of the clear operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid MockName"
+// @Router /mockfault/{MockName} [delete]
+func ClearMockFault(c echo.Context) error {
+	cblog.Info("call ClearMockFault()")
+
+	result, err := cmrt.ClearMockFault(c.Param("MockName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
@@ -172,7 +172,9 @@ type NLBListenerCreateRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param NLBCreateRequest body restruntime.NLBCreateRequest true "Request body for creating an NLB"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
 // @Success 200 {object} cres.NLBInfo "Details of the created NLB"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
 // @Failure 500 {object} SimpleMsg "Internal Server Error"
@@ -212,6 +214,11 @@ func CreateNLB(c echo.Context) error {
 	reqInfo.HealthChecker = healthChecker
 
 	// Call common-runtime API
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "CreateNLB", req.ConnectionName, NLB, req.ReqInfo.Name, func() (interface{}, error) {
+			return cmrt.CreateNLB(req.ConnectionName, NLB, reqInfo, req.IDTransformMode)
+		})
+	}
 	result, err := cmrt.CreateNLB(req.ConnectionName, NLB, reqInfo, req.IDTransformMode)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -147,7 +147,9 @@ type RDBMSCreateRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param RDBMSCreateRequest body restruntime.RDBMSCreateRequest true "Request body for creating an RDBMS"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
 // @Success 200 {object} cres.RDBMSInfo "Details of the created RDBMS"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
 // @Failure 500 {object} SimpleMsg "Internal Server Error"
@@ -207,6 +209,11 @@ func CreateRDBMS(c echo.Context) error {
 	}
 
 	// Call common-runtime API
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "CreateRDBMS", req.ConnectionName, RDBMS, req.ReqInfo.Name, func() (interface{}, error) {
+			return cmrt.CreateRDBMS(req.ConnectionName, RDBMS, reqInfo, req.IDTransformMode)
+		})
+	}
 	result, err := cmrt.CreateRDBMS(req.ConnectionName, RDBMS, reqInfo, req.IDTransformMode)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -555,6 +562,88 @@ func CountRDBMSByConnection(c echo.Context) error {
 	return c.JSON(http.StatusOK, jsonResult)
 }
 
+//================ RDBMS Instance Control
+
+// RDBMSChangeSpecRequest represents the request body for changing the spec of an RDBMS.
+type RDBMSChangeSpecRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		DBSpec string `json:"DBSpec" validate:"required" example:"db.t3.medium"` // one of the orderable specs of /dbspec
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// changeRDBMSSpec godoc
+// @ID change-rdbms-spec
+// @Summary Change RDBMS Spec
+// @Description Change the instance spec(class) of an RDBMS. The new spec is validated with the orderable DB specs of the connection. 🕷️ This call waits until the CSP applies the change and the RDBMS becomes Available.
+// @Tags [RDBMS Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the RDBMS"
+// @Param RDBMSChangeSpecRequest body restruntime.RDBMSChangeSpecRequest true "Request body for changing the spec of an RDBMS"
+// @Success 200 {object} cres.RDBMSInfo "Details of the changed RDBMS"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /rdbms/{Name}/spec [put]
+func ChangeRDBMSSpec(c echo.Context) error {
+	cblog.Info("call ChangeRDBMSSpec()")
+
+	var req RDBMSChangeSpecRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeRDBMSSpec(req.ConnectionName, RDBMS, c.Param("Name"), req.ReqInfo.DBSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// RDBMSChangeStorageRequest represents the request body for expanding the storage of an RDBMS.
+type RDBMSChangeStorageRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		StorageSize string `json:"StorageSize" validate:"required" example:"50"` // in GB, larger than the current size
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// changeRDBMSStorageSize godoc
+// @ID change-rdbms-storage-size
+// @Summary Expand RDBMS Storage
+// @Description Expand the storage size(GB) of an RDBMS. The size can only be increased within the storage range of the current spec. 🕷️ This call waits until the CSP applies the change and the RDBMS becomes Available.
+// @Tags [RDBMS Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the RDBMS"
+// @Param RDBMSChangeStorageRequest body restruntime.RDBMSChangeStorageRequest true "Request body for expanding the storage of an RDBMS"
+// @Success 200 {object} cres.RDBMSInfo "Details of the changed RDBMS"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /rdbms/{Name}/storage [put]
+func ChangeRDBMSStorageSize(c echo.Context) error {
+	cblog.Info("call ChangeRDBMSStorageSize()")
+
+	var req RDBMSChangeStorageRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeRDBMSStorageSize(req.ConnectionName, RDBMS, c.Param("Name"), req.ReqInfo.StorageSize)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 //================ RDBMS Database Management (CSP-native API)
 
 // RDBMSDatabaseRequest is used for database create/list/delete via CSP-native API.
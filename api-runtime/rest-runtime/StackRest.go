@@ -0,0 +1,267 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ Stack Handler
+
+// STACK is the resource type of the stacks in the jobs.
+const STACK string = "stack"
+
+// maxStackDocumentSize is the max size of a stack document.
+const maxStackDocumentSize = 1 << 20
+
+// StackListResponse represents the response body for listing stacks.
+type StackListResponse struct {
+	Result []*cmrt.StackInfo `json:"stack" validate:"required" description:"A list of stacks"`
+}
+
+// getStackDocument reads the YAML or JSON stack document of the request body.
+// The ConnectionName query parameter is used if the document has no ConnectionName, and
+// it is required for a YAML document because the permission is checked with it before the handler.
+func getStackDocument(c echo.Context) (*cmrt.StackDocument, error) {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxStackDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxStackDocumentSize {
+		return nil, fmt.Errorf("stack document is larger than %d bytes", maxStackDocumentSize)
+	}
+
+	doc, err := cmrt.ParseStackDocument(body)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionName := c.QueryParam("ConnectionName")
+	// only an exact JSON media type is peeked for the ConnectionName by the permission check
+	isJSON := getRequestMediaType(c.Request()) == echo.MIMEApplicationJSON
+	switch {
+	case connectionName == "" && !isJSON:
+		return nil, fmt.Errorf("ConnectionName query parameter is required for a YAML stack document")
+	case connectionName != "" && doc.ConnectionName == "":
+		doc.ConnectionName = connectionName
+	case connectionName != "" && connectionName != doc.ConnectionName:
+		return nil, fmt.Errorf("ConnectionName query parameter '%s' is different from the stack document '%s'",
+			connectionName, doc.ConnectionName)
+	}
+	return doc, nil
+}
+
+// planStack godoc
+// @ID plan-stack
+// @Summary Plan Stack
+// @Description Compute the actions to apply a stack document, without changing any resource. 🕷️ The document in YAML or JSON describes VPCs, SecurityGroups, KeyPairs, VMs, Disks and NLBs with references between them by name, and is diffed with the resources owned by the stack, the IID stores and the CSP. <br> * Create: not in the connection, * Attach: a disk not attached to its VM, * Delete: owned by the stack but removed from the document, * Update: differs from the document in fields which are updated in place, ex) VMSpecName, * Replace: differs in a field which cannot be updated, ex) DiskType, deleted and created again, BlockedBy lists the resources of the stack using it, * NoChange. <br> A KeyPair is imported from the PublicKey of its Spec. <br> A YAML document needs the ConnectionName query parameter.
+// @Tags [Stack Management]
+// @Accept  json
+// @Accept  application/yaml
+// @Produce  json
+// @Param StackDocument body cmrt.StackDocument true "Stack document in YAML or JSON"
+// @Param ConnectionName query string false "The name of the Connection, required for a YAML document"
+// @Success 200 {object} cmrt.StackPlanInfo "Actions in the order of apply"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to an invalid document or missing references"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /stack/plan [post]
+func PlanStack(c echo.Context) error {
+	cblog.Info("call PlanStack()")
+
+	doc, err := getStackDocument(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := cmrt.GetStackPlan(doc)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// applyStack godoc
+// @ID apply-stack
+// @Summary Apply Stack
+// @Description Apply a stack document in the order of the plan: removed resources are deleted first, then VPCs, SecurityGroups, KeyPairs, VMs, Disks and NLBs are created. 🕷️ The created resources are owned by the stack and persisted after each change, so a failed apply can be applied again. Drifted resources are updated in place or replaced, and the apply is refused if a resource to replace is used by other resources of the stack. A stack is applied or destroyed by one call at a time in all Spider servers sharing the MetaDB. <br> A YAML document needs the ConnectionName query parameter. With async=true, the apply runs as a job.
+// @Tags [Stack Management]
+// @Accept  json
+// @Accept  application/yaml
+// @Produce  json
+// @Param StackDocument body cmrt.StackDocument true "Stack document in YAML or JSON"
+// @Param ConnectionName query string false "The name of the Connection, required for a YAML document"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
+// @Success 200 {object} cmrt.StackInfo "Applied stack with the owned resources"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to an invalid document"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /stack/apply [post]
+func ApplyStack(c echo.Context) error {
+	cblog.Info("call ApplyStack()")
+
+	doc, err := getStackDocument(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "ApplyStack", doc.ConnectionName, STACK, doc.Name, func() (interface{}, error) {
+			return cmrt.ApplyStack(doc)
+		})
+	}
+	result, err := cmrt.ApplyStack(doc)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listStack godoc
+// @ID list-stack
+// @Summary List Stacks
+// @Description Retrieve a list of stacks applied to a connection.
+// @Tags [Stack Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Success 200 {object} StackListResponse "List of stacks"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to missing parameters"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /stack [get]
+func ListStack(c echo.Context) error {
+	cblog.Info("call ListStack()")
+
+	var req ConnectionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	result, err := cmrt.ListStack(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult StackListResponse
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getStack godoc
+// @ID get-stack
+// @Summary Get Stack
+// @Description Retrieve a stack with its last applied document and the resources owned by the stack.
+// @Tags [Stack Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param Name path string true "The name of the Stack"
+// @Success 200 {object} cmrt.StackInfo "Details of the Stack"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to missing parameters"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /stack/{Name} [get]
+func GetStack(c echo.Context) error {
+	cblog.Info("call GetStack()")
+
+	var req ConnectionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	result, err := cmrt.GetStack(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// getStackDrift godoc
+// @ID get-stack-drift
+// @Summary Get Stack Drift
+// @Description Compute the plan of the last applied document of a stack. 🕷️ The actions other than NoChange are drifts from the document, ex) a VM deleted outside of the stack is planned to Create.
+// @Tags [Stack Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param Name path string true "The name of the Stack"
+// @Success 200 {object} cmrt.StackPlanInfo "Actions to apply the last applied document again"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to missing parameters"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /stack/{Name}/drift [get]
+func GetStackDrift(c echo.Context) error {
+	cblog.Info("call GetStackDrift()")
+
+	var req ConnectionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	result, err := cmrt.GetStackDrift(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// destroyStack godoc
+// @ID destroy-stack
+// @Summary Destroy Stack
+// @Description Delete all resources owned by a stack in the reverse order of apply, and delete the stack. 🕷️ If some resources are not deleted, the stack keeps them with the Failed status, and can be destroyed again. With async=true, the destroy runs as a job.
+// @Tags [Stack Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for destroying a Stack"
+// @Param Name path string true "The name of the Stack"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
+// @Success 200 {object} cmrt.DestroyedInfo "Details of the destroyed resources"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to missing parameters"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /stack/{Name} [delete]
+func DestroyStack(c echo.Context) error {
+	cblog.Info("call DestroyStack()")
+
+	var req ConnectionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	stackName := c.Param("Name")
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "DestroyStack", req.ConnectionName, STACK, stackName, func() (interface{}, error) {
+			return cmrt.DestroyStack(req.ConnectionName, stackName)
+		})
+	}
+	result, err := cmrt.DestroyStack(req.ConnectionName, stackName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, &result)
+}
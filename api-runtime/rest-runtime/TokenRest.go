@@ -0,0 +1,130 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ API Token Management (admin only)
+
+// TokenIssueRequest represents the request body for issuing an API token.
+type TokenIssueRequest struct {
+	Name       string `json:"Name" validate:"required" example:"ci-pipeline"`
+	UserName   string `json:"UserName" validate:"required" example:"alice"`                          // owner of the token
+	Scope      string `json:"Scope,omitempty" example:"read-only" enums:"admin,operator,read-only,"` // limits the owner's roles, empty: no limit
+	ExpiryDays int    `json:"ExpiryDays,omitempty" example:"90"`                                     // 0: 90 days
+	S3Key      bool   `json:"S3Key,omitempty" example:"false"`                                       // issue an S3 access key pair together
+}
+
+// TokenListResponse represents the response body for listing API tokens.
+type TokenListResponse struct {
+	Result []*cmrt.APITokenInfo `json:"token" validate:"required" description:"A list of API tokens, newest first"`
+}
+
+// issueToken godoc
+// @ID issue-token
+// @Summary Issue Token
+// @Description Issue an API token of a user. 🕷️ The token is shown only in this response, send it as 'Authorization: Bearer {Token}'. <br> With S3Key, an S3 access key pair is issued together. Use '{S3AccessKeyID}@{ConnectionName}' as the access key and S3SecretKey as the secret key of S3 clients.
+// @Tags [API Token Management]
+// @Accept  json
+// @Produce  json
+// @Param TokenIssueRequest body restruntime.TokenIssueRequest true "Request body for issuing a Token"
+// @Success 200 {object} cmrt.IssuedTokenInfo "Details of the issued Token, including the Token and S3SecretKey"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /token [post]
+func IssueToken(c echo.Context) error {
+	cblog.Info("call IssueToken()")
+
+	req := TokenIssueRequest{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := cmrt.IssueToken(req.Name, req.UserName, req.Scope, req.ExpiryDays, req.S3Key)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listToken godoc
+// @ID list-token
+// @Summary List Tokens
+// @Description Retrieve a list of API tokens, newest first. The tokens and S3 secret keys are not shown.
+// @Tags [API Token Management]
+// @Accept  json
+// @Produce  json
+// @Param UserName query string false "The name of the User to filter the tokens"
+// @Success 200 {object} TokenListResponse "List of Tokens"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /token [get]
+func ListToken(c echo.Context) error {
+	cblog.Info("call ListToken()")
+
+	result, err := cmrt.ListToken(c.QueryParam("UserName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := TokenListResponse{Result: result}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// revokeToken godoc
+// @ID revoke-token
+// @Summary Revoke Token
+// @Description Revoke an API token and its S3 access key pair. The revoked token is still listed.
+// @Tags [API Token Management]
+// @Accept  json
+// @Produce  json
+// @Param TokenID path string true "The ID of the Token"
+// @Success 200 {object} BooleanInfo "Result of the revoke operation"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /token/{TokenID} [delete]
+func RevokeToken(c echo.Context) error {
+	cblog.Info("call RevokeToken()")
+
+	if _, err := cmrt.GetToken(c.Param("TokenID")); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	result, err := cmrt.RevokeToken(c.Param("TokenID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// authenticateBearer returns the user of the Bearer Authorization header.
+func authenticateBearer(auth string) (*cmrt.UserInfo, bool) {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(auth, prefix) {
+		return nil, false
+	}
+	userInfo, err := cmrt.AuthenticateToken(strings.TrimSpace(auth[len(prefix):]))
+	if err != nil {
+		cblog.Warnf("[AUTH] %v", err)
+		return nil, false
+	}
+	return userInfo, true
+}
@@ -0,0 +1,335 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	aw "github.com/cloud-barista/cb-spider/api-runtime/rest-runtime/admin-web"
+	"github.com/labstack/echo/v4"
+)
+
+// ================ User Management (admin only)
+
+// UserCreateRequest represents the request body for creating an API user.
+type UserCreateRequest struct {
+	UserName  string         `json:"UserName" validate:"required" example:"alice"`
+	Password  string         `json:"Password" validate:"required" example:"alice-password"`
+	Role      string         `json:"Role" example:"read-only" enums:"admin,operator,read-only,"` // applied to requests without a matching grant, empty: no access
+	GrantList cmrt.GrantList `json:"GrantList"`                                                  // roles per connection config or provider
+}
+
+// UserUpdateRequest represents the request body for updating an API user.
+type UserUpdateRequest struct {
+	Password  string         `json:"Password,omitempty" example:"new-password"` // empty: keep the current password
+	Role      string         `json:"Role" example:"operator" enums:"admin,operator,read-only,"`
+	GrantList cmrt.GrantList `json:"GrantList"`
+}
+
+// UserListResponse represents the response body for listing API users.
+type UserListResponse struct {
+	Result []*cmrt.UserInfo `json:"user" validate:"required" description:"A list of API users"`
+}
+
+// createUser godoc
+// @ID create-user
+// @Summary Create User
+// @Description Create an API user with a role and grants. 🕷️ A grant gives the operator or read-only role on a connection config or on all connection configs of a provider, and takes precedence over the user's Role. Admin-only APIs are checked with the user's Role only. <br> * admin: all APIs, * operator: all except user management, destroy, cloud info registration and mock fault injection, * read-only: only read APIs.
+// @Tags [User Management]
+// @Accept  json
+// @Produce  json
+// @Param UserCreateRequest body restruntime.UserCreateRequest true "Request body for creating a User"
+// @Success 200 {object} cmrt.UserInfo "Details of the created User"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /user [post]
+func CreateUser(c echo.Context) error {
+	cblog.Info("call CreateUser()")
+
+	req := UserCreateRequest{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := cmrt.CreateUser(req.UserName, req.Password, req.Role, req.GrantList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listUser godoc
+// @ID list-user
+// @Summary List Users
+// @Description Retrieve a list of API users. The built-in admin of SPIDER_USERNAME is not listed.
+// @Tags [User Management]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} UserListResponse "List of Users"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /user [get]
+func ListUser(c echo.Context) error {
+	cblog.Info("call ListUser()")
+
+	result, err := cmrt.ListUser()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := UserListResponse{Result: result}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getUser godoc
+// @ID get-user
+// @Summary Get User
+// @Description Retrieve the details of an API user.
+// @Tags [User Management]
+// @Accept  json
+// @Produce  json
+// @Param UserName path string true "The name of the User"
+// @Success 200 {object} cmrt.UserInfo "Details of the User"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Router /user/{UserName} [get]
+func GetUser(c echo.Context) error {
+	cblog.Info("call GetUser()")
+
+	result, err := cmrt.GetUser(c.Param("UserName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// updateUser godoc
+// @ID update-user
+// @Summary Update User
+// @Description Change the password, role and grants of an API user. The Role and GrantList are replaced with the requested ones.
+// @Tags [User Management]
+// @Accept  json
+// @Produce  json
+// @Param UserName path string true "The name of the User"
+// @Param UserUpdateRequest body restruntime.UserUpdateRequest true "Request body for updating a User"
+// @Success 200 {object} cmrt.UserInfo "Details of the updated User"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /user/{UserName} [put]
+func UpdateUser(c echo.Context) error {
+	cblog.Info("call UpdateUser()")
+
+	req := UserUpdateRequest{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := cmrt.UpdateUser(c.Param("UserName"), req.Password, req.Role, req.GrantList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteUser godoc
+// @ID delete-user
+// @Summary Delete User
+// @Description Delete an API user.
+// @Tags [User Management]
+// @Accept  json
+// @Produce  json
+// @Param UserName path string true "The name of the User"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /user/{UserName} [delete]
+func DeleteUser(c echo.Context) error {
+	cblog.Info("call DeleteUser()")
+
+	if _, err := cmrt.GetUser(c.Param("UserName")); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	result, err := cmrt.DeleteUser(c.Param("UserName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// ================ Authentication & Authorization
+
+// authUserKey is the echo.Context key of the authenticated *cmrt.UserInfo.
+const authUserKey = "spider.auth.user"
+
+// getAuthUser returns the authenticated user of the request, or nil.
+func getAuthUser(c echo.Context) *cmrt.UserInfo {
+	userInfo, _ := c.Get(authUserKey).(*cmrt.UserInfo)
+	return userInfo
+}
+
+// authenticateBasic returns the user of the Basic Authorization header.
+// The AdminWeb session user is used if the request has a valid AdminWeb session cookie.
+func authenticateBasic(c echo.Context, auth string) (*cmrt.UserInfo, bool) {
+	if userName, ok := aw.GetSessionUser(c); ok {
+		if userName == cmrt.GetBuiltinAdminUser().UserName {
+			return cmrt.GetBuiltinAdminUser(), true
+		}
+		userInfo, err := cmrt.GetUser(userName)
+		return userInfo, err == nil
+	}
+
+	const prefix = "Basic "
+	if !strings.HasPrefix(auth, prefix) {
+		return nil, false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(auth[len(prefix):])
+	if err != nil {
+		return nil, false
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return nil, false
+	}
+	userInfo, err := cmrt.AuthenticateUser(parts[0], parts[1])
+	if err != nil {
+		return nil, false
+	}
+	return userInfo, true
+}
+
+// maxPeekBodySize is the max size of a JSON or XML body read to find the ConnectionName.
+const maxPeekBodySize = 1 << 20
+
+// getRequestConnectionName returns the connection name of the request.
+// It collects every place a handler can take the ConnectionName from: the query, the path,
+// the X-Connection-Name header and the JSON or XML body, which echo's Bind decodes.
+// The request is rejected with 400 if they disagree, so that the connection authorized here
+// is the one the handler uses. The body is restored for the handler.
+func getRequestConnectionName(c echo.Context) (string, error) {
+	connectionName := ""
+	addName := func(from string, name string) error {
+		if name == "" {
+			return nil
+		}
+		if connectionName != "" && connectionName != name {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("ConnectionName of the %s '%s' is different from '%s' of the request", from, name, connectionName))
+		}
+		connectionName = name
+		return nil
+	}
+
+	if err := addName("query", c.QueryParam("ConnectionName")); err != nil {
+		return "", err
+	}
+	if err := addName("path", c.Param("ConnectionName")); err != nil {
+		return "", err
+	}
+	if err := addName("header", c.Request().Header.Get("X-Connection-Name")); err != nil {
+		return "", err
+	}
+
+	bodyName, err := getBodyConnectionName(c.Request())
+	if err != nil {
+		return "", err
+	}
+	if err := addName("body", bodyName); err != nil {
+		return "", err
+	}
+	return connectionName, nil
+}
+
+// getRequestMediaType returns the media type of the request Content-Type in lower case.
+// It is the type echo's Bind decodes, also with invalid parameters, ex) "application/json; =x".
+func getRequestMediaType(req *http.Request) string {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	return mediaType
+}
+
+// getBodyConnectionName returns the ConnectionName of the body with the media type echo's Bind decodes.
+// Other media types are not decoded by Bind, so they cannot carry the ConnectionName to the handler.
+func getBodyConnectionName(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0 {
+		return "", nil
+	}
+	mediaType := getRequestMediaType(req)
+	if mediaType != echo.MIMEApplicationJSON && mediaType != echo.MIMEApplicationXML && mediaType != echo.MIMETextXML {
+		return "", nil
+	}
+
+	if req.ContentLength > maxPeekBodySize {
+		return "", echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body is larger than %d bytes", maxPeekBodySize))
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxPeekBodySize+1))
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(body) > maxPeekBodySize {
+		return "", echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body is larger than %d bytes", maxPeekBodySize))
+	}
+
+	var reqInfo struct {
+		ConnectionName string `json:"ConnectionName"`
+	}
+	if mediaType == echo.MIMEApplicationJSON {
+		err = json.Unmarshal(body, &reqInfo)
+	} else {
+		err = xml.Unmarshal(body, &reqInfo)
+	}
+	if err != nil {
+		// the handler fails to bind it, too
+		return "", nil
+	}
+	return reqInfo.ConnectionName, nil
+}
+
+// authorizeRequest checks the permission of the authenticated user for the request.
+// It returns an *echo.HTTPError if the ConnectionName of the request cannot be determined.
+func authorizeRequest(c echo.Context, userInfo *cmrt.UserInfo) error {
+	connectionName, err := getRequestConnectionName(c)
+	if err != nil {
+		cblog.Warnf("[AUTH] %v", err)
+		return err
+	}
+	return authorizeConnectionRequest(c, userInfo, connectionName)
+}
+
+// authorizeConnectionRequest checks the permission of the authenticated user for the request on the connection.
+func authorizeConnectionRequest(c echo.Context, userInfo *cmrt.UserInfo, connectionName string) error {
+	routePath := strings.TrimPrefix(c.Path(), "/spider")
+
+	if err := cmrt.CheckPermission(userInfo, c.Request().Method, routePath, connectionName); err != nil {
+		cblog.Warnf("[AUTH] %v", err)
+		return err
+	}
+
+	c.Set(authUserKey, userInfo)
+	return nil
+}
@@ -0,0 +1,175 @@
+// Rest Runtime Auth Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package restruntime
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	"github.com/labstack/echo/v4"
+)
+
+func newConnectionNameContext(method string, target string, contentType string, body string, pathName string) echo.Context {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body == "" {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	if contentType != "" {
+		req.Header.Set(echo.HeaderContentType, contentType)
+	}
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	if pathName != "" {
+		c.SetParamNames("ConnectionName")
+		c.SetParamValues(pathName)
+	}
+	return c
+}
+
+func TestGetRequestConnectionName(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		pathName    string
+		want        string
+	}{
+		{"query", http.MethodGet, "/spider/vm?ConnectionName=aws-conn", "", "", "", "aws-conn"},
+		{"path", http.MethodGet, "/spider/connectionconfig/aws-conn", "", "", "aws-conn", "aws-conn"},
+		{"json body", http.MethodPost, "/spider/vm", "application/json", `{"ConnectionName":"aws-conn"}`, "", "aws-conn"},
+		{"json body with charset", http.MethodPost, "/spider/vm", " application/json; charset=utf-8", `{"ConnectionName":"aws-conn"}`, "", "aws-conn"},
+		{"xml body", http.MethodPost, "/spider/vm", "application/xml", `<Req><ConnectionName>aws-conn</ConnectionName></Req>`, "", "aws-conn"},
+		{"same query and body", http.MethodPost, "/spider/vm?ConnectionName=aws-conn", "application/json", `{"ConnectionName":"aws-conn"}`, "", "aws-conn"},
+		{"json body with invalid parameter", http.MethodPost, "/spider/vm", "application/json; =utf-8", `{"ConnectionName":"aws-conn"}`, "", "aws-conn"},
+		{"jsonx body is not bound", http.MethodPost, "/spider/vm", "application/jsonx", `{"ConnectionName":"aws-conn"}`, "", ""},
+		{"form body is not bound", http.MethodPost, "/spider/vm", "application/x-www-form-urlencoded", `ConnectionName=gcp-conn`, "", ""},
+		{"no connection", http.MethodGet, "/spider/cloudos", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		c := newConnectionNameContext(tt.method, tt.target, tt.contentType, tt.body, tt.pathName)
+		got, err := getRequestConnectionName(c)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: ConnectionName is '%s', want '%s'", tt.name, got, tt.want)
+		}
+	}
+
+	// the body must be restored for the handler
+	c := newConnectionNameContext(http.MethodPost, "/spider/vm", "application/json", `{"ConnectionName":"aws-conn"}`, "")
+	if _, err := getRequestConnectionName(c); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(c.Request().Body)
+	if string(body) != `{"ConnectionName":"aws-conn"}` {
+		t.Errorf("body is not restored: %s", body)
+	}
+}
+
+func TestGetRequestConnectionNameMismatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		pathName    string
+		header      string
+	}{
+		{"query and json body", "/spider/vm?ConnectionName=aws-conn", "application/json", `{"ConnectionName":"gcp-conn"}`, "", ""},
+		{"query and lowercase json key", "/spider/vm?ConnectionName=aws-conn", "application/json", `{"connectionname":"gcp-conn"}`, "", ""},
+		{"path and json body", "/spider/vm", "application/json", `{"ConnectionName":"gcp-conn"}`, "aws-conn", ""},
+		{"query and xml body", "/spider/vm?ConnectionName=aws-conn", "text/xml", `<Req><ConnectionName>gcp-conn</ConnectionName></Req>`, "", ""},
+		{"query and header", "/spider/s3?ConnectionName=aws-conn", "", "", "", "gcp-conn"},
+	}
+	for _, tt := range tests {
+		c := newConnectionNameContext(http.MethodPost, tt.target, tt.contentType, tt.body, tt.pathName)
+		if tt.header != "" {
+			c.Request().Header.Set("X-Connection-Name", tt.header)
+		}
+		_, err := getRequestConnectionName(c)
+		httpErr, ok := err.(*echo.HTTPError)
+		if !ok || httpErr.Code != http.StatusBadRequest {
+			t.Errorf("%s: mismatched ConnectionName should be rejected with 400: %v", tt.name, err)
+		}
+	}
+}
+
+func TestGetRequestConnectionNameTooLargeBody(t *testing.T) {
+	body := `{"ConnectionName":"gcp-conn","Pad":"` + strings.Repeat("x", maxPeekBodySize) + `"}`
+	c := newConnectionNameContext(http.MethodPost, "/spider/vm?ConnectionName=aws-conn", "application/json", body, "")
+	_, err := getRequestConnectionName(c)
+	httpErr, ok := err.(*echo.HTTPError)
+	if !ok || httpErr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too large body should be rejected with 413: %v", err)
+	}
+}
+
+func TestGetStackDocumentMediaType(t *testing.T) {
+	body := `{"Name":"web-stack","ConnectionName":"aws-conn","ResourceList":[]}`
+
+	c := newConnectionNameContext(http.MethodPost, "/spider/stack/plan", "application/json; charset=utf-8", body, "")
+	if doc, err := getStackDocument(c); err != nil || doc.ConnectionName != "aws-conn" {
+		t.Errorf("JSON document should be accepted without the query: %v", err)
+	}
+
+	// the permission check does not peek a non-JSON media type, so the query is required
+	c = newConnectionNameContext(http.MethodPost, "/spider/stack/plan", "application/jsonx", body, "")
+	if _, err := getStackDocument(c); err == nil {
+		t.Error("application/jsonx document without the ConnectionName query should be rejected")
+	}
+}
+
+func TestAuthorizeJob(t *testing.T) {
+	userInfo := &cmrt.UserInfo{
+		UserName:  "job-user",
+		GrantList: cmrt.GrantList{{ConnectionName: "aws-conn", Role: cmrt.RoleReadOnly}},
+	}
+
+	c := newConnectionNameContext(http.MethodGet, "/spider/job/job-1", "", "", "")
+	c.SetPath("/spider/job/:JobID")
+	c.Set(authUserKey, userInfo)
+	if err := authorizeJob(c, &cmrt.JobInfo{JobID: "job-1", ConnectionName: "aws-conn"}); err != nil {
+		t.Error(err)
+	}
+	if err := authorizeJob(c, &cmrt.JobInfo{JobID: "job-2", ConnectionName: "gcp-conn"}); err == nil {
+		t.Error("a job on a connection without a grant should be denied")
+	}
+
+	c = newConnectionNameContext(http.MethodDelete, "/spider/job/job-1", "", "", "")
+	c.SetPath("/spider/job/:JobID")
+	c.Set(authUserKey, userInfo)
+	if err := authorizeJob(c, &cmrt.JobInfo{JobID: "job-1", ConnectionName: "aws-conn"}); err == nil {
+		t.Error("a read-only user should not cancel a job")
+	}
+}
+
+func TestGetAuditUserName(t *testing.T) {
+	c := newConnectionNameContext(http.MethodPost, "/spider/vm", "", "", "")
+	if got := getAuditUserName(c); got != auditAnonymousUser {
+		t.Errorf("UserName without any auth is '%s', want '%s'", got, auditAnonymousUser)
+	}
+
+	// Basic Auth rejected with a wrong password
+	c.Request().SetBasicAuth("bob", "wrong-password")
+	if got := getAuditUserName(c); got != "bob" {
+		t.Errorf("UserName of a rejected Basic Auth is '%s', want 'bob'", got)
+	}
+
+	c.Set(authUserKey, &cmrt.UserInfo{UserName: "alice"})
+	if got := getAuditUserName(c); got != "alice" {
+		t.Errorf("UserName of the authenticated user is '%s', want 'alice'", got)
+	}
+}
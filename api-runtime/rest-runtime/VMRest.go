@@ -168,6 +168,15 @@ type VMStartRequest struct {
 		VMUserId     string `json:"VMUserId,omitempty" validate:"omitempty" example:"Administrator"`    // Administrator, Windows Only
 		VMUserPasswd string `json:"VMUserPasswd,omitempty" validate:"omitempty" example:"password1234"` // Windows Only
 
+		UserData         string `json:"UserData,omitempty" validate:"omitempty" example:"#cloud-config\npackages:\n  - nginx"` // run at the first boot
+		UserDataEncoding string `json:"UserDataEncoding,omitempty" validate:"omitempty" example:"plain" enums:"plain,base64"`  // encoding of UserData, default: plain
+
+		PurchaseOption struct {
+			LifecycleType        string `json:"LifecycleType,omitempty" validate:"omitempty" example:"Spot"`             // OnDemand or Spot, default: OnDemand
+			MaxPrice             string `json:"MaxPrice,omitempty" validate:"omitempty" example:"0.05"`                  // Spot only, max price per hour, default: up to the OnDemand price
+			InterruptionBehavior string `json:"InterruptionBehavior,omitempty" validate:"omitempty" example:"Terminate"` // Spot only, Terminate or Stop, default: Terminate
+		} `json:"PurchaseOption,omitempty" validate:"omitempty"`
+
 		TagList []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
 	} `json:"ReqInfo" validate:"required"`
 }
@@ -180,7 +189,9 @@ type VMStartRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param VMStartRequest body restruntime.VMStartRequest true "Request body for starting a VM"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
 // @Success 200 {object} cres.VMInfo "Details of the started VM"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
 // @Failure 500 {object} SimpleMsg "Internal Server Error"
@@ -229,10 +240,24 @@ func StartVM(c echo.Context) error {
 		VMUserId:     req.ReqInfo.VMUserId,
 		VMUserPasswd: req.ReqInfo.VMUserPasswd,
 
+		UserData:         req.ReqInfo.UserData,
+		UserDataEncoding: req.ReqInfo.UserDataEncoding,
+
+		PurchaseOption: cres.VMPurchaseOption{
+			LifecycleType:        cres.VMLifecycleType(req.ReqInfo.PurchaseOption.LifecycleType),
+			MaxPrice:             req.ReqInfo.PurchaseOption.MaxPrice,
+			InterruptionBehavior: cres.SpotInterruptionBehavior(req.ReqInfo.PurchaseOption.InterruptionBehavior),
+		},
+
 		TagList: req.ReqInfo.TagList,
 	}
 
 	// Call common-runtime API
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "StartVM", req.ConnectionName, VM, req.ReqInfo.Name, func() (interface{}, error) {
+			return cmrt.StartVM(req.ConnectionName, VM, reqInfo, req.IDTransformMode)
+		})
+	}
 	result, err := cmrt.StartVM(req.ConnectionName, VM, reqInfo, req.IDTransformMode)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -616,6 +641,54 @@ func ControlVM(c echo.Context) error {
 	return c.JSON(http.StatusOK, &resultInfo)
 }
 
+// VMChangeSpecRequest represents the request body for changing the spec of a VM.
+type VMChangeSpecRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		VMSpecName string `json:"VMSpecName" validate:"required" example:"t3.large"` // one of the specs of /vmspec
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// changeVMSpec godoc
+// @ID change-vm-spec
+// @Summary Change VM Spec
+// @Description Change the VMSpec of a Virtual Machine (VM). The new spec is validated with the VMSpecs of the connection. 🕷️ If the CSP requires it, the VM is stopped, changed and started again, and it is returned in its original status, Running or Suspended.
+// @Tags [VM Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the VM"
+// @Param VMChangeSpecRequest body restruntime.VMChangeSpecRequest true "Request body for changing the spec of a VM"
+// @Param async query string false "Run as an asynchronous job and return the Job right away. ex) true or false(default: false)"
+// @Success 200 {object} cres.VMInfo "Details of the changed VM"
+// @Success 202 {object} cmrt.JobInfo "Accepted Job, when async=true"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vm/{Name}/spec [put]
+func ChangeVMSpec(c echo.Context) error {
+	cblog.Info("call ChangeVMSpec()")
+
+	var req VMChangeSpecRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// Call common-runtime API
+	vmName := c.Param("Name")
+	if isAsyncRequest(c) {
+		return submitAsyncJob(c, "ChangeVMSpec", req.ConnectionName, VM, vmName, func() (interface{}, error) {
+			return cmrt.ChangeVMSpec(req.ConnectionName, VM, vmName, req.ReqInfo.VMSpecName)
+		})
+	}
+	result, err := cmrt.ChangeVMSpec(req.ConnectionName, VM, vmName, req.ReqInfo.VMSpecName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 // countAllVMs godoc
 // @ID count-all-vm
 // @Summary Count All VMs
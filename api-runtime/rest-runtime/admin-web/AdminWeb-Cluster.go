@@ -111,8 +111,8 @@ func ClusterManagement(c echo.Context) error {
 		RegionName:       regionName,
 		ProviderName:     providerName,
 		Clusters:         clusters,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 		ClusterSupported: isClusterSupported(connConfig),
 	}
 
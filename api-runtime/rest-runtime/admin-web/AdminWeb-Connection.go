@@ -686,8 +686,8 @@ func ConnectionManagement(c echo.Context) error {
 		Providers:         providers,
 		Regions:           regions,
 		Drivers:           drivers,
-		APIUsername:       os.Getenv("SPIDER_USERNAME"),
-		APIPassword:       os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:       apiUsername(c),
+		APIPassword:       apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/connection.html")
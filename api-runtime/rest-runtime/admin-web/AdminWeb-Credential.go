@@ -92,8 +92,8 @@ func CredentialManagement(c echo.Context) error {
 	}{
 		Credentials: credentials,
 		Providers:   providers,
-		APIUsername: os.Getenv("SPIDER_USERNAME"),
-		APIPassword: os.Getenv("SPIDER_PASSWORD"),
+		APIUsername: apiUsername(c),
+		APIPassword: apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/credential.html")
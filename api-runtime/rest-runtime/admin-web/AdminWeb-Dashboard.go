@@ -229,8 +229,8 @@ func Dashboard(c echo.Context) error {
 		ResourceCounts: resourceCounts,
 		Regions:        regionMap,
 		ShowEmpty:      showEmpty,
-		APIUsername:    os.Getenv("SPIDER_USERNAME"),
-		APIPassword:    os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:    apiUsername(c),
+		APIPassword:    apiPassword(c),
 	}
 
 	c.Response().WriteHeader(http.StatusOK)
@@ -108,8 +108,8 @@ func DiskManagement(c echo.Context) error {
 		Zone:             zone,
 		Disks:            disks,
 		VMs:              vms,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/disk.html")
@@ -202,8 +202,8 @@ func DriverManagement(c echo.Context) error {
 	}{
 		Drivers:     driverMap,
 		Providers:   providers,
-		APIUsername: os.Getenv("SPIDER_USERNAME"),
-		APIPassword: os.Getenv("SPIDER_PASSWORD"),
+		APIUsername: apiUsername(c),
+		APIPassword: apiPassword(c),
 	}
 
 	// Define template path
@@ -89,8 +89,8 @@ func FileSystemManagement(c echo.Context) error {
 		RegionName:       regionName,
 		FileSystems:      fileSystems,
 		ErrorMessage:     errorMessage,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/filesystem.html")
@@ -9,7 +9,6 @@ package adminweb
 
 import (
 	"crypto/rand"
-	"crypto/subtle"
 	"encoding/hex"
 	"html/template"
 	"net/http"
@@ -89,6 +88,39 @@ func validateSession(token string) (string, bool) {
 	return entry.Username, true
 }
 
+// GetSessionUser returns the user of the valid AdminWeb session of the request.
+func GetSessionUser(c echo.Context) (string, bool) {
+	cookie, err := c.Cookie(sessionCookieName())
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return validateSession(cookie.Value)
+}
+
+// HasSessionCookie returns true if the request has an AdminWeb session cookie.
+func HasSessionCookie(c echo.Context) bool {
+	cookie, err := c.Cookie(sessionCookieName())
+	return err == nil && cookie.Value != ""
+}
+
+// apiUsername returns the username for REST API calls of AdminWeb pages.
+func apiUsername(c echo.Context) string {
+	if user, ok := GetSessionUser(c); ok {
+		return user
+	}
+	return os.Getenv("SPIDER_USERNAME")
+}
+
+// apiPassword returns the password for REST API calls of AdminWeb pages.
+// Only the built-in admin's password is given to the page. Other users are
+// authenticated by the session cookie in the REST API, so no password is exposed.
+func apiPassword(c echo.Context) string {
+	if user, ok := GetSessionUser(c); ok && user != os.Getenv("SPIDER_USERNAME") {
+		return ""
+	}
+	return os.Getenv("SPIDER_PASSWORD")
+}
+
 func deleteSession(token string) {
 	sessionMu.Lock()
 	delete(sessionStore, token)
@@ -140,10 +172,22 @@ func AdminWebSessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		if err != nil || cookie.Value == "" {
 			return redirectToLogin(c)
 		}
-		if _, valid := validateSession(cookie.Value); !valid {
+		user, valid := validateSession(cookie.Value)
+		if !valid {
 			return redirectToLogin(c)
 		}
 
+		// AdminWeb actions(non-GET) follow the role of the session user
+		if c.Request().Method != http.MethodGet && user != os.Getenv("SPIDER_USERNAME") {
+			userInfo, err := cr.GetUser(user)
+			if err == nil {
+				err = cr.CheckPermission(userInfo, c.Request().Method, strings.TrimPrefix(reqPath, "/spider"), c.QueryParam("ConnectionName"))
+			}
+			if err != nil {
+				return c.JSON(http.StatusForbidden, map[string]string{"message": err.Error()})
+			}
+		}
+
 		return next(c)
 	}
 }
@@ -236,11 +280,8 @@ func Login(c echo.Context) error {
 		})
 	}
 
-	apiUsername := os.Getenv("SPIDER_USERNAME")
-	apiPassword := os.Getenv("SPIDER_PASSWORD")
-
-	if subtle.ConstantTimeCompare([]byte(req.Username), []byte(apiUsername)) == 1 &&
-		subtle.ConstantTimeCompare([]byte(req.Password), []byte(apiPassword)) == 1 {
+	// the built-in admin or a user in the user store
+	if _, err := cr.AuthenticateUser(req.Username, req.Password); err == nil {
 		token, err := createSession(req.Username)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]interface{}{
@@ -251,7 +292,7 @@ func Login(c echo.Context) error {
 		c.SetCookie(&http.Cookie{
 			Name:     sessionCookieName(),
 			Value:    token,
-			Path:     "/spider/adminweb",
+			Path:     "/spider", // REST API calls of AdminWeb pages are authenticated by the session, too
 			HttpOnly: true,
 			SameSite: http.SameSiteLaxMode,
 			MaxAge:   int(sessionMaxAge.Seconds()),
@@ -280,7 +321,7 @@ func Logout(c echo.Context) error {
 	c.SetCookie(&http.Cookie{
 		Name:     sessionCookieName(),
 		Value:    "",
-		Path:     "/spider/adminweb",
+		Path:     "/spider",
 		HttpOnly: true,
 		MaxAge:   -1,
 	})
@@ -92,8 +92,8 @@ func MyImageManagement(c echo.Context) error {
 		ConnectionConfig: connConfig,
 		RegionName:       regionName,
 		MyImages:         myImages,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/myimage.html")
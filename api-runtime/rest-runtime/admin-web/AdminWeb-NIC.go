@@ -125,8 +125,8 @@ func NICManagement(c echo.Context) error {
 		PublicIPAddrToName: publicIPAddrToName,
 		ProviderName:       providerName,
 		NICDataJSON:        template.JS(nicDataJSON),
-		APIUsername:        os.Getenv("SPIDER_USERNAME"),
-		APIPassword:        os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:        apiUsername(c),
+		APIPassword:        apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/nic.html")
@@ -105,8 +105,8 @@ func NLBManagement(c echo.Context) error {
 		Region:           region,
 		Zone:             zone,
 		NLBs:             nlbs,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/nlb.html")
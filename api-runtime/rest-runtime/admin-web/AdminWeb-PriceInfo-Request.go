@@ -104,9 +104,9 @@ func PriceInfoRequest(c echo.Context) error {
 		ProviderName:   providerName,
 		// RegionList:        regionNameList,
 		ProductFamilyList:  productFamilyList(),
-		APIUsername:        os.Getenv("SPIDER_USERNAME"),
+		APIUsername:        apiUsername(c),
 		PriceInfoSupported: isPriceInfoSupported(connConfig),
-		APIPassword:        os.Getenv("SPIDER_PASSWORD"),
+		APIPassword:        apiPassword(c),
 	}
 
 	// Parse the HTML template
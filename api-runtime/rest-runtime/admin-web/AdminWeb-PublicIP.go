@@ -96,8 +96,8 @@ func PublicIPManagement(c echo.Context) error {
 		PublicIPs:        publicIPs,
 		NICs:             nics,
 		VMs:              vms,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/publicip.html")
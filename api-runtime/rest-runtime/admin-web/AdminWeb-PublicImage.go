@@ -73,8 +73,8 @@ func VMImage(c echo.Context) error {
 	}{
 		ConnConfig:  connConfig,
 		Images:      info.ResultList,
-		APIUsername: os.Getenv("SPIDER_USERNAME"),
-		APIPassword: os.Getenv("SPIDER_PASSWORD"),
+		APIUsername: apiUsername(c),
+		APIPassword: apiPassword(c),
 	}
 
 	tmplPath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/vm-image.html")
@@ -131,8 +131,8 @@ func RDBMSManagement(c echo.Context) error {
 		Region:           region,
 		Zone:             zone,
 		RDBMSs:           rdbmss,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 		RDBMSSupported:   isRDBMSSupported(connConfig),
 	}
 
@@ -79,8 +79,8 @@ func RegionManagement(c echo.Context) error {
 	}{
 		Regions:     regions,
 		Providers:   providers,
-		APIUsername: os.Getenv("SPIDER_USERNAME"),
-		APIPassword: os.Getenv("SPIDER_PASSWORD"),
+		APIUsername: apiUsername(c),
+		APIPassword: apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/region.html")
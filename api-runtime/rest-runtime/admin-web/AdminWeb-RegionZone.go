@@ -118,8 +118,8 @@ func RegionZone(c echo.Context) error {
 		LoggingUrl:    template.JS(genLoggingGETURL2(connConfig, "regionzone")),
 		RegionInfo:    regionInfos,
 		LoggingResult: template.JS(genLoggingResult2(string(resBody[:len(resBody)-1]))),
-		APIUsername:   os.Getenv("SPIDER_USERNAME"),
-		APIPassword:   os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:   apiUsername(c),
+		APIPassword:   apiPassword(c),
 	}
 
 	// Parse the HTML template
@@ -88,8 +88,8 @@ func S3Management(c echo.Context) error {
 		RegionName:       regionName,
 		Buckets:          buckets,
 		ErrorMessage:     errorMessage,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/s3.html")
@@ -108,8 +108,8 @@ func SecurityGroupManagement(c echo.Context) error {
 		Region:           region,
 		Zone:             zone,
 		SecurityGroups:   sgs,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/security-group.html")
@@ -148,8 +148,8 @@ func SystemStatsInfoPage(c echo.Context) error {
 		SystemCoreKeys:  systemCoreKeys,
 		ProcessCoreKeys: processCoreKeys,
 		ShortStartTime:  cr.StartTime,
-		APIUsername:     os.Getenv("SPIDER_USERNAME"),
-		APIPassword:     os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:     apiUsername(c),
+		APIPassword:     apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/system-stats.html")
@@ -240,8 +240,8 @@ func TopologyManagement(c echo.Context) error {
 		ConnectionConfig: connConfig,
 		NodesJSON:        template.JS(nodesJSON),
 		EdgesJSON:        template.JS(edgesJSON),
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/topology.html")
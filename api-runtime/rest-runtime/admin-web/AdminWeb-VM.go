@@ -169,8 +169,8 @@ func VMManagement(c echo.Context) error {
 		Region:           region,
 		VMs:              vms,
 		VMStatusMap:      statusMap,
-		APIUsername:      os.Getenv("SPIDER_USERNAME"),
-		APIPassword:      os.Getenv("SPIDER_PASSWORD"),
+		APIUsername:      apiUsername(c),
+		APIPassword:      apiPassword(c),
 	}
 
 	templatePath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/vm.html")
@@ -65,8 +65,8 @@ func VMSpec(c echo.Context) error {
 	}{
 		ConnConfig:  connConfig,
 		VMSpecs:     info.ResultList,
-		APIUsername: os.Getenv("SPIDER_USERNAME"),
-		APIPassword: os.Getenv("SPIDER_PASSWORD"),
+		APIUsername: apiUsername(c),
+		APIPassword: apiPassword(c),
 	}
 
 	tmplPath := filepath.Join(os.Getenv("CBSPIDER_ROOT"), "/api-runtime/rest-runtime/admin-web/html/vm-spec.html")
@@ -75,9 +75,10 @@ func executeRequest(path, method string, operation map[string]interface{}, cmd *
 		return fmt.Errorf("error creating HTTP request: %v", err)
 	}
 
-	// Set Basic Auth credentials
-	user, pass := getCredentials()
-	if user != "" && pass != "" {
+	// Set Bearer token, or Basic Auth credentials
+	if token := getToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if user, pass := getCredentials(); user != "" && pass != "" {
 		req.SetBasicAuth(user, pass)
 	}
 
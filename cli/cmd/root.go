@@ -37,11 +37,13 @@ var rootCmd = &cobra.Command{
 var serverURL string
 var apiUsername string
 var apiPassword string
+var apiToken string
 
 func Execute() {
 	rootCmd.PersistentFlags().StringVarP(&serverURL, "server", "s", "localhost:1024", "Spider server URL")
 	rootCmd.PersistentFlags().StringVarP(&apiUsername, "username", "u", "", "API username (default: $SPIDER_USERNAME)")
 	rootCmd.PersistentFlags().StringVarP(&apiPassword, "password", "p", "", "API password (default: $SPIDER_PASSWORD)")
+	rootCmd.PersistentFlags().StringVarP(&apiToken, "token", "t", "", "API token, used instead of username and password (default: $SPIDER_TOKEN)")
 	rootCmd.Flags().BoolP("version", "v", false, "Print the version information")
 
 	loadSwagger()
@@ -61,6 +63,14 @@ func getCredentials() (string, string) {
 	return user, pass
 }
 
+// getToken returns the API token from flag or environment variable.
+func getToken() string {
+	if apiToken != "" {
+		return apiToken
+	}
+	return os.Getenv("SPIDER_TOKEN")
+}
+
 var swaggerDefinitions map[string]interface{}
 
 func loadSwagger() {
@@ -178,6 +178,7 @@ func createConnectionInfo(cloudConnectName string, targetZoneName string) (idrv.
 			Host:               KeyValueListGetValue(crdInfo.KeyValueInfoList, "Host"),
 			APIVersion:         KeyValueListGetValue(crdInfo.KeyValueInfoList, "APIVersion"),
 			MockName:           KeyValueListGetValue(crdInfo.KeyValueInfoList, "MockName"),
+			MockFault:          KeyValueListGetValue(crdInfo.KeyValueInfoList, "MockFault"),
 			ApiKey:             KeyValueListGetValue(crdInfo.KeyValueInfoList, "ApiKey"),
 			ClusterId:          KeyValueListGetValue(crdInfo.KeyValueInfoList, "ClusterId"),
 			RDSUserAccessKey:   KeyValueListGetValue(crdInfo.KeyValueInfoList, "User Access Key"),
@@ -273,6 +274,7 @@ func CreateCloudConnection(connectName string) (icon.CloudConnection, error) {
 				Host               string `json:"Host"`
 				IdentityEndpoint   string `json:"IdentityEndpoint"`
 				MockName           string `json:"MockName"`
+				MockFault          string `json:"MockFault"`
 				Password           string `json:"Password"`
 				PrivateKey         string `json:"PrivateKey"`
 				ProjectID          string `json:"ProjectID"`
@@ -321,6 +323,7 @@ func CreateCloudConnection(connectName string) (icon.CloudConnection, error) {
 			Host:               apiResponse.ConnectionInfo.CredentialInfo.Host,
 			IdentityEndpoint:   apiResponse.ConnectionInfo.CredentialInfo.IdentityEndpoint,
 			MockName:           apiResponse.ConnectionInfo.CredentialInfo.MockName,
+			MockFault:          apiResponse.ConnectionInfo.CredentialInfo.MockFault,
 			Password:           apiResponse.ConnectionInfo.CredentialInfo.Password,
 			PrivateKey:         apiResponse.ConnectionInfo.CredentialInfo.PrivateKey,
 			ProjectID:          apiResponse.ConnectionInfo.CredentialInfo.ProjectID,
@@ -391,6 +394,7 @@ func GetCloudConnectionByDriverNameAndCredentialName(driverName string, credenti
 			Host:               KeyValueListGetValue(crdInfo.KeyValueInfoList, "Host"),
 			APIVersion:         KeyValueListGetValue(crdInfo.KeyValueInfoList, "APIVersion"),
 			MockName:           KeyValueListGetValue(crdInfo.KeyValueInfoList, "MockName"),
+			MockFault:          KeyValueListGetValue(crdInfo.KeyValueInfoList, "MockFault"),
 			ApiKey:             KeyValueListGetValue(crdInfo.KeyValueInfoList, "ApiKey"),
 			ClusterId:          KeyValueListGetValue(crdInfo.KeyValueInfoList, "ClusterId"),
 			RDSUserAccessKey:   KeyValueListGetValue(crdInfo.KeyValueInfoList, "User Access Key"),
@@ -76,6 +76,9 @@ const (
 
 	//=========== NIC
 	NIC RES_TYPE = "NIC"
+
+	//=========== DiskSnapshot
+	DISKSNAPSHOT RES_TYPE = "DISKSNAPSHOT"
 )
 
 type CALLLogger struct {
@@ -15,12 +15,14 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"io"
 
 	"errors"
 	"regexp"
+	"strings"
 
 	cblogger "github.com/cloud-barista/cb-log"
 	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
@@ -83,6 +85,7 @@ type LocalKeyInfo struct {
 	HashString   string `gorm:"primaryKey"`
 	NameId       string `gorm:"primaryKey"`
 	PrivateKey   string
+	PublicKey    string // only for an imported KeyPair, which has no PrivateKey
 }
 
 func (LocalKeyInfo) TableName() string {
@@ -100,13 +103,13 @@ func init() {
 		cblog.Error(err)
 		return
 	}
-	db.AutoMigrate(&LocalKeyInfo{})
+	infostore.AutoMigrate(db, &LocalKeyInfo{})
 	infostore.Close(db)
 }
 
 func AddKey(providerName string, hashString string, keyPairNameId string, privateKey string) error {
 
-	encPrivateKey, err := enc.Encrypt(enc.SPIDER_KEY, []byte(privateKey))
+	encPrivateKey, err := enc.EncryptValue(privateKey)
 	if err != nil {
 		return err
 	}
@@ -119,7 +122,23 @@ func AddKey(providerName string, hashString string, keyPairNameId string, privat
 	return nil
 }
 
+// ImportKey saves the public key of a KeyPair imported by a user.
+// ex)
+//
+//	publicKey, _, err := ParsePublicKey(userPublicKey)
+//	ImportKey("GCP", strHash, keyPairReqInfo.IId.NameId, publicKey)
+func ImportKey(providerName string, hashString string, keyPairNameId string, publicKey string) error {
+
+	err := infostore.Insert(&LocalKeyInfo{ProviderName: providerName, HashString: hashString, NameId: keyPairNameId, PublicKey: publicKey})
+	if err != nil {
+		cblog.Error(err)
+		return err
+	}
+	return nil
+}
+
 // return: []KeyValue{Key:KeyPairNameId, Value:PrivateKey}
+// The Value of an imported KeyPair is empty.
 func ListKey(providerName string, hashString string) ([]*irs.KeyValue, error) {
 
 	var iidInfoList []*LocalKeyInfo
@@ -132,7 +151,7 @@ func ListKey(providerName string, hashString string) ([]*irs.KeyValue, error) {
 	var keyValueList []*irs.KeyValue
 	for _, iidInfo := range iidInfoList {
 
-		decPrivateKey, err := enc.Decrypt(enc.SPIDER_KEY, []byte(iidInfo.PrivateKey))
+		decPrivateKey, err := decryptPrivateKey(iidInfo.PrivateKey)
 		if err != nil {
 			return nil, err
 		}
@@ -148,6 +167,7 @@ func ListKey(providerName string, hashString string) ([]*irs.KeyValue, error) {
 }
 
 // return: KeyValue{Key:KeyPairNameId, Value:PrivateKey}
+// The Value of an imported KeyPair is empty.
 func GetKey(providerName string, hashString string, keyPairNameId string) (*irs.KeyValue, error) {
 
 	var localKeyInfo LocalKeyInfo
@@ -157,7 +177,7 @@ func GetKey(providerName string, hashString string, keyPairNameId string) (*irs.
 		return nil, err
 	}
 
-	decPrivateKey, err := enc.Decrypt(enc.SPIDER_KEY, []byte(localKeyInfo.PrivateKey))
+	decPrivateKey, err := decryptPrivateKey(localKeyInfo.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +190,39 @@ func GetKey(providerName string, hashString string, keyPairNameId string) (*irs.
 	return keyValue, nil
 }
 
+// return: KeyValue{Key:KeyPairNameId, Value:PublicKey}
+// The PublicKey of a generated KeyPair is made from its PrivateKey.
+func GetPublicKey(providerName string, hashString string, keyPairNameId string) (*irs.KeyValue, error) {
+
+	var localKeyInfo LocalKeyInfo
+	err := infostore.GetBy3Conditions(&localKeyInfo, "provider_name", providerName, "hash_string", hashString, "name_id", keyPairNameId)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := localKeyInfo.PublicKey
+	if publicKey == "" {
+		decPrivateKey, err := decryptPrivateKey(localKeyInfo.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err = MakePublicKeyFromPrivateKey(decPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &irs.KeyValue{Key: localKeyInfo.NameId, Value: publicKey}, nil
+}
+
+// decryptPrivateKey returns an empty string for an imported KeyPair.
+func decryptPrivateKey(encPrivateKey string) (string, error) {
+	if encPrivateKey == "" {
+		return "", nil
+	}
+	return enc.DecryptValue(encPrivateKey)
+}
+
 func DelKey(providerName string, hashString string, keyPairNameId string) error {
 
 	_, err := infostore.DeleteBy3Conditions(&LocalKeyInfo{}, "provider_name", providerName, "hash_string", hashString, "name_id", keyPairNameId)
@@ -180,6 +233,40 @@ func DelKey(providerName string, hashString string, keyPairNameId string) error
 	return nil
 }
 
+// ReEncryptKeys re-encrypts all private keys with the active Spider key.
+// returns: the number of the re-encrypted keys
+func ReEncryptKeys() (int, error) {
+
+	var keyInfoList []*LocalKeyInfo
+	err := infostore.List(&keyInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return 0, err
+	}
+
+	count := 0
+	for _, keyInfo := range keyInfoList {
+		if keyInfo.PrivateKey == "" { // imported KeyPair
+			continue
+		}
+		encPrivateKey, reEncrypted, err := enc.ReEncryptValue(keyInfo.PrivateKey)
+		if err != nil {
+			return count, fmt.Errorf("failed to re-encrypt the key of %s: %v", keyInfo.NameId, err)
+		}
+		if !reEncrypted {
+			continue
+		}
+		keyInfo.PrivateKey = encPrivateKey
+		err = infostore.Insert(keyInfo)
+		if err != nil {
+			cblog.Error(err)
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
 func GenHash(sourceList []string) (string, error) {
 	var keyString string
 	for _, str := range sourceList {
@@ -211,6 +298,17 @@ func MakePublicKeyFromPrivateKey(pem string) (string, error) {
 	return string(bytes.TrimRight(ssh.MarshalAuthorizedKey(pub), "\n")), nil
 }
 
+// ParsePublicKey validates a public key in the OpenSSH authorized_keys format.
+// returns: the public key without its comment, ex) "ssh-rsa AAAAB3...", MD5 fingerprint, error
+func ParsePublicKey(publicKey string) (string, string, error) {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(publicKey)))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid public key: %v", err)
+	}
+
+	return string(bytes.TrimRight(ssh.MarshalAuthorizedKey(pub), "\n")), ssh.FingerprintLegacyMD5(pub), nil
+}
+
 //-------------------
 
 func ValidateWindowsPassword(pw string) error {
@@ -249,3 +347,111 @@ func ValidateWindowsPassword(pw string) error {
 
 	}
 }
+
+//-------------------
+
+// userDataBoundary is the boundary of the MIME multipart user data merged by MergeUserData.
+const userDataBoundary = "==CB-SPIDER-USERDATA=="
+
+// MergeUserData combines the init script of a driver, ex) adding cb-user, and the UserData of a VM
+// into a cloud-init MIME multipart document, so both are run at the first boot in this order.
+func MergeUserData(initUserData string, userData string) string {
+	if userData == "" {
+		return initUserData
+	}
+	if initUserData == "" {
+		return userData
+	}
+
+	boundary := userDataBoundary
+	for strings.Contains(initUserData, boundary) || strings.Contains(userData, boundary) {
+		boundary = "=" + boundary + "="
+	}
+
+	var merged strings.Builder
+	merged.WriteString("Content-Type: multipart/mixed; boundary=\"" + boundary + "\"\nMIME-Version: 1.0\n")
+	for _, part := range []string{initUserData, userData} {
+		merged.WriteString("\n--" + boundary + "\n")
+		// a MIME document, ex) multipart, has its own headers and is nested as it is
+		if !strings.HasPrefix(part, "Content-Type:") {
+			merged.WriteString("Content-Type: " + getUserDataContentType(part) + "; charset=\"utf-8\"\nMIME-Version: 1.0\n\n")
+		}
+		merged.WriteString(strings.TrimRight(part, "\n") + "\n")
+	}
+	merged.WriteString("\n--" + boundary + "--\n")
+	return merged.String()
+}
+
+// userDataLimit is the max size of the user data of a CSP.
+type userDataLimit struct {
+	Size          int
+	Base64Encoded bool // true: the limit is of the base64 encoded user data
+}
+
+// userDataLimitMap is the user data size limits of the CSPs which support the VM UserData.
+var userDataLimitMap = map[string]userDataLimit{
+	"AWS":       {Size: 16 * 1024},
+	"AZURE":     {Size: 64 * 1024, Base64Encoded: true},
+	"GCP":       {Size: 256 * 1024},
+	"ALIBABA":   {Size: 32 * 1024, Base64Encoded: true},
+	"TENCENT":   {Size: 16 * 1024, Base64Encoded: true},
+	"IBM":       {Size: 64 * 1024},
+	"OPENSTACK": {Size: 65535, Base64Encoded: true},
+	"NHN":       {Size: 65535, Base64Encoded: true},
+	"ORACLE":    {Size: 32000, Base64Encoded: true},
+}
+
+// CheckUserDataSize checks the size of a plain text user data with the limit of a CSP.
+// Drivers check the user data passed to the CSP, ex) merged by MergeUserData with their init script,
+// because the init script and the MIME headers are counted in the limit too.
+func CheckUserDataSize(providerName string, userData string) error {
+	limit, ok := userDataLimitMap[strings.ToUpper(providerName)]
+	if !ok {
+		return nil
+	}
+
+	size := len(userData)
+	sizeType := ""
+	if limit.Base64Encoded {
+		size = base64.StdEncoding.EncodedLen(size)
+		sizeType = " encoded in base64"
+	}
+	if size > limit.Size {
+		return fmt.Errorf("UserData is %d bytes%s, which exceeds the %s limit of %d bytes", size, sizeType, providerName, limit.Size)
+	}
+	return nil
+}
+
+// MergeUserDataWithLimit merges the init script of a driver and the UserData of a VM by MergeUserData,
+// and checks the size of the merged user data with the limit of the CSP.
+func MergeUserDataWithLimit(providerName string, initUserData string, userData string) (string, error) {
+	merged := MergeUserData(initUserData, userData)
+	if userData == "" {
+		return merged, nil
+	}
+	if err := CheckUserDataSize(providerName, merged); err != nil {
+		return "", fmt.Errorf("%v, with the init script of the driver (%d bytes of UserData)", err, len(userData))
+	}
+	return merged, nil
+}
+
+// getUserDataContentType returns the cloud-init content type of a user data by its first line.
+func getUserDataContentType(userData string) string {
+	contentTypeList := []struct {
+		prefix      string
+		contentType string
+	}{
+		{"#cloud-config", "text/cloud-config"},
+		{"#cloud-boothook", "text/cloud-boothook"},
+		{"#include", "text/x-include-url"},
+		{"#upstart-job", "text/upstart-job"},
+		{"#!", "text/x-shellscript"},
+	}
+	trimmed := strings.TrimLeft(userData, " \t\r\n")
+	for _, ct := range contentTypeList {
+		if strings.HasPrefix(trimmed, ct.prefix) {
+			return ct.contentType
+		}
+	}
+	return "text/plain"
+}
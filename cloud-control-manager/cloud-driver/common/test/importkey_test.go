@@ -0,0 +1,64 @@
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"strings"
+	"testing"
+
+	cdcom "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
+)
+
+func TestImportKey(t *testing.T) {
+	_, publicKey, err := cdcom.GenKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the comment is removed and the fingerprint is MD5(aa:bb:...)
+	normalized, fingerprint, err := cdcom.ParsePublicKey("  " + strings.TrimSpace(string(publicKey)) + " user@laptop\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(normalized, "ssh-rsa ") || strings.Contains(normalized, "user@laptop") {
+		t.Errorf("unexpected normalized key: %s", normalized)
+	}
+	if len(strings.Split(fingerprint, ":")) != 16 {
+		t.Errorf("unexpected fingerprint: %s", fingerprint)
+	}
+	if _, _, err := cdcom.ParsePublicKey("ssh-rsa not-a-key"); err == nil {
+		t.Error("invalid public key should be rejected")
+	}
+
+	strHash, err := cdcom.GenHash([]string{"IdentityEndpoint-02", "AuthToken-02", "TenantId-02"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPairNameId := "keypair-import-c6ncl9aba5o081np93og"
+
+	// the imported key has no PrivateKey
+	if err := cdcom.ImportKey("CLOUDIT", strHash, keyPairNameId, normalized); err != nil {
+		t.Fatal(err)
+	}
+	defer cdcom.DelKey("CLOUDIT", strHash, keyPairNameId)
+
+	keyValue, err := cdcom.GetKey("CLOUDIT", strHash, keyPairNameId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyValue.Value != "" {
+		t.Error("imported key should not have a PrivateKey")
+	}
+	pubKeyValue, err := cdcom.GetPublicKey("CLOUDIT", strHash, keyPairNameId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pubKeyValue.Value != normalized {
+		t.Errorf("unexpected public key: %s", pubKeyValue.Value)
+	}
+}
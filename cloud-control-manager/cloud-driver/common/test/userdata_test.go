@@ -0,0 +1,81 @@
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package validatetest
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	cdcom "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
+)
+
+func TestMergeUserData(t *testing.T) {
+	initUserData := "#!/bin/bash\nuseradd -s /bin/bash cb-user -rm -G sudo;\n"
+	userData := "#cloud-config\npackages:\n  - nginx\n"
+
+	if merged := cdcom.MergeUserData(initUserData, ""); merged != initUserData {
+		t.Errorf("init script should be kept without UserData: %s", merged)
+	}
+	if merged := cdcom.MergeUserData("", userData); merged != userData {
+		t.Errorf("UserData should be kept without init script: %s", merged)
+	}
+
+	merged := cdcom.MergeUserData(initUserData, userData)
+	msg, err := mail.ReadMessage(strings.NewReader(merged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("merged user data should be multipart: %s, %v", mediaType, err)
+	}
+
+	var partList []string
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		partList = append(partList, strings.Split(part.Header.Get("Content-Type"), ";")[0]+" "+strings.SplitN(string(body), "\n", 2)[0])
+	}
+	expected := "text/x-shellscript #!/bin/bash, text/cloud-config #cloud-config"
+	if strings.Join(partList, ", ") != expected {
+		t.Errorf("expected parts %s, got %s", expected, strings.Join(partList, ", "))
+	}
+}
+
+func TestMergeUserDataWithLimit(t *testing.T) {
+	initUserData := "#!/bin/bash\nuseradd -s /bin/bash cb-user -rm -G sudo;\n"
+
+	// the init script and the MIME headers are counted in the limit
+	userData := "#!/bin/bash\n" + strings.Repeat("a", 16*1024-len("#!/bin/bash\n"))
+	if err := cdcom.CheckUserDataSize("AWS", userData); err != nil {
+		t.Errorf("16KB should be allowed for AWS: %v", err)
+	}
+	if _, err := cdcom.MergeUserDataWithLimit("AWS", initUserData, userData); err == nil || !strings.Contains(err.Error(), "init script") {
+		t.Errorf("16KB with the init script should be rejected for AWS: %v", err)
+	}
+
+	merged, err := cdcom.MergeUserDataWithLimit("AWS", initUserData, userData[:8*1024])
+	if err != nil || merged != cdcom.MergeUserData(initUserData, userData[:8*1024]) {
+		t.Errorf("8KB with the init script should be merged for AWS: %v", err)
+	}
+	// the init script alone is not checked
+	if merged, err := cdcom.MergeUserDataWithLimit("AWS", strings.Repeat("a", 32*1024), ""); err != nil || len(merged) != 32*1024 {
+		t.Errorf("init script without UserData should be kept: %v", err)
+	}
+}
@@ -82,9 +82,13 @@ func (AlibabaDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.RDBMSMariaDBHandler = true
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false // implemented but not yet validated
 	drvCapabilityInfo.PublicIPHandler = true
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	drvCapabilityInfo.VPC_CIDR = true
 
+	drvCapabilityInfo.VM_USERDATA = true
+	drvCapabilityInfo.SPOT_VM = true
+
 	return drvCapabilityInfo
 }
 
@@ -235,3 +235,7 @@ func (cloudConn *AlibabaCloudConnection) CreatePublicIPHandler() (irs.PublicIPHa
 	handler := alirs.AlibabaPublicIPHandler{Region: cloudConn.Region, VpcClient: cloudConn.VpcClient}
 	return &handler, nil
 }
+
+func (cloudConn *AlibabaCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("Alibaba Driver: DiskSnapshotHandler not implemented yet")
+}
@@ -874,6 +874,10 @@ func (fileSystemHandler *AlibabaFileSystemHandler) DeleteBackup(fsIID irs.IID, b
 	return false, errors.New("backup deletion is not supported in Alibaba Cloud NAS")
 }
 
+func (fileSystemHandler *AlibabaFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	return irs.FileSystemInfo{}, errors.New("backup restore is not supported in Alibaba Cloud NAS")
+}
+
 // Helper functions
 
 // validateFileSystemIID validates that the file system IID is valid
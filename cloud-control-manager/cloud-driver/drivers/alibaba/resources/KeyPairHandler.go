@@ -205,6 +205,53 @@ func (keyPairHandler *AlibabaKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPai
 	return keyPairInfo, nil
 }
 
+// ImportKey registers the user's public key with ImportKeyPair().
+func (keyPairHandler *AlibabaKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Info("Start ImportKey() : ", keyIID)
+
+	request := ecs.CreateImportKeyPairRequest()
+	request.Scheme = "https"
+
+	request.KeyPairName = keyIID.NameId
+	request.PublicKeyBody = publicKey
+
+	// logger for HisCall
+	callogger := call.GetLogger("HISCALL")
+	callLogInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.ALIBABA,
+		RegionZone:   keyPairHandler.Region.Zone,
+		ResourceType: call.VMKEYPAIR,
+		ResourceName: keyIID.NameId,
+		CloudOSAPI:   "ImportKeyPair()",
+		ElapsedTime:  "",
+		ErrorMSG:     "",
+	}
+
+	callLogStart := call.Start()
+	result, err := keyPairHandler.Client.ImportKeyPair(request)
+	callLogInfo.ElapsedTime = call.Elapsed(callLogStart)
+
+	if err != nil {
+		callLogInfo.ErrorMSG = err.Error()
+		callogger.Error(call.String(callLogInfo))
+
+		cblogger.Errorf("Unable to import key pair: %s, %v.", keyIID.NameId, err)
+		return irs.KeyPairInfo{}, err
+	}
+	callogger.Info(call.String(callLogInfo))
+
+	cblogger.Infof("Imported key pair %q %s", result.KeyPairName, result.KeyPairFingerPrint)
+
+	keyPairInfo, err := keyPairHandler.GetKey(irs.IID{NameId: keyIID.NameId, SystemId: result.KeyPairName})
+	if err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+	keyPairInfo.PublicKey = publicKey // GetKey() does not return PublicKey
+
+	return keyPairInfo, nil
+}
+
 // 2021-10-27 이슈#480에 의해 Local Key 로직 제거
 // 혼선을 피하기 위해 keyPairID 대신 keyPairName으로 변경 함.
 func (keyPairHandler *AlibabaKeyPairHandler) GetKey(keyIID irs.IID) (irs.KeyPairInfo, error) {
@@ -60,7 +60,7 @@ func (handler *AlibabaRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaI
 		return irs.RDBMSMetaInfo{}, err
 	}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, true, "7-730", true, false, true, true, true)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, true, "7-730", true, false, true, true, true, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -725,6 +725,60 @@ func (handler *AlibabaRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error)
 	return true, nil
 }
 
+// ChangeSpec changes the instance class of an ApsaraDB RDS instance immediately.
+func (handler *AlibabaRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "ModifyDBInstanceSpec()")
+	start := call.Start()
+
+	request := rds.CreateModifyDBInstanceSpecRequest()
+	request.DBInstanceId = rdbmsIID.SystemId
+	request.DBInstanceClass = newSpec
+	request.PayType = "Postpaid"
+	request.EffectiveTime = "Immediate"
+
+	_, err := handler.Client.ModifyDBInstanceSpec(request)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return handler.GetRDBMS(rdbmsIID)
+}
+
+// ChangeStorageSize expands the storage(GB) of an ApsaraDB RDS instance immediately.
+func (handler *AlibabaRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "ModifyDBInstanceSpec()")
+	start := call.Start()
+
+	size, err := strconv.Atoi(newSize)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	request := rds.CreateModifyDBInstanceSpecRequest()
+	request.DBInstanceId = rdbmsIID.SystemId
+	request.DBInstanceStorage = requests.NewInteger(size)
+	request.PayType = "Postpaid"
+	request.EffectiveTime = "Immediate"
+
+	_, err = handler.Client.ModifyDBInstanceSpec(request)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return true, nil
+}
+
 // ===== Helper Functions =====
 
 func (handler *AlibabaRDBMSHandler) getDBInstanceAttribute(dbInstanceId string) (irs.RDBMSInfo, error) {
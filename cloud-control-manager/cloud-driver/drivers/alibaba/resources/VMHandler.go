@@ -171,6 +171,11 @@ func (vmHandler *AlibabaVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo,
 	userData := string(fileDataCloudInit)
 	//userData = strings.ReplaceAll(userData, "{{username}}", CBDefaultVmUserName)
 	//userData = strings.ReplaceAll(userData, "{{public_key}}", keyPairInfo.PublicKey)
+	userData, err = cdcom.MergeUserDataWithLimit("ALIBABA", userData, vmReqInfo.UserData)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
 	userDataBase64 := base64.StdEncoding.EncodeToString([]byte(userData))
 	cblogger.Debugf("cloud-init data : [%s]", userDataBase64)
 
@@ -213,6 +218,9 @@ func (vmHandler *AlibabaVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo,
 	// 다른 os일 때 password는 cb-user의 password 로 사용
 	if isWindows {
 		request.Password = vmReqInfo.VMUserPasswd
+		if vmReqInfo.UserData != "" {
+			request.UserData = base64.StdEncoding.EncodeToString([]byte(vmReqInfo.UserData))
+		}
 	} else {
 		request.KeyPairName = vmReqInfo.KeyPairIID.SystemId
 
@@ -221,6 +229,19 @@ func (vmHandler *AlibabaVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo,
 		request.UserData = userDataBase64         // cbuser 추가
 	}
 
+	// Spot 인스턴스: MaxPrice가 없으면 시장 가격(최대 PayAsYouGo 가격)으로 생성
+	if vmReqInfo.PurchaseOption.LifecycleType == irs.SpotVM {
+		request.SpotStrategy = "SpotAsPriceGo"
+		if vmReqInfo.PurchaseOption.MaxPrice != "" {
+			request.SpotStrategy = "SpotWithPriceLimit"
+			request.SpotPriceLimit = requests.Float(vmReqInfo.PurchaseOption.MaxPrice)
+		}
+		request.SpotInterruptionBehavior = "Terminate"
+		if vmReqInfo.PurchaseOption.InterruptionBehavior == irs.SpotStop {
+			request.SpotInterruptionBehavior = "Stop"
+		}
+	}
+
 	request.VSwitchId = vmReqInfo.SubnetIID.SystemId
 
 	//==============
@@ -745,6 +766,95 @@ func (vmHandler *AlibabaVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, err
 	return irs.VMStatus("Terminating"), nil
 }
 
+// ChangeVMSpec changes the instance type of the VM.
+// ECS changes the instance type of a pay-as-you-go instance only when it is stopped, so a running VM is stopped and started again.
+func (vmHandler *AlibabaVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Infof("vmID : [%s], vmSpecName : [%s]", vmIID.SystemId, vmSpecName)
+
+	status, err := vmHandler.GetVMStatus(vmIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	wasRunning := false
+	switch status {
+	case irs.Running:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForSuspended(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+	case irs.Suspended:
+	default:
+		err := fmt.Errorf("VM %s is %s, the spec can be changed only when it is running or suspended", vmIID.SystemId, status)
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	request := ecs.CreateModifyInstanceSpecRequest()
+	request.Scheme = "https"
+	request.InstanceId = vmIID.SystemId
+	request.InstanceType = vmSpecName
+
+	// logger for HisCall
+	callogger := call.GetLogger("HISCALL")
+	callLogInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.ALIBABA,
+		RegionZone:   vmHandler.Region.Zone,
+		ResourceType: call.VM,
+		ResourceName: vmIID.SystemId,
+		CloudOSAPI:   "ModifyInstanceSpec()",
+		ElapsedTime:  "",
+		ErrorMSG:     "",
+	}
+
+	callLogStart := call.Start()
+	response, err := vmHandler.Client.ModifyInstanceSpec(request)
+	callLogInfo.ElapsedTime = call.Elapsed(callLogStart)
+	if err != nil {
+		callLogInfo.ErrorMSG = err.Error()
+		callogger.Error(call.String(callLogInfo))
+		cblogger.Error(err.Error())
+		if wasRunning {
+			// start the VM with the original instance type
+			vmHandler.ResumeVM(vmIID)
+		}
+		return irs.VMInfo{}, err
+	}
+	callogger.Debug(call.String(callLogInfo))
+	cblogger.Debug(response)
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if _, err := vmHandler.WaitForRun(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmIID)
+}
+
+// waitForSuspended waits until the stopped VM is Stopped.
+func (vmHandler *AlibabaVMHandler) waitForSuspended(vmIID irs.IID) error {
+	maxRetryCnt := 300
+	for curRetryCnt := 0; curRetryCnt < maxRetryCnt; curRetryCnt++ {
+		curStatus, err := vmHandler.GetVMStatus(vmIID)
+		if err != nil {
+			cblogger.Error(err.Error())
+		}
+		if curStatus == irs.Suspended {
+			return nil
+		}
+		time.Sleep(time.Second * 1)
+	}
+	return errors.New("After waiting for a long time, the status of the VM did not change to [Suspended], so the process is being terminated.")
+}
+
 func (vmHandler *AlibabaVMHandler) GetVM(vmIID irs.IID) (irs.VMInfo, error) {
 	cblogger.Infof("vmID : [%s]", vmIID.SystemId)
 
@@ -834,6 +944,11 @@ func (vmHandler *AlibabaVMHandler) ExtractDescribeInstances(instanceInfo *ecs.In
 	}
 	vmInfo.TagList = tagList
 
+	vmInfo.LifecycleType = irs.OnDemandVM
+	if instanceInfo.SpotStrategy != "" && instanceInfo.SpotStrategy != "NoSpot" {
+		vmInfo.LifecycleType = irs.SpotVM
+	}
+
 	// Platform 정보 추가
 	if instanceInfo.OSType == "windows" {
 		vmInfo.Platform = irs.WINDOWS
@@ -30,6 +30,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/backup"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/costexplorer"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -84,12 +85,16 @@ func (AwsDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.RDBMSMariaDBHandler = true
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false // implemented but not yet validated
 	drvCapabilityInfo.PublicIPHandler = true
+	drvCapabilityInfo.DiskSnapshotHandler = true
 
 	drvCapabilityInfo.TagHandler = true
 	drvCapabilityInfo.TagSupportResourceType = []ires.RSType{ires.VPC, ires.SUBNET, ires.SG, ires.KEY, ires.VM, ires.NLB, ires.DISK, ires.MYIMAGE, ires.CLUSTER, ires.FILESYSTEM, ires.RDBMS}
 
 	drvCapabilityInfo.VPC_CIDR = true
 
+	drvCapabilityInfo.VM_USERDATA = true
+	drvCapabilityInfo.SPOT_VM = true
+
 	return drvCapabilityInfo
 }
 
@@ -357,6 +362,16 @@ func getEFSClient(connectionInfo idrv.ConnectionInfo) (*efs.EFS, error) {
 	return efs.New(sess), nil
 }
 
+// EFS 백업(AWS Backup) 처리를 위한 Backup 클라이언트 획득
+func getBackupClient(connectionInfo idrv.ConnectionInfo) (*backup.Backup, error) {
+	sess, err := newAWSSession(connectionInfo, connectionInfo.RegionInfo.Region)
+	if err != nil {
+		cblog.Error("Could not create AWS session", err)
+		return nil, err
+	}
+	return backup.New(sess), nil
+}
+
 func getRDSClient(connectionInfo idrv.ConnectionInfo) (*rds.RDS, error) {
 	sess, err := newAWSSession(connectionInfo, connectionInfo.RegionInfo.Region)
 	if err != nil {
@@ -397,6 +412,7 @@ func (driver *AwsDriver) ConnectCloud(connectionInfo idrv.ConnectionInfo) (icon.
 	pricingClient, err := getPricingClient(connectionInfo)
 	autoScalingClient, err := getAutoScalingClient(connectionInfo)
 	efsClient, err := getEFSClient(connectionInfo)
+	backupClient, err := getBackupClient(connectionInfo)
 	serviceQuotasClient, err := getServiceQuotasClient(connectionInfo)
 	rdsClient, err := getRDSClient(connectionInfo)
 	//vmClient, err := getVMClient(connectionInfo.RegionInfo)
@@ -440,6 +456,7 @@ func (driver *AwsDriver) ConnectCloud(connectionInfo idrv.ConnectionInfo) (icon.
 		CostExplorerClient:  costExplorerClient,
 		CloudWatchClient:    cloudwatchClient,
 		FileSystemClient:    efsClient,
+		BackupClient:        backupClient,
 		ServiceQuotasClient: serviceQuotasClient,
 		RDSClient:           rdsClient,
 	}
@@ -22,6 +22,7 @@ import (
 
 	//ec2drv "github.com/aws/aws-sdk-go/service/ec2"
 
+	"github.com/aws/aws-sdk-go/service/backup"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/costexplorer"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -77,6 +78,7 @@ type AwsCloudConnection struct {
 
 	CloudWatchClient    *cloudwatch.CloudWatch
 	FileSystemClient    *efs.EFS
+	BackupClient        *backup.Backup
 	ServiceQuotasClient *servicequotas.ServiceQuotas
 	RDSClient           *rds.RDS
 }
@@ -176,7 +178,7 @@ func (cloudConn *AwsCloudConnection) CreateDiskHandler() (irs.DiskHandler, error
 // CreateFileSystemHandler implements connect.CloudConnection.
 func (cloudConn *AwsCloudConnection) CreateFileSystemHandler() (irs.FileSystemHandler, error) {
 	tagHandler := cloudConn.CreateAwsTagHandler()
-	handler := ars.AwsFileSystemHandler{Region: cloudConn.Region, Client: cloudConn.FileSystemClient, EC2Client: cloudConn.VNetworkClient, TagHandler: &tagHandler}
+	handler := ars.AwsFileSystemHandler{Region: cloudConn.Region, Client: cloudConn.FileSystemClient, BackupClient: cloudConn.BackupClient, EC2Client: cloudConn.VNetworkClient, TagHandler: &tagHandler}
 	return &handler, nil
 }
 
@@ -219,7 +221,7 @@ func (cloudConn *AwsCloudConnection) CreateRegionZoneHandler() (irs.RegionZoneHa
 }
 
 func (cloudConn *AwsCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
-	handler := ars.AwsPriceInfoHandler{Region: cloudConn.Region, Client: cloudConn.PriceInfoClient}
+	handler := ars.AwsPriceInfoHandler{Region: cloudConn.Region, Client: cloudConn.PriceInfoClient, EC2Client: cloudConn.VMClient}
 	return &handler, nil
 }
 
@@ -266,3 +268,9 @@ func (cloudConn *AwsCloudConnection) CreatePublicIPHandler() (irs.PublicIPHandle
 	handler := ars.AwsPublicIPHandler{Region: cloudConn.Region, Client: cloudConn.VMClient, TagHandler: &tagHandler}
 	return &handler, nil
 }
+
+func (cloudConn *AwsCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	tagHandler := cloudConn.CreateAwsTagHandler()
+	handler := ars.AwsDiskSnapshotHandler{Region: cloudConn.Region, Client: cloudConn.DiskClient, TagHandler: &tagHandler}
+	return &handler, nil
+}
@@ -0,0 +1,257 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// AWS Disk Snapshot (EBS Snapshot) Handler
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+type AwsDiskSnapshotHandler struct {
+	Region     idrv.RegionInfo
+	Client     *ec2.EC2
+	TagHandler *AwsTagHandler
+}
+
+const RESOURCE_TYPE_SNAPSHOT = "snapshot"
+
+// ListIID returns all EBS snapshot IIDs owned by this account in the current region.
+func (h *AwsDiskSnapshotHandler) ListIID() ([]*irs.IID, error) {
+	hiscallInfo := GetCallLogScheme(h.Region, call.DISKSNAPSHOT, "ListIID", "DescribeSnapshots()")
+	start := call.Start()
+
+	result, err := h.Client.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+	})
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return nil, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	iidList := []*irs.IID{}
+	for _, snapshot := range result.Snapshots {
+		iidList = append(iidList, &irs.IID{SystemId: aws.StringValue(snapshot.SnapshotId)})
+	}
+	return iidList, nil
+}
+
+// CreateSnapshot creates an EBS snapshot of the source volume.
+// The snapshot is returned in the Creating status until it is completed.
+func (h *AwsDiskSnapshotHandler) CreateSnapshot(snapshotReqInfo irs.DiskSnapshotInfo) (irs.DiskSnapshotInfo, error) {
+	hiscallInfo := GetCallLogScheme(h.Region, call.DISKSNAPSHOT, snapshotReqInfo.IId.NameId, "CreateSnapshot()")
+	start := call.Start()
+
+	tagSpecifications, err := ConvertTagListToTagSpecifications(RESOURCE_TYPE_SNAPSHOT, snapshotReqInfo.TagList, snapshotReqInfo.IId.NameId)
+	if err != nil {
+		return irs.DiskSnapshotInfo{}, fmt.Errorf("failed to convert tag list: %w", err)
+	}
+
+	result, err := h.Client.CreateSnapshot(&ec2.CreateSnapshotInput{
+		VolumeId:          aws.String(snapshotReqInfo.SourceDisk.SystemId),
+		Description:       aws.String(snapshotReqInfo.IId.NameId),
+		TagSpecifications: tagSpecifications,
+	})
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return h.GetSnapshot(irs.IID{NameId: snapshotReqInfo.IId.NameId, SystemId: aws.StringValue(result.SnapshotId)})
+}
+
+// ListSnapshot returns all EBS snapshots owned by this account in the current region.
+func (h *AwsDiskSnapshotHandler) ListSnapshot() ([]*irs.DiskSnapshotInfo, error) {
+	hiscallInfo := GetCallLogScheme(h.Region, call.DISKSNAPSHOT, "DiskSnapshot", "DescribeSnapshots()")
+	start := call.Start()
+
+	result, err := h.Client.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+	})
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return nil, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	infoList := []*irs.DiskSnapshotInfo{}
+	for _, snapshot := range result.Snapshots {
+		info := extractAwsDiskSnapshotInfo(snapshot)
+		infoList = append(infoList, &info)
+	}
+	return infoList, nil
+}
+
+// GetSnapshot retrieves a single EBS snapshot by IID (NameId = Name tag, SystemId = SnapshotId).
+func (h *AwsDiskSnapshotHandler) GetSnapshot(snapshotIID irs.IID) (irs.DiskSnapshotInfo, error) {
+	hiscallInfo := GetCallLogScheme(h.Region, call.DISKSNAPSHOT, snapshotIID.NameId, "DescribeSnapshots()")
+	start := call.Start()
+
+	input := &ec2.DescribeSnapshotsInput{OwnerIds: []*string{aws.String("self")}}
+	if snapshotIID.SystemId != "" {
+		input.SnapshotIds = []*string{aws.String(snapshotIID.SystemId)}
+	} else {
+		input.Filters = []*ec2.Filter{
+			{Name: aws.String("tag:Name"), Values: []*string{aws.String(snapshotIID.NameId)}},
+		}
+	}
+
+	result, err := h.Client.DescribeSnapshots(input)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	if len(result.Snapshots) == 0 {
+		return irs.DiskSnapshotInfo{}, fmt.Errorf("Disk Snapshot not found: %s", snapshotIID.NameId)
+	}
+
+	info := extractAwsDiskSnapshotInfo(result.Snapshots[0])
+	if snapshotIID.NameId != "" {
+		info.IId.NameId = snapshotIID.NameId
+	}
+	return info, nil
+}
+
+// DeleteSnapshot deletes an EBS snapshot.
+func (h *AwsDiskSnapshotHandler) DeleteSnapshot(snapshotIID irs.IID) (bool, error) {
+	hiscallInfo := GetCallLogScheme(h.Region, call.DISKSNAPSHOT, snapshotIID.NameId, "DeleteSnapshot()")
+	start := call.Start()
+
+	_, err := h.Client.DeleteSnapshot(&ec2.DeleteSnapshotInput{
+		SnapshotId: aws.String(snapshotIID.SystemId),
+	})
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return true, nil
+}
+
+// CreateDiskFromSnapshot creates a new EBS volume from the snapshot.
+// The DiskSize is the size of the snapshot if it is "default".
+func (h *AwsDiskSnapshotHandler) CreateDiskFromSnapshot(snapshotIID irs.IID, diskReqInfo irs.DiskInfo) (irs.DiskInfo, error) {
+	snapshotInfo, err := h.GetSnapshot(snapshotIID)
+	if err != nil {
+		return irs.DiskInfo{}, err
+	}
+	snapshotSize, _ := strconv.ParseInt(snapshotInfo.DiskSize, 10, 64)
+
+	if diskReqInfo.DiskSize == "" || diskReqInfo.DiskSize == "default" {
+		diskReqInfo.DiskSize = snapshotInfo.DiskSize
+	}
+	err = validateCreateDisk(&diskReqInfo)
+	if err != nil {
+		return irs.DiskInfo{}, err
+	}
+	volumeSize, _ := strconv.ParseInt(diskReqInfo.DiskSize, 10, 64)
+	if volumeSize < snapshotSize {
+		return irs.DiskInfo{}, fmt.Errorf("DiskSize(%d) can not be smaller than the size(%d) of %s Disk Snapshot", volumeSize, snapshotSize, snapshotIID.NameId)
+	}
+
+	zone := h.Region.Zone
+	if diskReqInfo.Zone != "" {
+		zone = diskReqInfo.Zone
+	}
+
+	tagSpecifications, err := ConvertTagListToTagSpecifications(RESOURCE_TYPE_VOLUME, diskReqInfo.TagList, diskReqInfo.IId.NameId)
+	if err != nil {
+		return irs.DiskInfo{}, fmt.Errorf("failed to convert tag list: %w", err)
+	}
+
+	hiscallInfo := GetCallLogScheme(h.Region, call.DISKSNAPSHOT, diskReqInfo.IId.NameId, "CreateVolume()")
+	start := call.Start()
+
+	result, err := h.Client.CreateVolume(&ec2.CreateVolumeInput{
+		AvailabilityZone:  aws.String(zone),
+		SnapshotId:        aws.String(snapshotInfo.IId.SystemId),
+		Size:              aws.Int64(volumeSize),
+		VolumeType:        aws.String(diskReqInfo.DiskType),
+		TagSpecifications: tagSpecifications,
+	})
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.DiskInfo{}, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	newVolume := irs.IID{NameId: diskReqInfo.IId.NameId, SystemId: aws.StringValue(result.VolumeId)}
+	err = WaitUntilVolumeAvailable(h.Client, newVolume.SystemId)
+	if err != nil {
+		return irs.DiskInfo{}, err
+	}
+
+	diskHandler := AwsDiskHandler{Region: h.Region, Client: h.Client, TagHandler: h.TagHandler}
+	return diskHandler.GetDisk(newVolume)
+}
+
+func extractAwsDiskSnapshotInfo(snapshot *ec2.Snapshot) irs.DiskSnapshotInfo {
+	info := irs.DiskSnapshotInfo{
+		IId: irs.IID{
+			NameId:   aws.StringValue(snapshot.SnapshotId),
+			SystemId: aws.StringValue(snapshot.SnapshotId),
+		},
+		SourceDisk: irs.IID{SystemId: aws.StringValue(snapshot.VolumeId)},
+		DiskSize:   strconv.FormatInt(aws.Int64Value(snapshot.VolumeSize), 10),
+		Status:     convertAwsSnapshotState(aws.StringValue(snapshot.State)),
+	}
+	if snapshot.StartTime != nil {
+		info.CreatedTime = *snapshot.StartTime
+	}
+
+	// Name tag
+	var tagList []irs.KeyValue
+	for _, t := range snapshot.Tags {
+		if aws.StringValue(t.Key) == "Name" {
+			info.IId.NameId = aws.StringValue(t.Value)
+		} else {
+			tagList = append(tagList, irs.KeyValue{Key: aws.StringValue(t.Key), Value: aws.StringValue(t.Value)})
+		}
+	}
+	info.TagList = tagList
+
+	info.KeyValueList = irs.StructToKeyValueList(snapshot)
+	return info
+}
+
+// pending | completed | error | recoverable | recovering
+func convertAwsSnapshotState(state string) irs.DiskSnapshotStatus {
+	switch state {
+	case ec2.SnapshotStateCompleted:
+		return irs.DiskSnapshotAvailable
+	case ec2.SnapshotStateError:
+		return irs.DiskSnapshotError
+	default:
+		return irs.DiskSnapshotCreating
+	}
+}
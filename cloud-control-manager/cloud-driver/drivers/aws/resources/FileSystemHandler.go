@@ -3,12 +3,14 @@ package resources
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/backup"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/efs"
 	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
@@ -17,10 +19,11 @@ import (
 )
 
 type AwsFileSystemHandler struct {
-	Region     idrv.RegionInfo
-	Client     *efs.EFS
-	EC2Client  *ec2.EC2
-	TagHandler *AwsTagHandler // TagHandler add
+	Region       idrv.RegionInfo
+	Client       *efs.EFS
+	BackupClient *backup.Backup // AWS Backup for the file system backups
+	EC2Client    *ec2.EC2
+	TagHandler   *AwsTagHandler // TagHandler add
 }
 
 // GetMetaInfo returns metadata about the file system capabilities
@@ -651,6 +654,11 @@ func (fileSystemHandler *AwsFileSystemHandler) DeleteFileSystem(iid irs.IID) (bo
 	}
 	LoggingInfo(hiscallInfo, start)
 
+	// the scheduled backups of the deleted file system are not needed any more
+	if err := fileSystemHandler.deleteBackupPlan(iid.SystemId); err != nil {
+		cblogger.Warnf("Failed to delete the backup plan of file system %s: %v", iid.SystemId, err)
+	}
+
 	return true, nil
 }
 
@@ -766,56 +774,325 @@ func (fileSystemHandler *AwsFileSystemHandler) ListAccessSubnet(iid irs.IID) ([]
 	return subnetList, nil
 }
 
-// ScheduleBackup creates a backup with the specified schedule
+// ScheduleBackup creates or updates the AWS Backup plan of the file system with the specified schedule
+// AWS Backup needs the IAM role "service-role/AWSBackupDefaultServiceRole" in the account
 func (fileSystemHandler *AwsFileSystemHandler) ScheduleBackup(reqInfo irs.FileSystemBackupInfo) (irs.FileSystemBackupInfo, error) {
 	cblogger.Debug("AWS EFS ScheduleBackup() called")
 
-	// TODO: AWS EFS scheduled backup implementation
-	// According to the specification document, backup functionality will be developed after basic FS create/delete functionality
-	// This would need to be implemented using AWS Lambda + EventBridge for scheduled backups
-	// For now, return an error indicating this feature is planned for future development
-	return irs.FileSystemBackupInfo{}, errors.New("scheduled backups are planned for future development in AWS EFS driver")
+	if reqInfo.Schedule == (irs.CronSchedule{}) {
+		reqInfo.Schedule = irs.CronSchedule{Minute: "0", Hour: "5", DayOfMonth: "*", Month: "*", DayOfWeek: "*"}
+	}
+	scheduleExpression, err := convertToAwsBackupCron(reqInfo.Schedule)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	fsArn, ownerId, err := fileSystemHandler.getFileSystemArn(reqInfo.FileSystemIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	err = fileSystemHandler.ensureBackupVault()
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	planName := awsBackupPlanNamePrefix + reqInfo.FileSystemIID
+	planInput := &backup.PlanInput{
+		BackupPlanName: aws.String(planName),
+		Rules: []*backup.RuleInput{
+			{
+				RuleName:              aws.String("cb-spider-scheduled-backup"),
+				TargetBackupVaultName: aws.String(awsBackupVaultName),
+				ScheduleExpression:    aws.String(scheduleExpression),
+			},
+		},
+	}
+
+	hiscallInfo := GetCallLogScheme(fileSystemHandler.Region, call.FILESYSTEM, reqInfo.FileSystemIID, "ScheduleBackup()")
+	start := call.Start()
+
+	planId, err := fileSystemHandler.findBackupPlan(planName)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	if planId != "" {
+		// the file system already has a plan: change its schedule
+		_, err = fileSystemHandler.BackupClient.UpdateBackupPlan(&backup.UpdateBackupPlanInput{
+			BackupPlanId: aws.String(planId),
+			BackupPlan:   planInput,
+		})
+		if err != nil {
+			cblogger.Error(err)
+			LoggingError(hiscallInfo, err)
+			return irs.FileSystemBackupInfo{}, err
+		}
+	} else {
+		planResult, err := fileSystemHandler.BackupClient.CreateBackupPlan(&backup.CreateBackupPlanInput{
+			BackupPlan: planInput,
+		})
+		if err != nil {
+			cblogger.Error(err)
+			LoggingError(hiscallInfo, err)
+			return irs.FileSystemBackupInfo{}, err
+		}
+		planId = *planResult.BackupPlanId
+
+		_, err = fileSystemHandler.BackupClient.CreateBackupSelection(&backup.CreateBackupSelectionInput{
+			BackupPlanId: aws.String(planId),
+			BackupSelection: &backup.Selection{
+				SelectionName: aws.String(planName),
+				IamRoleArn:    aws.String(getAwsBackupRoleArn(fsArn, ownerId)),
+				Resources:     []*string{aws.String(fsArn)},
+			},
+		})
+		if err != nil {
+			cblogger.Error(err)
+			LoggingError(hiscallInfo, err)
+			// rollback
+			if _, delErr := fileSystemHandler.BackupClient.DeleteBackupPlan(&backup.DeleteBackupPlanInput{BackupPlanId: aws.String(planId)}); delErr != nil {
+				cblogger.Errorf("Failed to delete backup plan %s: %v", planId, delErr)
+			}
+			return irs.FileSystemBackupInfo{}, err
+		}
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return irs.FileSystemBackupInfo{
+		FileSystemIID: reqInfo.FileSystemIID,
+		Schedule:      reqInfo.Schedule,
+		BackupID:      planId, // the scheduled backups are made by this AWS Backup plan
+		CreationTime:  time.Now(),
+		KeyValueList: []irs.KeyValue{
+			{Key: "BackupType", Value: "AWS Backup Plan"},
+			{Key: "BackupPlanName", Value: planName},
+			{Key: "ScheduleExpression", Value: scheduleExpression},
+			{Key: "BackupVaultName", Value: awsBackupVaultName},
+		},
+	}, nil
 }
 
-// OnDemandBackup creates an on-demand backup
+// OnDemandBackup starts an AWS Backup job for the file system
+// The BackupID is the ID of the recovery point, which is available when the job is completed
 func (fileSystemHandler *AwsFileSystemHandler) OnDemandBackup(fsIID irs.IID) (irs.FileSystemBackupInfo, error) {
 	cblogger.Debug("AWS EFS OnDemandBackup() called")
 
-	// TODO: AWS EFS on-demand backup implementation
-	// According to the specification document, backup functionality will be developed after basic FS create/delete functionality
-	// AWS EFS doesn't support traditional backups or snapshots, but could use EFS-to-EFS replication or external backup solutions
-	// For now, return an error indicating this feature is planned for future development
-	return irs.FileSystemBackupInfo{}, errors.New("on-demand backups are planned for future development in AWS EFS driver")
+	fsArn, ownerId, err := fileSystemHandler.getFileSystemArn(fsIID.SystemId)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	err = fileSystemHandler.ensureBackupVault()
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	input := &backup.StartBackupJobInput{
+		BackupVaultName:  aws.String(awsBackupVaultName),
+		ResourceArn:      aws.String(fsArn),
+		IamRoleArn:       aws.String(getAwsBackupRoleArn(fsArn, ownerId)),
+		IdempotencyToken: aws.String(fmt.Sprintf("cb-spider-%s-%d", fsIID.SystemId, time.Now().UnixNano())),
+	}
+
+	hiscallInfo := GetCallLogScheme(fileSystemHandler.Region, call.FILESYSTEM, fsIID.SystemId, "StartBackupJob()")
+	start := call.Start()
+
+	result, err := fileSystemHandler.BackupClient.StartBackupJob(input)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	backupInfo := irs.FileSystemBackupInfo{
+		FileSystemIID: fsIID.SystemId,
+		BackupID:      getRecoveryPointId(aws.StringValue(result.RecoveryPointArn)),
+		KeyValueList: []irs.KeyValue{
+			{Key: "BackupType", Value: "AWS Backup On-Demand"},
+			{Key: "BackupJobId", Value: aws.StringValue(result.BackupJobId)},
+			{Key: "BackupVaultName", Value: awsBackupVaultName},
+			{Key: "RecoveryPointArn", Value: aws.StringValue(result.RecoveryPointArn)},
+		},
+	}
+	if result.CreationDate != nil {
+		backupInfo.CreationTime = *result.CreationDate
+	}
+	return backupInfo, nil
 }
 
-// ListBackup returns list of backups for the file system
+// ListBackup returns the AWS Backup recovery points of the file system
+// It includes the recovery points of the EFS automatic backups
 func (fileSystemHandler *AwsFileSystemHandler) ListBackup(fsIID irs.IID) ([]irs.FileSystemBackupInfo, error) {
 	cblogger.Debug("AWS EFS ListBackup() called")
 
-	// TODO: AWS EFS backup listing implementation
-	// According to the specification document, backup functionality will be developed after basic FS create/delete functionality
-	// For now, return an error indicating this feature is planned for future development
-	return nil, errors.New("backup listing is planned for future development in AWS EFS driver")
+	fsArn, _, err := fileSystemHandler.getFileSystemArn(fsIID.SystemId)
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	recoveryPoints, err := fileSystemHandler.listRecoveryPoints(fsArn)
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	backupInfoList := []irs.FileSystemBackupInfo{}
+	for _, rp := range recoveryPoints {
+		if aws.StringValue(rp.Status) == backup.RecoveryPointStatusDeleting {
+			continue
+		}
+		backupInfoList = append(backupInfoList, convertToFileSystemBackupInfo(fsIID.SystemId, rp))
+	}
+	return backupInfoList, nil
 }
 
-// GetBackup returns specific backup info
+// GetBackup returns the AWS Backup recovery point of the file system
 func (fileSystemHandler *AwsFileSystemHandler) GetBackup(fsIID irs.IID, backupID string) (irs.FileSystemBackupInfo, error) {
 	cblogger.Debug("AWS EFS GetBackup() called")
 
-	// TODO: AWS EFS backup retrieval implementation
-	// According to the specification document, backup functionality will be developed after basic FS create/delete functionality
-	// For now, return an error indicating this feature is planned for future development
-	return irs.FileSystemBackupInfo{}, errors.New("backup retrieval is planned for future development in AWS EFS driver")
+	rp, err := fileSystemHandler.getRecoveryPoint(fsIID.SystemId, backupID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+	return convertToFileSystemBackupInfo(fsIID.SystemId, rp), nil
 }
 
-// DeleteBackup deletes the specified backup
+// DeleteBackup deletes the AWS Backup recovery point of the file system
 func (fileSystemHandler *AwsFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID string) (bool, error) {
 	cblogger.Debug("AWS EFS DeleteBackup() called")
 
-	// TODO: AWS EFS backup deletion implementation
-	// According to the specification document, backup functionality will be developed after basic FS create/delete functionality
-	// For now, return an error indicating this feature is planned for future development
-	return false, errors.New("backup deletion is planned for future development in AWS EFS driver")
+	rp, err := fileSystemHandler.getRecoveryPoint(fsIID.SystemId, backupID)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	hiscallInfo := GetCallLogScheme(fileSystemHandler.Region, call.FILESYSTEM, backupID, "DeleteRecoveryPoint()")
+	start := call.Start()
+
+	_, err = fileSystemHandler.BackupClient.DeleteRecoveryPoint(&backup.DeleteRecoveryPointInput{
+		BackupVaultName:  rp.BackupVaultName,
+		RecoveryPointArn: rp.RecoveryPointArn,
+	})
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return true, nil
+}
+
+// RestoreBackup creates a new file system from the recovery point with AWS Backup StartRestoreJob
+// The new file system gets the mount targets of the source file system
+func (fileSystemHandler *AwsFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	cblogger.Debug("AWS EFS RestoreBackup() called")
+
+	rp, err := fileSystemHandler.getRecoveryPoint(fsIID.SystemId, backupIID.SystemId)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	if aws.StringValue(rp.Status) != backup.RecoveryPointStatusCompleted {
+		err = fmt.Errorf("backup %s is not completed yet (status: %s)", backupIID.SystemId, aws.StringValue(rp.Status))
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	fsArn, ownerId, err := fileSystemHandler.getFileSystemArn(fsIID.SystemId)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	metaResult, err := fileSystemHandler.BackupClient.GetRecoveryPointRestoreMetadata(&backup.GetRecoveryPointRestoreMetadataInput{
+		BackupVaultName:  rp.BackupVaultName,
+		RecoveryPointArn: rp.RecoveryPointArn,
+	})
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	metadata := metaResult.RestoreMetadata
+	if metadata == nil {
+		metadata = map[string]*string{}
+	}
+	metadata["file-system-id"] = aws.String(fsIID.SystemId)
+	metadata["newFileSystem"] = aws.String("true")
+	metadata["CreationToken"] = aws.String(fmt.Sprintf("cb-spider-efs-%d", time.Now().UnixNano()))
+
+	hiscallInfo := GetCallLogScheme(fileSystemHandler.Region, call.FILESYSTEM, backupIID.SystemId, "StartRestoreJob()")
+	start := call.Start()
+
+	restoreResult, err := fileSystemHandler.BackupClient.StartRestoreJob(&backup.StartRestoreJobInput{
+		RecoveryPointArn: rp.RecoveryPointArn,
+		IamRoleArn:       aws.String(getAwsBackupRoleArn(fsArn, ownerId)),
+		ResourceType:     aws.String("EFS"),
+		Metadata:         metadata,
+	})
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.FileSystemInfo{}, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	newFsId, err := fileSystemHandler.waitUntilRestoreJobCompleted(*restoreResult.RestoreJobId)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	newIID := irs.IID{NameId: newFsIID.NameId, SystemId: newFsId}
+
+	err = fileSystemHandler.waitUntilFileSystemAvailable(newFsId)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	if newFsIID.NameId != "" {
+		_, err = fileSystemHandler.Client.TagResource(&efs.TagResourceInput{
+			ResourceId: aws.String(newFsId),
+			Tags:       []*efs.Tag{{Key: aws.String("Name"), Value: aws.String(newFsIID.NameId)}},
+		})
+		if err != nil {
+			cblogger.Errorf("Failed to set the Name tag of the restored file system %s: %v", newFsId, err)
+		}
+	}
+
+	// the restored file system has no mount targets: use the subnets and security groups of the source
+	mountTargets, err := fileSystemHandler.listMountTargets(fsIID.SystemId)
+	if err != nil {
+		cblogger.Errorf("Failed to list mount targets of the source file system %s: %v", fsIID.SystemId, err)
+	}
+	for _, mt := range mountTargets {
+		if mt == nil || mt.SubnetId == nil || mt.MountTargetId == nil {
+			continue
+		}
+		securityGroups, err := fileSystemHandler.getMountTargetSecurityGroups(*mt.MountTargetId)
+		if err != nil {
+			cblogger.Errorf("Failed to get security groups for mount target %s: %v", *mt.MountTargetId, err)
+		}
+		err = fileSystemHandler.createMountTargetWithSecurityGroups(newIID, irs.IID{SystemId: *mt.SubnetId}, securityGroups)
+		if err != nil {
+			cblogger.Errorf("Failed to create mount target of the restored file system %s in subnet %s: %v", newFsId, *mt.SubnetId, err)
+		}
+	}
+
+	return fileSystemHandler.GetFileSystem(newIID)
 }
 
 // Helper functions
@@ -1634,3 +1911,264 @@ func (fileSystemHandler *AwsFileSystemHandler) getSubnetZone(subnetId string) (s
 
 	return *result.Subnets[0].AvailabilityZone, nil
 }
+
+// ---------- AWS Backup helpers for the file system backups
+
+const (
+	awsBackupVaultName      = "Default"        // vault of the on-demand and scheduled backups
+	awsBackupPlanNamePrefix = "cb-spider-efs-" // + file system ID
+	awsBackupRoleName       = "service-role/AWSBackupDefaultServiceRole"
+)
+
+var awsBackupDayOfWeekNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// getFileSystemArn returns the ARN and the owner account ID of the file system
+func (fileSystemHandler *AwsFileSystemHandler) getFileSystemArn(fileSystemId string) (string, string, error) {
+	if fileSystemHandler.BackupClient == nil {
+		return "", "", errors.New("AWS Backup client is not initialized")
+	}
+
+	result, err := fileSystemHandler.Client.DescribeFileSystems(&efs.DescribeFileSystemsInput{
+		FileSystemId: aws.String(fileSystemId),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if len(result.FileSystems) == 0 || result.FileSystems[0].OwnerId == nil {
+		return "", "", fmt.Errorf("file system %s not found", fileSystemId)
+	}
+
+	ownerId := *result.FileSystems[0].OwnerId
+	region := fileSystemHandler.Region.Region
+	return fmt.Sprintf("arn:%s:elasticfilesystem:%s:%s:file-system/%s", getAwsPartition(region), region, ownerId, fileSystemId), ownerId, nil
+}
+
+func getAwsPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// getAwsBackupRoleArn returns the ARN of the IAM role that AWS Backup uses for the backup and restore jobs
+func getAwsBackupRoleArn(fsArn string, ownerId string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", strings.Split(fsArn, ":")[1], ownerId, awsBackupRoleName)
+}
+
+// getRecoveryPointId returns the ID part of the recovery point ARN, used as the BackupID
+// ex) arn:aws:backup:us-east-1:123456789012:recovery-point:1EB3B5E7-9EB0-435A-A80B-108B488B0D45
+func getRecoveryPointId(recoveryPointArn string) string {
+	idx := strings.LastIndex(recoveryPointArn, ":")
+	if idx < 0 {
+		return recoveryPointArn
+	}
+	return recoveryPointArn[idx+1:]
+}
+
+// ensureBackupVault creates the backup vault if it does not exist
+func (fileSystemHandler *AwsFileSystemHandler) ensureBackupVault() error {
+	_, err := fileSystemHandler.BackupClient.DescribeBackupVault(&backup.DescribeBackupVaultInput{
+		BackupVaultName: aws.String(awsBackupVaultName),
+	})
+	if err == nil {
+		return nil
+	}
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != backup.ErrCodeResourceNotFoundException {
+		return err
+	}
+
+	_, err = fileSystemHandler.BackupClient.CreateBackupVault(&backup.CreateBackupVaultInput{
+		BackupVaultName: aws.String(awsBackupVaultName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == backup.ErrCodeAlreadyExistsException {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// findBackupPlan returns the ID of the backup plan with the name, or "" if it does not exist
+func (fileSystemHandler *AwsFileSystemHandler) findBackupPlan(planName string) (string, error) {
+	planId := ""
+	err := fileSystemHandler.BackupClient.ListBackupPlansPages(&backup.ListBackupPlansInput{},
+		func(page *backup.ListBackupPlansOutput, lastPage bool) bool {
+			for _, plan := range page.BackupPlansList {
+				if aws.StringValue(plan.BackupPlanName) == planName {
+					planId = aws.StringValue(plan.BackupPlanId)
+					return false
+				}
+			}
+			return true
+		})
+	return planId, err
+}
+
+// deleteBackupPlan deletes the backup plan of the file system made by ScheduleBackup, if any
+func (fileSystemHandler *AwsFileSystemHandler) deleteBackupPlan(fileSystemId string) error {
+	if fileSystemHandler.BackupClient == nil {
+		return nil
+	}
+
+	planId, err := fileSystemHandler.findBackupPlan(awsBackupPlanNamePrefix + fileSystemId)
+	if err != nil || planId == "" {
+		return err
+	}
+
+	// a backup plan can be deleted after its selections are deleted
+	selections, err := fileSystemHandler.BackupClient.ListBackupSelections(&backup.ListBackupSelectionsInput{
+		BackupPlanId: aws.String(planId),
+	})
+	if err != nil {
+		return err
+	}
+	for _, selection := range selections.BackupSelectionsList {
+		_, err = fileSystemHandler.BackupClient.DeleteBackupSelection(&backup.DeleteBackupSelectionInput{
+			BackupPlanId: aws.String(planId),
+			SelectionId:  selection.SelectionId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fileSystemHandler.BackupClient.DeleteBackupPlan(&backup.DeleteBackupPlanInput{
+		BackupPlanId: aws.String(planId),
+	})
+	return err
+}
+
+// listRecoveryPoints returns all recovery points of the file system in all backup vaults
+func (fileSystemHandler *AwsFileSystemHandler) listRecoveryPoints(fsArn string) ([]*backup.RecoveryPointByResource, error) {
+	hiscallInfo := GetCallLogScheme(fileSystemHandler.Region, call.FILESYSTEM, fsArn, "ListRecoveryPointsByResource()")
+	start := call.Start()
+
+	recoveryPoints := []*backup.RecoveryPointByResource{}
+	err := fileSystemHandler.BackupClient.ListRecoveryPointsByResourcePages(&backup.ListRecoveryPointsByResourceInput{
+		ResourceArn: aws.String(fsArn),
+	}, func(page *backup.ListRecoveryPointsByResourceOutput, lastPage bool) bool {
+		recoveryPoints = append(recoveryPoints, page.RecoveryPoints...)
+		return true
+	})
+	if err != nil {
+		LoggingError(hiscallInfo, err)
+		return nil, err
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return recoveryPoints, nil
+}
+
+// getRecoveryPoint returns the recovery point of the file system by the BackupID or the recovery point ARN
+func (fileSystemHandler *AwsFileSystemHandler) getRecoveryPoint(fileSystemId string, backupID string) (*backup.RecoveryPointByResource, error) {
+	fsArn, _, err := fileSystemHandler.getFileSystemArn(fileSystemId)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryPoints, err := fileSystemHandler.listRecoveryPoints(fsArn)
+	if err != nil {
+		return nil, err
+	}
+	for _, rp := range recoveryPoints {
+		rpArn := aws.StringValue(rp.RecoveryPointArn)
+		if rpArn == backupID || getRecoveryPointId(rpArn) == backupID {
+			return rp, nil
+		}
+	}
+	return nil, fmt.Errorf("backup %s of file system %s not found", backupID, fileSystemId)
+}
+
+func convertToFileSystemBackupInfo(fileSystemId string, rp *backup.RecoveryPointByResource) irs.FileSystemBackupInfo {
+	backupInfo := irs.FileSystemBackupInfo{
+		FileSystemIID: fileSystemId,
+		BackupID:      getRecoveryPointId(aws.StringValue(rp.RecoveryPointArn)),
+		KeyValueList: []irs.KeyValue{
+			{Key: "BackupVaultName", Value: aws.StringValue(rp.BackupVaultName)},
+			{Key: "Status", Value: aws.StringValue(rp.Status)},
+			{Key: "RecoveryPointArn", Value: aws.StringValue(rp.RecoveryPointArn)},
+		},
+	}
+	if rp.CreationDate != nil {
+		backupInfo.CreationTime = *rp.CreationDate
+	}
+	if rp.BackupSizeBytes != nil {
+		backupInfo.KeyValueList = append(backupInfo.KeyValueList, irs.KeyValue{Key: "BackupSizeBytes", Value: strconv.FormatInt(*rp.BackupSizeBytes, 10)})
+	}
+	return backupInfo
+}
+
+// convertToAwsBackupCron converts the CronSchedule into the AWS Backup schedule expression
+// ex) {0 5 * * 1} => cron(0 5 ? * MON *)
+// AWS Backup needs '?' in one of DayOfMonth and DayOfWeek, so both can not be set together
+func convertToAwsBackupCron(schedule irs.CronSchedule) (string, error) {
+	orDefault := func(value string, defaultValue string) string {
+		if strings.TrimSpace(value) == "" {
+			return defaultValue
+		}
+		return strings.TrimSpace(value)
+	}
+	minute := orDefault(schedule.Minute, "0")
+	hour := orDefault(schedule.Hour, "5")
+	dayOfMonth := orDefault(schedule.DayOfMonth, "*")
+	month := orDefault(schedule.Month, "*")
+	dayOfWeek := orDefault(schedule.DayOfWeek, "*")
+
+	if dayOfWeek == "*" {
+		dayOfWeek = "?"
+	} else if dayOfMonth == "*" {
+		dayOfMonth = "?"
+		// Cron DayOfWeek(0-6, Sunday=0) => AWS Backup DayOfWeek(SUN-SAT)
+		var convErr error
+		dayOfWeek = regexp.MustCompile(`\d+`).ReplaceAllStringFunc(dayOfWeek, func(day string) string {
+			n, err := strconv.Atoi(day)
+			if err != nil || n > 7 {
+				convErr = fmt.Errorf("invalid DayOfWeek: %s", schedule.DayOfWeek)
+				return day
+			}
+			return awsBackupDayOfWeekNames[n%7]
+		})
+		if convErr != nil {
+			return "", convErr
+		}
+	} else {
+		return "", errors.New("AWS Backup does not support both DayOfMonth and DayOfWeek in a schedule")
+	}
+
+	return fmt.Sprintf("cron(%s %s %s %s %s *)", minute, hour, dayOfMonth, month, dayOfWeek), nil
+}
+
+// waitUntilRestoreJobCompleted waits for the restore job and returns the ID of the restored file system
+func (fileSystemHandler *AwsFileSystemHandler) waitUntilRestoreJobCompleted(restoreJobId string) (string, error) {
+	cblogger.Infof("Waiting for restore job %s to be completed...", restoreJobId)
+
+	for {
+		result, err := fileSystemHandler.BackupClient.DescribeRestoreJob(&backup.DescribeRestoreJobInput{
+			RestoreJobId: aws.String(restoreJobId),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		switch aws.StringValue(result.Status) {
+		case backup.RestoreJobStatusCompleted:
+			// ex) arn:aws:elasticfilesystem:us-east-1:123456789012:file-system/fs-0123456789abcdef0
+			createdArn := aws.StringValue(result.CreatedResourceArn)
+			idx := strings.LastIndex(createdArn, "/")
+			if idx < 0 {
+				return "", fmt.Errorf("restore job %s returned an invalid resource ARN: %s", restoreJobId, createdArn)
+			}
+			cblogger.Info("Restore job is now completed")
+			return createdArn[idx+1:], nil
+		case backup.RestoreJobStatusAborted, backup.RestoreJobStatusFailed:
+			return "", fmt.Errorf("restore job %s %s: %s", restoreJobId, strings.ToLower(aws.StringValue(result.Status)), aws.StringValue(result.StatusMessage))
+		}
+
+		time.Sleep(10 * time.Second)
+	}
+}
@@ -224,6 +224,55 @@ func (keyPairHandler *AwsKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairReq
 	return keyPairInfo, nil
 }
 
+// ImportKey registers the user's public key with ImportKeyPair().
+// AWS returns the MD5 fingerprint of the imported public key.
+func (keyPairHandler *AwsKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Debug(keyIID)
+
+	// logger for HisCall
+	callogger := call.GetLogger("HISCALL")
+	callLogInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.AWS,
+		RegionZone:   keyPairHandler.Region.Zone,
+		ResourceType: call.VMKEYPAIR,
+		ResourceName: keyIID.NameId,
+		CloudOSAPI:   "ImportKeyPair()",
+		ElapsedTime:  "",
+		ErrorMSG:     "",
+	}
+	callLogStart := call.Start()
+
+	result, err := keyPairHandler.Client.ImportKeyPair(&ec2.ImportKeyPairInput{
+		KeyName:           aws.String(keyIID.NameId),
+		PublicKeyMaterial: []byte(publicKey),
+	})
+	callLogInfo.ElapsedTime = call.Elapsed(callLogStart)
+
+	if err != nil {
+		callLogInfo.ErrorMSG = err.Error()
+		callogger.Info(call.String(callLogInfo))
+
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "InvalidKeyPair.Duplicate" {
+			cblogger.Errorf("Keypair %q already exists.", keyIID.NameId)
+			return irs.KeyPairInfo{}, err
+		}
+		cblogger.Errorf("Unable to import key pair: %s, %v.", keyIID.NameId, err)
+		return irs.KeyPairInfo{}, err
+	}
+	callogger.Info(call.String(callLogInfo))
+
+	cblogger.Infof("Imported key pair %q %s", aws.StringValue(result.KeyName), aws.StringValue(result.KeyFingerprint))
+
+	keyPairInfo := irs.KeyPairInfo{
+		IId:         irs.IID{NameId: keyIID.NameId, SystemId: aws.StringValue(result.KeyName)},
+		Fingerprint: aws.StringValue(result.KeyFingerprint),
+		PublicKey:   publicKey,
+	}
+	keyPairInfo.KeyValueList = irs.StructToKeyValueList(result)
+
+	return keyPairInfo, nil
+}
+
 // 2021-10-26 이슈#480에 의해 Local Key 로직 제거
 // 혼선을 피하기 위해 keyPairID 대신 keyName으로 변경 함.
 func (keyPairHandler *AwsKeyPairHandler) GetKey(keyIID irs.IID) (irs.KeyPairInfo, error) {
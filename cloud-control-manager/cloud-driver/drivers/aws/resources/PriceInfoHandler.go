@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
 	"github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
@@ -14,12 +15,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/pricing"
 )
 
 type AwsPriceInfoHandler struct {
-	Region idrv.RegionInfo
-	Client *pricing.Pricing
+	Region    idrv.RegionInfo
+	Client    *pricing.Pricing
+	EC2Client *ec2.EC2 // for the Spot prices, which are published only by the EC2 API of each region
 }
 
 func (priceInfoHandler *AwsPriceInfoHandler) ListProductFamily(regionName string) ([]string, error) {
@@ -256,6 +259,14 @@ func (priceInfoHandler *AwsPriceInfoHandler) GetPriceInfo(productFamily string,
 		return "", err
 	}
 
+	if priceInfoHandler.EC2Client != nil && currentRegion == priceInfoHandler.Region.Region {
+		err = priceInfoHandler.setSpotPrice(priceMap)
+		if err != nil {
+			// the OnDemand prices are returned without the Spot prices
+			cblogger.Error("Failed to get the Spot prices", err)
+		}
+	}
+
 	priceList := []irs.Price{}
 	for _, value := range priceMap {
 		priceList = append(priceList, value)
@@ -271,6 +282,59 @@ func (priceInfoHandler *AwsPriceInfoHandler) GetPriceInfo(productFamily string,
 	return string(resultString), nil
 }
 
+// setSpotPrice sets the current lowest Spot price among the zones of the region to the price of each instance type.
+func (priceInfoHandler *AwsPriceInfoHandler) setSpotPrice(priceMap map[string]irs.Price) error {
+	spotPriceMap := make(map[string]*ec2.SpotPrice)
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		ProductDescriptions: aws.StringSlice([]string{"Linux/UNIX"}),
+		StartTime:           aws.Time(time.Now()), // only the current prices
+	}
+	err := priceInfoHandler.EC2Client.DescribeSpotPriceHistoryPages(input,
+		func(page *ec2.DescribeSpotPriceHistoryOutput, lastPage bool) bool {
+			for _, spotPrice := range page.SpotPriceHistory {
+				if spotPrice.InstanceType == nil || parseSpotPrice(aws.StringValue(spotPrice.SpotPrice)) <= 0 {
+					continue
+				}
+				prev, ok := spotPriceMap[*spotPrice.InstanceType]
+				if !ok || parseSpotPrice(*spotPrice.SpotPrice) < parseSpotPrice(*prev.SpotPrice) {
+					spotPriceMap[*spotPrice.InstanceType] = spotPrice
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return err
+	}
+
+	for productId, price := range priceMap {
+		instanceType := price.ProductInfo.VMSpecName
+		if price.ProductInfo.VMSpecInfo != nil {
+			instanceType = price.ProductInfo.VMSpecInfo.Name
+		}
+		spotPrice, ok := spotPriceMap[instanceType]
+		if !ok {
+			continue
+		}
+		price.PriceInfo.Spot = &irs.Spot{
+			PricingId:   "spot-" + instanceType,
+			Unit:        "Hour",
+			Currency:    "USD",
+			Price:       strconv.FormatFloat(parseSpotPrice(*spotPrice.SpotPrice), 'f', -1, 64),
+			Description: fmt.Sprintf("Lowest Spot price in %s", aws.StringValue(spotPrice.AvailabilityZone)),
+		}
+		priceMap[productId] = price
+	}
+	return nil
+}
+
+func parseSpotPrice(price string) float64 {
+	priceFloat, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return 0
+	}
+	return priceFloat
+}
+
 func setProductsInputRequestFilter(filterList []irs.KeyValue) ([]*pricing.Filter, error) {
 	requestFilters := []*pricing.Filter{}
 
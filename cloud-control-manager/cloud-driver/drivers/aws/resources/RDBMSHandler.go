@@ -143,7 +143,7 @@ func (handler *AwsRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaInfo,
 		Max: irs.GiBToGB(storageSizeRange.Max),
 	}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, true, "0-35", true, true, true, true, true)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, true, "0-35", true, true, true, true, true, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -562,6 +562,65 @@ func (handler *AwsRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error) {
 	return true, nil
 }
 
+// ChangeSpec changes the instance class of an RDBMS instance.
+// The change is applied immediately, and the instance is in modifying status until it is done.
+func (handler *AwsRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "ModifyDBInstance()")
+	start := call.Start()
+
+	input := &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(rdbmsIID.SystemId),
+		DBInstanceClass:      aws.String(newSpec),
+		ApplyImmediately:     aws.Bool(true),
+	}
+
+	result, err := handler.Client.ModifyDBInstance(input)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	if result.DBInstance == nil {
+		return handler.GetRDBMS(rdbmsIID)
+	}
+	return handler.convertDBInstanceToRDBMSInfo(result.DBInstance), nil
+}
+
+// ChangeStorageSize expands the allocated storage(GB) of an RDBMS instance.
+// RDS does not support shrinking the storage.
+func (handler *AwsRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "ModifyDBInstance()")
+	start := call.Start()
+
+	size, err := strconv.ParseInt(newSize, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	input := &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(rdbmsIID.SystemId),
+		AllocatedStorage:     aws.Int64(size),
+		ApplyImmediately:     aws.Bool(true),
+	}
+
+	_, err = handler.Client.ModifyDBInstance(input)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return true, nil
+}
+
 // ===== Helper Functions =====
 
 // ensureVPCDnsHostnames checks whether the VPC has EnableDnsHostnames enabled.
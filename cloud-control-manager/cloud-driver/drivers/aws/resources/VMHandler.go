@@ -13,6 +13,7 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -311,9 +312,17 @@ func (vmHandler *AwsVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 	//OS 종류에 따른 Cloud Init Data 처리
 	if isWindowsImage {
 		userData = strings.Replace(string(fileDataCloudInit), "*PASSWORD*", vmReqInfo.VMUserPasswd, 1)
-		cblogger.Debugf("Windows Cloud-Init : [%s]", userData)
+		userData, err = mergeWindowsUserData(userData, vmReqInfo.UserData)
+		if err != nil {
+			cblogger.Error(err)
+			return irs.VMInfo{}, err
+		}
 	} else {
-		userData = string(fileDataCloudInit)
+		userData, err = cdcom.MergeUserDataWithLimit("AWS", string(fileDataCloudInit), vmReqInfo.UserData)
+		if err != nil {
+			cblogger.Error(err)
+			return irs.VMInfo{}, err
+		}
 	}
 
 	//userData = strings.ReplaceAll(userData, "{{username}}", CBDefaultVmUserName)
@@ -375,6 +384,10 @@ func (vmHandler *AwsVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 		TagSpecifications: tagSpecifications,
 	}
 
+	if vmReqInfo.PurchaseOption.LifecycleType == irs.SpotVM {
+		input.InstanceMarketOptions = getSpotMarketOptions(vmReqInfo.PurchaseOption)
+	}
+
 	//=============================
 	// SystemDisk 처리 - 이슈 #348에 의해 RootDisk 기능 지원
 	//=============================
@@ -586,6 +599,74 @@ func (vmHandler *AwsVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 	return newVmInfo, nil
 }
 
+// windowsUserDataBlockRegex matches the blocks of a Windows user data which EC2Launch runs.
+var windowsUserDataBlockRegex = regexp.MustCompile(`(?s)<(powershell|script|persist)>(.*?)</(powershell|script|persist)>`)
+
+// mergeWindowsUserData adds the UserData of a Windows VM to the user data of the driver, which sets the password.
+// EC2Launch does not run a MIME multipart user data, so the PowerShell of the UserData, a plain script or
+// <powershell> blocks, runs in the <powershell> block of the driver after the password is set,
+// and <script> blocks(cmd) are kept as they are. <persist> of the UserData is ignored,
+// the <persist>true</persist> of the driver runs the UserData at every boot.
+func mergeWindowsUserData(initUserData string, userData string) (string, error) {
+	if userData == "" {
+		return initUserData, nil
+	}
+
+	var psList, scriptList []string
+	blockList := windowsUserDataBlockRegex.FindAllStringSubmatch(userData, -1)
+	if len(blockList) == 0 {
+		psList = append(psList, strings.TrimSpace(userData))
+	}
+	for _, block := range blockList {
+		if block[1] != block[3] {
+			return "", fmt.Errorf("UserData of a Windows image has the mismatched block <%s>...</%s>", block[1], block[3])
+		}
+		switch block[1] {
+		case "powershell":
+			psList = append(psList, strings.TrimSpace(block[2]))
+		case "script":
+			scriptList = append(scriptList, block[0])
+		}
+	}
+	if len(blockList) > 0 && strings.TrimSpace(windowsUserDataBlockRegex.ReplaceAllString(userData, "")) != "" {
+		return "", errors.New("UserData of a Windows image should be a PowerShell script, or <powershell> and <script> blocks")
+	}
+
+	end := strings.LastIndex(initUserData, "</powershell>")
+	if end < 0 {
+		return "", errors.New("the Windows cloud-init template of the driver has no <powershell> block")
+	}
+	merged := initUserData[:end] + strings.Join(psList, "\n") + "\n" + initUserData[end:]
+	if len(scriptList) > 0 {
+		merged = strings.TrimRight(merged, "\n") + "\n" + strings.Join(scriptList, "\n") + "\n"
+	}
+	if err := cdcom.CheckUserDataSize("AWS", merged); err != nil {
+		return "", fmt.Errorf("%v, with the init script of the driver (%d bytes of UserData)", err, len(userData))
+	}
+	return merged, nil
+}
+
+// getSpotMarketOptions returns the market options of a Spot instance.
+// The Stop behavior needs a persistent Spot request, which is canceled by TerminateVM.
+func getSpotMarketOptions(purchaseOption irs.VMPurchaseOption) *ec2.InstanceMarketOptionsRequest {
+	spotOptions := &ec2.SpotMarketOptions{
+		SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+		InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+	}
+	if purchaseOption.InterruptionBehavior == irs.SpotStop {
+		spotOptions.SpotInstanceType = aws.String(ec2.SpotInstanceTypePersistent)
+		spotOptions.InstanceInterruptionBehavior = aws.String(ec2.InstanceInterruptionBehaviorStop)
+	}
+	if purchaseOption.MaxPrice != "" {
+		spotOptions.MaxPrice = aws.String(purchaseOption.MaxPrice)
+	}
+
+	return &ec2.InstanceMarketOptionsRequest{
+		MarketType:  aws.String(ec2.MarketTypeSpot),
+		SpotOptions: spotOptions,
+	}
+}
+
 // VM이 Running 상태일때까지 대기 함.
 func WaitForRun(svc *ec2.EC2, instanceID string) {
 	cblogger.Infof("EC2 ID : [%s]", instanceID)
@@ -803,6 +884,18 @@ func (vmHandler *AwsVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, error)
 	vmID := vmIID.SystemId
 	cblogger.Infof("vmID : [%s]", vmID)
 
+	// a persistent Spot request launches a new instance after the termination, so it is canceled first
+	instance, err := DescribeInstanceById(vmHandler.Client, vmIID)
+	if err == nil && instance.SpotInstanceRequestId != nil {
+		_, err = vmHandler.Client.CancelSpotInstanceRequests(&ec2.CancelSpotInstanceRequestsInput{
+			SpotInstanceRequestIds: []*string{instance.SpotInstanceRequestId},
+		})
+		if err != nil {
+			cblogger.Error("Could not cancel the Spot request", err)
+			return irs.VMStatus("Failed"), err
+		}
+	}
+
 	input := &ec2.TerminateInstancesInput{
 		//InstanceIds: instanceIds,
 		InstanceIds: []*string{
@@ -838,6 +931,67 @@ func (vmHandler *AwsVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, error)
 	return irs.VMStatus("Terminating"), nil
 }
 
+// ChangeVMSpec changes the instance type of the VM.
+// EC2 changes the instance type only of a stopped instance, so a running VM is stopped and started again.
+func (vmHandler *AwsVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Infof("vmNameId : [%s], vmSpecName : [%s]", vmIID.NameId, vmSpecName)
+
+	instance, err := DescribeInstanceById(vmHandler.Client, vmIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+	input := &ec2.DescribeInstancesInput{InstanceIds: []*string{instance.InstanceId}}
+
+	wasRunning := false
+	switch state := aws.StringValue(instance.State.Name); state {
+	case ec2.InstanceStateNameRunning:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.Client.WaitUntilInstanceStopped(input); err != nil {
+			cblogger.Error(err)
+			return irs.VMInfo{}, err
+		}
+	case ec2.InstanceStateNameStopped:
+	default:
+		err := fmt.Errorf("VM %s is %s, the spec can be changed only when it is running or stopped", vmIID.SystemId, state)
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	hiscallInfo := GetCallLogScheme(vmHandler.Region, call.VM, vmIID.SystemId, "ModifyInstanceAttribute()")
+	start := call.Start()
+	_, err = vmHandler.Client.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+		InstanceId:   instance.InstanceId,
+		InstanceType: &ec2.AttributeValue{Value: aws.String(vmSpecName)},
+	})
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		if wasRunning {
+			// start the VM with the original spec
+			vmHandler.ResumeVM(vmIID)
+		}
+		return irs.VMInfo{}, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.Client.WaitUntilInstanceRunning(input); err != nil {
+			cblogger.Error(err)
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmIID)
+}
+
 // https://docs.aws.amazon.com/ko_kr/AWSEC2/latest/APIReference/API_GetPasswordData.html
 // https://awscli.amazonaws.com/v2/documentation/api/latest/reference/ec2/get-password-data.html
 // @TODO : ssh key를 이용해서 암호가 해독된 Password를 조회해야 함.
@@ -1214,6 +1368,11 @@ func (vmHandler *AwsVMHandler) ExtractDescribeInstanceToVmInfo(instance *ec2.Ins
 		vmInfo.Platform = irs.LINUX_UNIX
 	}
 
+	vmInfo.LifecycleType = irs.OnDemandVM
+	if instance.InstanceLifecycle != nil && *instance.InstanceLifecycle == ec2.InstanceLifecycleTypeSpot {
+		vmInfo.LifecycleType = irs.SpotVM
+	}
+
 	// if !reflect.ValueOf(instance.VirtualizationType).IsNil() {
 	// 	keyValueList = append(keyValueList, irs.KeyValue{Key: "VirtualizationType", Value: *instance.VirtualizationType})
 	// }
@@ -107,9 +107,13 @@ func (AzureDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.QuotaInfoHandler = true
 
 	drvCapabilityInfo.PublicIPHandler = true
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	drvCapabilityInfo.VPC_CIDR = true
 
+	drvCapabilityInfo.VM_USERDATA = true
+	drvCapabilityInfo.SPOT_VM = true
+
 	return drvCapabilityInfo
 }
 
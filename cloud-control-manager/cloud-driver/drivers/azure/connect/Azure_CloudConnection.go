@@ -350,3 +350,7 @@ func (cloudConn *AzureCloudConnection) CreatePublicIPHandler() (irs.PublicIPHand
 	}
 	return &handler, nil
 }
+
+func (cloudConn *AzureCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("Azure Driver: DiskSnapshotHandler not implemented yet")
+}
@@ -695,3 +695,6 @@ func (af *AzureFileSystemHandler) GetBackup(fsIID irs.IID, backupID string) (irs
 func (af *AzureFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID string) (bool, error) {
 	return false, nil
 }
+func (af *AzureFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	return irs.FileSystemInfo{}, fmt.Errorf("backup restore is not supported in Azure Files driver")
+}
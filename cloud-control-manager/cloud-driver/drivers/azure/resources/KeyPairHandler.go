@@ -108,6 +108,63 @@ func (keyPairHandler *AzureKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairR
 	return *keyPairInfo, nil
 }
 
+// ImportKey creates an Azure SSH Public Key resource with the user's public key.
+func (keyPairHandler *AzureKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	hiscallInfo := GetCallLogScheme(keyPairHandler.Region, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+	// 0. Check keyIID
+	err := checkKeyPairReqInfo(irs.KeyPairReqInfo{IId: keyIID})
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	// 1. Check Exist
+	exist, err := CheckExistKey(keyIID, keyPairHandler.Region.Region, keyPairHandler.Client, keyPairHandler.Ctx)
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	if exist {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = The Key already exist"))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	// 2. Set KeyPairData with the public key
+	createOpt := armcompute.SSHPublicKeyResource{
+		Location: &keyPairHandler.Region.Region,
+		Properties: &armcompute.SSHPublicKeyResourceProperties{
+			PublicKey: toStrPtr(publicKey),
+		},
+	}
+
+	start := call.Start()
+	// 3. Import KeyPair(Azure SSH Resource)
+	keyResult, err := keyPairHandler.Client.Create(keyPairHandler.Ctx, keyPairHandler.Region.Region, keyIID.NameId, createOpt, nil)
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	// 4. Set keyPairInfo
+	keyPairInfo, err := keyPairHandler.setterKey(&keyResult.SSHPublicKeyResource, "")
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	LoggingInfo(hiscallInfo, start)
+	return *keyPairInfo, nil
+}
+
 func (keyPairHandler *AzureKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	hiscallInfo := GetCallLogScheme(keyPairHandler.Region, call.VMKEYPAIR, KeyPair, "ListKey()")
 	start := call.Start()
@@ -270,6 +270,18 @@ func (priceInfoHandler *AzurePriceInfoHandler) GetPriceInfo(productFamily string
 		organized[item.SkuID] = append(organized[item.SkuID], item)
 	}
 
+	// Spot prices are published as the separated "Spot" SKUs of the same VM size
+	spotPriceMap := make(map[string]Item)
+	for _, item := range priceInfo.Items {
+		if !strings.Contains(item.SkuName, "Spot") || strings.Contains(item.ProductName, "Windows") ||
+			strings.Contains(item.ProductName, "Cloud Services") || item.RetailPrice <= 0 {
+			continue
+		}
+		if prev, ok := spotPriceMap[item.ArmSkuName]; !ok || item.RetailPrice < prev.RetailPrice {
+			spotPriceMap[item.ArmSkuName] = item
+		}
+	}
+
 	var priceList []irs.Price
 	for _, value := range organized {
 		if len(value) == 0 {
@@ -409,11 +421,22 @@ func (priceInfoHandler *AzurePriceInfoHandler) GetPriceInfo(productFamily string
 		}
 
 		if picked {
+			var spot *irs.Spot
+			if spotItem, ok := spotPriceMap[value[0].ArmSkuName]; ok {
+				spot = &irs.Spot{
+					PricingId:   spotItem.SkuID,
+					Unit:        strings.TrimPrefix(spotItem.UnitOfMeasure, "1 "),
+					Currency:    spotItem.CurrencyCode,
+					Price:       strconv.FormatFloat(spotItem.RetailPrice, 'f', 4, 64),
+					Description: spotItem.SkuName,
+				}
+			}
 			priceList = append(priceList, irs.Price{
 				ZoneName:    "NA",
 				ProductInfo: productInfo,
 				PriceInfo: irs.PriceInfo{
 					OnDemand:     onDemand,
+					Spot:         spot,
 					CSPPriceInfo: value,
 				},
 			})
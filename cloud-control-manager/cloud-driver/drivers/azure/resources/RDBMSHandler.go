@@ -79,7 +79,7 @@ func (handler *AzureRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaInf
 	// Azure storageSku is read-only and set automatically; not user-selectable
 	storageTypeOptions := map[string][]string{"mysql": {"NA"}}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, map[string][]string{"mysql": versions}, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, false, true, "1-35", false, false, false, true, true)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, map[string][]string{"mysql": versions}, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, false, true, "1-35", false, false, false, true, true, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -581,6 +581,73 @@ func (handler *AzureRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error) {
 	return true, nil
 }
 
+// ChangeSpec changes the SKU of a MySQL Flexible Server.
+// Azure restarts the server to apply the new SKU.
+func (handler *AzureRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	parameters := armmysqlfs.ServerForUpdate{
+		SKU: &armmysqlfs.SKU{
+			Name: &newSpec,
+			Tier: skuTierFromSpec(newSpec),
+		},
+	}
+
+	resp, err := handler.updateServer(rdbmsIID, parameters)
+	if err != nil {
+		return irs.RDBMSInfo{}, err
+	}
+	return handler.convertToRDBMSInfo(&resp.Server), nil
+}
+
+// ChangeStorageSize expands the storage(GB) of a MySQL Flexible Server.
+// Azure does not support shrinking the storage.
+func (handler *AzureRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	size, err := strconv.ParseInt(newSize, 10, 32)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		return false, err
+	}
+	storageSizeGB32 := int32(size)
+
+	parameters := armmysqlfs.ServerForUpdate{
+		Properties: &armmysqlfs.ServerPropertiesForUpdate{
+			Storage: &armmysqlfs.Storage{
+				StorageSizeGB: &storageSizeGB32,
+			},
+		},
+	}
+
+	_, err = handler.updateServer(rdbmsIID, parameters)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateServer updates a MySQL Flexible Server and waits until the update is done.
+func (handler *AzureRDBMSHandler) updateServer(rdbmsIID irs.IID, parameters armmysqlfs.ServerForUpdate) (armmysqlfs.ServersClientUpdateResponse, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "Servers.BeginUpdate()")
+	start := call.Start()
+
+	resourceGroup := handler.Region.Region
+
+	poller, err := handler.ServersClient.BeginUpdate(handler.Ctx, resourceGroup, rdbmsIID.SystemId, parameters, nil)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return armmysqlfs.ServersClientUpdateResponse{}, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	resp, err := poller.PollUntilDone(handler.Ctx, nil)
+	if err != nil {
+		cblogger.Error(err)
+		return armmysqlfs.ServersClientUpdateResponse{}, fmt.Errorf("failed waiting for server update: %w", err)
+	}
+	return resp, nil
+}
+
 // ===== Helper Functions =====
 
 // ensureSubnetDelegation checks whether the subnet is delegated to Microsoft.DBforMySQL/flexibleServers.
@@ -12,6 +12,7 @@ package resources
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -98,6 +99,17 @@ func (vmHandler *AzureVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, e
 		LoggingError(hiscallInfo, createErr)
 		return irs.VMInfo{}, createErr
 	}
+	// 1-3. Spot MaxPrice, -1: up to the pay-as-you-go price, evicted only by the capacity
+	spotMaxPrice := float64(-1)
+	if vmReqInfo.PurchaseOption.LifecycleType == irs.SpotVM && vmReqInfo.PurchaseOption.MaxPrice != "" {
+		spotMaxPrice, err = strconv.ParseFloat(vmReqInfo.PurchaseOption.MaxPrice, 64)
+		if err != nil {
+			createErr := errors.New(fmt.Sprintf("Failed to Create VM. err = invalid MaxPrice %s", vmReqInfo.PurchaseOption.MaxPrice))
+			cblogger.Error(createErr.Error())
+			LoggingError(hiscallInfo, createErr)
+			return irs.VMInfo{}, createErr
+		}
+	}
 	vmImage := vmReqInfo.ImageIID.SystemId
 	if vmImage == "" {
 		vmImage = vmReqInfo.ImageIID.NameId
@@ -229,6 +241,20 @@ func (vmHandler *AzureVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, e
 			},
 		},
 	}
+	if vmReqInfo.UserData != "" {
+		// CustomData is processed by cloud-init on Linux, and saved to C:\AzureData\CustomData.bin on Windows
+		vmOpts.Properties.OSProfile.CustomData = toStrPtr(base64.StdEncoding.EncodeToString([]byte(vmReqInfo.UserData)))
+	}
+	if vmReqInfo.PurchaseOption.LifecycleType == irs.SpotVM {
+		evictionPolicy := armcompute.VirtualMachineEvictionPolicyTypesDelete
+		if vmReqInfo.PurchaseOption.InterruptionBehavior == irs.SpotStop {
+			evictionPolicy = armcompute.VirtualMachineEvictionPolicyTypesDeallocate
+		}
+		priority := armcompute.VirtualMachinePriorityTypesSpot
+		vmOpts.Properties.Priority = &priority
+		vmOpts.Properties.EvictionPolicy = &evictionPolicy
+		vmOpts.Properties.BillingProfile = &armcompute.BillingProfile{MaxPrice: &spotMaxPrice}
+	}
 
 	// Setting zone if available
 	if vmHandler.Region.TargetZone != "" {
@@ -763,6 +789,55 @@ func (vmHandler *AzureVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, error
 	return irs.NotExist, nil
 }
 
+// ChangeVMSpec changes the size of the VM.
+// Azure resizes a running VM with a restart, so the VM is not stopped by the driver.
+func (vmHandler *AzureVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	// log HisCall
+	hiscallInfo := GetCallLogScheme(vmHandler.Region, call.VM, vmIID.NameId, "ChangeVMSpec()")
+
+	convertedIID, err := ConvertVMIID(vmIID, vmHandler.CredentialInfo, vmHandler.Region)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to Change VM Spec. err = %s", err))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	if strings.Contains(convertedIID.SystemId, "/virtualMachineScaleSets/") {
+		changeErr := errors.New("Failed to Change VM Spec. err = the spec of a VM in a scale set is changed by the scale set")
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+
+	vmSize := armcompute.VirtualMachineSizeTypes(vmSpecName)
+	updateOpts := armcompute.VirtualMachineUpdate{
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: &vmSize,
+			},
+		},
+	}
+
+	start := call.Start()
+	poller, err := vmHandler.Client.BeginUpdate(vmHandler.Ctx, vmHandler.Region.Region, convertedIID.NameId, updateOpts, nil)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to Change VM Spec. err = %s", err))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	_, err = poller.PollUntilDone(vmHandler.Ctx, nil)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to Change VM Spec. err = %s", err))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return vmHandler.GetVM(vmIID)
+}
+
 func (vmHandler *AzureVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	// log HisCall
 	hiscallInfo := GetCallLogScheme(vmHandler.Region, call.VM, VM, "ListVMStatus()")
@@ -1224,6 +1299,11 @@ func (vmHandler *AzureVMHandler) mappingServerInfo(server armcompute.VirtualMach
 		}
 		vmInfo.DataDiskIIDs = dataDiskIIDList
 	}
+	vmInfo.LifecycleType = irs.OnDemandVM
+	if server.Properties != nil && server.Properties.Priority != nil &&
+		(*server.Properties.Priority == armcompute.VirtualMachinePriorityTypesSpot || *server.Properties.Priority == armcompute.VirtualMachinePriorityTypesLow) {
+		vmInfo.LifecycleType = irs.SpotVM
+	}
 	osPlatform := getOSTypeByVM(server)
 	vmInfo.Platform = osPlatform
 	if vmInfo.PublicIP != "" {
@@ -83,9 +83,13 @@ func (GCPDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false // implemented but not yet validated
 	drvCapabilityInfo.PublicIPHandler = false
 	drvCapabilityInfo.NICHandler = false
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	drvCapabilityInfo.VPC_CIDR = false
 
+	drvCapabilityInfo.VM_USERDATA = true
+	drvCapabilityInfo.SPOT_VM = true
+
 	return drvCapabilityInfo
 }
 
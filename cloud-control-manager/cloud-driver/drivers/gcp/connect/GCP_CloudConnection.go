@@ -13,6 +13,7 @@ package connect
 
 import (
 	"context"
+	"errors"
 
 	filestore "cloud.google.com/go/filestore/apiv1"
 	cblog "github.com/cloud-barista/cb-log"
@@ -114,6 +115,10 @@ func (cloudConn *GCPCloudConnection) CreatePublicIPHandler() (irs.PublicIPHandle
 	return &handler, nil
 }
 
+func (cloudConn *GCPCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: DiskSnapshotHandler not implemented yet")
+}
+
 func (cloudConn *GCPCloudConnection) CreateVMHandler() (irs.VMHandler, error) {
 	cblogger.Info("GCP Cloud Driver: called CreateVMHandler()!")
 	vmHandler := gcprs.GCPVMHandler{cloudConn.Region, cloudConn.Ctx, cloudConn.VMClient, cloudConn.Credential}
@@ -591,6 +591,9 @@ func (fsHandler *GCPFileSystemHandler) GetBackup(fsIID irs.IID, backupID string)
 func (fsHandler *GCPFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID string) (bool, error) {
 	return false, nil
 }
+func (fsHandler *GCPFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	return irs.FileSystemInfo{}, errors.New("backup restore is not supported in GCP Filestore driver")
+}
 
 func getTier(tier string) filestorepb.Instance_Tier {
 	switch tier {
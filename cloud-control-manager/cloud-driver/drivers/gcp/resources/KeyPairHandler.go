@@ -114,6 +114,45 @@ func (keyPairHandler *GCPKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairReq
 	return keyPairInfo, nil
 }
 
+// GCP has no KeyPair resource, so the imported public key is saved in the local key store
+// and set to the metadata of a VM like a generated KeyPair.
+func (keyPairHandler *GCPKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	keyPairName := strings.ToLower(keyIID.NameId)
+	cblogger.Infof("keyPairName [%s] --> [%s]", keyIID.NameId, keyPairName)
+
+	hashString, err := CreateHashString(keyPairHandler.CredentialInfo)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	//중복 체크
+	_, err = keypair.GetKey(CBKeyPairProvider, hashString, keyPairName)
+	if err == nil {
+		importErr := errors.New(fmt.Sprintf("KeyPair with name %s already exist", keyPairName))
+		cblogger.Error(importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	if !strings.Contains(err.Error(), "does not exist") {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	publicKey, _, err = keypair.ParsePublicKey(publicKey)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	err = keypair.ImportKey(CBKeyPairProvider, hashString, keyPairName, publicKey)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	return keyPairHandler.GetKey(irs.IID{SystemId: keyPairName})
+}
+
 func (keyPairHandler *GCPKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	hashString, err := CreateHashString(keyPairHandler.CredentialInfo)
 	if err != nil {
@@ -169,6 +208,17 @@ func (keyPairHandler *GCPKeyPairHandler) GetKey(keyIID irs.IID) (irs.KeyPairInfo
 		PublicKey:  "",
 		PrivateKey: keyValue.Value,
 	}
+
+	// an imported KeyPair has only the PublicKey
+	if keyValue.Value == "" {
+		publicKeyValue, err := keypair.GetPublicKey(CBKeyPairProvider, hashString, keyPairName)
+		if err != nil {
+			cblogger.Error(err)
+			return irs.KeyPairInfo{}, err
+		}
+		keypairInfo.PublicKey = publicKeyValue.Value
+		_, keypairInfo.Fingerprint, _ = keypair.ParsePublicKey(publicKeyValue.Value)
+	}
 	return keypairInfo, nil
 }
 
@@ -62,7 +62,7 @@ func (handler *GCPRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaInfo,
 	// with no confirmed native unit, so it is left unconverted (see MarkStatic below).
 	storageSizeRange.Max = irs.GiBToGB(storageSizeRange.Max)
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, true, "1-7", false, false, true, true, true)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, true, "1-7", false, false, true, true, true, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -655,6 +655,58 @@ func (handler *GCPRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error) {
 	return true, nil
 }
 
+// ChangeSpec changes the machine tier of a Cloud SQL instance.
+// Cloud SQL restarts the instance to apply the new tier.
+func (handler *GCPRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "Instances.Patch()")
+	start := call.Start()
+
+	projectId := handler.getProjectId()
+	patch := &sqladmin.DatabaseInstance{
+		Settings: &sqladmin.Settings{Tier: newSpec},
+	}
+	_, err := handler.Client.Instances.Patch(projectId, rdbmsIID.SystemId, patch).Do()
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return handler.GetRDBMS(rdbmsIID)
+}
+
+// ChangeStorageSize expands the data disk size(GB) of a Cloud SQL instance.
+// Cloud SQL does not support shrinking the disk.
+func (handler *GCPRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "Instances.Patch()")
+	start := call.Start()
+
+	size, err := strconv.ParseInt(newSize, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	projectId := handler.getProjectId()
+	patch := &sqladmin.DatabaseInstance{
+		Settings: &sqladmin.Settings{DataDiskSizeGb: size},
+	}
+	_, err = handler.Client.Instances.Patch(projectId, rdbmsIID.SystemId, patch).Do()
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return true, nil
+}
+
 // ===== Helper Functions =====
 
 func (handler *GCPRDBMSHandler) getProjectId() string {
@@ -219,11 +219,15 @@ func (vmHandler *GCPVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 			return irs.VMInfo{}, errKeypair
 		}
 
-		cblogger.Debug("Creation Public key")
-		publicKey, errPub := cdcom.MakePublicKeyFromPrivateKey(keypairInfo.PrivateKey)
-		if errPub != nil {
-			cblogger.Error(errPub)
-			return irs.VMInfo{}, errPub
+		publicKey := keypairInfo.PublicKey // imported KeyPair
+		if keypairInfo.PrivateKey != "" {
+			cblogger.Debug("Creation Public key")
+			var errPub error
+			publicKey, errPub = cdcom.MakePublicKeyFromPrivateKey(keypairInfo.PrivateKey)
+			if errPub != nil {
+				cblogger.Error(errPub)
+				return irs.VMInfo{}, errPub
+			}
 		}
 
 		//pubKey := "cb-user:" + keypairInfo.PublicKey
@@ -320,6 +324,35 @@ func (vmHandler *GCPVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 		instance.Metadata.Items = append(instance.Metadata.Items, &winOsPwd)
 	}
 
+	// UserData: cloud-config by cloud-init, otherwise a startup script which is run at every boot
+	if vmReqInfo.UserData != "" {
+		userData := vmReqInfo.UserData
+		userDataKey := "startup-script"
+		if isWindows {
+			userDataKey = "windows-startup-script-ps1"
+		} else if strings.HasPrefix(strings.TrimSpace(userData), "#cloud-config") {
+			userDataKey = "user-data"
+		}
+		instance.Metadata.Items = append(instance.Metadata.Items, &compute.MetadataItems{Key: userDataKey, Value: &userData})
+	}
+
+	// Spot VM: GCP has no max price, the price is fixed by the machine type
+	if vmReqInfo.PurchaseOption.LifecycleType == irs.SpotVM {
+		if vmReqInfo.PurchaseOption.MaxPrice != "" {
+			return irs.VMInfo{}, errors.New("MaxPrice of Spot VM is not supported by GCP")
+		}
+		terminationAction := "DELETE"
+		if vmReqInfo.PurchaseOption.InterruptionBehavior == irs.SpotStop {
+			terminationAction = "STOP"
+		}
+		instance.Scheduling = &compute.Scheduling{
+			ProvisioningModel:         "SPOT",
+			InstanceTerminationAction: terminationAction,
+			OnHostMaintenance:         "TERMINATE",
+			AutomaticRestart:          googleapi.Bool(false),
+		}
+	}
+
 	// imageType이 MyImage인 경우 SourceMachineImage Setting
 	if isMyImage {
 		instance.SourceMachineImage = imageURL
@@ -462,9 +495,10 @@ func (vmHandler *GCPVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 			strings.Contains(errorLower, "not support live migration")
 		if ok && e.Code == http.StatusBadRequest && liveMigrationNotSupport {
 			cblogger.Info("vm creating with Scheduling struct to set live migration to TERMINATE")
-			instance.Scheduling = &compute.Scheduling{
-				OnHostMaintenance: "TERMINATE",
+			if instance.Scheduling == nil {
+				instance.Scheduling = &compute.Scheduling{}
 			}
+			instance.Scheduling.OnHostMaintenance = "TERMINATE"
 			op, err1 = vmHandler.Client.Instances.Insert(projectID, zone, instance).Do()
 
 			if err1 != nil {
@@ -867,6 +901,100 @@ func (vmHandler *GCPVMHandler) TerminateVM(vmID irs.IID) (irs.VMStatus, error) {
 	return irs.VMStatus("Terminating"), nil
 }
 
+// ChangeVMSpec changes the machine type of the VM.
+// GCP changes the machine type only of a stopped(TERMINATED) instance, so a running VM is stopped and started again.
+func (vmHandler *GCPVMHandler) ChangeVMSpec(vmID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	projectID := vmHandler.Credential.ProjectID
+	zone := vmHandler.Region.Zone
+
+	// set zone if TargetZone is not empty
+	if vmHandler.Region.TargetZone != "" {
+		zone = vmHandler.Region.TargetZone
+	}
+
+	status, err := vmHandler.GetVMStatus(vmID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	wasRunning := false
+	switch status {
+	case irs.Running:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForSuspended(vmID); err != nil {
+			return irs.VMInfo{}, err
+		}
+	case irs.Suspended:
+	default:
+		err := fmt.Errorf("VM %s is %s, the spec can be changed only when it is running or suspended", vmID.SystemId, status)
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	// logger for HisCall
+	callogger := call.GetLogger("HISCALL")
+	callLogInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.GCP,
+		RegionZone:   vmHandler.Region.Zone,
+		ResourceType: call.VM,
+		ResourceName: vmID.SystemId,
+		CloudOSAPI:   "SetMachineType()",
+		ElapsedTime:  "",
+		ErrorMSG:     "",
+	}
+	callLogStart := call.Start()
+	req := &compute.InstancesSetMachineTypeRequest{
+		MachineType: "zones/" + zone + "/machineTypes/" + vmSpecName,
+	}
+	operation, err := vmHandler.Client.Instances.SetMachineType(projectID, zone, vmID.SystemId, req).Context(vmHandler.Ctx).Do()
+	if err == nil {
+		err = WaitOperationComplete(vmHandler.Client, projectID, vmHandler.Region.Region, zone, operation.Name, OperationZone)
+	}
+	callLogInfo.ElapsedTime = call.Elapsed(callLogStart)
+	if err != nil {
+		callLogInfo.ErrorMSG = err.Error()
+		callogger.Info(call.String(callLogInfo))
+		cblogger.Error(err)
+		if wasRunning {
+			// start the VM with the original machine type
+			vmHandler.ResumeVM(vmID)
+		}
+		return irs.VMInfo{}, err
+	}
+	callogger.Info(call.String(callLogInfo))
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if _, err := vmHandler.WaitForRun(vmID); err != nil {
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmID)
+}
+
+// waitForSuspended waits until the stopped VM is TERMINATED.
+func (vmHandler *GCPVMHandler) waitForSuspended(vmIID irs.IID) error {
+	maxRetryCnt := 40 // 15sec * 40 = 600sec = 10min
+	for curRetryCnt := 0; curRetryCnt < maxRetryCnt; curRetryCnt++ {
+		curStatus, err := vmHandler.GetVMStatus(vmIID)
+		if err != nil {
+			cblogger.Info(err.Error())
+		}
+		if curStatus == irs.Suspended {
+			return nil
+		}
+		time.Sleep(time.Second * 15)
+	}
+	return errors.New("Stopped waiting after waiting for a long time, but the status of the VM did not change to [Suspended].")
+}
+
 func (vmHandler *GCPVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	projectID := vmHandler.Credential.ProjectID
 	regionID := vmHandler.Region.Region
@@ -1303,6 +1431,11 @@ func (vmHandler *GCPVMHandler) mappingServerInfo(server *compute.Instance) irs.V
 		vmInfo.VMSpecName = arrVmSpec[len(arrVmSpec)-1]
 	}
 
+	vmInfo.LifecycleType = irs.OnDemandVM
+	if server.Scheduling != nil && (server.Scheduling.ProvisioningModel == "SPOT" || server.Scheduling.Preemptible) {
+		vmInfo.LifecycleType = irs.SpotVM
+	}
+
 	guestOSFeatures := server.Disks[0].GuestOsFeatures
 	vmInfo.Platform = irs.LINUX_UNIX
 
@@ -268,3 +268,7 @@ func (cloudConn *IbmCloudConnection) CreatePublicIPHandler() (irs.PublicIPHandle
 	}
 	return &handler, nil
 }
+
+func (cloudConn *IbmCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("Ibm Cloud Driver: DiskSnapshotHandler not implemented yet")
+}
@@ -1188,6 +1188,10 @@ func (filesystemHandler *IbmFileSystemHandler) DeleteBackup(fsIID irs.IID, backu
 	return false, fmt.Errorf("backup deletion is not supported in IBM Cloud")
 }
 
+func (filesystemHandler *IbmFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	return irs.FileSystemInfo{}, fmt.Errorf("backup restore is not supported in IBM Cloud")
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
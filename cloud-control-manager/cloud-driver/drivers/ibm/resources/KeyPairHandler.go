@@ -106,6 +106,60 @@ func (keyPairHandler *IbmKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairReq
 	return createKeypairInfo, nil
 }
 
+// ImportKey registers the user's public key with CreateKey(), which always takes a public key.
+func (keyPairHandler *IbmKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	hiscallInfo := GetCallLogScheme(keyPairHandler.Region, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+
+	//IID확인
+	err := checkValidKeyReqInfo(irs.KeyPairReqInfo{IId: keyIID})
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	//존재여부 확인
+	exist, err := keyPairHandler.existKey(keyIID)
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	if exist {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = The Key already exists"))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	start := call.Start()
+
+	options := &vpcv1.CreateKeyOptions{}
+	options.SetName(keyIID.NameId)
+	options.SetPublicKey(publicKey)
+	key, _, err := keyPairHandler.VpcService.CreateKeyWithContext(keyPairHandler.Ctx, options)
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	importKeypairInfo, err := keyPairHandler.setKeyInfo(*key, "")
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	LoggingInfo(hiscallInfo, start)
+
+	return importKeypairInfo, nil
+}
+
 func (keyPairHandler *IbmKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	hiscallInfo := GetCallLogScheme(keyPairHandler.Region, call.VMKEYPAIR, "VMKEYPAIR", "ListKey()")
 	start := call.Start()
@@ -117,7 +117,7 @@ func (handler *IbmRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaInfo,
 		Max: irs.GiBToGB(storageSizeRange.Max),
 	}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, metaInfoStorageSizeRange, true, true, true, true, true, "NA", false, false, false, true, true)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, metaInfoStorageSizeRange, true, true, true, true, true, "NA", false, false, false, true, true, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -779,6 +779,105 @@ func (handler *IbmRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error) {
 	return true, nil
 }
 
+// ChangeSpec changes the host flavor of the member scaling group of a Cloud Databases deployment.
+// IBM applies the new host flavor to the members one by one.
+func (handler *IbmRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "SetDeploymentScalingGroup()")
+	start := call.Start()
+
+	deploymentID, _, err := handler.getMemberScalingGroup(rdbmsIID)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	options := handler.CloudDBService.NewSetDeploymentScalingGroupOptions(deploymentID, clouddatabasesv5.GroupIDMemberConst)
+	options.Group = &clouddatabasesv5.GroupScaling{
+		HostFlavor: &clouddatabasesv5.GroupScalingHostFlavor{ID: core.StringPtr(newSpec)},
+	}
+	_, _, err = handler.CloudDBService.SetDeploymentScalingGroupWithContext(handler.getContext(), options)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return handler.GetRDBMS(rdbmsIID)
+}
+
+// ChangeStorageSize expands the disk(GB per member) of the member scaling group of a Cloud Databases deployment.
+// IBM Cloud Databases does not support shrinking the disk.
+func (handler *IbmRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "SetDeploymentScalingGroup()")
+	start := call.Start()
+
+	size, err := strconv.ParseInt(newSize, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	deploymentID, group, err := handler.getMemberScalingGroup(rdbmsIID)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	// the disk allocation of the scaling group is the total of all members, as in enrichRDBMSInfoFromCloudDB
+	memberCount := int64(1)
+	if group.Members != nil && group.Members.AllocationCount != nil && *group.Members.AllocationCount > 0 {
+		memberCount = *group.Members.AllocationCount
+	}
+
+	options := handler.CloudDBService.NewSetDeploymentScalingGroupOptions(deploymentID, clouddatabasesv5.GroupIDMemberConst)
+	options.Group = &clouddatabasesv5.GroupScaling{
+		Disk: &clouddatabasesv5.GroupScalingDisk{AllocationMb: core.Int64Ptr(size * ibmStorageUnitGB * memberCount)},
+	}
+	_, _, err = handler.CloudDBService.SetDeploymentScalingGroupWithContext(handler.getContext(), options)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+
+	return true, nil
+}
+
+// getMemberScalingGroup returns the deployment ID and the member scaling group of an RDBMS instance.
+func (handler *IbmRDBMSHandler) getMemberScalingGroup(rdbmsIID irs.IID) (string, *clouddatabasesv5.Group, error) {
+	inst, _, err := handler.ResourceController.GetResourceInstance(&resourcecontrollerv2.GetResourceInstanceOptions{
+		ID: &rdbmsIID.SystemId,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	deploymentID := ibmDeploymentIDFromResourceInstance(inst)
+	if deploymentID == "" {
+		return "", nil, errors.New("IBM Resource Controller instance did not include CRN for Cloud Databases API calls")
+	}
+
+	groups, _, err := handler.CloudDBService.ListDeploymentScalingGroupsWithContext(handler.getContext(), handler.CloudDBService.NewListDeploymentScalingGroupsOptions(deploymentID))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get IBM Cloud Databases scaling groups for %s: %w", rdbmsIID.NameId, err)
+	}
+	if groups != nil {
+		for _, group := range groups.Groups {
+			if group.ID != nil && *group.ID == clouddatabasesv5.GroupIDMemberConst {
+				return deploymentID, &group, nil
+			}
+		}
+	}
+	return "", nil, fmt.Errorf("IBM Cloud Databases deployment %s has no member scaling group", rdbmsIID.NameId)
+}
+
 // ===== Helper Functions =====
 
 func (handler *IbmRDBMSHandler) listResourceInstancesByType(serviceID string) ([]*irs.IID, error) {
@@ -164,6 +164,9 @@ func (vmHandler *IbmVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 		if userId == "" {
 			userId = "Administrator"
 		}
+		if vmReqInfo.UserData != "" {
+			return irs.VMInfo{}, errors.New("UserData is not supported for Windows images")
+		}
 
 		pwValidErr := cdcom.ValidateWindowsPassword(vmReqInfo.VMUserPasswd)
 		if pwValidErr != nil {
@@ -183,6 +186,12 @@ func (vmHandler *IbmVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, err
 		userData = string(fileDataCloudInit)
 		userData = strings.ReplaceAll(userData, "{{username}}", CBDefaultVmUserName)
 		userData = strings.ReplaceAll(userData, "{{public_key}}", *key.PublicKey)
+		userData, err = cdcom.MergeUserDataWithLimit("IBM", userData, vmReqInfo.UserData)
+		if err != nil {
+			cblogger.Error(err)
+			LoggingError(hiscallInfo, err)
+			return irs.VMInfo{}, err
+		}
 	}
 
 	// 2.Create VM
@@ -712,6 +721,105 @@ func (vmHandler *IbmVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, error)
 	return irs.Terminating, nil
 }
 
+func (vmHandler *IbmVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	hiscallInfo := GetCallLogScheme(vmHandler.Region, call.VM, vmIID.NameId, "ChangeVMSpec()")
+	start := call.Start()
+	err := checkVmIID(vmIID)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to ChangeVMSpec. err = %s", err.Error()))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	instance, err := getRawInstance(vmIID, vmHandler.VpcService, vmHandler.Ctx)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to ChangeVMSpec. err = %s", err.Error()))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	// the profile of an instance can be changed only when the instance is stopped
+	wasRunning := *instance.Status == "running"
+	if wasRunning {
+		instanceActionOptions := &vpcv1.CreateInstanceActionOptions{}
+		instanceActionOptions.SetInstanceID(*instance.ID)
+		instanceActionOptions.SetType("stop")
+		_, _, err = vmHandler.VpcService.CreateInstanceActionWithContext(vmHandler.Ctx, instanceActionOptions)
+		if err == nil {
+			err = vmHandler.waitInstanceStatus(*instance.ID, "stopped")
+		}
+		if err != nil {
+			changeErr := errors.New(fmt.Sprintf("Failed to ChangeVMSpec. failed to stop VM err = %s", err.Error()))
+			cblogger.Error(changeErr.Error())
+			LoggingError(hiscallInfo, changeErr)
+			return irs.VMInfo{}, changeErr
+		}
+	} else if *instance.Status != "stopped" {
+		status, _ := convertInstanceStatus(*instance.Status)
+		changeErr := errors.New(fmt.Sprintf("Failed to ChangeVMSpec. err = can't change VM Spec when your VM Status is %s", status))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+
+	instancePatchModel := &vpcv1.InstancePatch{
+		Profile: &vpcv1.InstancePatchProfileInstanceProfileIdentityByName{
+			Name: core.StringPtr(vmSpecName),
+		},
+	}
+	instancePatch, err := instancePatchModel.AsPatch()
+	if err == nil {
+		updateInstanceOptions := vmHandler.VpcService.NewUpdateInstanceOptions(*instance.ID, instancePatch)
+		_, _, err = vmHandler.VpcService.UpdateInstanceWithContext(vmHandler.Ctx, updateInstanceOptions)
+	}
+	if err != nil {
+		if wasRunning {
+			vmHandler.ResumeVM(vmIID)
+		}
+		changeErr := errors.New(fmt.Sprintf("Failed to ChangeVMSpec. err = %s", err.Error()))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+
+	if wasRunning {
+		_, err = vmHandler.ResumeVM(vmIID)
+		if err == nil {
+			err = vmHandler.waitInstanceStatus(*instance.ID, "running")
+		}
+		if err != nil {
+			changeErr := errors.New(fmt.Sprintf("Failed to ChangeVMSpec. failed to start VM err = %s", err.Error()))
+			cblogger.Error(changeErr.Error())
+			LoggingError(hiscallInfo, changeErr)
+			return irs.VMInfo{}, changeErr
+		}
+	}
+	LoggingInfo(hiscallInfo, start)
+	return vmHandler.GetVM(vmIID)
+}
+
+func (vmHandler *IbmVMHandler) waitInstanceStatus(instanceID string, status string) error {
+	curRetryCnt := 0
+	maxRetryCnt := 120
+	for {
+		instance, err := getRawInstance(irs.IID{SystemId: instanceID}, vmHandler.VpcService, vmHandler.Ctx)
+		if err != nil {
+			return err
+		}
+		if *instance.Status == status {
+			return nil
+		}
+		if *instance.Status == "failed" {
+			return errors.New("IBM instance entered failed status")
+		}
+		curRetryCnt++
+		if curRetryCnt > maxRetryCnt {
+			return errors.New(fmt.Sprintf("IBM instance did not reach %s status within %d seconds (last status: %s)", status, maxRetryCnt*5, *instance.Status))
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
 func (vmHandler *IbmVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	hiscallInfo := GetCallLogScheme(vmHandler.Region, call.VM, "VMStatus", "ListVMStatus()")
 	start := call.Start()
@@ -128,6 +128,7 @@ func (KTCloudVpcDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false
 	drvCapabilityInfo.PublicIPHandler = false
 	drvCapabilityInfo.NICHandler = false
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	return drvCapabilityInfo
 }
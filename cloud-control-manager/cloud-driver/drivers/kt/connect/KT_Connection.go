@@ -171,3 +171,7 @@ func (cloudConn *KTCloudVpcConnection) CreatePublicIPHandler() (irs.PublicIPHand
 	}
 	return &handler, nil
 }
+
+func (cloudConn *KTCloudVpcConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: DiskSnapshotHandler not implemented yet")
+}
@@ -563,6 +563,12 @@ func (filesystemHandler *KTVpcFileSystemHandler) DeleteBackup(fsIID irs.IID, bac
 	return false, fmt.Errorf("backup deletion is not supported in OpenStack Manila")
 }
 
+func (filesystemHandler *KTVpcFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	cblogger.Info("KT Cloud Driver: called RestoreBackup()")
+
+	return irs.FileSystemInfo{}, fmt.Errorf("backup restore is not supported in OpenStack Manila")
+}
+
 func (filesystemHandler *KTVpcFileSystemHandler) waitForShareAvailable(shareID string, timeout time.Duration) (*shares.Share, error) {
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
@@ -105,6 +105,74 @@ func (keyPairHandler *KTVpcKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairR
 	return *keyPairInfo, nil
 }
 
+func (keyPairHandler *KTVpcKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Info("KT Cloud VPC Driver: called ImportKey()")
+	callLogInfo := getCallLogScheme(keyPairHandler.RegionInfo.Zone, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+
+	if strings.EqualFold(keyIID.NameId, "") {
+		newErr := fmt.Errorf("Invalid KeyPair Name!!")
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	exist, err := keyPairHandler.keyPairExists(keyIID)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Import Key. : [%v]", err)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+	if exist {
+		newErr := fmt.Errorf("Failed to Import Key. The Key name [%s] already exists", keyIID.NameId)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	// The Key is imported with the PublicKey
+	create0pts := keys.CreateOpts{
+		Name:      keyIID.NameId,
+		PublicKey: publicKey,
+	}
+
+	start := call.Start()
+	keyPair, err := keys.Create(keyPairHandler.VMClient, create0pts).Extract()
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Import Key. : [%v]", err)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+	loggingInfo(callLogInfo, start)
+
+	// # Save the publicKey to DB in other to use on VMHandler(Cloud-init)
+	strList := []string{
+		keyPairHandler.CredentialInfo.Username,
+		keyPairHandler.CredentialInfo.Password,
+	}
+	hashString, err := keycommon.GenHash(strList)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Generate Hash String : [%v]", err)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	addKeyErr := keycommon.AddKey("KT", hashString, keyIID.NameId, strings.TrimSpace(publicKey)+" "+LnxUserName)
+	if addKeyErr != nil {
+		newErr := fmt.Errorf("Failed to Save the Public Key to DB : [%v]", addKeyErr)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	keyPairInfo := mappingKeyPairInfo(*keyPair)
+	keyPairInfo.PublicKey = publicKey
+
+	return *keyPairInfo, nil
+}
+
 func (keyPairHandler *KTVpcKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	cblogger.Info("KT Cloud VPC Driver: called ListKey()")
 	callLogInfo := getCallLogScheme(keyPairHandler.RegionInfo.Zone, call.VMKEYPAIR, "ListKey()", "ListKey()")
@@ -784,6 +784,105 @@ func (vmHandler *KTVpcVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, error
 	return irs.Terminating, nil
 }
 
+// ChangeVMSpec changes the flavor of the VM with the resize of the server.
+// A running VM is stopped before the resize and started again.
+func (vmHandler *KTVpcVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Info("KT Cloud VPC Driver: called ChangeVMSpec()")
+	callLogInfo := getCallLogScheme(vmHandler.RegionInfo.Zone, call.VM, vmIID.SystemId, "ChangeVMSpec()")
+
+	if strings.EqualFold(vmIID.SystemId, "") {
+		newErr := fmt.Errorf("Invalid VM SystemId!!")
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	flavorId, err := getFlavorIdWithName(vmHandler.VMClient, vmSpecName)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Get the Flavor ID with the name [%s] : [%v]", vmSpecName, err)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	vmStatus, err := vmHandler.GetVMStatus(vmIID)
+	if err != nil {
+		cblogger.Error(err)
+		loggingError(callLogInfo, err)
+		return irs.VMInfo{}, err
+	}
+
+	wasRunning := false
+	switch vmStatus {
+	case irs.Running:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForServerStatus(vmIID.SystemId, "SHUTOFF"); err != nil {
+			loggingError(callLogInfo, err)
+			return irs.VMInfo{}, err
+		}
+	case irs.Suspended:
+	default:
+		newErr := fmt.Errorf("The VM is %s, the spec can be changed only when it is Running or Suspended.", vmStatus)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	start := call.Start()
+	err = servers.Resize(vmHandler.VMClient, vmIID.SystemId, servers.ResizeOpts{FlavorRef: flavorId}).ExtractErr()
+	if err == nil {
+		err = vmHandler.waitForServerStatus(vmIID.SystemId, "VERIFY_RESIZE")
+	}
+	if err == nil {
+		err = servers.ConfirmResize(vmHandler.VMClient, vmIID.SystemId).ExtractErr()
+	}
+	if err == nil {
+		err = vmHandler.waitForServerStatus(vmIID.SystemId, "SHUTOFF")
+	}
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Change the VMSpec of the VM!! : [%v] ", err)
+		cblogger.Error(newErr.Error())
+		loggingError(callLogInfo, newErr)
+		if wasRunning {
+			// start the VM with the original flavor
+			vmHandler.ResumeVM(vmIID)
+		}
+		return irs.VMInfo{}, newErr
+	}
+	loggingInfo(callLogInfo, start)
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForServerStatus(vmIID.SystemId, "ACTIVE"); err != nil {
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmIID)
+}
+
+// waitForServerStatus waits until the server is in the given status of KT Cloud VPC, ex) SHUTOFF, VERIFY_RESIZE, ACTIVE.
+func (vmHandler *KTVpcVMHandler) waitForServerStatus(vmId string, status string) error {
+	maxRetryCnt := 120
+	for curRetryCnt := 0; curRetryCnt < maxRetryCnt; curRetryCnt++ {
+		ktVM, err := vmHandler.getKtVMInfo(vmId)
+		if err != nil {
+			cblogger.Error(err)
+		} else if strings.EqualFold(ktVM.Status, status) {
+			return nil
+		} else if strings.EqualFold(ktVM.Status, "ERROR") {
+			return fmt.Errorf("The VM status is ERROR while waiting for [%s]", status)
+		}
+		time.Sleep(time.Second * 5)
+	}
+	return fmt.Errorf("Despite waiting for a long time(%d sec), the VM status is not %s, so it is forcibly finished.", maxRetryCnt*5, status)
+}
+
 func (vmHandler *KTVpcVMHandler) GetVMStatus(vmIID irs.IID) (irs.VMStatus, error) {
 	cblogger.Info("KT Cloud VPC Driver: called GetVMStatus()")
 	callLogInfo := getCallLogScheme(vmHandler.RegionInfo.Zone, call.VM, vmIID.SystemId, "GetVMStatus()")
@@ -61,6 +61,7 @@ func (KtCloudDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.MyImageHandler = true
 	drvCapabilityInfo.NLBHandler = true
 	drvCapabilityInfo.ClusterHandler = false
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	drvCapabilityInfo.TagHandler = true
 	// ires.VPC, ires.SUBNET, ires.SG, ires.KEY, ires.NLB, ires.CLUSTER
@@ -157,6 +157,10 @@ func (cloudConn *KtCloudConnection) CreatePublicIPHandler() (irs.PublicIPHandler
 	return nil, fmt.Errorf("KT Classic Cloud Driver: PublicIPHandler not supported")
 }
 
+func (cloudConn *KtCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, fmt.Errorf("KT Classic Cloud Driver: DiskSnapshotHandler not implemented yet")
+}
+
 func (cloudConn *KtCloudConnection) IsConnected() (bool, error) {
 	cblogger.Info("KT Cloud Driver: called IsConnected()!")
 	if cloudConn == nil {
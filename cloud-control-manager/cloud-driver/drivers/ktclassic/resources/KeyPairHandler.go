@@ -54,7 +54,13 @@ func (keyPairHandler *KtCloudKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, erro
 	LoggingInfo(callLogInfo, callLogStart)
 	// spew.Dump(result)
 
-	if result.Listsshkeypairsresponse.Count < 1 {
+	importedKeyPairList, err := listImportedKeyPairInfo(keyPairHandler.CredentialInfo)
+	if err != nil {
+		cblogger.Error(err.Error())
+		return nil, err
+	}
+
+	if result.Listsshkeypairsresponse.Count < 1 && len(importedKeyPairList) < 1 {
 		// if len(result.Listsshkeypairsresponse.Keypair) < 1 {
 		cblogger.Info("KeyPair does not exit on the zone!!")
 		return nil, nil // Caution!!
@@ -66,6 +72,7 @@ func (keyPairHandler *KtCloudKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, erro
 		keyPairInfo := mappingKeyPairInfo(keyPair)
 		keyPairList = append(keyPairList, &keyPairInfo)
 	}
+	keyPairList = append(keyPairList, importedKeyPairList...)
 	// cblogger.Debug(keyPairList)
 	//spew.Dump(keyPairList)
 	return keyPairList, nil
@@ -143,6 +150,50 @@ func (keyPairHandler *KtCloudKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPai
 	return keyPairInfo, nil
 }
 
+// KT Classic has no API to import a KeyPair, so an imported KeyPair is kept only in the local key store
+// and its public key is set to a VM by cloud-init.
+func (keyPairHandler *KtCloudKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Info("KT Classic driver: called ImportKey()!!")
+	InitLog()
+	callLogInfo := GetCallLogScheme(keyPairHandler.RegionInfo.Zone, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+
+	//***** Make sure that Keypair Name already exists *****
+	resultKey, keyGetError := keyPairHandler.GetKey(keyIID)
+	if keyGetError != nil {
+		cblogger.Debug("The KeyPair with the Name does't exit!!: [%v]", keyGetError)
+	}
+	if resultKey.Fingerprint != "" {
+		return irs.KeyPairInfo{}, errors.New("The KeyPair name already exists!!")
+	}
+
+	publicKey, _, err := keycommon.ParsePublicKey(publicKey)
+	if err != nil {
+		cblogger.Error(err.Error())
+		LoggingError(callLogInfo, err)
+		return irs.KeyPairInfo{}, err
+	}
+	publicKey = publicKey + " " + LinuxUserName // Append VM User Name
+
+	hashString, err := getKeyHashString(keyPairHandler.CredentialInfo)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Generate Hash String : [%v]", err)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	// Save the publicKey to DB in other to use on VMHandler(Cloud-init)
+	importKeyErr := keycommon.ImportKey("KTCLASSIC", hashString, keyIID.NameId, publicKey)
+	if importKeyErr != nil {
+		newErr := fmt.Errorf("Failed to Save the Public Key to DB : [%v]", importKeyErr)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	return mappingImportedKeyPairInfo(keyIID.NameId, publicKey), nil
+}
+
 func (keyPairHandler *KtCloudKeyPairHandler) GetKey(keyIID irs.IID) (irs.KeyPairInfo, error) {
 	cblogger.Info("KT Classic driver: called GetKey()!!")
 
@@ -157,6 +208,15 @@ func (keyPairHandler *KtCloudKeyPairHandler) GetKey(keyIID irs.IID) (irs.KeyPair
 
 	var resultKeyPairInfo irs.KeyPairInfo
 	if result.Listsshkeypairsresponse.Count < 1 {
+		// imported KeyPair
+		importedKey, err := getImportedPublicKey(keyPairHandler.CredentialInfo, keyIID.NameId)
+		if err != nil {
+			cblogger.Error(err.Error())
+			return irs.KeyPairInfo{}, err
+		}
+		if importedKey != "" {
+			return mappingImportedKeyPairInfo(keyIID.NameId, importedKey), nil
+		}
 		errors.New("Failed to Find KeyPair with the Name!!")
 		return irs.KeyPairInfo{}, errors.New("Failed to Find KeyPair with the Name!!")
 
@@ -183,20 +243,23 @@ func (keyPairHandler *KtCloudKeyPairHandler) DeleteKey(keyIID irs.IID) (bool, er
 	}
 
 	//It is necessary to check in advance because it succeeds unconditionally without Keypair.
-	_, keyError := keyPairHandler.GetKey(keyIID)
+	keyInfo, keyError := keyPairHandler.GetKey(keyIID)
 	if keyError != nil {
 		newErr := fmt.Errorf("Failed to Get the KeyPair : [%s], [%v]", keyIID.SystemId, keyError)
 		cblogger.Error(newErr.Error())
 		return false, newErr
 	}
 
-	result, err := keyPairHandler.Client.DeleteSSHKeyPair(keyIID.NameId)
-	if err != nil {
-		newErr := fmt.Errorf("Failed to Delete the KeyPair : %s, %v", keyIID.NameId, err)
-		cblogger.Error(newErr.Error())
-		return false, newErr
+	// An imported KeyPair exists only in DB
+	if !isImportedKeyPairInfo(keyInfo) {
+		result, err := keyPairHandler.Client.DeleteSSHKeyPair(keyIID.NameId)
+		if err != nil {
+			newErr := fmt.Errorf("Failed to Delete the KeyPair : %s, %v", keyIID.NameId, err)
+			cblogger.Error(newErr.Error())
+			return false, newErr
+		}
+		cblogger.Infof("Deletion result on KT Cloud : %s", result.Deletesshkeypairresponse.Success)
 	}
-	cblogger.Infof("Deletion result on KT Cloud : %s", result.Deletesshkeypairresponse.Success)
 
 	strList := []string{
 		keyPairHandler.CredentialInfo.ClientId,
@@ -259,7 +322,13 @@ func (keyPairHandler *KtCloudKeyPairHandler) ListIID() ([]*irs.IID, error) {
 	LoggingInfo(callLogInfo, callLogStart)
 	// spew.Dump(result)
 
-	if result.Listsshkeypairsresponse.Count < 1 {
+	importedKeyPairList, err := listImportedKeyPairInfo(keyPairHandler.CredentialInfo)
+	if err != nil {
+		cblogger.Error(err.Error())
+		return nil, err
+	}
+
+	if result.Listsshkeypairsresponse.Count < 1 && len(importedKeyPairList) < 1 {
 		cblogger.Info("KeyPair does not exist in the zone!!")
 		return nil, nil
 	}
@@ -272,5 +341,84 @@ func (keyPairHandler *KtCloudKeyPairHandler) ListIID() ([]*irs.IID, error) {
 		}
 		iidList = append(iidList, iid)
 	}
+	for _, keyPairInfo := range importedKeyPairList {
+		iidList = append(iidList, &irs.IID{NameId: keyPairInfo.IId.NameId, SystemId: keyPairInfo.IId.SystemId})
+	}
 	return iidList, nil
 }
+
+func getKeyHashString(credentialInfo idrv.CredentialInfo) (string, error) {
+	strList := []string{
+		credentialInfo.ClientId,
+		credentialInfo.ClientSecret,
+	}
+	return keycommon.GenHash(strList)
+}
+
+// getImportedPublicKey returns the public key of an imported KeyPair, or "" for others.
+func getImportedPublicKey(credentialInfo idrv.CredentialInfo, keyName string) (string, error) {
+	hashString, err := getKeyHashString(credentialInfo)
+	if err != nil {
+		return "", fmt.Errorf("Failed to Generate Hash String : [%v]", err)
+	}
+
+	keyValue, err := keycommon.GetKey("KTCLASSIC", hashString, keyName)
+	if err != nil || keyValue.Value != "" { // not found or a KeyPair created on KT Cloud
+		return "", nil
+	}
+
+	publicKeyValue, err := keycommon.GetPublicKey("KTCLASSIC", hashString, keyName)
+	if err != nil {
+		return "", fmt.Errorf("Failed to Get the Public Key from DB : [%v]", err)
+	}
+	return publicKeyValue.Value, nil
+}
+
+func listImportedKeyPairInfo(credentialInfo idrv.CredentialInfo) ([]*irs.KeyPairInfo, error) {
+	hashString, err := getKeyHashString(credentialInfo)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to Generate Hash String : [%v]", err)
+	}
+
+	keyValueList, err := keycommon.ListKey("KTCLASSIC", hashString)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to Get the KeyPair list from DB : [%v]", err)
+	}
+
+	var keyPairList []*irs.KeyPairInfo
+	for _, keyValue := range keyValueList {
+		if keyValue.Value != "" { // a KeyPair created on KT Cloud
+			continue
+		}
+		publicKeyValue, err := keycommon.GetPublicKey("KTCLASSIC", hashString, keyValue.Key)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to Get the Public Key from DB : [%v]", err)
+		}
+		keyPairInfo := mappingImportedKeyPairInfo(keyValue.Key, publicKeyValue.Value)
+		keyPairList = append(keyPairList, &keyPairInfo)
+	}
+	return keyPairList, nil
+}
+
+func mappingImportedKeyPairInfo(keyName string, publicKey string) irs.KeyPairInfo {
+	_, fingerprint, _ := keycommon.ParsePublicKey(publicKey)
+	return irs.KeyPairInfo{
+		IId: irs.IID{
+			NameId:   keyName,
+			SystemId: keyName,
+		},
+		Fingerprint:  fingerprint,
+		PublicKey:    publicKey,
+		VMUserID:     LinuxUserName,
+		KeyValueList: []irs.KeyValue{{Key: "Imported", Value: "true"}},
+	}
+}
+
+func isImportedKeyPairInfo(keyPairInfo irs.KeyPairInfo) bool {
+	for _, kv := range keyPairInfo.KeyValueList {
+		if kv.Key == "Imported" && kv.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -106,6 +106,19 @@ func (vmHandler *KtCloudVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo,
 	} else {
 		keyPairId = vmReqInfo.KeyPairIID.NameId
 	}
+
+	// An imported KeyPair does not exist on KT Cloud, and its public key is set only by cloud-init.
+	ktKeyPairName := vmReqInfo.KeyPairIID.SystemId
+	importedKey, err := getImportedPublicKey(vmHandler.CredentialInfo, keyPairId)
+	if err != nil {
+		cblogger.Error(err.Error())
+		LoggingError(callLogInfo, err)
+		return irs.VMInfo{}, err
+	}
+	if importedKey != "" {
+		ktKeyPairName = ""
+	}
+
 	if vmReqInfo.ImageType == irs.PublicImage || vmReqInfo.ImageType == "" || vmReqInfo.ImageType == "default" {
 		// isPublicImage() in 'MyImage'Handler
 		myImageHandler := KtCloudMyImageHandler{
@@ -296,7 +309,7 @@ func (vmHandler *KtCloudVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo,
 		DisplayName:   vmReqInfo.IId.NameId,
 		UsagePlanType: DefaultVMUsagePlanType,
 		RunSysPrep:    false,
-		KeyPair:       vmReqInfo.KeyPairIID.SystemId,
+		KeyPair:       ktKeyPairName,
 		// UserData:			cmdString,
 		UserData: *initUserData,
 	}
@@ -1020,6 +1033,12 @@ func (vmHandler *KtCloudVMHandler) RebootVM(vmIID irs.IID) (irs.VMStatus, error)
 	return irs.VMStatus("Running"), nil
 }
 
+func (vmHandler *KtCloudVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Info("KT Classic driver: called ChangeVMSpec()!")
+
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
 func (vmHandler *KtCloudVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, error) {
 	cblogger.Info("KT Classic driver: called TerminateVM()!")
 
@@ -2054,6 +2073,14 @@ func (vmHandler *KtCloudVMHandler) createLinuxInitUserData(imageIID irs.IID, key
 		cblogger.Error(newErr.Error())
 		return nil, newErr
 	}
+	if keyValue.Value == "" { // imported KeyPair
+		keyValue, getKeyErr = keycommon.GetPublicKey("KTCLASSIC", hashString, keyPairId)
+		if getKeyErr != nil {
+			newErr := fmt.Errorf("Failed to Get the Public Key from DB : [%v]", getKeyErr)
+			cblogger.Error(newErr.Error())
+			return nil, newErr
+		}
+	}
 
 	// Set Linux cloud-init script
 	cmdString = strings.ReplaceAll(cmdString, "{{username}}", LinuxUserName)
@@ -52,12 +52,25 @@ func (MockDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.MyImageHandler = true
 	drvCapabilityInfo.NLBHandler = true
 	drvCapabilityInfo.ClusterHandler = true
+	drvCapabilityInfo.RDBMSHandler = true
+	drvCapabilityInfo.DBSpecHandler = true
+	drvCapabilityInfo.RDBMSMySQLHandler = true
+	drvCapabilityInfo.RDBMSMariaDBHandler = true
+	drvCapabilityInfo.RDBMSPostgreSQLHandler = true
+	drvCapabilityInfo.PublicIPHandler = true
+	drvCapabilityInfo.NICHandler = true
+	drvCapabilityInfo.DiskSnapshotHandler = true
+	drvCapabilityInfo.FileSystemHandler = true
+	drvCapabilityInfo.QuotaInfoHandler = true
 
 	drvCapabilityInfo.TagHandler = true
 	drvCapabilityInfo.TagSupportResourceType = []ires.RSType{ires.VPC, ires.SUBNET, ires.SG, ires.KEY, ires.VM, ires.NLB, ires.DISK, ires.MYIMAGE, ires.CLUSTER}
 
 	drvCapabilityInfo.VPC_CIDR = true
 
+	drvCapabilityInfo.VM_USERDATA = true
+	drvCapabilityInfo.SPOT_VM = true
+
 	return drvCapabilityInfo
 }
 
@@ -79,6 +92,15 @@ func (driver *MockDriver) ConnectCloud(connectionInfo idrv.ConnectionInfo) (icon
 	mkrs.PrepareVMImage(iConn.MockName)
 	mkrs.PrepareVMSpec(iConn.MockName)
 	mkrs.PrepareRegionZone(iConn.MockName)
+	mkrs.PrepareDBSpec(iConn.MockName)
+
+	// fault injection rules of the credential, ex) MockFault = "StartVM:delay=2s,error=0.3;ListVM:lag=5s"
+	// They are applied only at the first connection, so the rules set by the admin API are kept.
+	if connectionInfo.CredentialInfo.MockFault != "" {
+		if err := mkrs.InitFaultSpec(iConn.MockName, connectionInfo.CredentialInfo.MockFault); err != nil {
+			return nil, err
+		}
+	}
 
 	return &iConn, nil
 }
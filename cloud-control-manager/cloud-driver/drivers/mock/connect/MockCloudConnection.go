@@ -1,9 +1,6 @@
 package connect
 
 import (
-	"errors"
-	"fmt"
-
 	cblog "github.com/cloud-barista/cb-log"
 	mkrs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
@@ -25,7 +22,9 @@ type MockConnection struct {
 
 // CreateFileSystemHandler implements connect.CloudConnection.
 func (cloudConn *MockConnection) CreateFileSystemHandler() (irs.FileSystemHandler, error) {
-	panic("unimplemented")
+	cblogger.Info("Mock Driver: called CreateFileSystemHandler()!")
+	handler := mkrs.MockFileSystemHandler{Region: cloudConn.Region, MockName: cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreateImageHandler() (irs.ImageHandler, error) {
@@ -129,25 +128,43 @@ func (cloudConn *MockConnection) CreateTagHandler() (irs.TagHandler, error) {
 
 // CreateQuotaInfoHandler implements connect.CloudConnection.
 func (cloudConn *MockConnection) CreateQuotaInfoHandler() (irs.QuotaInfoHandler, error) {
-	panic("unimplemented")
+	cblogger.Info("Mock Driver: called CreateQuotaInfoHandler()!")
+	handler := mkrs.MockQuotaInfoHandler{Region: cloudConn.Region, MockName: cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreateMonitoringHandler() (irs.MonitoringHandler, error) {
-	return nil, errors.New("Mock Driver: not implemented")
+	cblogger.Info("Mock Driver: called CreateMonitoringHandler()!")
+	handler := mkrs.MockMonitoringHandler{MockName: cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreateRDBMSHandler() (irs.RDBMSHandler, error) {
-	return nil, fmt.Errorf("Mock Driver: RDBMSHandler not supported")
+	cblogger.Info("Mock Driver: called CreateRDBMSHandler()!")
+	handler := mkrs.MockRDBMSHandler{MockName: cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreateDBSpecHandler() (irs.DBSpecHandler, error) {
-	return nil, fmt.Errorf("Mock Driver: DBSpecHandler not supported")
+	cblogger.Info("Mock Driver: called CreateDBSpecHandler()!")
+	handler := mkrs.MockDBSpecHandler{MockName: cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreateNICHandler() (irs.NICHandler, error) {
-	return nil, fmt.Errorf("Mock Driver: NICHandler not supported")
+	cblogger.Info("Mock Driver: called CreateNICHandler()!")
+	handler := mkrs.MockNICHandler{MockName: cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreatePublicIPHandler() (irs.PublicIPHandler, error) {
-	return nil, fmt.Errorf("Mock Driver: PublicIPHandler not supported")
+	cblogger.Info("Mock Driver: called CreatePublicIPHandler()!")
+	handler := mkrs.MockPublicIPHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
+func (cloudConn *MockConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	cblogger.Info("Mock Driver: called CreateDiskSnapshotHandler()!")
+	handler := mkrs.MockDiskSnapshotHandler{MockName: cloudConn.MockName}
+	return &handler, nil
 }
@@ -40,6 +40,11 @@ func (anyCallHandler *MockAnyCallHandler) AnyCall(callInfo irs.AnyCallInfo) (irs
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AnyCall()!")
 
+	if err := injectFault(anyCallHandler.MockName, "AnyCall"); err != nil {
+		cblogger.Error(err)
+		return irs.AnyCallInfo{}, err
+	}
+
 	switch callInfo.FID {
 	case "countAll" : 
 		return countAll(anyCallHandler, callInfo)
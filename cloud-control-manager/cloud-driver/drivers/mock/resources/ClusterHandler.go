@@ -38,6 +38,17 @@ func (clusterHandler *MockClusterHandler) CreateCluster(clusterReqInfo irs.Clust
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateCluster()!")
 
+	if err := injectFault(clusterHandler.MockName, "CreateCluster"); err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
+	defer lockQuota(clusterHandler.MockName)()
+	if err := checkQuota(clusterHandler.MockName, "container", "clusters", 1); err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
 	mockName := clusterHandler.MockName
 	clusterReqInfo.IId.SystemId = clusterReqInfo.IId.NameId
 
@@ -62,6 +73,7 @@ func (clusterHandler *MockClusterHandler) CreateCluster(clusterReqInfo irs.Clust
 			node.SystemId = node.NameId
 			nodeGroup.Nodes[j] = node
 		}
+		resizeMockNodes(&nodeGroup)
 		clusterReqInfo.NodeGroupList[i] = nodeGroup
 	}
 
@@ -77,6 +89,11 @@ func (clusterHandler *MockClusterHandler) CreateCluster(clusterReqInfo irs.Clust
 	clusterInfoMap[mockName] = infoList
 
 	clusterReqInfo.Status = irs.ClusterActive
+	if err := recordFaultCreation(mockName, "CreateCluster", "cluster", clusterReqInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
 	return CloneClusterInfo(clusterReqInfo), nil
 }
 
@@ -173,6 +190,11 @@ func (clusterHandler *MockClusterHandler) ListCluster() ([]*irs.ClusterInfo, err
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListCluster()!")
 
+	if err := injectFault(clusterHandler.MockName, "ListCluster"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := clusterHandler.MockName
 	clusterMapLock.RLock()
 	defer clusterMapLock.RUnlock()
@@ -181,14 +203,26 @@ func (clusterHandler *MockClusterHandler) ListCluster() ([]*irs.ClusterInfo, err
 		return []*irs.ClusterInfo{}, nil
 	}
 
+	infoList = applyListLag(mockName, "ListCluster", "cluster", infoList, func(info *irs.ClusterInfo) irs.IID { return info.IId })
 	// cloning list of Cluster
-	return CloneClusterInfoList(infoList), nil
+	clonedInfoList := CloneClusterInfoList(infoList)
+	for _, info := range clonedInfoList {
+		if isFaultStuck(mockName, "cluster", info.IId.SystemId) {
+			info.Status = irs.ClusterCreating
+		}
+	}
+	return clonedInfoList, nil
 }
 
 func (clusterHandler *MockClusterHandler) GetCluster(iid irs.IID) (irs.ClusterInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetCluster()!")
 
+	if err := injectFault(clusterHandler.MockName, "GetCluster"); err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
 	clusterMapLock.RLock()
 	defer clusterMapLock.RUnlock()
 
@@ -200,7 +234,11 @@ func (clusterHandler *MockClusterHandler) GetCluster(iid irs.IID) (irs.ClusterIn
 
 	for _, info := range infoList {
 		if info.IId.NameId == iid.NameId {
-			return CloneClusterInfo(*info), nil
+			clonedInfo := CloneClusterInfo(*info)
+			if isFaultStuck(mockName, "cluster", clonedInfo.IId.SystemId) {
+				clonedInfo.Status = irs.ClusterCreating
+			}
+			return clonedInfo, nil
 		}
 	}
 
@@ -213,6 +251,11 @@ func (clusterHandler *MockClusterHandler) GenerateClusterToken(clusterIID irs.II
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GenerateClusterToken()!")
 
+	if err := injectFault(clusterHandler.MockName, "GenerateClusterToken"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	// Return a mock token for testing purposes
 	return "mock-token-k8s-aws-v1.aHR0cHM6Ly9zdHMuYW1hem9uYXdzLmNvbS8_QWN0aW9uPUdldENhbGxlcklkZW50aXR5", nil
 }
@@ -221,6 +264,11 @@ func (clusterHandler *MockClusterHandler) DeleteCluster(iid irs.IID) (bool, erro
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteCluster()!")
 
+	if err := injectFault(clusterHandler.MockName, "DeleteCluster"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	clusterMapLock.Lock()
 	defer clusterMapLock.Unlock()
 
@@ -244,6 +292,11 @@ func (clusterHandler *MockClusterHandler) AddNodeGroup(clusterIID irs.IID, nodeG
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AddNodeGroup()!")
 
+	if err := injectFault(clusterHandler.MockName, "AddNodeGroup"); err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
 	clusterMapLock.Lock()
 	defer clusterMapLock.Unlock()
 
@@ -255,6 +308,7 @@ func (clusterHandler *MockClusterHandler) AddNodeGroup(clusterIID irs.IID, nodeG
 
 	nodeGroupReqInfo.IId.SystemId = nodeGroupReqInfo.IId.NameId
 	nodeGroupReqInfo.Status = irs.NodeGroupActive
+	resizeMockNodes(&nodeGroupReqInfo)
 
 	for _, info := range infoList {
 		if info.IId.NameId == clusterIID.NameId {
@@ -266,10 +320,31 @@ func (clusterHandler *MockClusterHandler) AddNodeGroup(clusterIID irs.IID, nodeG
 	return irs.NodeGroupInfo{}, fmt.Errorf("%s Cluster does not exist!!", clusterIID.NameId)
 }
 
+// resizeMockNodes adds or removes mock nodes to match the DesiredNodeSize of the NodeGroup.
+// New nodes are named '<NodeGroup NameId>-node-<number>'.
+func resizeMockNodes(nodeGroup *irs.NodeGroupInfo) {
+	if nodeGroup.DesiredNodeSize < 0 {
+		return
+	}
+	if len(nodeGroup.Nodes) > nodeGroup.DesiredNodeSize {
+		nodeGroup.Nodes = nodeGroup.Nodes[:nodeGroup.DesiredNodeSize]
+		return
+	}
+	for num := len(nodeGroup.Nodes) + 1; num <= nodeGroup.DesiredNodeSize; num++ {
+		nodeName := fmt.Sprintf("%s-node-%d", nodeGroup.IId.NameId, num)
+		nodeGroup.Nodes = append(nodeGroup.Nodes, irs.IID{NameId: nodeName, SystemId: nodeName})
+	}
+}
+
 func (clusterHandler *MockClusterHandler) SetNodeGroupAutoScaling(clusterIID irs.IID, nodeGroupIID irs.IID, on bool) (bool, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called SetNodeGroupAutoScaling()!")
 
+	if err := injectFault(clusterHandler.MockName, "SetNodeGroupAutoScaling"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	clusterMapLock.Lock()
 	defer clusterMapLock.Unlock()
 
@@ -297,6 +372,11 @@ func (clusterHandler *MockClusterHandler) ChangeNodeGroupScaling(clusterIID irs.
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ChangeNodeGroupScaling()!")
 
+	if err := injectFault(clusterHandler.MockName, "ChangeNodeGroupScaling"); err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
 	clusterMapLock.Lock()
 	defer clusterMapLock.Unlock()
 
@@ -313,6 +393,7 @@ func (clusterHandler *MockClusterHandler) ChangeNodeGroupScaling(clusterIID irs.
 					info.NodeGroupList[idx].DesiredNodeSize = DesiredNodeSize
 					info.NodeGroupList[idx].MinNodeSize = MinNodeSize
 					info.NodeGroupList[idx].MaxNodeSize = MaxNodeSize
+					resizeMockNodes(&info.NodeGroupList[idx])
 					return CloneNodeGroupInfo(info.NodeGroupList[idx]), nil
 				}
 			}
@@ -326,6 +407,11 @@ func (clusterHandler *MockClusterHandler) RemoveNodeGroup(clusterIID irs.IID, no
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called RemoveNodeGroup()!")
 
+	if err := injectFault(clusterHandler.MockName, "RemoveNodeGroup"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	clusterMapLock.Lock()
 	defer clusterMapLock.Unlock()
 
@@ -353,6 +439,11 @@ func (clusterHandler *MockClusterHandler) UpgradeCluster(clusterIID irs.IID, new
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called UpgradeCluster()!")
 
+	if err := injectFault(clusterHandler.MockName, "UpgradeCluster"); err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
 	clusterMapLock.Lock()
 	defer clusterMapLock.Unlock()
 
@@ -379,6 +470,11 @@ func (ClusterHandler *MockClusterHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(ClusterHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	clusterMapLock.RLock()
 	defer clusterMapLock.RUnlock()
 
@@ -387,6 +483,7 @@ func (ClusterHandler *MockClusterHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "cluster", infoList, func(info *irs.ClusterInfo) irs.IID { return info.IId })
 
 	iidList := make([]*irs.IID, len(infoList))
 	for i, info := range infoList {
@@ -0,0 +1,214 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// dbSpecInfoMap: MockName => DBEngine => DBSpec list
+var dbSpecInfoMap map[string]map[string][]*irs.DBSpecInfo
+
+type MockDBSpecHandler struct {
+	MockName string
+}
+
+func init() {
+	dbSpecInfoMap = make(map[string]map[string][]*irs.DBSpecInfo)
+}
+
+var dbSpecMapLock = new(sync.RWMutex)
+
+// mock DB engines and their major versions
+var mockDBEngineVersions = map[string][]string{
+	"mysql":      {"5.7", "8.0", "8.4"},
+	"mariadb":    {"10.6", "10.11", "11.4"},
+	"postgresql": {"14", "15", "16"},
+}
+
+// mock storage types and size range(GB), same for all engines
+var mockDBStorageTypes = []string{"SSD", "HDD"}
+var mockDBStorageSizeRange = irs.StorageSizeRange{Min: 20, Max: 16384}
+
+// Be called before using the User function.
+// Called in MockDriver
+func PrepareDBSpec(mockName string) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called PrepareDBSpec()!")
+
+	dbSpecMapLock.Lock()
+	defer dbSpecMapLock.Unlock()
+
+	if dbSpecInfoMap[mockName] != nil {
+		return
+	}
+
+	specMap := map[string][]*irs.DBSpecInfo{}
+	for engine := range mockDBEngineVersions {
+		specMap[engine] = []*irs.DBSpecInfo{
+			{Region: "common-region", DBEngine: engine, Name: "mock-dbspec-01", VCpu: irs.VCpuInfo{Count: "2", ClockGHz: "2.5"}, MemSizeMiB: "4096", StorageSizeRangeGB: irs.StorageSizeRange{Min: 20, Max: 4096}},
+			{Region: "common-region", DBEngine: engine, Name: "mock-dbspec-02", VCpu: irs.VCpuInfo{Count: "4", ClockGHz: "2.5"}, MemSizeMiB: "16384", StorageSizeRangeGB: irs.StorageSizeRange{Min: 20, Max: 8192}},
+			{Region: "common-region", DBEngine: engine, Name: "mock-dbspec-03", VCpu: irs.VCpuInfo{Count: "8", ClockGHz: "3.0"}, MemSizeMiB: "32768", StorageSizeRangeGB: irs.StorageSizeRange{Min: 100, Max: 16384}},
+			// spec without usable data: excluded from ListDBSpec, still returned by GetDBSpec
+			{Region: "common-region", DBEngine: engine, Name: "mock-dbspec-nodata", VCpu: irs.VCpuInfo{Count: "-1", ClockGHz: "-1"}, MemSizeMiB: "-1", StorageSizeRangeGB: irs.StorageSizeRange{Min: -1, Max: -1}},
+		}
+	}
+	dbSpecInfoMap[mockName] = specMap
+}
+
+func CloneDBSpecInfo(srcInfo irs.DBSpecInfo) irs.DBSpecInfo {
+	clonedInfo := irs.DBSpecInfo{
+		Region:             srcInfo.Region,
+		DBEngine:           srcInfo.DBEngine,
+		Name:               srcInfo.Name,
+		VCpu:               srcInfo.VCpu,
+		MemSizeMiB:         srcInfo.MemSizeMiB,
+		StorageSizeRangeGB: srcInfo.StorageSizeRangeGB,
+		KeyValueList:       srcInfo.KeyValueList, // now, do not need cloning
+	}
+	if srcInfo.HasNoSpecData() {
+		clonedInfo.MarkStatic("VCpu", "Mock Driver: this spec intentionally provides no vCPU data")
+		clonedInfo.MarkStatic("MemSizeMiB", "Mock Driver: this spec intentionally provides no memory data")
+	}
+	return clonedInfo
+}
+
+func (dbSpecHandler *MockDBSpecHandler) listDBSpec(dbEngine string) ([]*irs.DBSpecInfo, error) {
+	engine, err := irs.NormalizeRDBMSEngine(dbEngine)
+	if err != nil {
+		return nil, err
+	}
+
+	dbSpecMapLock.RLock()
+	defer dbSpecMapLock.RUnlock()
+
+	specMap, ok := dbSpecInfoMap[dbSpecHandler.MockName]
+	if !ok {
+		return []*irs.DBSpecInfo{}, nil
+	}
+
+	clonedInfoList := []*irs.DBSpecInfo{}
+	for _, info := range specMap[engine] {
+		clonedInfo := CloneDBSpecInfo(*info)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList, nil
+}
+
+func (dbSpecHandler *MockDBSpecHandler) ListDBSpec(dbEngine string) ([]*irs.DBSpecInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListDBSpec()!")
+
+	if err := injectFault(dbSpecHandler.MockName, "ListDBSpec"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	infoList, err := dbSpecHandler.listDBSpec(dbEngine)
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	return irs.FilterDBSpecsWithNoData(infoList), nil
+}
+
+func (dbSpecHandler *MockDBSpecHandler) GetDBSpec(dbEngine string, Name string) (irs.DBSpecInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetDBSpec()!")
+
+	if err := injectFault(dbSpecHandler.MockName, "GetDBSpec"); err != nil {
+		cblogger.Error(err)
+		return irs.DBSpecInfo{}, err
+	}
+
+	infoList, err := dbSpecHandler.listDBSpec(dbEngine)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.DBSpecInfo{}, err
+	}
+
+	for _, info := range infoList {
+		if info.Name == Name {
+			return *info, nil
+		}
+	}
+
+	return irs.DBSpecInfo{}, fmt.Errorf("%s DBSpec does not exist for %s!!", Name, dbEngine)
+}
+
+func (dbSpecHandler *MockDBSpecHandler) ListOrgDBSpec(dbEngine string) (string, error) { // return string: json format
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListOrgDBSpec()!")
+
+	if err := injectFault(dbSpecHandler.MockName, "ListOrgDBSpec"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
+	infoList, err := dbSpecHandler.listDBSpec(dbEngine)
+	if err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
+	jsonData, err := json.MarshalIndent(infoList, "", "  ")
+	if err != nil {
+		cblogger.Error("Error while converting to JSON: ", err)
+		return "", err
+	}
+
+	return string(jsonData), nil
+}
+
+func (dbSpecHandler *MockDBSpecHandler) GetOrgDBSpec(dbEngine string, Name string) (string, error) { // return string: json format
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetOrgDBSpec()!")
+
+	if err := injectFault(dbSpecHandler.MockName, "GetOrgDBSpec"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
+	info, err := dbSpecHandler.GetDBSpec(dbEngine, Name)
+	if err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
+	jsonData, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		cblogger.Error("Error while converting to JSON: ", err)
+		return "", err
+	}
+
+	return string(jsonData), nil
+}
+
+// mockDBSpecNames returns the names of the usable DBSpecs of the given engine.
+func mockDBSpecNames(mockName string, engine string) []string {
+	dbSpecHandler := MockDBSpecHandler{mockName}
+	infoList, err := dbSpecHandler.ListDBSpec(engine)
+	if err != nil {
+		return []string{}
+	}
+
+	names := []string{}
+	for _, info := range infoList {
+		names = append(names, info.Name)
+	}
+	return names
+}
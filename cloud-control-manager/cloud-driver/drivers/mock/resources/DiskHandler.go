@@ -39,6 +39,17 @@ func (diskHandler *MockDiskHandler) CreateDisk(diskReqInfo irs.DiskInfo) (irs.Di
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateDisk()!")
 
+	if err := injectFault(diskHandler.MockName, "CreateDisk"); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
+	defer lockQuota(diskHandler.MockName)()
+	if err := checkQuota(diskHandler.MockName, "compute", "disks", 1); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
 	mockName := diskHandler.MockName
 	diskReqInfo.IId.SystemId = diskReqInfo.IId.NameId
 	diskReqInfo.Status = irs.DiskAvailable
@@ -58,6 +69,11 @@ func (diskHandler *MockDiskHandler) CreateDisk(diskReqInfo irs.DiskInfo) (irs.Di
 	infoList = append(infoList, &diskReqInfo)
 	diskInfoMap[mockName] = infoList
 
+	if err := recordFaultCreation(mockName, "CreateDisk", "disk", diskReqInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
 	return CloneDiskInfo(diskReqInfo), nil
 }
 
@@ -105,6 +121,11 @@ func (diskHandler *MockDiskHandler) ListDisk() ([]*irs.DiskInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListDisk()!")
 
+	if err := injectFault(diskHandler.MockName, "ListDisk"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := diskHandler.MockName
 	diskMapLock.RLock()
 	defer diskMapLock.RUnlock()
@@ -112,14 +133,26 @@ func (diskHandler *MockDiskHandler) ListDisk() ([]*irs.DiskInfo, error) {
 	if !ok {
 		return []*irs.DiskInfo{}, nil
 	}
+	infoList = applyListLag(mockName, "ListDisk", "disk", infoList, func(info *irs.DiskInfo) irs.IID { return info.IId })
 	// cloning list of Disk
-	return CloneDiskInfoList(infoList), nil
+	clonedInfoList := CloneDiskInfoList(infoList)
+	for _, info := range clonedInfoList {
+		if isFaultStuck(mockName, "disk", info.IId.SystemId) {
+			info.Status = irs.DiskCreating
+		}
+	}
+	return clonedInfoList, nil
 }
 
 func (diskHandler *MockDiskHandler) GetDisk(iid irs.IID) (irs.DiskInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetDisk()!")
 
+	if err := injectFault(diskHandler.MockName, "GetDisk"); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
 	mockName := diskHandler.MockName
 	diskMapLock.RLock()
 	defer diskMapLock.RUnlock()
@@ -130,7 +163,11 @@ func (diskHandler *MockDiskHandler) GetDisk(iid irs.IID) (irs.DiskInfo, error) {
 
 	for _, info := range infoList {
 		if (*info).IId.NameId == iid.NameId {
-			return CloneDiskInfo(*info), nil
+			clonedInfo := CloneDiskInfo(*info)
+			if isFaultStuck(diskHandler.MockName, "disk", clonedInfo.IId.SystemId) {
+				clonedInfo.Status = irs.DiskCreating
+			}
+			return clonedInfo, nil
 		}
 	}
 
@@ -141,6 +178,11 @@ func (diskHandler *MockDiskHandler) ChangeDiskSize(iid irs.IID, size string) (bo
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ChangeDiskSize()!")
 
+	if err := injectFault(diskHandler.MockName, "ChangeDiskSize"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	mockName := diskHandler.MockName
 
 	diskMapLock.RLock()
@@ -164,6 +206,11 @@ func (diskHandler *MockDiskHandler) DeleteDisk(iid irs.IID) (bool, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteDisk()!")
 
+	if err := injectFault(diskHandler.MockName, "DeleteDisk"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	mockName := diskHandler.MockName
 
 	diskMapLock.Lock()
@@ -191,6 +238,11 @@ func (diskHandler *MockDiskHandler) AttachDisk(diskIID irs.IID, ownerVM irs.IID)
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AttachDisk()!")
 
+	if err := injectFault(diskHandler.MockName, "AttachDisk"); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
 	mockName := diskHandler.MockName
 
 	diskMapLock.RLock()
@@ -222,6 +274,11 @@ func (diskHandler *MockDiskHandler) DetachDisk(diskIID irs.IID, ownerVM irs.IID)
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DetachDisk()!")
 
+	if err := injectFault(diskHandler.MockName, "DetachDisk"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	mockName := diskHandler.MockName
 
 	diskMapLock.RLock()
@@ -300,6 +357,11 @@ func (DiskHandler *MockDiskHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(DiskHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := DiskHandler.MockName
 	diskMapLock.RLock()
 	defer diskMapLock.RUnlock()
@@ -307,6 +369,7 @@ func (DiskHandler *MockDiskHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "disk", infoList, func(info *irs.DiskInfo) irs.IID { return info.IId })
 
 	iidList := []*irs.IID{}
 	for _, info := range infoList {
@@ -0,0 +1,295 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var diskSnapshotInfoMap map[string][]*irs.DiskSnapshotInfo
+
+type MockDiskSnapshotHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	diskSnapshotInfoMap = make(map[string][]*irs.DiskSnapshotInfo)
+}
+
+var diskSnapshotMapLock = new(sync.RWMutex)
+
+// (1) get the source Disk
+// (2) insert snapshotInfo into global Map
+func (snapshotHandler *MockDiskSnapshotHandler) CreateSnapshot(snapshotReqInfo irs.DiskSnapshotInfo) (irs.DiskSnapshotInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateSnapshot()!")
+
+	if err := injectFault(snapshotHandler.MockName, "CreateSnapshot"); err != nil {
+		cblogger.Error(err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+
+	defer lockQuota(snapshotHandler.MockName)()
+	if err := checkQuota(snapshotHandler.MockName, "compute", "disk-snapshots", 1); err != nil {
+		cblogger.Error(err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+
+	mockName := snapshotHandler.MockName
+
+	// (1) get the source Disk
+	diskHandler := MockDiskHandler{MockName: mockName}
+	diskInfo, err := diskHandler.GetDisk(snapshotReqInfo.SourceDisk)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+
+	diskSnapshotMapLock.Lock()
+	defer diskSnapshotMapLock.Unlock()
+
+	for _, info := range diskSnapshotInfoMap[mockName] {
+		if info.IId.NameId == snapshotReqInfo.IId.NameId {
+			err := fmt.Errorf("%s Disk Snapshot already exists!!", snapshotReqInfo.IId.NameId)
+			cblogger.Error(err)
+			return irs.DiskSnapshotInfo{}, err
+		}
+	}
+
+	snapshotInfo := irs.DiskSnapshotInfo{
+		IId:         irs.IID{NameId: snapshotReqInfo.IId.NameId, SystemId: snapshotReqInfo.IId.NameId},
+		SourceDisk:  diskInfo.IId,
+		DiskSize:    diskInfo.DiskSize,
+		Status:      irs.DiskSnapshotAvailable,
+		CreatedTime: time.Now(),
+		TagList:     snapshotReqInfo.TagList,
+	}
+
+	// (2) insert DiskSnapshotInfo into global Map
+	diskSnapshotInfoMap[mockName] = append(diskSnapshotInfoMap[mockName], &snapshotInfo)
+
+	if err := recordFaultCreation(mockName, "CreateSnapshot", "disksnapshot", snapshotInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+
+	return CloneDiskSnapshotInfo(snapshotInfo), nil
+}
+
+func CloneDiskSnapshotInfoList(srcInfoList []*irs.DiskSnapshotInfo) []*irs.DiskSnapshotInfo {
+	clonedInfoList := []*irs.DiskSnapshotInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneDiskSnapshotInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneDiskSnapshotInfo(srcInfo irs.DiskSnapshotInfo) irs.DiskSnapshotInfo {
+	// clone DiskSnapshotInfo
+	clonedInfo := irs.DiskSnapshotInfo{
+		IId:          irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		SourceDisk:   irs.IID{srcInfo.SourceDisk.NameId, srcInfo.SourceDisk.SystemId},
+		DiskSize:     srcInfo.DiskSize,
+		Status:       srcInfo.Status,
+		CreatedTime:  srcInfo.CreatedTime,
+		TagList:      srcInfo.TagList,      // clone TagList
+		KeyValueList: srcInfo.KeyValueList, // now, do not need cloning
+	}
+
+	return clonedInfo
+}
+
+func (snapshotHandler *MockDiskSnapshotHandler) ListSnapshot() ([]*irs.DiskSnapshotInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListSnapshot()!")
+
+	if err := injectFault(snapshotHandler.MockName, "ListSnapshot"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := snapshotHandler.MockName
+	diskSnapshotMapLock.RLock()
+	defer diskSnapshotMapLock.RUnlock()
+	infoList, ok := diskSnapshotInfoMap[mockName]
+	if !ok {
+		return []*irs.DiskSnapshotInfo{}, nil
+	}
+	infoList = applyListLag(mockName, "ListSnapshot", "disksnapshot", infoList, func(info *irs.DiskSnapshotInfo) irs.IID { return info.IId })
+	// cloning list of DiskSnapshot
+	clonedInfoList := CloneDiskSnapshotInfoList(infoList)
+	for _, info := range clonedInfoList {
+		if isFaultStuck(mockName, "disksnapshot", info.IId.SystemId) {
+			info.Status = irs.DiskSnapshotCreating
+		}
+	}
+	return clonedInfoList, nil
+}
+
+// findDiskSnapshot returns the snapshot in the global Map. Caller must hold diskSnapshotMapLock.
+func findDiskSnapshot(mockName string, iid irs.IID) (*irs.DiskSnapshotInfo, error) {
+	for _, info := range diskSnapshotInfoMap[mockName] {
+		if info.IId.NameId == iid.NameId {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%s Disk Snapshot does not exist!!", iid.NameId)
+}
+
+func (snapshotHandler *MockDiskSnapshotHandler) GetSnapshot(iid irs.IID) (irs.DiskSnapshotInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetSnapshot()!")
+
+	if err := injectFault(snapshotHandler.MockName, "GetSnapshot"); err != nil {
+		cblogger.Error(err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+
+	diskSnapshotMapLock.RLock()
+	defer diskSnapshotMapLock.RUnlock()
+
+	info, err := findDiskSnapshot(snapshotHandler.MockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.DiskSnapshotInfo{}, err
+	}
+	clonedInfo := CloneDiskSnapshotInfo(*info)
+	if isFaultStuck(snapshotHandler.MockName, "disksnapshot", clonedInfo.IId.SystemId) {
+		clonedInfo.Status = irs.DiskSnapshotCreating
+	}
+	return clonedInfo, nil
+}
+
+func (snapshotHandler *MockDiskSnapshotHandler) DeleteSnapshot(iid irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteSnapshot()!")
+
+	if err := injectFault(snapshotHandler.MockName, "DeleteSnapshot"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := snapshotHandler.MockName
+
+	diskSnapshotMapLock.Lock()
+	defer diskSnapshotMapLock.Unlock()
+
+	infoList := diskSnapshotInfoMap[mockName]
+	for idx, info := range infoList {
+		if info.IId.SystemId == iid.SystemId {
+			diskSnapshotInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+
+	err := fmt.Errorf("%s Disk Snapshot does not exist!!", iid.NameId)
+	cblogger.Error(err)
+	return false, err
+}
+
+// (1) check the size of the new Disk
+// (2) insert diskInfo into global Map of Disks
+func (snapshotHandler *MockDiskSnapshotHandler) CreateDiskFromSnapshot(snapshotIID irs.IID, diskReqInfo irs.DiskInfo) (irs.DiskInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateDiskFromSnapshot()!")
+
+	if err := injectFault(snapshotHandler.MockName, "CreateDiskFromSnapshot"); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
+	defer lockQuota(snapshotHandler.MockName)()
+	if err := checkQuota(snapshotHandler.MockName, "compute", "disks", 1); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
+	mockName := snapshotHandler.MockName
+
+	diskSnapshotMapLock.RLock()
+	snapshotInfo, err := findDiskSnapshot(mockName, snapshotIID)
+	if err != nil {
+		diskSnapshotMapLock.RUnlock()
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+	snapshotSize := snapshotInfo.DiskSize
+	diskSnapshotMapLock.RUnlock()
+
+	// (1) check the size of the new Disk
+	if diskReqInfo.DiskSize == "default" || diskReqInfo.DiskSize == "" {
+		diskReqInfo.DiskSize = snapshotSize
+	}
+	reqSize, err := strconv.Atoi(diskReqInfo.DiskSize)
+	if err != nil {
+		err := fmt.Errorf("invalid DiskSize: %s", diskReqInfo.DiskSize)
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+	if srcSize, err := strconv.Atoi(snapshotSize); err == nil && reqSize < srcSize {
+		err := fmt.Errorf("DiskSize(%d) can not be smaller than the size(%d) of %s Disk Snapshot!!", reqSize, srcSize, snapshotIID.NameId)
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
+	diskReqInfo.IId.SystemId = diskReqInfo.IId.NameId
+	diskReqInfo.Status = irs.DiskAvailable
+	diskReqInfo.OwnerVM = irs.IID{}
+	diskReqInfo.CreatedTime = time.Now()
+	if diskReqInfo.DiskType == "default" || diskReqInfo.DiskType == "" {
+		diskReqInfo.DiskType = "SSD"
+	}
+
+	// (2) insert DiskInfo into global Map of Disks
+	diskMapLock.Lock()
+	defer diskMapLock.Unlock()
+	diskInfoMap[mockName] = append(diskInfoMap[mockName], &diskReqInfo)
+
+	if err := recordFaultCreation(mockName, "CreateDiskFromSnapshot", "disk", diskReqInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.DiskInfo{}, err
+	}
+
+	return CloneDiskInfo(diskReqInfo), nil
+}
+
+func (snapshotHandler *MockDiskSnapshotHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	if err := injectFault(snapshotHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := snapshotHandler.MockName
+	diskSnapshotMapLock.RLock()
+	defer diskSnapshotMapLock.RUnlock()
+	infoList, ok := diskSnapshotInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+	infoList = applyListLag(mockName, "ListIID", "disksnapshot", infoList, func(info *irs.DiskSnapshotInfo) irs.IID { return info.IId })
+
+	iidList := []*irs.IID{}
+	for _, info := range infoList {
+		iidList = append(iidList, &irs.IID{NameId: info.IId.NameId, SystemId: info.IId.SystemId})
+	}
+	return iidList, nil
+}
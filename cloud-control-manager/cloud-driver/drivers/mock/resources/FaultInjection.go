@@ -0,0 +1,351 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// ErrInjectedFault is wrapped by the errors injected by the fault rules.
+var ErrInjectedFault = errors.New("InjectedFault")
+
+// FAULT_ALL_OPERATIONS is the operation name of the rule applied to operations without their own rule.
+const FAULT_ALL_OPERATIONS = "*"
+
+// FaultRule is the fault injected into an operation of the mock handlers.
+// The operation is the name of a handler method, ex) StartVM, ListVPC, GetRDBMS.
+type FaultRule struct {
+	Delay       time.Duration // sleep before the operation
+	ErrorRate   float64       // probability(0~1) of failing before the operation
+	PartialRate float64       // probability(0~1) of failing after the resource is created, only for create operations
+	Stuck       bool          // created resource stays in Creating, only for create operations
+	StuckTime   time.Duration // how long the resource stays in Creating, 0 means forever
+	ListLag     time.Duration // list operations hide resources created within this time, only for list operations
+}
+
+// faultRuleMap: MockName => operation => rule
+var faultRuleMap map[string]map[string]FaultRule
+var faultRuleMapLock = new(sync.RWMutex)
+
+// faultInitMap: MockName => true if the rules are initialized by the credential or set by the admin API.
+// It is protected by faultRuleMapLock.
+var faultInitMap map[string]bool
+
+type mockCreationInfo struct {
+	createdTime time.Time
+	stuck       bool
+	stuckUntil  time.Time // zero means forever
+}
+
+// faultCreationMap: MockName => kind/SystemId => creation info
+// It is a leaf lock, so it can be used while holding the map lock of a resource.
+var faultCreationMap map[string]map[string]mockCreationInfo
+var faultCreationMapLock = new(sync.RWMutex)
+
+func init() {
+	faultRuleMap = make(map[string]map[string]FaultRule)
+	faultInitMap = make(map[string]bool)
+	faultCreationMap = make(map[string]map[string]mockCreationInfo)
+}
+
+// ParseFaultSpec parses a fault spec string.
+// Rules are separated by ';', and each rule is '<operation>:<key>=<value>,...'.
+// The operation '*' is applied to all operations without their own rule.
+//
+//	StartVM:delay=2s,error=0.3;CreateRDBMS:stuck=true;ListVM:lag=5s;*:delay=100ms
+//
+// keys:
+//
+//	delay=<duration>        sleep before the operation
+//	error=<0~1>             probability of failing before the operation
+//	partial=<0~1>           probability of failing after the resource is created
+//	stuck=<true|duration>   created resource stays in Creating forever or for the duration
+//	lag=<duration>          list hides resources created within the duration
+func ParseFaultSpec(spec string) (map[string]FaultRule, error) {
+	rules := map[string]FaultRule{}
+	for _, ruleStr := range strings.Split(spec, ";") {
+		ruleStr = strings.TrimSpace(ruleStr)
+		if ruleStr == "" {
+			continue
+		}
+		op, params, ok := strings.Cut(ruleStr, ":")
+		op = strings.TrimSpace(op)
+		if !ok || op == "" {
+			return nil, fmt.Errorf("%s: invalid fault rule, it should be '<operation>:<key>=<value>,...'", ruleStr)
+		}
+
+		rule := rules[op]
+		for _, param := range strings.Split(params, ",") {
+			param = strings.TrimSpace(param)
+			if param == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(param, "=")
+			if !ok {
+				return nil, fmt.Errorf("%s: invalid fault parameter, it should be '<key>=<value>'", param)
+			}
+			key = strings.ToLower(strings.TrimSpace(key))
+			value = strings.TrimSpace(value)
+
+			var err error
+			switch key {
+			case "delay":
+				rule.Delay, err = parseFaultDuration(value)
+			case "error":
+				rule.ErrorRate, err = parseFaultRate(value)
+			case "partial":
+				rule.PartialRate, err = parseFaultRate(value)
+			case "stuck":
+				if stuck, boolErr := strconv.ParseBool(value); boolErr == nil {
+					rule.Stuck, rule.StuckTime = stuck, 0
+				} else {
+					rule.StuckTime, err = parseFaultDuration(value)
+					rule.Stuck = err == nil
+				}
+			case "lag":
+				rule.ListLag, err = parseFaultDuration(value)
+			default:
+				err = fmt.Errorf("unknown fault key, it should be one of delay, error, partial, stuck, lag")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", param, err)
+			}
+		}
+		rules[op] = rule
+	}
+	return rules, nil
+}
+
+func parseFaultDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration, ex) 500ms, 2s, 1m")
+	}
+	return duration, nil
+}
+
+func parseFaultRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid rate, it should be between 0 and 1")
+	}
+	return rate, nil
+}
+
+// FormatFaultSpec returns the spec string of the rules, sorted by the operation.
+func FormatFaultSpec(rules map[string]FaultRule) string {
+	ops := make([]string, 0, len(rules))
+	for op := range rules {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	ruleStrs := []string{}
+	for _, op := range ops {
+		rule := rules[op]
+		params := []string{}
+		if rule.Delay > 0 {
+			params = append(params, "delay="+rule.Delay.String())
+		}
+		if rule.ErrorRate > 0 {
+			params = append(params, "error="+strconv.FormatFloat(rule.ErrorRate, 'f', -1, 64))
+		}
+		if rule.PartialRate > 0 {
+			params = append(params, "partial="+strconv.FormatFloat(rule.PartialRate, 'f', -1, 64))
+		}
+		if rule.Stuck {
+			if rule.StuckTime > 0 {
+				params = append(params, "stuck="+rule.StuckTime.String())
+			} else {
+				params = append(params, "stuck=true")
+			}
+		}
+		if rule.ListLag > 0 {
+			params = append(params, "lag="+rule.ListLag.String())
+		}
+		if len(params) > 0 {
+			ruleStrs = append(ruleStrs, op+":"+strings.Join(params, ","))
+		}
+	}
+	return strings.Join(ruleStrs, ";")
+}
+
+// SetFaultSpec replaces the fault rules of the MockName with the spec.
+// An empty spec clears the rules.
+func SetFaultSpec(mockName string, spec string) error {
+	rules, err := ParseFaultSpec(spec)
+	if err != nil {
+		return err
+	}
+	SetFaultRules(mockName, rules)
+	return nil
+}
+
+// InitFaultSpec applies the fault spec of the credential when the MockName is first connected.
+// After that, the rules are kept, so the rules set by the admin API take precedence.
+func InitFaultSpec(mockName string, spec string) error {
+	rules, err := ParseFaultSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	faultRuleMapLock.Lock()
+	defer faultRuleMapLock.Unlock()
+
+	if faultInitMap[mockName] {
+		return nil
+	}
+	setFaultRules(mockName, rules)
+	return nil
+}
+
+// SetFaultRules replaces the fault rules of the MockName.
+func SetFaultRules(mockName string, rules map[string]FaultRule) {
+	faultRuleMapLock.Lock()
+	defer faultRuleMapLock.Unlock()
+
+	setFaultRules(mockName, rules)
+}
+
+// setFaultRules replaces the fault rules of the MockName. Caller must hold faultRuleMapLock.
+func setFaultRules(mockName string, rules map[string]FaultRule) {
+	faultInitMap[mockName] = true
+	if len(rules) == 0 {
+		delete(faultRuleMap, mockName)
+		return
+	}
+	copied := make(map[string]FaultRule, len(rules))
+	for op, rule := range rules {
+		copied[op] = rule
+	}
+	faultRuleMap[mockName] = copied
+}
+
+// GetFaultSpec returns the spec string of the fault rules of the MockName.
+func GetFaultSpec(mockName string) string {
+	faultRuleMapLock.RLock()
+	defer faultRuleMapLock.RUnlock()
+
+	return FormatFaultSpec(faultRuleMap[mockName])
+}
+
+// ClearFault removes the fault rules of the MockName and releases its stuck resources.
+func ClearFault(mockName string) {
+	faultRuleMapLock.Lock()
+	setFaultRules(mockName, nil)
+	faultRuleMapLock.Unlock()
+
+	faultCreationMapLock.Lock()
+	defer faultCreationMapLock.Unlock()
+	for key, info := range faultCreationMap[mockName] {
+		info.stuck = false
+		faultCreationMap[mockName][key] = info
+	}
+}
+
+// getFaultRule returns the rule of the operation, or the rule of all operations.
+func getFaultRule(mockName string, op string) (FaultRule, bool) {
+	faultRuleMapLock.RLock()
+	defer faultRuleMapLock.RUnlock()
+
+	rules, ok := faultRuleMap[mockName]
+	if !ok {
+		return FaultRule{}, false
+	}
+	if rule, ok := rules[op]; ok {
+		return rule, true
+	}
+	rule, ok := rules[FAULT_ALL_OPERATIONS]
+	return rule, ok
+}
+
+// injectFault sleeps and fails the operation by its fault rule.
+// Must be called before the operation changes the mock state.
+func injectFault(mockName string, op string) error {
+	rule, ok := getFaultRule(mockName, op)
+	if !ok {
+		return nil
+	}
+	if rule.Delay > 0 {
+		time.Sleep(rule.Delay)
+	}
+	if rule.ErrorRate > 0 && rand.Float64() < rule.ErrorRate {
+		return fmt.Errorf("%w: %s failed by the fault rule of %s!! (error rate: %v)", ErrInjectedFault, op, mockName, rule.ErrorRate)
+	}
+	return nil
+}
+
+// recordFaultCreation records the creation of a resource for the stuck and lag rules,
+// and fails the create operation after the creation by the partial rate.
+// kind is the resource type, ex) vm, vpc, disk.
+func recordFaultCreation(mockName string, op string, kind string, systemId string) error {
+	rule, _ := getFaultRule(mockName, op)
+
+	info := mockCreationInfo{createdTime: time.Now(), stuck: rule.Stuck}
+	if rule.Stuck && rule.StuckTime > 0 {
+		info.stuckUntil = info.createdTime.Add(rule.StuckTime)
+	}
+
+	faultCreationMapLock.Lock()
+	if faultCreationMap[mockName] == nil {
+		faultCreationMap[mockName] = make(map[string]mockCreationInfo)
+	}
+	faultCreationMap[mockName][kind+"/"+systemId] = info
+	faultCreationMapLock.Unlock()
+
+	if rule.PartialRate > 0 && rand.Float64() < rule.PartialRate {
+		return fmt.Errorf("%w: %s failed after creating %s by the fault rule of %s!! (partial rate: %v)", ErrInjectedFault, op, systemId, mockName, rule.PartialRate)
+	}
+	return nil
+}
+
+// isFaultStuck reports whether the resource is still stuck in Creating.
+func isFaultStuck(mockName string, kind string, systemId string) bool {
+	faultCreationMapLock.RLock()
+	defer faultCreationMapLock.RUnlock()
+
+	info, ok := faultCreationMap[mockName][kind+"/"+systemId]
+	if !ok || !info.stuck {
+		return false
+	}
+	return info.stuckUntil.IsZero() || time.Now().Before(info.stuckUntil)
+}
+
+// applyListLag hides the resources created within the lag of the list operation,
+// like the eventual consistency of CSP list APIs.
+func applyListLag[T any](mockName string, op string, kind string, infoList []*T, getIID func(*T) irs.IID) []*T {
+	rule, ok := getFaultRule(mockName, op)
+	if !ok || rule.ListLag <= 0 {
+		return infoList
+	}
+
+	faultCreationMapLock.RLock()
+	defer faultCreationMapLock.RUnlock()
+
+	visibleList := make([]*T, 0, len(infoList))
+	for _, info := range infoList {
+		creationInfo, ok := faultCreationMap[mockName][kind+"/"+getIID(info).SystemId]
+		if ok && time.Since(creationInfo.createdTime) < rule.ListLag {
+			continue
+		}
+		visibleList = append(visibleList, info)
+	}
+	return visibleList
+}
@@ -0,0 +1,854 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var fileSystemInfoMap map[string][]*irs.FileSystemInfo
+
+// fsBackupInfoMap: MockName => backups of all file systems
+var fsBackupInfoMap map[string][]*irs.FileSystemBackupInfo
+
+type MockFileSystemHandler struct {
+	Region   idrv.RegionInfo
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	fileSystemInfoMap = make(map[string][]*irs.FileSystemInfo)
+	fsBackupInfoMap = make(map[string][]*irs.FileSystemBackupInfo)
+}
+
+// fileSystemMapLock guards both fileSystemInfoMap and fsBackupInfoMap
+var fileSystemMapLock = new(sync.RWMutex)
+
+var fsBackupSeq uint32
+
+// mock file system options: Tier => capacity range(GB)
+var mockFileSystemTiers = map[string]irs.CapacityGBRange{
+	"STANDARD": {Min: 100, Max: 65536},
+	"PREMIUM":  {Min: 1024, Max: 102400},
+}
+
+var mockNFSVersions = []string{"3.0", "4.1"}
+
+// default backup schedule: "0 5 * * *" (Every day at 5 AM)
+var mockDefaultBackupSchedule = irs.CronSchedule{Minute: "0", Hour: "5", DayOfMonth: "*", Month: "*", DayOfWeek: "*"}
+
+func (fileSystemHandler *MockFileSystemHandler) GetMetaInfo() (irs.FileSystemMetaInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetMetaInfo()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "GetMetaInfo"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemMetaInfo{}, err
+	}
+
+	metaInfo := irs.FileSystemMetaInfo{
+		SupportsFileSystemType: map[irs.FileSystemType]bool{
+			irs.RegionType:          true,
+			irs.ZoneType:            true,
+			irs.RegionVPCBasedType:  true,
+			irs.RegionZoneBasedType: true,
+		},
+		SupportsVPC: map[irs.RSType]bool{
+			irs.VPC: true,
+		},
+		SupportsNFSVersion: mockNFSVersions,
+		SupportsCapacity:   true,
+		CapacityGBOptions:  map[string]irs.CapacityGBRange{},
+		PerformanceOptions: map[string][]string{
+			"Tier": {"STANDARD", "PREMIUM"},
+		},
+	}
+	for tier, capacityRange := range mockFileSystemTiers {
+		metaInfo.CapacityGBOptions[tier] = capacityRange
+	}
+
+	return metaInfo, nil
+}
+
+// (1) validate the request and fill defaults
+// (2) create a mount target for each access subnet
+// (3) insert FileSystemInfo into global Map
+func (fileSystemHandler *MockFileSystemHandler) CreateFileSystem(reqInfo irs.FileSystemInfo) (irs.FileSystemInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateFileSystem()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "CreateFileSystem"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	defer lockQuota(fileSystemHandler.MockName)()
+	if err := checkQuota(fileSystemHandler.MockName, "storage", "filesystems", 1); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	// (1) validation
+	if reqInfo.IId.NameId == "" {
+		err := fmt.Errorf("FileSystem NameId is required!!")
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	err := fileSystemHandler.validateFileSystemReqInfo(&reqInfo)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	for _, info := range fileSystemInfoMap[mockName] {
+		if info.IId.NameId == reqInfo.IId.NameId {
+			err := fmt.Errorf("%s FileSystem already exists!!", reqInfo.IId.NameId)
+			cblogger.Error(err)
+			return irs.FileSystemInfo{}, err
+		}
+	}
+
+	fsIID := irs.IID{NameId: reqInfo.IId.NameId, SystemId: reqInfo.IId.NameId}
+	fsInfo := irs.FileSystemInfo{
+		IId:              fsIID,
+		Region:           reqInfo.Region,
+		Zone:             reqInfo.Zone,
+		VpcIID:           reqInfo.VpcIID,
+		AccessSubnetList: reqInfo.AccessSubnetList,
+		Encryption:       reqInfo.Encryption,
+		BackupSchedule: irs.FileSystemBackupInfo{
+			FileSystemIID: fsIID.SystemId,
+			Schedule:      reqInfo.BackupSchedule.Schedule,
+		},
+		TagList:         reqInfo.TagList,
+		FileSystemType:  reqInfo.FileSystemType,
+		NFSVersion:      reqInfo.NFSVersion,
+		CapacityGB:      reqInfo.CapacityGB,
+		PerformanceInfo: reqInfo.PerformanceInfo,
+		Status:          irs.FileSystemAvailable,
+		UsedSizeGB:      0,
+		CreatedTime:     time.Now(),
+	}
+
+	// (2) mount targets
+	for _, subnetIID := range fsInfo.AccessSubnetList {
+		fsInfo.MountTargetList = append(fsInfo.MountTargetList, newMockMountTarget(fsInfo, subnetIID))
+	}
+
+	// (3) insert FileSystemInfo into global Map
+	fileSystemInfoMap[mockName] = append(fileSystemInfoMap[mockName], &fsInfo)
+
+	if err := recordFaultCreation(mockName, "CreateFileSystem", "filesystem", fsInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	return CloneFileSystemInfo(fsInfo), nil
+}
+
+func (fileSystemHandler *MockFileSystemHandler) validateFileSystemReqInfo(reqInfo *irs.FileSystemInfo) error {
+	mockName := fileSystemHandler.MockName
+
+	// region & zone
+	if reqInfo.Region == "" {
+		reqInfo.Region = fileSystemHandler.Region.Region
+	}
+	switch reqInfo.FileSystemType {
+	case "":
+		reqInfo.FileSystemType = irs.RegionType
+	case irs.RegionType, irs.RegionVPCBasedType:
+	case irs.ZoneType, irs.RegionZoneBasedType:
+		if reqInfo.Zone == "" {
+			reqInfo.Zone = fileSystemHandler.Region.Zone
+		}
+		if reqInfo.Zone == "" {
+			return fmt.Errorf("Zone is required for %s FileSystem!!", reqInfo.FileSystemType)
+		}
+	default:
+		return fmt.Errorf("%s FileSystemType is not supported!!", reqInfo.FileSystemType)
+	}
+
+	// NFS version
+	if reqInfo.NFSVersion == "" || reqInfo.NFSVersion == "default" {
+		reqInfo.NFSVersion = mockNFSVersions[len(mockNFSVersions)-1]
+	}
+	if !containsString(mockNFSVersions, reqInfo.NFSVersion) {
+		return fmt.Errorf("NFS %s is not supported!! Supported versions: %s", reqInfo.NFSVersion, strings.Join(mockNFSVersions, ", "))
+	}
+
+	// performance & capacity
+	if reqInfo.PerformanceInfo == nil {
+		reqInfo.PerformanceInfo = map[string]string{}
+	}
+	for key := range reqInfo.PerformanceInfo {
+		if key != "Tier" {
+			return fmt.Errorf("%s is not a supported performance option!! Supported options: Tier", key)
+		}
+	}
+	tier := reqInfo.PerformanceInfo["Tier"]
+	if tier == "" {
+		tier = "STANDARD"
+	}
+	capacityRange, ok := mockFileSystemTiers[tier]
+	if !ok {
+		return fmt.Errorf("%s Tier is not supported!! Supported tiers: STANDARD, PREMIUM", tier)
+	}
+	reqInfo.PerformanceInfo["Tier"] = tier
+	if reqInfo.CapacityGB <= 0 {
+		reqInfo.CapacityGB = capacityRange.Min
+	}
+	if reqInfo.CapacityGB < capacityRange.Min || reqInfo.CapacityGB > capacityRange.Max {
+		return fmt.Errorf("CapacityGB %d is out of range(%d-%dGB) for %s Tier!!", reqInfo.CapacityGB,
+			capacityRange.Min, capacityRange.Max, tier)
+	}
+
+	// backup schedule
+	if reqInfo.BackupSchedule.Schedule == (irs.CronSchedule{}) {
+		reqInfo.BackupSchedule.Schedule = mockDefaultBackupSchedule
+	}
+	if err := validateCronSchedule(reqInfo.BackupSchedule.Schedule); err != nil {
+		return err
+	}
+
+	// vpc & access subnets
+	vpcInfo, err := getMockVPC(mockName, reqInfo.VpcIID)
+	if err != nil {
+		return err
+	}
+	reqInfo.VpcIID = vpcInfo.IId
+	accessSubnetList := []irs.IID{}
+	for _, subnetIID := range reqInfo.AccessSubnetList {
+		_, subnetInfo, err := getMockVPCSubnet(mockName, vpcInfo.IId, subnetIID)
+		if err != nil {
+			return err
+		}
+		for _, one := range accessSubnetList {
+			if one.SystemId == subnetInfo.IId.SystemId {
+				return fmt.Errorf("%s Subnet is duplicated in AccessSubnetList!!", subnetIID.NameId)
+			}
+		}
+		accessSubnetList = append(accessSubnetList, subnetInfo.IId)
+	}
+	reqInfo.AccessSubnetList = accessSubnetList
+
+	return nil
+}
+
+// getMockVPC finds the VPC in the mock VPC map by SystemId(or NameId if SystemId is empty).
+func getMockVPC(mockName string, vpcIID irs.IID) (irs.VPCInfo, error) {
+	vpcHandler := MockVPCHandler{mockName}
+	vpcInfoList, err := vpcHandler.ListVPC()
+	if err != nil {
+		return irs.VPCInfo{}, err
+	}
+	for _, vpcInfo := range vpcInfoList {
+		if isSameMockIID(vpcInfo.IId, vpcIID) {
+			return *vpcInfo, nil
+		}
+	}
+	return irs.VPCInfo{}, fmt.Errorf("%s VPC does not exist!!", vpcIID.NameId)
+}
+
+// validateCronSchedule accepts '*', '*/step', 'n', 'n-m' and comma-separated lists of them.
+func validateCronSchedule(schedule irs.CronSchedule) error {
+	fields := []struct {
+		name     string
+		value    string
+		min, max int
+	}{
+		{"Minute", schedule.Minute, 0, 59},
+		{"Hour", schedule.Hour, 0, 23},
+		{"DayOfMonth", schedule.DayOfMonth, 1, 31},
+		{"Month", schedule.Month, 1, 12},
+		{"DayOfWeek", schedule.DayOfWeek, 0, 6},
+	}
+	for _, field := range fields {
+		if err := validateCronField(field.value, field.min, field.max); err != nil {
+			return fmt.Errorf("invalid cron %s '%s': %v", field.name, field.value, err)
+		}
+	}
+	return nil
+}
+
+func validateCronField(value string, min int, max int) error {
+	if value == "" {
+		return fmt.Errorf("empty value")
+	}
+	for _, item := range strings.Split(value, ",") {
+		if strings.HasPrefix(item, "*") {
+			if item == "*" {
+				continue
+			}
+			step, err := strconv.Atoi(strings.TrimPrefix(item, "*/"))
+			if !strings.HasPrefix(item, "*/") || err != nil || step < 1 || step > max {
+				return fmt.Errorf("bad step '%s'", item)
+			}
+			continue
+		}
+		bounds := strings.SplitN(item, "-", 2)
+		for _, bound := range bounds {
+			n, err := strconv.Atoi(bound)
+			if err != nil || n < min || n > max {
+				return fmt.Errorf("'%s' is not in %d-%d", bound, min, max)
+			}
+		}
+		if len(bounds) == 2 {
+			from, _ := strconv.Atoi(bounds[0])
+			to, _ := strconv.Atoi(bounds[1])
+			if from > to {
+				return fmt.Errorf("bad range '%s'", item)
+			}
+		}
+	}
+	return nil
+}
+
+func newMockMountTarget(fsInfo irs.FileSystemInfo, subnetIID irs.IID) irs.MountTargetInfo {
+	endpoint := fmt.Sprintf("%s.%s.nfs.spider.barista.com", fsInfo.IId.SystemId, subnetIID.SystemId)
+	return irs.MountTargetInfo{
+		SubnetIID:           subnetIID,
+		Endpoint:            endpoint,
+		MountCommandExample: fmt.Sprintf("sudo mount -t nfs -o vers=%s %s:/ /mnt/%s", fsInfo.NFSVersion, endpoint, fsInfo.IId.NameId),
+	}
+}
+
+func CloneFileSystemInfoList(srcInfoList []*irs.FileSystemInfo) []*irs.FileSystemInfo {
+	clonedInfoList := []*irs.FileSystemInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneFileSystemInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneFileSystemInfo(srcInfo irs.FileSystemInfo) irs.FileSystemInfo {
+	// clone FileSystemInfo
+	clonedInfo := irs.FileSystemInfo{
+		IId:              irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		Region:           srcInfo.Region,
+		Zone:             srcInfo.Zone,
+		VpcIID:           irs.IID{srcInfo.VpcIID.NameId, srcInfo.VpcIID.SystemId},
+		AccessSubnetList: append([]irs.IID{}, srcInfo.AccessSubnetList...),
+		Encryption:       srcInfo.Encryption,
+		BackupSchedule:   CloneFileSystemBackupInfo(srcInfo.BackupSchedule),
+		TagList:          srcInfo.TagList, // clone TagList
+		FileSystemType:   srcInfo.FileSystemType,
+		NFSVersion:       srcInfo.NFSVersion,
+		CapacityGB:       srcInfo.CapacityGB,
+		PerformanceInfo:  map[string]string{},
+		Status:           srcInfo.Status,
+		UsedSizeGB:       srcInfo.UsedSizeGB,
+		MountTargetList:  append([]irs.MountTargetInfo{}, srcInfo.MountTargetList...),
+		CreatedTime:      srcInfo.CreatedTime,
+		KeyValueList:     srcInfo.KeyValueList, // now, do not need cloning
+	}
+	for key, value := range srcInfo.PerformanceInfo {
+		clonedInfo.PerformanceInfo[key] = value
+	}
+
+	return clonedInfo
+}
+
+func CloneFileSystemBackupInfo(srcInfo irs.FileSystemBackupInfo) irs.FileSystemBackupInfo {
+	return irs.FileSystemBackupInfo{
+		FileSystemIID: srcInfo.FileSystemIID,
+		Schedule:      srcInfo.Schedule,
+		BackupID:      srcInfo.BackupID,
+		CreationTime:  srcInfo.CreationTime,
+		KeyValueList:  srcInfo.KeyValueList, // now, do not need cloning
+	}
+}
+
+func (fileSystemHandler *MockFileSystemHandler) ListFileSystem() ([]*irs.FileSystemInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListFileSystem()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "ListFileSystem"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := fileSystemHandler.MockName
+	fileSystemMapLock.RLock()
+	defer fileSystemMapLock.RUnlock()
+
+	infoList, ok := fileSystemInfoMap[mockName]
+	if !ok {
+		return []*irs.FileSystemInfo{}, nil
+	}
+
+	infoList = applyListLag(mockName, "ListFileSystem", "filesystem", infoList, func(info *irs.FileSystemInfo) irs.IID { return info.IId })
+	// cloning list of FileSystem
+	clonedInfoList := CloneFileSystemInfoList(infoList)
+	for _, info := range clonedInfoList {
+		if isFaultStuck(mockName, "filesystem", info.IId.SystemId) {
+			info.Status = irs.FileSystemCreating
+		}
+	}
+	return clonedInfoList, nil
+}
+
+// findFileSystem returns the FileSystem in the global Map. Caller must hold fileSystemMapLock.
+func findFileSystem(mockName string, iid irs.IID) (*irs.FileSystemInfo, error) {
+	for _, info := range fileSystemInfoMap[mockName] {
+		if isSameMockIID(info.IId, iid) {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%s FileSystem does not exist!!", iid.NameId)
+}
+
+func (fileSystemHandler *MockFileSystemHandler) GetFileSystem(iid irs.IID) (irs.FileSystemInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetFileSystem()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "GetFileSystem"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	fileSystemMapLock.RLock()
+	defer fileSystemMapLock.RUnlock()
+
+	info, err := findFileSystem(fileSystemHandler.MockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	clonedInfo := CloneFileSystemInfo(*info)
+	if isFaultStuck(fileSystemHandler.MockName, "filesystem", clonedInfo.IId.SystemId) {
+		clonedInfo.Status = irs.FileSystemCreating
+	}
+	return clonedInfo, nil
+}
+
+// DeleteFileSystem deletes the FileSystem with all of its backups.
+func (fileSystemHandler *MockFileSystemHandler) DeleteFileSystem(iid irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteFileSystem()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "DeleteFileSystem"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	infoList := fileSystemInfoMap[mockName]
+	for idx, info := range infoList {
+		if info.IId.SystemId == iid.SystemId {
+			fileSystemInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+
+			backupList := []*irs.FileSystemBackupInfo{}
+			for _, backupInfo := range fsBackupInfoMap[mockName] {
+				if backupInfo.FileSystemIID != info.IId.SystemId {
+					backupList = append(backupList, backupInfo)
+				}
+			}
+			fsBackupInfoMap[mockName] = backupList
+			return true, nil
+		}
+	}
+
+	err := fmt.Errorf("%s FileSystem does not exist!!", iid.NameId)
+	cblogger.Error(err)
+	return false, err
+}
+
+func (fileSystemHandler *MockFileSystemHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := fileSystemHandler.MockName
+	fileSystemMapLock.RLock()
+	defer fileSystemMapLock.RUnlock()
+
+	infoList, ok := fileSystemInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+	infoList = applyListLag(mockName, "ListIID", "filesystem", infoList, func(info *irs.FileSystemInfo) irs.IID { return info.IId })
+
+	iidList := []*irs.IID{}
+	for _, info := range infoList {
+		iidList = append(iidList, &irs.IID{NameId: info.IId.NameId, SystemId: info.IId.SystemId})
+	}
+	return iidList, nil
+}
+
+//================ Access Subnet Management
+
+func (fileSystemHandler *MockFileSystemHandler) AddAccessSubnet(iid irs.IID, subnetIID irs.IID) (irs.FileSystemInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AddAccessSubnet()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "AddAccessSubnet"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	fsInfo, err := fileSystemHandler.GetFileSystem(iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	// the subnet must be in the VPC of the FileSystem
+	_, subnetInfo, err := getMockVPCSubnet(mockName, fsInfo.VpcIID, subnetIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	info, err := findFileSystem(mockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	for _, accessSubnetIID := range info.AccessSubnetList {
+		if accessSubnetIID.SystemId == subnetInfo.IId.SystemId {
+			err := fmt.Errorf("%s Subnet is already an access subnet of %s FileSystem!!", subnetIID.NameId, iid.NameId)
+			cblogger.Error(err)
+			return irs.FileSystemInfo{}, err
+		}
+	}
+	info.AccessSubnetList = append(info.AccessSubnetList, subnetInfo.IId)
+	info.MountTargetList = append(info.MountTargetList, newMockMountTarget(*info, subnetInfo.IId))
+
+	return CloneFileSystemInfo(*info), nil
+}
+
+func (fileSystemHandler *MockFileSystemHandler) RemoveAccessSubnet(iid irs.IID, subnetIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RemoveAccessSubnet()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "RemoveAccessSubnet"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	info, err := findFileSystem(mockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+	for idx, accessSubnetIID := range info.AccessSubnetList {
+		if isSameMockIID(accessSubnetIID, subnetIID) {
+			info.AccessSubnetList = append(info.AccessSubnetList[:idx], info.AccessSubnetList[idx+1:]...)
+			mountTargetList := []irs.MountTargetInfo{}
+			for _, mountTarget := range info.MountTargetList {
+				if mountTarget.SubnetIID.SystemId != accessSubnetIID.SystemId {
+					mountTargetList = append(mountTargetList, mountTarget)
+				}
+			}
+			info.MountTargetList = mountTargetList
+			return true, nil
+		}
+	}
+
+	err = fmt.Errorf("%s Subnet is not an access subnet of %s FileSystem!!", subnetIID.NameId, iid.NameId)
+	cblogger.Error(err)
+	return false, err
+}
+
+func (fileSystemHandler *MockFileSystemHandler) ListAccessSubnet(iid irs.IID) ([]irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListAccessSubnet()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "ListAccessSubnet"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	info, err := fileSystemHandler.GetFileSystem(iid)
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+	return info.AccessSubnetList, nil
+}
+
+//================ Backup Management
+
+// ScheduleBackup sets the backup schedule of the FileSystem and records a backup
+// created under the new schedule.
+func (fileSystemHandler *MockFileSystemHandler) ScheduleBackup(reqInfo irs.FileSystemBackupInfo) (irs.FileSystemBackupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ScheduleBackup()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "ScheduleBackup"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	if reqInfo.Schedule == (irs.CronSchedule{}) {
+		reqInfo.Schedule = mockDefaultBackupSchedule
+	}
+	if err := validateCronSchedule(reqInfo.Schedule); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	fsIID := irs.IID{NameId: reqInfo.FileSystemIID, SystemId: reqInfo.FileSystemIID}
+	return fileSystemHandler.createBackup(fsIID, &reqInfo.Schedule)
+}
+
+func (fileSystemHandler *MockFileSystemHandler) OnDemandBackup(fsIID irs.IID) (irs.FileSystemBackupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called OnDemandBackup()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "OnDemandBackup"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	return fileSystemHandler.createBackup(fsIID, nil)
+}
+
+// createBackup records a new backup of the FileSystem.
+// If schedule is not nil, it also becomes the backup schedule of the FileSystem.
+func (fileSystemHandler *MockFileSystemHandler) createBackup(fsIID irs.IID, schedule *irs.CronSchedule) (irs.FileSystemBackupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	mockName := fileSystemHandler.MockName
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	fsInfo, err := findFileSystem(mockName, fsIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	backupInfo := irs.FileSystemBackupInfo{
+		FileSystemIID: fsInfo.IId.SystemId,
+		BackupID:      fmt.Sprintf("%s-backup-%d", fsInfo.IId.SystemId, atomic.AddUint32(&fsBackupSeq, 1)),
+		CreationTime:  time.Now(),
+		KeyValueList:  []irs.KeyValue{{Key: "BackupType", Value: "OnDemand"}},
+	}
+	if schedule != nil {
+		backupInfo.Schedule = *schedule
+		backupInfo.KeyValueList = []irs.KeyValue{{Key: "BackupType", Value: "Scheduled"}}
+		fsInfo.BackupSchedule = irs.FileSystemBackupInfo{
+			FileSystemIID: fsInfo.IId.SystemId,
+			Schedule:      *schedule,
+			BackupID:      backupInfo.BackupID,
+			CreationTime:  backupInfo.CreationTime,
+		}
+	}
+
+	fsBackupInfoMap[mockName] = append(fsBackupInfoMap[mockName], &backupInfo)
+
+	return CloneFileSystemBackupInfo(backupInfo), nil
+}
+
+func (fileSystemHandler *MockFileSystemHandler) ListBackup(fsIID irs.IID) ([]irs.FileSystemBackupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListBackup()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "ListBackup"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	fileSystemMapLock.RLock()
+	defer fileSystemMapLock.RUnlock()
+
+	fsInfo, err := findFileSystem(mockName, fsIID)
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	backupList := []irs.FileSystemBackupInfo{}
+	for _, backupInfo := range fsBackupInfoMap[mockName] {
+		if backupInfo.FileSystemIID == fsInfo.IId.SystemId {
+			backupList = append(backupList, CloneFileSystemBackupInfo(*backupInfo))
+		}
+	}
+	return backupList, nil
+}
+
+func (fileSystemHandler *MockFileSystemHandler) GetBackup(fsIID irs.IID, backupID string) (irs.FileSystemBackupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetBackup()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "GetBackup"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+
+	backupList, err := fileSystemHandler.ListBackup(fsIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemBackupInfo{}, err
+	}
+	for _, backupInfo := range backupList {
+		if backupInfo.BackupID == backupID {
+			return backupInfo, nil
+		}
+	}
+
+	err = fmt.Errorf("%s Backup does not exist in %s FileSystem!!", backupID, fsIID.NameId)
+	cblogger.Error(err)
+	return irs.FileSystemBackupInfo{}, err
+}
+
+func (fileSystemHandler *MockFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID string) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteBackup()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "DeleteBackup"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	fsInfo, err := findFileSystem(mockName, fsIID)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	backupList := fsBackupInfoMap[mockName]
+	for idx, backupInfo := range backupList {
+		if backupInfo.FileSystemIID == fsInfo.IId.SystemId && backupInfo.BackupID == backupID {
+			fsBackupInfoMap[mockName] = append(backupList[:idx], backupList[idx+1:]...)
+			return true, nil
+		}
+	}
+
+	err = fmt.Errorf("%s Backup does not exist in %s FileSystem!!", backupID, fsIID.NameId)
+	cblogger.Error(err)
+	return false, err
+}
+
+// RestoreBackup creates a new FileSystem from the backup.
+// The mock FileSystem has no data, so the new one takes the configuration of the backup's FileSystem.
+func (fileSystemHandler *MockFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RestoreBackup()!")
+
+	if err := injectFault(fileSystemHandler.MockName, "RestoreBackup"); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	defer lockQuota(fileSystemHandler.MockName)()
+	if err := checkQuota(fileSystemHandler.MockName, "storage", "filesystems", 1); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	mockName := fileSystemHandler.MockName
+
+	if newFsIID.NameId == "" {
+		err := fmt.Errorf("NameId of the new FileSystem is required!!")
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	backupID := backupIID.SystemId
+	if backupID == "" {
+		backupID = backupIID.NameId
+	}
+
+	fileSystemMapLock.Lock()
+	defer fileSystemMapLock.Unlock()
+
+	srcInfo, err := findFileSystem(mockName, fsIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	var backupInfo *irs.FileSystemBackupInfo
+	for _, info := range fsBackupInfoMap[mockName] {
+		if info.FileSystemIID == srcInfo.IId.SystemId && info.BackupID == backupID {
+			backupInfo = info
+			break
+		}
+	}
+	if backupInfo == nil {
+		err := fmt.Errorf("%s Backup does not exist in %s FileSystem!!", backupID, fsIID.NameId)
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+	for _, info := range fileSystemInfoMap[mockName] {
+		if info.IId.NameId == newFsIID.NameId {
+			err := fmt.Errorf("%s FileSystem already exists!!", newFsIID.NameId)
+			cblogger.Error(err)
+			return irs.FileSystemInfo{}, err
+		}
+	}
+
+	fsInfo := CloneFileSystemInfo(*srcInfo)
+	fsInfo.IId = irs.IID{NameId: newFsIID.NameId, SystemId: newFsIID.NameId}
+	fsInfo.BackupSchedule = irs.FileSystemBackupInfo{
+		FileSystemIID: fsInfo.IId.SystemId,
+		Schedule:      srcInfo.BackupSchedule.Schedule,
+	}
+	fsInfo.Status = irs.FileSystemAvailable
+	fsInfo.CreatedTime = time.Now()
+	fsInfo.KeyValueList = []irs.KeyValue{
+		{Key: "RestoredFromFileSystem", Value: srcInfo.IId.SystemId},
+		{Key: "RestoredFromBackup", Value: backupInfo.BackupID},
+	}
+	fsInfo.MountTargetList = []irs.MountTargetInfo{}
+	for _, subnetIID := range fsInfo.AccessSubnetList {
+		fsInfo.MountTargetList = append(fsInfo.MountTargetList, newMockMountTarget(fsInfo, subnetIID))
+	}
+
+	fileSystemInfoMap[mockName] = append(fileSystemInfoMap[mockName], &fsInfo)
+
+	if err := recordFaultCreation(mockName, "RestoreBackup", "filesystem", fsInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.FileSystemInfo{}, err
+	}
+
+	return CloneFileSystemInfo(fsInfo), nil
+}
@@ -107,6 +107,11 @@ func (imageHandler *MockImageHandler) CreateImage(imageReqInfo irs.ImageReqInfo)
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateImage()!")
 
+	if err := injectFault(imageHandler.MockName, "CreateImage"); err != nil {
+		cblogger.Error(err)
+		return irs.ImageInfo{}, err
+	}
+
 	mockName := imageHandler.MockName
 	imageReqInfo.IId.SystemId = imageReqInfo.IId.NameId
 
@@ -135,6 +140,11 @@ func (imageHandler *MockImageHandler) ListImage() ([]*irs.ImageInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListImage()!")
 
+	if err := injectFault(imageHandler.MockName, "ListImage"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := imageHandler.MockName
 	imgInfoList, ok := imgInfoMap[mockName]
 	if !ok {
@@ -150,6 +160,11 @@ func (imageHandler *MockImageHandler) GetImage(imageIID irs.IID) (irs.ImageInfo,
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetImage()!")
 
+	if err := injectFault(imageHandler.MockName, "GetImage"); err != nil {
+		cblogger.Error(err)
+		return irs.ImageInfo{}, err
+	}
+
 	imgInfoList, err := imageHandler.ListImage()
 	if err != nil {
 		cblogger.Error(err)
@@ -169,6 +184,11 @@ func (imageHandler *MockImageHandler) DeleteImage(imageIID irs.IID) (bool, error
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteImage()!")
 
+	if err := injectFault(imageHandler.MockName, "DeleteImage"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	imgInfoList, err := imageHandler.ListImage()
 	if err != nil {
 		cblogger.Error(err)
@@ -12,11 +12,13 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	cblog "github.com/cloud-barista/cb-log"
 	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	_ "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
 )
 
 var keyPairInfoMap map[string][]*irs.KeyPairInfo
@@ -38,6 +40,17 @@ func (keyPairHandler *MockKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairRe
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateKey()!")
 
+	if err := injectFault(keyPairHandler.MockName, "CreateKey"); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	defer lockQuota(keyPairHandler.MockName)()
+	if err := checkQuota(keyPairHandler.MockName, "compute", "keypairs", 1); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
 	mockName := keyPairHandler.MockName
 	keyPairReqInfo.IId.SystemId = keyPairReqInfo.IId.NameId
 
@@ -59,6 +72,68 @@ func (keyPairHandler *MockKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairRe
 	infoList = append(infoList, &keyPairInfo)
 	keyPairInfoMap[mockName] = infoList
 
+	if err := recordFaultCreation(mockName, "CreateKey", "keypair", keyPairInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	return CloneKeyPairInfo(keyPairInfo), nil
+}
+
+// (1) validate the public key
+// (2) insert keyPairInfo into global Map
+func (keyPairHandler *MockKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ImportKey()!")
+
+	if err := injectFault(keyPairHandler.MockName, "ImportKey"); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	defer lockQuota(keyPairHandler.MockName)()
+	if err := checkQuota(keyPairHandler.MockName, "compute", "keypairs", 1); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	// (1) validate the public key
+	// do not use the common package of drivers, because it opens the metadb
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(publicKey)))
+	if err != nil {
+		err := fmt.Errorf("invalid public key: %v", err)
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	mockName := keyPairHandler.MockName
+
+	keyMapLock.Lock()
+	defer keyMapLock.Unlock()
+
+	for _, info := range keyPairInfoMap[mockName] {
+		if info.IId.NameId == keyIID.NameId {
+			err := fmt.Errorf("%s Keypair already exists!!", keyIID.NameId)
+			cblogger.Error(err)
+			return irs.KeyPairInfo{}, err
+		}
+	}
+
+	keyPairInfo := irs.KeyPairInfo{
+		IId:         irs.IID{NameId: keyIID.NameId, SystemId: keyIID.NameId},
+		Fingerprint: ssh.FingerprintLegacyMD5(pub),
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))),
+		VMUserID:    "cb-user",
+	}
+
+	// (2) insert KeyPairInfo into global Map
+	keyPairInfoMap[mockName] = append(keyPairInfoMap[mockName], &keyPairInfo)
+
+	if err := recordFaultCreation(mockName, "ImportKey", "keypair", keyPairInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
 	return CloneKeyPairInfo(keyPairInfo), nil
 }
 
@@ -103,6 +178,11 @@ func (keyPairHandler *MockKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error)
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListKey()!")
 
+	if err := injectFault(keyPairHandler.MockName, "ListKey"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := keyPairHandler.MockName
 	keyMapLock.RLock()
 	defer keyMapLock.RUnlock()
@@ -110,6 +190,7 @@ func (keyPairHandler *MockKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error)
 	if !ok {
 		return []*irs.KeyPairInfo{}, nil
 	}
+	infoList = applyListLag(mockName, "ListKey", "keypair", infoList, func(info *irs.KeyPairInfo) irs.IID { return info.IId })
 	// cloning list of KeyPair
 	return CloneKeyPairInfoList(infoList), nil
 }
@@ -118,6 +199,11 @@ func (keyPairHandler *MockKeyPairHandler) GetKey(iid irs.IID) (irs.KeyPairInfo,
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetKey()!")
 
+	if err := injectFault(keyPairHandler.MockName, "GetKey"); err != nil {
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+
 	mockName := keyPairHandler.MockName
 	keyMapLock.RLock()
 	defer keyMapLock.RUnlock()
@@ -139,6 +225,11 @@ func (keyPairHandler *MockKeyPairHandler) DeleteKey(iid irs.IID) (bool, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteKey()!")
 
+	if err := injectFault(keyPairHandler.MockName, "DeleteKey"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	mockName := keyPairHandler.MockName
 
 	keyMapLock.Lock()
@@ -163,6 +254,11 @@ func (keyPairHandler *MockKeyPairHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(keyPairHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := keyPairHandler.MockName
 	keyMapLock.RLock()
 	defer keyMapLock.RUnlock()
@@ -170,6 +266,7 @@ func (keyPairHandler *MockKeyPairHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "keypair", infoList, func(info *irs.KeyPairInfo) irs.IID { return info.IId })
 
 	iidList := []*irs.IID{}
 	for _, info := range infoList {
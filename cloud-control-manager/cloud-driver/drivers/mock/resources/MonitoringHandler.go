@@ -0,0 +1,201 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+type MockMonitoringHandler struct {
+	MockName string
+}
+
+// MetricSeed is mixed into every synthetic metric value.
+// The same seed, resource, metric type and timestamp always produce the same value,
+// so tests and demos can get reproducible time series.
+var MetricSeed int64 = 0
+
+// shape of the synthetic time series of each MetricType:
+// value = base + amplitude * sin(daily cycle) + noise * (random in [0,1))
+type mockMetricShape struct {
+	base      float64
+	amplitude float64
+	noise     float64
+	perMinute bool // value accumulates over the interval(Bytes)
+	max       float64
+}
+
+var mockMetricShapes = map[irs.MetricType]mockMetricShape{
+	irs.CPUUsage:     {base: 25, amplitude: 15, noise: 10, max: 100},
+	irs.MemoryUsage:  {base: 45, amplitude: 10, noise: 5, max: 100},
+	irs.DiskRead:     {base: 2 * 1024 * 1024, amplitude: 1024 * 1024, noise: 512 * 1024, perMinute: true},
+	irs.DiskWrite:    {base: 4 * 1024 * 1024, amplitude: 2 * 1024 * 1024, noise: 1024 * 1024, perMinute: true},
+	irs.DiskReadOps:  {base: 20, amplitude: 10, noise: 5},
+	irs.DiskWriteOps: {base: 35, amplitude: 15, noise: 10},
+	irs.NetworkIn:    {base: 8 * 1024 * 1024, amplitude: 4 * 1024 * 1024, noise: 2 * 1024 * 1024, perMinute: true},
+	irs.NetworkOut:   {base: 6 * 1024 * 1024, amplitude: 3 * 1024 * 1024, noise: 1024 * 1024, perMinute: true},
+}
+
+func (monitoringHandler *MockMonitoringHandler) GetVMMetricData(vmMonitoringReqInfo irs.VMMonitoringReqInfo) (irs.MetricData, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetVMMetricData()!")
+
+	if err := injectFault(monitoringHandler.MockName, "GetVMMetricData"); err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+
+	intervalMinute, timeBeforeHour, err := parseMockMonitoringPeriod(vmMonitoringReqInfo.IntervalMinute, vmMonitoringReqInfo.TimeBeforeHour)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+
+	// only for the VMs in the mock state
+	vmHandler := MockVMHandler{MockName: monitoringHandler.MockName}
+	vmInfo, err := vmHandler.GetVM(vmMonitoringReqInfo.VMIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+
+	resourceKey := "vm/" + vmInfo.IId.SystemId
+	return monitoringHandler.generateMetricData(resourceKey, vmMonitoringReqInfo.MetricType, intervalMinute, timeBeforeHour)
+}
+
+func (monitoringHandler *MockMonitoringHandler) GetClusterNodeMetricData(clusterMonitoringReqInfo irs.ClusterNodeMonitoringReqInfo) (irs.MetricData, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetClusterNodeMetricData()!")
+
+	if err := injectFault(monitoringHandler.MockName, "GetClusterNodeMetricData"); err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+
+	intervalMinute, timeBeforeHour, err := parseMockMonitoringPeriod(clusterMonitoringReqInfo.IntervalMinute, clusterMonitoringReqInfo.TimeBeforeHour)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+
+	// only for the nodes in the mock state
+	clusterHandler := MockClusterHandler{MockName: monitoringHandler.MockName}
+	clusterInfo, err := clusterHandler.GetCluster(clusterMonitoringReqInfo.ClusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+	nodeIID, err := findMockClusterNode(clusterInfo, clusterMonitoringReqInfo.NodeGroupID, clusterMonitoringReqInfo.NodeIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.MetricData{}, err
+	}
+
+	resourceKey := "cluster/" + clusterInfo.IId.SystemId + "/node/" + nodeIID.SystemId
+	return monitoringHandler.generateMetricData(resourceKey, clusterMonitoringReqInfo.MetricType, intervalMinute, timeBeforeHour)
+}
+
+func findMockClusterNode(clusterInfo irs.ClusterInfo, nodeGroupIID irs.IID, nodeIID irs.IID) (irs.IID, error) {
+	for _, nodeGroup := range clusterInfo.NodeGroupList {
+		if nodeGroup.IId.NameId != nodeGroupIID.NameId && nodeGroup.IId.SystemId != nodeGroupIID.SystemId {
+			continue
+		}
+		for _, node := range nodeGroup.Nodes {
+			if (nodeIID.NameId != "" && node.NameId == nodeIID.NameId) ||
+				(nodeIID.SystemId != "" && node.SystemId == nodeIID.SystemId) {
+				return node, nil
+			}
+		}
+		return irs.IID{}, fmt.Errorf("%s Node does not exist in %s NodeGroup!!", nodeIID.NameId, nodeGroupIID.NameId)
+	}
+	return irs.IID{}, fmt.Errorf("%s NodeGroup does not exist in %s Cluster!!", nodeGroupIID.NameId, clusterInfo.IId.NameId)
+}
+
+// parseMockMonitoringPeriod validates the period like the CSP drivers:
+// empty strings default to "1", and TimeBeforeHour*60 must be >= IntervalMinute.
+func parseMockMonitoringPeriod(intervalMinuteStr string, timeBeforeHourStr string) (int, int, error) {
+	if intervalMinuteStr == "" {
+		intervalMinuteStr = "1"
+	}
+	if timeBeforeHourStr == "" {
+		timeBeforeHourStr = "1"
+	}
+
+	intervalMinute, err := strconv.Atoi(intervalMinuteStr)
+	if err != nil || intervalMinute <= 0 {
+		return 0, 0, errors.New("invalid value of IntervalMinute")
+	}
+	timeBeforeHour, err := strconv.Atoi(timeBeforeHourStr)
+	if err != nil || timeBeforeHour <= 0 {
+		return 0, 0, errors.New("invalid value of TimeBeforeHour")
+	}
+	if timeBeforeHour*60 < intervalMinute {
+		return 0, 0, errors.New("IntervalMinute is too far in the past")
+	}
+	return intervalMinute, timeBeforeHour, nil
+}
+
+// generateMetricData returns one value per interval over the last timeBeforeHour hours.
+// Timestamps are aligned to the interval, so repeated calls within the same interval
+// return the same series.
+func (monitoringHandler *MockMonitoringHandler) generateMetricData(resourceKey string, metricType irs.MetricType, intervalMinute int, timeBeforeHour int) (irs.MetricData, error) {
+	shape, ok := mockMetricShapes[metricType]
+	if !ok {
+		return irs.MetricData{}, fmt.Errorf("unsupported metric type: %s", metricType)
+	}
+
+	metricName, metricUnit := irs.MetricNameAndUnit(metricType)
+	metricData := irs.MetricData{
+		MetricName:      metricName,
+		MetricUnit:      metricUnit,
+		TimestampValues: []irs.TimestampValue{},
+	}
+
+	interval := time.Duration(intervalMinute) * time.Minute
+	end := time.Now().UTC().Truncate(interval)
+	count := timeBeforeHour * 60 / intervalMinute
+	for i := count - 1; i >= 0; i-- {
+		timestamp := end.Add(-time.Duration(i) * interval)
+
+		dayRatio := float64(timestamp.Unix()%86400) / 86400
+		value := shape.base + shape.amplitude*math.Sin(2*math.Pi*dayRatio) +
+			shape.noise*mockMetricRandom(monitoringHandler.MockName, resourceKey, metricType, timestamp)
+		if shape.perMinute {
+			value *= float64(intervalMinute)
+		}
+		value = math.Max(value, 0)
+		if shape.max > 0 {
+			value = math.Min(value, shape.max)
+		}
+
+		metricData.TimestampValues = append(metricData.TimestampValues, irs.TimestampValue{
+			Timestamp: timestamp,
+			Value:     strconv.FormatFloat(value, 'f', 2, 64),
+		})
+	}
+
+	return metricData, nil
+}
+
+// mockMetricRandom returns a deterministic pseudo-random number in [0,1).
+func mockMetricRandom(mockName string, resourceKey string, metricType irs.MetricType, timestamp time.Time) float64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%s/%s/%d", MetricSeed, mockName, resourceKey, metricType, timestamp.Unix())
+	return float64(h.Sum64()>>11) / float64(1<<53)
+}
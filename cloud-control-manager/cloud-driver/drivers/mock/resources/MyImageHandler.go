@@ -39,6 +39,17 @@ func (myImageHandler *MockMyImageHandler) SnapshotVM(myImageReqInfo irs.MyImageI
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called SnapshotVM()!")
 
+	if err := injectFault(myImageHandler.MockName, "SnapshotVM"); err != nil {
+		cblogger.Error(err)
+		return irs.MyImageInfo{}, err
+	}
+
+	defer lockQuota(myImageHandler.MockName)()
+	if err := checkQuota(myImageHandler.MockName, "compute", "myimages", 1); err != nil {
+		cblogger.Error(err)
+		return irs.MyImageInfo{}, err
+	}
+
 	mockName := myImageHandler.MockName
 	myImageReqInfo.IId.SystemId = myImageReqInfo.IId.NameId
 	myImageReqInfo.Status = irs.MyImageAvailable
@@ -51,6 +62,11 @@ func (myImageHandler *MockMyImageHandler) SnapshotVM(myImageReqInfo irs.MyImageI
 	infoList = append(infoList, &myImageReqInfo)
 	myImageInfoMap[mockName] = infoList
 
+	if err := recordFaultCreation(mockName, "SnapshotVM", "myimage", myImageReqInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.MyImageInfo{}, err
+	}
+
 	return CloneMyImageInfo(myImageReqInfo), nil
 }
 
@@ -92,6 +108,11 @@ func (myImageHandler *MockMyImageHandler) ListMyImage() ([]*irs.MyImageInfo, err
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListMyImage()!")
 
+	if err := injectFault(myImageHandler.MockName, "ListMyImage"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := myImageHandler.MockName
 	myImageMapLock.RLock()
 	defer myImageMapLock.RUnlock()
@@ -99,6 +120,7 @@ func (myImageHandler *MockMyImageHandler) ListMyImage() ([]*irs.MyImageInfo, err
 	if !ok {
 		return []*irs.MyImageInfo{}, nil
 	}
+	infoList = applyListLag(mockName, "ListMyImage", "myimage", infoList, func(info *irs.MyImageInfo) irs.IID { return info.IId })
 	// cloning list of MyImage
 	return CloneMyImageInfoList(infoList), nil
 }
@@ -107,6 +129,11 @@ func (myImageHandler *MockMyImageHandler) GetMyImage(iid irs.IID) (irs.MyImageIn
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetMyImage()!")
 
+	if err := injectFault(myImageHandler.MockName, "GetMyImage"); err != nil {
+		cblogger.Error(err)
+		return irs.MyImageInfo{}, err
+	}
+
 	mockName := myImageHandler.MockName
 	myImageMapLock.RLock()
 	defer myImageMapLock.RUnlock()
@@ -128,6 +155,11 @@ func (myImageHandler *MockMyImageHandler) DeleteMyImage(iid irs.IID) (bool, erro
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteMyImage()!")
 
+	if err := injectFault(myImageHandler.MockName, "DeleteMyImage"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	mockName := myImageHandler.MockName
 
 	myImageMapLock.Lock()
@@ -156,6 +188,11 @@ func (ImageHandler *MockMyImageHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(ImageHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := ImageHandler.MockName
 	myImageMapLock.RLock()
 	defer myImageMapLock.RUnlock()
@@ -163,6 +200,7 @@ func (ImageHandler *MockMyImageHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "myimage", infoList, func(info *irs.MyImageInfo) irs.IID { return info.IId })
 
 	iidList := make([]*irs.IID, len(infoList))
 	for i, info := range infoList {
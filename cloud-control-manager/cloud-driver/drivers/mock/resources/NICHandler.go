@@ -0,0 +1,752 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var nicInfoMap map[string][]*irs.NICInfo
+
+type MockNICHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	nicInfoMap = make(map[string][]*irs.NICInfo)
+}
+
+var nicMapLock = new(sync.RWMutex)
+
+// sequence for mock MAC addresses
+var nicMACSeq uint32
+
+// (1) validate VPC, Subnet and SGs
+// (2) allocate a private IP in the subnet CIDR
+// (3) insert nicInfo into global Map
+func (nicHandler *MockNICHandler) CreateNIC(nicReqInfo irs.NICReqInfo) (irs.NICInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateNIC()!")
+
+	if err := injectFault(nicHandler.MockName, "CreateNIC"); err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	defer lockQuota(nicHandler.MockName)()
+	if err := checkQuota(nicHandler.MockName, "network", "nics", 1); err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	mockName := nicHandler.MockName
+
+	// (1) validation
+	vpcIID, subnetInfo, err := getMockVPCSubnet(mockName, nicReqInfo.VpcIID, nicReqInfo.SubnetIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	sgIIDs, err := getMockSGIIDs(mockName, nicReqInfo.SecurityGroupIIDs)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	nicMapLock.Lock()
+	defer nicMapLock.Unlock()
+
+	for _, info := range nicInfoMap[mockName] {
+		if info.IId.NameId == nicReqInfo.IId.NameId {
+			err := fmt.Errorf("%s NIC already exists!!", nicReqInfo.IId.NameId)
+			cblogger.Error(err)
+			return irs.NICInfo{}, err
+		}
+	}
+
+	// (2) private IP
+	privateIP, err := allocatePrivateIP(mockName, subnetInfo, "")
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	nicInfo := irs.NICInfo{
+		IId:               irs.IID{NameId: nicReqInfo.IId.NameId, SystemId: nicReqInfo.IId.NameId},
+		VpcIID:            vpcIID,
+		SubnetIID:         subnetInfo.IId,
+		SecurityGroupIIDs: sgIIDs,
+		PrivateIP:         privateIP,
+		PrivateIPs:        []string{privateIP},
+		PublicIPs:         []string{""},
+		MACAddress:        newMockMACAddress(),
+		Status:            irs.NICAvailable,
+		CreatedTime:       time.Now(),
+		TagList:           nicReqInfo.TagList,
+	}
+
+	// (3) insert NICInfo into global Map
+	nicInfoMap[mockName] = append(nicInfoMap[mockName], &nicInfo)
+
+	if err := recordFaultCreation(mockName, "CreateNIC", "nic", nicInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	return CloneNICInfo(nicInfo), nil
+}
+
+// getMockVPCSubnet finds the VPC and its Subnet in the mock VPC map.
+// Spider passes the CSP SystemIds, so SystemId is matched first and NameId is a fallback.
+func getMockVPCSubnet(mockName string, vpcIID irs.IID, subnetIID irs.IID) (irs.IID, irs.SubnetInfo, error) {
+	vpcHandler := MockVPCHandler{mockName}
+	vpcInfoList, err := vpcHandler.ListVPC()
+	if err != nil {
+		return irs.IID{}, irs.SubnetInfo{}, err
+	}
+
+	for _, vpcInfo := range vpcInfoList {
+		if !isSameMockIID(vpcInfo.IId, vpcIID) {
+			continue
+		}
+		for _, subnetInfo := range vpcInfo.SubnetInfoList {
+			if isSameMockIID(subnetInfo.IId, subnetIID) {
+				return vpcInfo.IId, subnetInfo, nil
+			}
+		}
+		return irs.IID{}, irs.SubnetInfo{}, fmt.Errorf("%s Subnet does not exist in %s VPC!!", subnetIID.NameId, vpcIID.NameId)
+	}
+
+	return irs.IID{}, irs.SubnetInfo{}, fmt.Errorf("%s VPC does not exist!!", vpcIID.NameId)
+}
+
+func getMockSGIIDs(mockName string, sgIIDs []irs.IID) ([]irs.IID, error) {
+	securityHandler := MockSecurityHandler{mockName}
+	sgInfoList, err := securityHandler.ListSecurity()
+	if err != nil {
+		return nil, err
+	}
+
+	validatedSgIIDs := []irs.IID{}
+	for _, sgIID := range sgIIDs {
+		found := false
+		for _, sgInfo := range sgInfoList {
+			if isSameMockIID(sgInfo.IId, sgIID) {
+				validatedSgIIDs = append(validatedSgIIDs, sgInfo.IId)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s security group iid does not exist!!", sgIID.NameId)
+		}
+	}
+	return validatedSgIIDs, nil
+}
+
+func isSameMockIID(mockIID irs.IID, reqIID irs.IID) bool {
+	if reqIID.SystemId != "" {
+		return mockIID.SystemId == reqIID.SystemId
+	}
+	return mockIID.NameId == reqIID.NameId
+}
+
+// allocatePrivateIP returns a free IP in the subnet CIDR.
+// If reqIP is not empty, it is validated instead of allocating a new one.
+// Like most CSPs, the network address, the first 3 host addresses(gateway, DNS, reserved)
+// and the broadcast address are reserved.
+// Caller must hold nicMapLock.
+func allocatePrivateIP(mockName string, subnetInfo irs.SubnetInfo, reqIP string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(subnetInfo.IPv4_CIDR)
+	if err != nil {
+		return "", fmt.Errorf("%s Subnet has an invalid CIDR '%s': %v", subnetInfo.IId.NameId, subnetInfo.IPv4_CIDR, err)
+	}
+	ipv4Net := ipNet.IP.To4()
+	if ipv4Net == nil {
+		return "", fmt.Errorf("%s Subnet CIDR '%s' is not IPv4!!", subnetInfo.IId.NameId, subnetInfo.IPv4_CIDR)
+	}
+	ones, bits := ipNet.Mask.Size()
+	netAddr := binary.BigEndian.Uint32(ipv4Net)
+	broadcast := netAddr + uint32(1)<<uint(bits-ones) - 1
+
+	usedIPs := map[string]bool{}
+	for _, info := range nicInfoMap[mockName] {
+		if info.SubnetIID.SystemId != subnetInfo.IId.SystemId {
+			continue
+		}
+		for _, ip := range info.PrivateIPs {
+			usedIPs[ip] = true
+		}
+	}
+
+	if reqIP != "" {
+		ip := net.ParseIP(reqIP).To4()
+		if ip == nil || !ipNet.Contains(ip) {
+			return "", fmt.Errorf("%s is not in %s Subnet CIDR %s!!", reqIP, subnetInfo.IId.NameId, subnetInfo.IPv4_CIDR)
+		}
+		addr := binary.BigEndian.Uint32(ip)
+		if addr <= netAddr+3 || addr >= broadcast {
+			return "", fmt.Errorf("%s is a reserved address of %s Subnet!!", reqIP, subnetInfo.IId.NameId)
+		}
+		if usedIPs[ip.String()] {
+			return "", fmt.Errorf("%s is already in use in %s Subnet!!", reqIP, subnetInfo.IId.NameId)
+		}
+		return ip.String(), nil
+	}
+
+	for addr := netAddr + 4; addr < broadcast; addr++ {
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, addr)
+		if !usedIPs[ip.String()] {
+			return ip.String(), nil
+		}
+	}
+	return "", fmt.Errorf("%s Subnet has no free IP address!!", subnetInfo.IId.NameId)
+}
+
+func newMockMACAddress() string {
+	nicMACSeq++
+	return fmt.Sprintf("02:00:00:%02x:%02x:%02x", byte(nicMACSeq>>16), byte(nicMACSeq>>8), byte(nicMACSeq))
+}
+
+func CloneNICInfoList(srcInfoList []*irs.NICInfo) []*irs.NICInfo {
+	clonedInfoList := []*irs.NICInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneNICInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneNICInfo(srcInfo irs.NICInfo) irs.NICInfo {
+	// clone NICInfo
+	clonedInfo := irs.NICInfo{
+		IId:               irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		VpcIID:            irs.IID{srcInfo.VpcIID.NameId, srcInfo.VpcIID.SystemId},
+		SubnetIID:         irs.IID{srcInfo.SubnetIID.NameId, srcInfo.SubnetIID.SystemId},
+		SecurityGroupIIDs: cloneIIDArray(srcInfo.SecurityGroupIIDs),
+		PrivateIP:         srcInfo.PrivateIP,
+		PrivateIPs:        append([]string{}, srcInfo.PrivateIPs...),
+		PublicIPs:         append([]string{}, srcInfo.PublicIPs...),
+		PublicIP:          srcInfo.PublicIP,
+		OwnerVM:           irs.IID{srcInfo.OwnerVM.NameId, srcInfo.OwnerVM.SystemId},
+		DeviceIndex:       srcInfo.DeviceIndex,
+		MACAddress:        srcInfo.MACAddress,
+		Status:            srcInfo.Status,
+		CreatedTime:       srcInfo.CreatedTime,
+		TagList:           srcInfo.TagList,      // clone TagList
+		KeyValueList:      srcInfo.KeyValueList, // now, do not need cloning
+	}
+
+	return clonedInfo
+}
+
+// toVMNICInfo converts a NICInfo into the device-level view kept in VMInfo.NICs.
+func toVMNICInfo(nicInfo irs.NICInfo) irs.VMNICInfo {
+	return irs.VMNICInfo{
+		IId:         irs.IID{nicInfo.IId.NameId, nicInfo.IId.SystemId},
+		DeviceIndex: nicInfo.DeviceIndex,
+		PrivateIPs:  append([]string{}, nicInfo.PrivateIPs...),
+		PublicIPs:   append([]string{}, nicInfo.PublicIPs...),
+		MACAddress:  nicInfo.MACAddress,
+		SubnetIID:   irs.IID{nicInfo.SubnetIID.NameId, nicInfo.SubnetIID.SystemId},
+	}
+}
+
+func (nicHandler *MockNICHandler) ListNIC() ([]*irs.NICInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListNIC()!")
+
+	if err := injectFault(nicHandler.MockName, "ListNIC"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := nicHandler.MockName
+	nicMapLock.RLock()
+	defer nicMapLock.RUnlock()
+	infoList, ok := nicInfoMap[mockName]
+	if !ok {
+		return []*irs.NICInfo{}, nil
+	}
+
+	infoList = applyListLag(mockName, "ListNIC", "nic", infoList, func(info *irs.NICInfo) irs.IID { return info.IId })
+	// cloning list of NIC
+	return CloneNICInfoList(infoList), nil
+}
+
+// findNIC returns the NIC in the global Map. Caller must hold nicMapLock.
+func findNIC(mockName string, iid irs.IID) (*irs.NICInfo, error) {
+	for _, info := range nicInfoMap[mockName] {
+		if info.IId.NameId == iid.NameId {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%s NIC does not exist!!", iid.NameId)
+}
+
+func (nicHandler *MockNICHandler) GetNIC(iid irs.IID) (irs.NICInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetNIC()!")
+
+	if err := injectFault(nicHandler.MockName, "GetNIC"); err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	nicMapLock.RLock()
+	defer nicMapLock.RUnlock()
+
+	info, err := findNIC(nicHandler.MockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	return CloneNICInfo(*info), nil
+}
+
+func (nicHandler *MockNICHandler) DeleteNIC(iid irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteNIC()!")
+
+	if err := injectFault(nicHandler.MockName, "DeleteNIC"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := nicHandler.MockName
+
+	nicMapLock.Lock()
+	infoList := nicInfoMap[mockName]
+	var deletedInfo *irs.NICInfo
+	for idx, info := range infoList {
+		if info.IId.SystemId == iid.SystemId {
+			if info.Status == irs.NICAttached {
+				nicMapLock.Unlock()
+				err := fmt.Errorf("%s NIC is attached to %s VM. Detach it first!!", iid.NameId, info.OwnerVM.NameId)
+				cblogger.Error(err)
+				return false, err
+			}
+			deletedInfo = info
+			nicInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			break
+		}
+	}
+	nicMapLock.Unlock()
+
+	if deletedInfo == nil {
+		err := fmt.Errorf("%s NIC does not exist!!", iid.NameId)
+		cblogger.Error(err)
+		return false, err
+	}
+
+	// public IPs of the deleted NIC are released
+	releasePublicIPsOfNIC(mockName, deletedInfo.IId)
+	return true, nil
+}
+
+func (nicHandler *MockNICHandler) AttachNIC(nicIID irs.IID, vmIID irs.IID) (irs.NICInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AttachNIC()!")
+
+	if err := injectFault(nicHandler.MockName, "AttachNIC"); err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	mockName := nicHandler.MockName
+
+	// VM validation
+	vmHandler := MockVMHandler{MockName: mockName}
+	vmInfo, err := vmHandler.GetVM(vmIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	nicMapLock.Lock()
+	info, err := findNIC(mockName, nicIID)
+	if err != nil {
+		nicMapLock.Unlock()
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	if info.Status == irs.NICAttached {
+		nicMapLock.Unlock()
+		err := fmt.Errorf("%s NIC is already attached to %s VM!!", nicIID.NameId, info.OwnerVM.NameId)
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	if info.VpcIID.SystemId != vmInfo.VpcIID.SystemId {
+		nicMapLock.Unlock()
+		err := fmt.Errorf("%s NIC and %s VM are not in the same VPC!!", nicIID.NameId, vmIID.NameId)
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	// next free device index of the VM
+	usedIndex := map[int]bool{}
+	for _, vmNIC := range vmInfo.NICs {
+		usedIndex[vmNIC.DeviceIndex] = true
+	}
+	deviceIndex := 1
+	for usedIndex[deviceIndex] {
+		deviceIndex++
+	}
+
+	info.Status = irs.NICAttached
+	info.OwnerVM = vmInfo.IId
+	info.DeviceIndex = deviceIndex
+	attachedInfo := CloneNICInfo(*info)
+	nicMapLock.Unlock()
+
+	nicAttach(mockName, vmInfo.IId, toVMNICInfo(attachedInfo))
+	setPublicIPOwnerVM(mockName, attachedInfo.IId, attachedInfo.OwnerVM)
+
+	return attachedInfo, nil
+}
+
+func (nicHandler *MockNICHandler) DetachNIC(nicIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DetachNIC()!")
+
+	if err := injectFault(nicHandler.MockName, "DetachNIC"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := nicHandler.MockName
+
+	nicMapLock.Lock()
+	info, err := findNIC(mockName, nicIID)
+	if err != nil {
+		nicMapLock.Unlock()
+		cblogger.Error(err)
+		return false, err
+	}
+	if info.Status != irs.NICAttached {
+		nicMapLock.Unlock()
+		err := fmt.Errorf("%s NIC is not Attached status!! It is %s status", nicIID.NameId, info.Status)
+		cblogger.Error(err)
+		return false, err
+	}
+	if info.DeviceIndex == 0 {
+		nicMapLock.Unlock()
+		err := fmt.Errorf("%s NIC is the primary NIC of %s VM and cannot be detached!!", nicIID.NameId, info.OwnerVM.NameId)
+		cblogger.Error(err)
+		return false, err
+	}
+
+	ownerVM := info.OwnerVM
+	info.Status = irs.NICAvailable
+	info.OwnerVM = irs.IID{}
+	info.DeviceIndex = 0
+	nicMapLock.Unlock()
+
+	nicDetach(mockName, ownerVM, info.IId)
+	setPublicIPOwnerVM(mockName, info.IId, irs.IID{})
+
+	return true, nil
+}
+
+func (nicHandler *MockNICHandler) AddPrivateIP(nicIID irs.IID, privateIP string) (irs.NICInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AddPrivateIP()!")
+
+	if err := injectFault(nicHandler.MockName, "AddPrivateIP"); err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	mockName := nicHandler.MockName
+
+	curInfo, err := nicHandler.GetNIC(nicIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	_, subnetInfo, err := getMockVPCSubnet(mockName, curInfo.VpcIID, curInfo.SubnetIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+
+	nicMapLock.Lock()
+	info, err := findNIC(mockName, nicIID)
+	if err != nil {
+		nicMapLock.Unlock()
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	newIP, err := allocatePrivateIP(mockName, subnetInfo, privateIP)
+	if err != nil {
+		nicMapLock.Unlock()
+		cblogger.Error(err)
+		return irs.NICInfo{}, err
+	}
+	info.PrivateIPs = append(info.PrivateIPs, newIP)
+	info.PublicIPs = append(info.PublicIPs, "")
+	changedInfo := CloneNICInfo(*info)
+	nicMapLock.Unlock()
+
+	if changedInfo.Status == irs.NICAttached {
+		nicAttach(mockName, changedInfo.OwnerVM, toVMNICInfo(changedInfo))
+	}
+	return changedInfo, nil
+}
+
+func (nicHandler *MockNICHandler) RemovePrivateIP(nicIID irs.IID, privateIP string) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RemovePrivateIP()!")
+
+	if err := injectFault(nicHandler.MockName, "RemovePrivateIP"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := nicHandler.MockName
+
+	nicMapLock.Lock()
+	info, err := findNIC(mockName, nicIID)
+	if err != nil {
+		nicMapLock.Unlock()
+		cblogger.Error(err)
+		return false, err
+	}
+	idx := -1
+	for i, ip := range info.PrivateIPs {
+		if ip == privateIP {
+			idx = i
+			break
+		}
+	}
+	switch {
+	case idx < 0:
+		err = fmt.Errorf("%s is not a private IP of %s NIC!!", privateIP, nicIID.NameId)
+	case idx == 0:
+		err = fmt.Errorf("%s is the primary private IP of %s NIC and cannot be removed!!", privateIP, nicIID.NameId)
+	case idx < len(info.PublicIPs) && info.PublicIPs[idx] != "":
+		err = fmt.Errorf("%s is mapped to the public IP %s. Disassociate it first!!", privateIP, info.PublicIPs[idx])
+	}
+	if err != nil {
+		nicMapLock.Unlock()
+		cblogger.Error(err)
+		return false, err
+	}
+	info.PrivateIPs = append(info.PrivateIPs[:idx], info.PrivateIPs[idx+1:]...)
+	if idx < len(info.PublicIPs) {
+		info.PublicIPs = append(info.PublicIPs[:idx], info.PublicIPs[idx+1:]...)
+	}
+	changedInfo := CloneNICInfo(*info)
+	nicMapLock.Unlock()
+
+	if changedInfo.Status == irs.NICAttached {
+		nicAttach(mockName, changedInfo.OwnerVM, toVMNICInfo(changedInfo))
+	}
+	return true, nil
+}
+
+func (nicHandler *MockNICHandler) GetNICOSConfigScript(nicIID irs.IID) (string, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetNICOSConfigScript()!")
+
+	if err := injectFault(nicHandler.MockName, "GetNICOSConfigScript"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
+	info, err := nicHandler.GetNIC(nicIID)
+	if err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+	if info.Status != irs.NICAttached {
+		err := fmt.Errorf("%s NIC is not attached to any VM!!", nicIID.NameId)
+		cblogger.Error(err)
+		return "", err
+	}
+	// the primary NIC is configured by the OS at boot time
+	if info.DeviceIndex == 0 {
+		return "", nil
+	}
+
+	_, subnetInfo, err := getMockVPCSubnet(nicHandler.MockName, info.VpcIID, info.SubnetIID)
+	if err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+	_, ipNet, err := net.ParseCIDR(subnetInfo.IPv4_CIDR)
+	if err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+	gatewayIP := make(net.IP, 4)
+	binary.BigEndian.PutUint32(gatewayIP, binary.BigEndian.Uint32(ipNet.IP.To4())+1)
+	ones, _ := ipNet.Mask.Size()
+
+	table := strconv.Itoa(100 + info.DeviceIndex)
+	var sb strings.Builder
+	sb.WriteString("#!/bin/bash\n")
+	sb.WriteString("# 1. Identify interface name by MAC\n")
+	sb.WriteString("IFACE=$(ip -o link | grep -i \"" + info.MACAddress + "\" | awk -F': ' '{print $2}')\n")
+	sb.WriteString("echo \"Target interface: $IFACE\"\n")
+	sb.WriteString("\n# 2. Bring up the interface with its private IPs\n")
+	sb.WriteString("sudo ip link set dev $IFACE up\n")
+	for _, ip := range info.PrivateIPs {
+		sb.WriteString("sudo ip addr add " + ip + "/" + strconv.Itoa(ones) + " dev $IFACE 2>/dev/null || true\n")
+	}
+	sb.WriteString("\n# 3. Configure Policy-based Routing (PBR)\n")
+	sb.WriteString("sudo ip route flush table " + table + " 2>/dev/null || true\n")
+	sb.WriteString("sudo ip route add " + ipNet.String() + " dev $IFACE src " + info.PrivateIP + " table " + table + "\n")
+	sb.WriteString("sudo ip route add default via " + gatewayIP.String() + " dev $IFACE table " + table + "\n")
+	for _, ip := range info.PrivateIPs {
+		sb.WriteString("sudo ip rule del from " + ip + " lookup " + table + " 2>/dev/null || true\n")
+		sb.WriteString("sudo ip rule add from " + ip + " lookup " + table + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (nicHandler *MockNICHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	if err := injectFault(nicHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := nicHandler.MockName
+	nicMapLock.RLock()
+	defer nicMapLock.RUnlock()
+	infoList, ok := nicInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+	infoList = applyListLag(mockName, "ListIID", "nic", infoList, func(info *irs.NICInfo) irs.IID { return info.IId })
+
+	iidList := []*irs.IID{}
+	for _, info := range infoList {
+		iidList = append(iidList, &irs.IID{NameId: info.IId.NameId, SystemId: info.IId.SystemId})
+	}
+	return iidList, nil
+}
+
+//================ helpers for the VM and PublicIP handlers
+
+// createPrimaryNIC creates the primary NIC(DeviceIndex 0) of a new VM.
+func createPrimaryNIC(mockName string, vmIID irs.IID, vpcIID irs.IID, subnetInfo irs.SubnetInfo, sgIIDs []irs.IID) (irs.NICInfo, error) {
+	nicMapLock.Lock()
+	defer nicMapLock.Unlock()
+
+	privateIP, err := allocatePrivateIP(mockName, subnetInfo, "")
+	if err != nil {
+		return irs.NICInfo{}, err
+	}
+
+	nicName := vmIID.NameId + "-nic0"
+	nicInfo := irs.NICInfo{
+		IId:               irs.IID{NameId: nicName, SystemId: nicName},
+		VpcIID:            vpcIID,
+		SubnetIID:         subnetInfo.IId,
+		SecurityGroupIIDs: cloneIIDArray(sgIIDs),
+		PrivateIP:         privateIP,
+		PrivateIPs:        []string{privateIP},
+		PublicIPs:         []string{""},
+		OwnerVM:           vmIID,
+		DeviceIndex:       0,
+		MACAddress:        newMockMACAddress(),
+		Status:            irs.NICAttached,
+		CreatedTime:       time.Now(),
+	}
+	nicInfoMap[mockName] = append(nicInfoMap[mockName], &nicInfo)
+
+	return CloneNICInfo(nicInfo), nil
+}
+
+// releaseVMNICs is called when a VM is terminated:
+// the primary NIC is deleted and the secondary NICs become Available.
+func releaseVMNICs(mockName string, vmIID irs.IID) {
+	nicMapLock.Lock()
+	deletedNICs := []irs.IID{}
+	detachedNICs := []irs.IID{}
+	remainList := []*irs.NICInfo{}
+	for _, info := range nicInfoMap[mockName] {
+		if info.Status == irs.NICAttached && info.OwnerVM.SystemId == vmIID.SystemId {
+			if info.DeviceIndex == 0 {
+				deletedNICs = append(deletedNICs, info.IId)
+				continue
+			}
+			info.Status = irs.NICAvailable
+			info.OwnerVM = irs.IID{}
+			info.DeviceIndex = 0
+			detachedNICs = append(detachedNICs, info.IId)
+		}
+		remainList = append(remainList, info)
+	}
+	nicInfoMap[mockName] = remainList
+	nicMapLock.Unlock()
+
+	for _, nicIID := range deletedNICs {
+		releasePublicIPsOfNIC(mockName, nicIID)
+	}
+	for _, nicIID := range detachedNICs {
+		setPublicIPOwnerVM(mockName, nicIID, irs.IID{})
+	}
+}
+
+// setNICPublicIP maps(or unmaps with an empty publicIP) a public IP to a private IP of the NIC,
+// and returns the NIC after the change.
+func setNICPublicIP(mockName string, nicIID irs.IID, privateIP string, publicIP string) (irs.NICInfo, error) {
+	nicMapLock.Lock()
+	info, err := findNIC(mockName, nicIID)
+	if err != nil {
+		nicMapLock.Unlock()
+		return irs.NICInfo{}, err
+	}
+	for len(info.PublicIPs) < len(info.PrivateIPs) {
+		info.PublicIPs = append(info.PublicIPs, "")
+	}
+	found := false
+	for idx, ip := range info.PrivateIPs {
+		if ip == privateIP {
+			if publicIP != "" && info.PublicIPs[idx] != "" {
+				nicMapLock.Unlock()
+				return irs.NICInfo{}, fmt.Errorf("%s of %s NIC is already mapped to the public IP %s!!", privateIP, nicIID.NameId, info.PublicIPs[idx])
+			}
+			info.PublicIPs[idx] = publicIP
+			found = true
+			break
+		}
+	}
+	if !found {
+		nicMapLock.Unlock()
+		return irs.NICInfo{}, fmt.Errorf("%s is not a private IP of %s NIC!!", privateIP, nicIID.NameId)
+	}
+	info.PublicIP = info.PublicIPs[0]
+	changedInfo := CloneNICInfo(*info)
+	nicMapLock.Unlock()
+
+	if changedInfo.Status == irs.NICAttached {
+		nicAttach(mockName, changedInfo.OwnerVM, toVMNICInfo(changedInfo))
+	}
+	return changedInfo, nil
+}
@@ -38,6 +38,17 @@ func (nlbHandler *MockNLBHandler) CreateNLB(nlbInfo irs.NLBInfo) (irs.NLBInfo, e
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateNLB()!")
 
+	if err := injectFault(nlbHandler.MockName, "CreateNLB"); err != nil {
+		cblogger.Error(err)
+		return irs.NLBInfo{}, err
+	}
+
+	defer lockQuota(nlbHandler.MockName)()
+	if err := checkQuota(nlbHandler.MockName, "network", "nlbs", 1); err != nil {
+		cblogger.Error(err)
+		return irs.NLBInfo{}, err
+	}
+
 	mockName := nlbHandler.MockName
 	nlbInfo.IId.SystemId = nlbInfo.IId.NameId
 	nlbInfo.VpcIID.SystemId = nlbInfo.VpcIID.NameId
@@ -56,6 +67,11 @@ func (nlbHandler *MockNLBHandler) CreateNLB(nlbInfo irs.NLBInfo) (irs.NLBInfo, e
 	infoList = append(infoList, &clonedInfo)
 	nlbInfoMap[mockName] = infoList
 
+	if err := recordFaultCreation(mockName, "CreateNLB", "nlb", nlbInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.NLBInfo{}, err
+	}
+
 	return CloneNLBInfo(nlbInfo), nil
 }
 
@@ -110,6 +126,11 @@ func (nlbHandler *MockNLBHandler) ListNLB() ([]*irs.NLBInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListNLB()!")
 
+	if err := injectFault(nlbHandler.MockName, "ListNLB"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := nlbHandler.MockName
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
@@ -118,6 +139,7 @@ func (nlbHandler *MockNLBHandler) ListNLB() ([]*irs.NLBInfo, error) {
 		return []*irs.NLBInfo{}, nil
 	}
 
+	infoList = applyListLag(mockName, "ListNLB", "nlb", infoList, func(info *irs.NLBInfo) irs.IID { return info.IId })
 	return CloneNLBInfoList(infoList), nil
 }
 
@@ -125,6 +147,11 @@ func (nlbHandler *MockNLBHandler) GetNLB(iid irs.IID) (irs.NLBInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetNLB()!")
 
+	if err := injectFault(nlbHandler.MockName, "GetNLB"); err != nil {
+		cblogger.Error(err)
+		return irs.NLBInfo{}, err
+	}
+
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
 
@@ -147,6 +174,11 @@ func (nlbHandler *MockNLBHandler) DeleteNLB(iid irs.IID) (bool, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteNLB()!")
 
+	if err := injectFault(nlbHandler.MockName, "DeleteNLB"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	nlbMapLock.Lock()
 	defer nlbMapLock.Unlock()
 
@@ -170,6 +202,11 @@ func (nlbHandler *MockNLBHandler) AddVMs(nlbIID irs.IID, vmIIDs *[]irs.IID) (irs
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AddVMs()!")
 
+	if err := injectFault(nlbHandler.MockName, "AddVMs"); err != nil {
+		cblogger.Error(err)
+		return irs.VMGroupInfo{}, err
+	}
+
 	nlbMapLock.Lock()
 	defer nlbMapLock.Unlock()
 
@@ -209,6 +246,11 @@ func (nlbHandler *MockNLBHandler) RemoveVMs(nlbIID irs.IID, vmIIDs *[]irs.IID) (
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called RemoveVMs()!")
 
+	if err := injectFault(nlbHandler.MockName, "RemoveVMs"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	nlbMapLock.Lock()
 	defer nlbMapLock.Unlock()
 
@@ -263,6 +305,11 @@ func (nlbHandler *MockNLBHandler) ChangeListener(nlbIID irs.IID, listener irs.Li
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ChangeListener()!")
 
+	if err := injectFault(nlbHandler.MockName, "ChangeListener"); err != nil {
+		cblogger.Error(err)
+		return irs.ListenerInfo{}, err
+	}
+
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
 
@@ -313,6 +360,11 @@ func (nlbHandler *MockNLBHandler) ChangeVMGroupInfo(nlbIID irs.IID, vmGroup irs.
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ChangeVMGroupInfo()!")
 
+	if err := injectFault(nlbHandler.MockName, "ChangeVMGroupInfo"); err != nil {
+		cblogger.Error(err)
+		return irs.VMGroupInfo{}, err
+	}
+
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
 
@@ -372,6 +424,11 @@ func (nlbHandler *MockNLBHandler) ChangeHealthCheckerInfo(nlbIID irs.IID, health
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ChangeHealthCheckerInfo()!")
 
+	if err := injectFault(nlbHandler.MockName, "ChangeHealthCheckerInfo"); err != nil {
+		cblogger.Error(err)
+		return irs.HealthCheckerInfo{}, err
+	}
+
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
 
@@ -426,6 +483,11 @@ func (nlbHandler *MockNLBHandler) GetVMGroupHealthInfo(nlbIID irs.IID) (irs.Heal
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetVMGroupHealthInfo()!")
 
+	if err := injectFault(nlbHandler.MockName, "GetVMGroupHealthInfo"); err != nil {
+		cblogger.Error(err)
+		return irs.HealthInfo{}, err
+	}
+
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
 
@@ -457,6 +519,11 @@ func (NLBHandler *MockNLBHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(NLBHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := NLBHandler.MockName
 	nlbMapLock.RLock()
 	defer nlbMapLock.RUnlock()
@@ -464,6 +531,7 @@ func (NLBHandler *MockNLBHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "nlb", infoList, func(info *irs.NLBInfo) irs.IID { return info.IId })
 
 	iidList := []*irs.IID{}
 	for _, info := range infoList {
@@ -110,6 +110,11 @@ func (handler *MockPriceInfoHandler) ListProductFamily(regionName string) ([]str
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListProductFamily()!")
 
+	if err := injectFault(handler.MockName, "ListProductFamily"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	productFamily := []string{
 		COMPUTE_INSTANCE,
 		STORAGE,
@@ -134,6 +139,11 @@ func (handler *MockPriceInfoHandler) GetPriceInfo(productFamily string, regionNa
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetPriceInfo()!")
 
+	if err := injectFault(handler.MockName, "GetPriceInfo"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	// if filterList is empty slice, set to nil to make simple processing
 	if len(filterList) == 0 {
 		filterList = nil
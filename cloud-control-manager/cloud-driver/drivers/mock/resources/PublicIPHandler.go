@@ -0,0 +1,385 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var publicIPInfoMap map[string][]*irs.PublicIPInfo
+
+type MockPublicIPHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	publicIPInfoMap = make(map[string][]*irs.PublicIPInfo)
+}
+
+var publicIPMapLock = new(sync.RWMutex)
+
+// address pools of mock public IPs: TEST-NET-3(RFC 5737), then TEST-NET-2
+var mockPublicIPPools = []string{"203.0.113.", "198.51.100."}
+
+// (1) allocate a free public IP address
+// (2) insert publicIPInfo into global Map
+func (publicIPHandler *MockPublicIPHandler) CreatePublicIP(publicIPReqInfo irs.PublicIPInfo) (irs.PublicIPInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreatePublicIP()!")
+
+	if err := injectFault(publicIPHandler.MockName, "CreatePublicIP"); err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	defer lockQuota(publicIPHandler.MockName)()
+	if err := checkQuota(publicIPHandler.MockName, "network", "public-ips", 1); err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	mockName := publicIPHandler.MockName
+
+	publicIPMapLock.Lock()
+	defer publicIPMapLock.Unlock()
+
+	usedIPs := map[string]bool{}
+	for _, info := range publicIPInfoMap[mockName] {
+		if info.IId.NameId == publicIPReqInfo.IId.NameId {
+			err := fmt.Errorf("%s PublicIP already exists!!", publicIPReqInfo.IId.NameId)
+			cblogger.Error(err)
+			return irs.PublicIPInfo{}, err
+		}
+		usedIPs[info.PublicIPAddress] = true
+	}
+
+	// (1) allocate
+	address := ""
+	for _, pool := range mockPublicIPPools {
+		for i := 1; i < 255 && address == ""; i++ {
+			ip := fmt.Sprintf("%s%d", pool, i)
+			if !usedIPs[ip] {
+				address = ip
+			}
+		}
+	}
+	if address == "" {
+		err := fmt.Errorf("no more public IP addresses are available in %s!!", mockName)
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	publicIPInfo := irs.PublicIPInfo{
+		IId:             irs.IID{NameId: publicIPReqInfo.IId.NameId, SystemId: publicIPReqInfo.IId.NameId},
+		PublicIPAddress: address,
+		Status:          irs.PublicIPAvailable,
+		CreatedTime:     time.Now(),
+		TagList:         publicIPReqInfo.TagList,
+	}
+
+	// (2) insert PublicIPInfo into global Map
+	publicIPInfoMap[mockName] = append(publicIPInfoMap[mockName], &publicIPInfo)
+
+	if err := recordFaultCreation(mockName, "CreatePublicIP", "publicip", publicIPInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	return ClonePublicIPInfo(publicIPInfo), nil
+}
+
+func ClonePublicIPInfoList(srcInfoList []*irs.PublicIPInfo) []*irs.PublicIPInfo {
+	clonedInfoList := []*irs.PublicIPInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := ClonePublicIPInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func ClonePublicIPInfo(srcInfo irs.PublicIPInfo) irs.PublicIPInfo {
+	// clone PublicIPInfo
+	clonedInfo := irs.PublicIPInfo{
+		IId:             irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		PublicIPAddress: srcInfo.PublicIPAddress,
+		Status:          srcInfo.Status,
+		OwnedVM:         irs.IID{srcInfo.OwnedVM.NameId, srcInfo.OwnedVM.SystemId},
+		OwnedNIC:        irs.IID{srcInfo.OwnedNIC.NameId, srcInfo.OwnedNIC.SystemId},
+		OwnedPrivateIP:  srcInfo.OwnedPrivateIP,
+		CreatedTime:     srcInfo.CreatedTime,
+		TagList:         srcInfo.TagList,      // clone TagList
+		KeyValueList:    srcInfo.KeyValueList, // now, do not need cloning
+	}
+
+	return clonedInfo
+}
+
+func (publicIPHandler *MockPublicIPHandler) ListPublicIP() ([]*irs.PublicIPInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListPublicIP()!")
+
+	if err := injectFault(publicIPHandler.MockName, "ListPublicIP"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := publicIPHandler.MockName
+	publicIPMapLock.RLock()
+	defer publicIPMapLock.RUnlock()
+	infoList, ok := publicIPInfoMap[mockName]
+	if !ok {
+		return []*irs.PublicIPInfo{}, nil
+	}
+
+	infoList = applyListLag(mockName, "ListPublicIP", "publicip", infoList, func(info *irs.PublicIPInfo) irs.IID { return info.IId })
+	// cloning list of PublicIP
+	return ClonePublicIPInfoList(infoList), nil
+}
+
+// findPublicIP returns the PublicIP in the global Map. Caller must hold publicIPMapLock.
+func findPublicIP(mockName string, iid irs.IID) (*irs.PublicIPInfo, error) {
+	for _, info := range publicIPInfoMap[mockName] {
+		if info.IId.NameId == iid.NameId {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%s PublicIP does not exist!!", iid.NameId)
+}
+
+func (publicIPHandler *MockPublicIPHandler) GetPublicIP(iid irs.IID) (irs.PublicIPInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetPublicIP()!")
+
+	if err := injectFault(publicIPHandler.MockName, "GetPublicIP"); err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	publicIPMapLock.RLock()
+	defer publicIPMapLock.RUnlock()
+
+	info, err := findPublicIP(publicIPHandler.MockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+	return ClonePublicIPInfo(*info), nil
+}
+
+func (publicIPHandler *MockPublicIPHandler) DeletePublicIP(iid irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeletePublicIP()!")
+
+	if err := injectFault(publicIPHandler.MockName, "DeletePublicIP"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := publicIPHandler.MockName
+
+	publicIPMapLock.Lock()
+	defer publicIPMapLock.Unlock()
+
+	infoList := publicIPInfoMap[mockName]
+	for idx, info := range infoList {
+		if info.IId.SystemId == iid.SystemId {
+			if info.Status == irs.PublicIPAssociated {
+				err := fmt.Errorf("%s PublicIP is associated with %s NIC. Disassociate it first!!", iid.NameId, info.OwnedNIC.NameId)
+				cblogger.Error(err)
+				return false, err
+			}
+			publicIPInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+
+	err := fmt.Errorf("%s PublicIP does not exist!!", iid.NameId)
+	cblogger.Error(err)
+	return false, err
+}
+
+// AssociatePublicIP maps the PublicIP to a private IP of a NIC.
+// If nicIID is empty, the primary NIC of the VM is used(VM-level NAT).
+// If privateIP is empty, the primary private IP of the NIC is used.
+func (publicIPHandler *MockPublicIPHandler) AssociatePublicIP(publicIPIID irs.IID, vmIID irs.IID, nicIID irs.IID, privateIP string) (irs.PublicIPInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AssociatePublicIP()!")
+
+	if err := injectFault(publicIPHandler.MockName, "AssociatePublicIP"); err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	mockName := publicIPHandler.MockName
+
+	// (1) resolve the target NIC
+	if nicIID.NameId == "" && nicIID.SystemId == "" {
+		if vmIID.NameId == "" {
+			err := fmt.Errorf("a VM or a NIC is required to associate %s PublicIP!!", publicIPIID.NameId)
+			cblogger.Error(err)
+			return irs.PublicIPInfo{}, err
+		}
+		vmHandler := MockVMHandler{MockName: mockName}
+		vmInfo, err := vmHandler.GetVM(vmIID)
+		if err != nil {
+			cblogger.Error(err)
+			return irs.PublicIPInfo{}, err
+		}
+		for _, vmNIC := range vmInfo.NICs {
+			if vmNIC.DeviceIndex == 0 {
+				nicIID = vmNIC.IId
+				break
+			}
+		}
+	}
+	nicHandler := MockNICHandler{MockName: mockName}
+	nicInfo, err := nicHandler.GetNIC(nicIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+	if vmIID.NameId != "" && nicInfo.OwnerVM.SystemId != vmIID.SystemId {
+		err := fmt.Errorf("%s NIC is not attached to %s VM!!", nicIID.NameId, vmIID.NameId)
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+	if privateIP == "" {
+		privateIP = nicInfo.PrivateIP
+	}
+
+	// (2) one association per PublicIP
+	publicIPMapLock.Lock()
+	info, err := findPublicIP(mockName, publicIPIID)
+	if err != nil {
+		publicIPMapLock.Unlock()
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+	if info.Status == irs.PublicIPAssociated {
+		publicIPMapLock.Unlock()
+		err := fmt.Errorf("%s PublicIP is already associated with %s NIC(%s)!!", publicIPIID.NameId, info.OwnedNIC.NameId, info.OwnedPrivateIP)
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	// (3) map to the NIC's private IP: fails if the private IP is unknown or already mapped
+	nicInfo, err = setNICPublicIP(mockName, nicInfo.IId, privateIP, info.PublicIPAddress)
+	if err != nil {
+		publicIPMapLock.Unlock()
+		cblogger.Error(err)
+		return irs.PublicIPInfo{}, err
+	}
+
+	info.Status = irs.PublicIPAssociated
+	info.OwnedNIC = nicInfo.IId
+	info.OwnedVM = nicInfo.OwnerVM
+	info.OwnedPrivateIP = privateIP
+	associatedInfo := ClonePublicIPInfo(*info)
+	publicIPMapLock.Unlock()
+
+	return associatedInfo, nil
+}
+
+func (publicIPHandler *MockPublicIPHandler) DisassociatePublicIP(publicIPIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DisassociatePublicIP()!")
+
+	if err := injectFault(publicIPHandler.MockName, "DisassociatePublicIP"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := publicIPHandler.MockName
+
+	publicIPMapLock.Lock()
+	info, err := findPublicIP(mockName, publicIPIID)
+	if err != nil {
+		publicIPMapLock.Unlock()
+		cblogger.Error(err)
+		return false, err
+	}
+	if info.Status != irs.PublicIPAssociated {
+		publicIPMapLock.Unlock()
+		err := fmt.Errorf("%s PublicIP is not Associated status!! It is %s status", publicIPIID.NameId, info.Status)
+		cblogger.Error(err)
+		return false, err
+	}
+	nicIID, privateIP := info.OwnedNIC, info.OwnedPrivateIP
+	info.Status = irs.PublicIPAvailable
+	info.OwnedNIC = irs.IID{}
+	info.OwnedVM = irs.IID{}
+	info.OwnedPrivateIP = ""
+	publicIPMapLock.Unlock()
+
+	// the NIC could be already deleted
+	setNICPublicIP(mockName, nicIID, privateIP, "")
+	return true, nil
+}
+
+func (publicIPHandler *MockPublicIPHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	if err := injectFault(publicIPHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := publicIPHandler.MockName
+	publicIPMapLock.RLock()
+	defer publicIPMapLock.RUnlock()
+	infoList, ok := publicIPInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+	infoList = applyListLag(mockName, "ListIID", "publicip", infoList, func(info *irs.PublicIPInfo) irs.IID { return info.IId })
+
+	iidList := []*irs.IID{}
+	for _, info := range infoList {
+		iidList = append(iidList, &irs.IID{NameId: info.IId.NameId, SystemId: info.IId.SystemId})
+	}
+	return iidList, nil
+}
+
+//================ helpers for the NIC handler
+
+// releasePublicIPsOfNIC disassociates all PublicIPs of a deleted NIC.
+func releasePublicIPsOfNIC(mockName string, nicIID irs.IID) {
+	publicIPMapLock.Lock()
+	defer publicIPMapLock.Unlock()
+
+	for _, info := range publicIPInfoMap[mockName] {
+		if info.Status == irs.PublicIPAssociated && info.OwnedNIC.SystemId == nicIID.SystemId {
+			info.Status = irs.PublicIPAvailable
+			info.OwnedNIC = irs.IID{}
+			info.OwnedVM = irs.IID{}
+			info.OwnedPrivateIP = ""
+		}
+	}
+}
+
+// setPublicIPOwnerVM updates the owner VM of the PublicIPs of a NIC after attach/detach.
+func setPublicIPOwnerVM(mockName string, nicIID irs.IID, vmIID irs.IID) {
+	publicIPMapLock.Lock()
+	defer publicIPMapLock.Unlock()
+
+	for _, info := range publicIPInfoMap[mockName] {
+		if info.Status == irs.PublicIPAssociated && info.OwnedNIC.SystemId == nicIID.SystemId {
+			info.OwnedVM = vmIID
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	cblog "github.com/cloud-barista/cb-log"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	"gopkg.in/yaml.v3"
+)
+
+type MockQuotaInfoHandler struct {
+	Region   idrv.RegionInfo
+	MockName string
+}
+
+// ErrQuotaExceeded is wrapped by the errors of create calls rejected by a mock quota.
+var ErrQuotaExceeded = errors.New("QuotaExceeded")
+
+// DEFAULT_QUOTA_NAME is the quota table applied to MockNames without their own table.
+const DEFAULT_QUOTA_NAME = "default"
+
+type mockQuotaItem struct {
+	name        string
+	unit        string
+	description string
+}
+
+// mock service types and their quota items
+var mockQuotaServiceTypes = []string{"compute", "network", "storage", "database", "container"}
+var mockQuotaItems = map[string][]mockQuotaItem{
+	"compute": {
+		{"vm-instances", "count", "Number of VMs"},
+		{"keypairs", "count", "Number of KeyPairs"},
+		{"disks", "count", "Number of Disks"},
+		{"myimages", "count", "Number of MyImages"},
+		{"disk-snapshots", "count", "Number of Disk Snapshots"},
+	},
+	"network": {
+		{"vpcs", "count", "Number of VPCs"},
+		{"subnets", "count", "Number of Subnets in all VPCs"},
+		{"security-groups", "count", "Number of SecurityGroups"},
+		{"nlbs", "count", "Number of NLBs"},
+		{"public-ips", "count", "Number of PublicIPs"},
+		{"nics", "count", "Number of NICs including the primary NICs of VMs"},
+	},
+	"storage": {
+		{"filesystems", "count", "Number of FileSystems"},
+	},
+	"database": {
+		{"rdbms-instances", "count", "Number of RDBMS instances"},
+	},
+	"container": {
+		{"clusters", "count", "Number of Clusters"},
+	},
+}
+
+// quotaLimitMap: MockName(or DEFAULT_QUOTA_NAME) => service type => quota name => limit
+// A negative limit means unlimited.
+type mockQuotaTable map[string]map[string]int64
+
+var quotaLimitMap map[string]mockQuotaTable
+var quotaMapLock = new(sync.RWMutex)
+var quotaLoadOnce sync.Once
+
+func init() {
+	quotaLimitMap = map[string]mockQuotaTable{
+		DEFAULT_QUOTA_NAME: {
+			"compute":   {"vm-instances": 100, "keypairs": 500, "disks": 500, "myimages": 100, "disk-snapshots": 500},
+			"network":   {"vpcs": 20, "subnets": 200, "security-groups": 500, "nlbs": 50, "public-ips": 50, "nics": 350},
+			"storage":   {"filesystems": 50},
+			"database":  {"rdbms-instances": 40},
+			"container": {"clusters": 20},
+		},
+	}
+}
+
+// loadDefaultQuotaFile loads the quota file once.
+// The file is $MOCK_QUOTA_FILE, or the mock-quota.yaml of this package under $CBSPIDER_ROOT.
+// Built-in limits are used if there is no file.
+func loadDefaultQuotaFile() {
+	quotaLoadOnce.Do(func() {
+		cblogger := cblog.GetLogger("CB-SPIDER")
+
+		filePath := os.Getenv("MOCK_QUOTA_FILE")
+		if filePath == "" {
+			cbspiderRoot := os.Getenv("CBSPIDER_ROOT")
+			if cbspiderRoot == "" {
+				return
+			}
+			filePath = cbspiderRoot + "/cloud-control-manager/cloud-driver/drivers/mock/resources/quota-info/mock-quota.yaml"
+		}
+		if _, err := os.Stat(filePath); err != nil {
+			return
+		}
+		if err := loadQuotaFile(filePath); err != nil {
+			cblogger.Error(err)
+		}
+	})
+}
+
+// LoadQuotaFile loads quota tables from a YAML file.
+// The top-level keys are MockNames, and 'default' is for all other MockNames:
+//
+//	default:
+//	  compute:
+//	    vm-instances: 100
+//	MockDriver-01:
+//	  network:
+//	    vpcs: 2
+//
+// The loaded items overwrite the current limits, other items are kept.
+func LoadQuotaFile(filePath string) error {
+	loadDefaultQuotaFile()
+	return loadQuotaFile(filePath)
+}
+
+func loadQuotaFile(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	tables := map[string]mockQuotaTable{}
+	if err := yaml.Unmarshal(data, &tables); err != nil {
+		return fmt.Errorf("failed to parse the quota file %s: %v", filePath, err)
+	}
+
+	// validate all items before applying
+	for _, table := range tables {
+		for serviceType, limits := range table {
+			for quotaName := range limits {
+				if !isMockQuotaItem(serviceType, quotaName) {
+					return fmt.Errorf("%s: %s/%s is not a mock quota item!!", filePath, serviceType, quotaName)
+				}
+			}
+		}
+	}
+
+	for mockName, table := range tables {
+		for serviceType, limits := range table {
+			for quotaName, limit := range limits {
+				SetQuotaLimit(mockName, serviceType, quotaName, limit)
+			}
+		}
+	}
+	return nil
+}
+
+// SetQuotaLimit sets the limit of a quota item for the MockName.
+// A negative limit means unlimited.
+func SetQuotaLimit(mockName string, serviceType string, quotaName string, limit int64) error {
+	if !isMockQuotaItem(serviceType, quotaName) {
+		return fmt.Errorf("%s/%s is not a mock quota item!!", serviceType, quotaName)
+	}
+
+	quotaMapLock.Lock()
+	defer quotaMapLock.Unlock()
+
+	table, ok := quotaLimitMap[mockName]
+	if !ok {
+		table = mockQuotaTable{}
+		quotaLimitMap[mockName] = table
+	}
+	if table[serviceType] == nil {
+		table[serviceType] = map[string]int64{}
+	}
+	table[serviceType][quotaName] = limit
+	return nil
+}
+
+// ResetQuotaLimit removes the quota table of the MockName, then the default table is applied.
+func ResetQuotaLimit(mockName string) {
+	quotaMapLock.Lock()
+	defer quotaMapLock.Unlock()
+
+	if mockName != DEFAULT_QUOTA_NAME {
+		delete(quotaLimitMap, mockName)
+	}
+}
+
+func isMockQuotaItem(serviceType string, quotaName string) bool {
+	for _, item := range mockQuotaItems[serviceType] {
+		if item.name == quotaName {
+			return true
+		}
+	}
+	return false
+}
+
+// getQuotaLimit returns the limit of the MockName's table, or the default table's limit.
+func getQuotaLimit(mockName string, serviceType string, quotaName string) int64 {
+	loadDefaultQuotaFile()
+
+	quotaMapLock.RLock()
+	defer quotaMapLock.RUnlock()
+
+	if limit, ok := quotaLimitMap[mockName][serviceType][quotaName]; ok {
+		return limit
+	}
+	if limit, ok := quotaLimitMap[DEFAULT_QUOTA_NAME][serviceType][quotaName]; ok {
+		return limit
+	}
+	return -1
+}
+
+// getQuotaUsage counts the resources of the quota item in the mock state.
+func getQuotaUsage(mockName string, quotaName string) int64 {
+	var count int
+	switch quotaName {
+	case "vm-instances":
+		vmMapLock.RLock()
+		count = len(vmInfoMap[mockName])
+		vmMapLock.RUnlock()
+	case "keypairs":
+		keyMapLock.RLock()
+		count = len(keyPairInfoMap[mockName])
+		keyMapLock.RUnlock()
+	case "disks":
+		diskMapLock.RLock()
+		count = len(diskInfoMap[mockName])
+		diskMapLock.RUnlock()
+	case "myimages":
+		myImageMapLock.RLock()
+		count = len(myImageInfoMap[mockName])
+		myImageMapLock.RUnlock()
+	case "disk-snapshots":
+		diskSnapshotMapLock.RLock()
+		count = len(diskSnapshotInfoMap[mockName])
+		diskSnapshotMapLock.RUnlock()
+	case "vpcs":
+		vpcMapLock.RLock()
+		count = len(vpcInfoMap[mockName])
+		vpcMapLock.RUnlock()
+	case "subnets":
+		vpcMapLock.RLock()
+		for _, vpcInfo := range vpcInfoMap[mockName] {
+			count += len(vpcInfo.SubnetInfoList)
+		}
+		vpcMapLock.RUnlock()
+	case "security-groups":
+		sgMapLock.RLock()
+		count = len(securityInfoMap[mockName])
+		sgMapLock.RUnlock()
+	case "nlbs":
+		nlbMapLock.RLock()
+		count = len(nlbInfoMap[mockName])
+		nlbMapLock.RUnlock()
+	case "public-ips":
+		publicIPMapLock.RLock()
+		count = len(publicIPInfoMap[mockName])
+		publicIPMapLock.RUnlock()
+	case "nics":
+		nicMapLock.RLock()
+		count = len(nicInfoMap[mockName])
+		nicMapLock.RUnlock()
+	case "filesystems":
+		fileSystemMapLock.RLock()
+		count = len(fileSystemInfoMap[mockName])
+		fileSystemMapLock.RUnlock()
+	case "rdbms-instances":
+		rdbmsMapLock.RLock()
+		count = len(rdbmsInfoMap[mockName])
+		rdbmsMapLock.RUnlock()
+	case "clusters":
+		clusterMapLock.RLock()
+		count = len(clusterInfoMap[mockName])
+		clusterMapLock.RUnlock()
+	}
+	return int64(count)
+}
+
+// quotaLockMap serializes the quota-checked creations of each MockName(connection),
+// so no other creation can be counted between the check and the insert.
+var quotaLockMap sync.Map // MockName => *sync.Mutex
+
+// lockQuota locks the creations of the MockName and returns the unlock function.
+// Hold it from checkQuota until the new resource is inserted:
+//
+//	defer lockQuota(mockName)()
+func lockQuota(mockName string) func() {
+	value, _ := quotaLockMap.LoadOrStore(mockName, new(sync.Mutex))
+	lock := value.(*sync.Mutex)
+	lock.Lock()
+	return lock.Unlock
+}
+
+// checkQuota returns an error wrapping ErrQuotaExceeded
+// if creating addCount more resources exceeds the quota.
+// Must be called holding lockQuota(mockName) and without holding the map lock of the resource.
+func checkQuota(mockName string, serviceType string, quotaName string, addCount int) error {
+	limit := getQuotaLimit(mockName, serviceType, quotaName)
+	if limit < 0 || addCount <= 0 {
+		return nil
+	}
+	used := getQuotaUsage(mockName, quotaName)
+	if used+int64(addCount) > limit {
+		return fmt.Errorf("%w: %s quota(%d) is exceeded!! Used: %d, Requested: %d", ErrQuotaExceeded, quotaName, limit, used, addCount)
+	}
+	return nil
+}
+
+func (quotaInfoHandler *MockQuotaInfoHandler) ListServiceType() ([]string, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListServiceType()!")
+
+	if err := injectFault(quotaInfoHandler.MockName, "ListServiceType"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	return append([]string{}, mockQuotaServiceTypes...), nil
+}
+
+func (quotaInfoHandler *MockQuotaInfoHandler) GetQuotaInfo(serviceType string) (irs.QuotaInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetQuotaInfo()!")
+
+	if err := injectFault(quotaInfoHandler.MockName, "GetQuotaInfo"); err != nil {
+		cblogger.Error(err)
+		return irs.QuotaInfo{}, err
+	}
+
+	mockName := quotaInfoHandler.MockName
+
+	items, ok := mockQuotaItems[serviceType]
+	if !ok {
+		err := fmt.Errorf("%s service type does not exist!!", serviceType)
+		cblogger.Error(err)
+		return irs.QuotaInfo{}, err
+	}
+
+	quotaInfo := irs.QuotaInfo{
+		CSP:    "Mock",
+		Region: quotaInfoHandler.Region.Region,
+		Quotas: []irs.Quota{},
+	}
+	for _, item := range items {
+		limit := getQuotaLimit(mockName, serviceType, item.name)
+		used := getQuotaUsage(mockName, item.name)
+		quota := irs.Quota{
+			QuotaName:   item.name,
+			Limit:       "NA",
+			Used:        strconv.FormatInt(used, 10),
+			Available:   "NA",
+			Unit:        item.unit,
+			Description: item.description,
+		}
+		if limit >= 0 {
+			quota.Limit = strconv.FormatInt(limit, 10)
+			quota.Available = strconv.FormatInt(max(limit-used, 0), 10)
+		}
+		quotaInfo.Quotas = append(quotaInfo.Quotas, quota)
+	}
+
+	return quotaInfo, nil
+}
@@ -0,0 +1,556 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var rdbmsInfoMap map[string][]*irs.RDBMSInfo
+
+// rdbmsModifiedTimeMap: MockName => SystemId => time of the last spec or storage change
+var rdbmsModifiedTimeMap map[string]map[string]time.Time
+
+type MockRDBMSHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	rdbmsInfoMap = make(map[string][]*irs.RDBMSInfo)
+	rdbmsModifiedTimeMap = make(map[string]map[string]time.Time)
+}
+
+// rdbmsMapLock guards both rdbmsInfoMap and rdbmsModifiedTimeMap
+var rdbmsMapLock = new(sync.RWMutex)
+
+// RDBMSCreatingDuration is the simulated provisioning time.
+// A new RDBMS stays in Creating status until this duration has passed since its CreatedTime.
+// Tests can set it to 0 to get Available status right after CreateRDBMS().
+var RDBMSCreatingDuration = 3 * time.Second
+
+// RDBMSModifyingDuration is the simulated time to apply a spec or storage change.
+// A changed RDBMS stays in Creating status(like CSP's modifying) until this duration has passed.
+var RDBMSModifyingDuration = 3 * time.Second
+
+// default listener port of each DB engine, used for the mock Endpoint
+var mockDBEnginePorts = map[string]string{
+	"mysql":      "3306",
+	"mariadb":    "3306",
+	"postgresql": "5432",
+}
+
+func (rdbmsHandler *MockRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetMetaInfo()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "GetMetaInfo"); err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSMetaInfo{}, err
+	}
+
+	engine, err := irs.NormalizeRDBMSEngine(dbEngine)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSMetaInfo{}, err
+	}
+
+	dbSpecOptions := map[string][]string{engine: mockDBSpecNames(rdbmsHandler.MockName, engine)}
+	storageTypeOptions := map[string][]string{engine: mockDBStorageTypes}
+
+	metaInfo, err := irs.BuildRDBMSMetaInfo(engine, mockDBEngineVersions, dbSpecOptions, storageTypeOptions, mockDBStorageSizeRange,
+		true, true, true, true, true, "0-35", true, false, true, true, true, true, true)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSMetaInfo{}, err
+	}
+	return metaInfo, nil
+}
+
+// (1) validate request with mock VPC, SG and DBSpec
+// (2) create rdbmsInfo object with Creating status
+// (3) insert rdbmsInfo into global Map
+func (rdbmsHandler *MockRDBMSHandler) CreateRDBMS(rdbmsReqInfo irs.RDBMSInfo) (irs.RDBMSInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateRDBMS()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "CreateRDBMS"); err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	defer lockQuota(rdbmsHandler.MockName)()
+	if err := checkQuota(rdbmsHandler.MockName, "database", "rdbms-instances", 1); err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	mockName := rdbmsHandler.MockName
+
+	// (1) validation
+	err := validateRDBMSReqInfo(mockName, &rdbmsReqInfo)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	// (2) create rdbmsInfo object
+	rdbmsReqInfo.IId.SystemId = rdbmsReqInfo.IId.NameId
+	rdbmsReqInfo.DBInstanceType = "Primary"
+	rdbmsReqInfo.Endpoint = rdbmsReqInfo.IId.NameId + ".rdbms.spider.barista.com:" + mockDBEnginePorts[rdbmsReqInfo.DBEngine]
+	rdbmsReqInfo.MasterUserPassword = "" // never keep or return the password
+	rdbmsReqInfo.BackupTime = "03:00"
+	rdbmsReqInfo.Encryption = true
+	rdbmsReqInfo.Status = irs.RDBMSCreating
+	rdbmsReqInfo.CreatedTime = time.Now()
+
+	// (3) insert RDBMSInfo into global Map
+	rdbmsMapLock.Lock()
+	defer rdbmsMapLock.Unlock()
+
+	for _, info := range rdbmsInfoMap[mockName] {
+		if info.IId.NameId == rdbmsReqInfo.IId.NameId {
+			return irs.RDBMSInfo{}, fmt.Errorf("%s RDBMS already exists!!", rdbmsReqInfo.IId.NameId)
+		}
+	}
+	infoList, _ := rdbmsInfoMap[mockName]
+	infoList = append(infoList, &rdbmsReqInfo)
+	rdbmsInfoMap[mockName] = infoList
+
+	if err := recordFaultCreation(mockName, "CreateRDBMS", "rdbms", rdbmsReqInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	return CloneRDBMSInfo(rdbmsReqInfo), nil
+}
+
+func validateRDBMSReqInfo(mockName string, reqInfo *irs.RDBMSInfo) error {
+	// engine & version
+	engine, err := irs.NormalizeRDBMSEngine(reqInfo.DBEngine)
+	if err != nil {
+		return err
+	}
+	reqInfo.DBEngine = engine
+
+	versions := mockDBEngineVersions[engine]
+	if reqInfo.DBEngineVersion == "" || reqInfo.DBEngineVersion == "default" {
+		reqInfo.DBEngineVersion = versions[len(versions)-1]
+	}
+	if !containsString(versions, reqInfo.DBEngineVersion) {
+		return fmt.Errorf("%s %s is not supported!! Supported versions: %s", engine, reqInfo.DBEngineVersion, strings.Join(versions, ", "))
+	}
+
+	// spec
+	if reqInfo.DBSpec == "" {
+		return fmt.Errorf("DBSpec is required!!")
+	}
+	dbSpecHandler := MockDBSpecHandler{mockName}
+	specInfo, err := dbSpecHandler.GetDBSpec(engine, reqInfo.DBSpec)
+	if err != nil {
+		return err
+	}
+	if specInfo.HasNoSpecData() {
+		return fmt.Errorf("%s DBSpec is not orderable!!", reqInfo.DBSpec)
+	}
+
+	// storage
+	if reqInfo.StorageType == "" || reqInfo.StorageType == "default" {
+		reqInfo.StorageType = mockDBStorageTypes[0]
+	}
+	if !containsString(mockDBStorageTypes, reqInfo.StorageType) {
+		return fmt.Errorf("%s StorageType is not supported!! Supported types: %s", reqInfo.StorageType, strings.Join(mockDBStorageTypes, ", "))
+	}
+	if reqInfo.StorageSize == "" || reqInfo.StorageSize == "default" {
+		reqInfo.StorageSize = strconv.FormatInt(specInfo.StorageSizeRangeGB.Min, 10)
+	}
+	size, err := strconv.ParseInt(reqInfo.StorageSize, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid StorageSize '%s': %v", reqInfo.StorageSize, err)
+	}
+	if size < specInfo.StorageSizeRangeGB.Min || size > specInfo.StorageSizeRangeGB.Max {
+		return fmt.Errorf("StorageSize %dGB is out of range(%d-%dGB) for %s!!", size,
+			specInfo.StorageSizeRangeGB.Min, specInfo.StorageSizeRangeGB.Max, reqInfo.DBSpec)
+	}
+
+	// auth
+	if reqInfo.MasterUserName == "" {
+		return fmt.Errorf("MasterUserName is required!!")
+	}
+
+	// vpc & subnets
+	vpcHandler := MockVPCHandler{mockName}
+	vpcInfo, err := vpcHandler.GetVPC(reqInfo.VpcIID)
+	if err != nil {
+		return err
+	}
+	reqInfo.VpcIID = vpcInfo.IId
+	for idx, subnetIID := range reqInfo.SubnetIIDs {
+		found := false
+		for _, subnetInfo := range vpcInfo.SubnetInfoList {
+			if subnetInfo.IId.NameId == subnetIID.NameId {
+				reqInfo.SubnetIIDs[idx] = subnetInfo.IId
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s subnet iid does not exist in %s VPC!!", subnetIID.NameId, vpcInfo.IId.NameId)
+		}
+	}
+
+	// security groups
+	securityHandler := MockSecurityHandler{mockName}
+	for idx, sgIID := range reqInfo.SecurityGroupIIDs {
+		sgInfo, err := securityHandler.GetSecurity(sgIID)
+		if err != nil {
+			return err
+		}
+		reqInfo.SecurityGroupIIDs[idx] = sgInfo.IId
+	}
+
+	return nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, one := range list {
+		if one == value {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshRDBMSStatus simulates the CSP's asynchronous provisioning:
+// Creating => Available after RDBMSCreatingDuration, or after RDBMSModifyingDuration for a change.
+// Caller must hold rdbmsMapLock.
+func refreshRDBMSStatus(mockName string, info *irs.RDBMSInfo) {
+	if info.Status != irs.RDBMSCreating {
+		return
+	}
+	if modifiedTime, ok := rdbmsModifiedTimeMap[mockName][info.IId.SystemId]; ok {
+		if time.Since(modifiedTime) >= RDBMSModifyingDuration {
+			info.Status = irs.RDBMSAvailable
+			delete(rdbmsModifiedTimeMap[mockName], info.IId.SystemId)
+		}
+		return
+	}
+	if time.Since(info.CreatedTime) >= RDBMSCreatingDuration {
+		info.Status = irs.RDBMSAvailable
+	}
+}
+
+func CloneRDBMSInfoList(srcInfoList []*irs.RDBMSInfo) []*irs.RDBMSInfo {
+	clonedInfoList := []*irs.RDBMSInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneRDBMSInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneRDBMSInfo(srcInfo irs.RDBMSInfo) irs.RDBMSInfo {
+	// clone RDBMSInfo
+	clonedInfo := irs.RDBMSInfo{
+		IId:    irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		VpcIID: irs.IID{srcInfo.VpcIID.NameId, srcInfo.VpcIID.SystemId},
+
+		DBEngine:        srcInfo.DBEngine,
+		DBEngineVersion: srcInfo.DBEngineVersion,
+		DBSpec:          srcInfo.DBSpec,
+		DBInstanceType:  srcInfo.DBInstanceType,
+
+		StorageType: srcInfo.StorageType,
+		StorageSize: srcInfo.StorageSize,
+		Iops:        srcInfo.Iops,
+
+		SubnetIIDs:        cloneIIDArray(srcInfo.SubnetIIDs),
+		SecurityGroupIIDs: cloneIIDArray(srcInfo.SecurityGroupIIDs),
+
+		MasterUserName: srcInfo.MasterUserName,
+
+		HighAvailability:    srcInfo.HighAvailability,
+		BackupRetentionDays: srcInfo.BackupRetentionDays,
+		BackupTime:          srcInfo.BackupTime,
+		PublicAccess:        srcInfo.PublicAccess,
+		Endpoint:            srcInfo.Endpoint,
+		Encryption:          srcInfo.Encryption,
+		DeletionProtection:  srcInfo.DeletionProtection,
+
+		Status:       srcInfo.Status,
+		CreatedTime:  srcInfo.CreatedTime,
+		TagList:      srcInfo.TagList,      // clone TagList
+		KeyValueList: srcInfo.KeyValueList, // now, do not need cloning
+	}
+
+	return clonedInfo
+}
+
+func (rdbmsHandler *MockRDBMSHandler) ListRDBMS() ([]*irs.RDBMSInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListRDBMS()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "ListRDBMS"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := rdbmsHandler.MockName
+	rdbmsMapLock.Lock()
+	defer rdbmsMapLock.Unlock()
+	infoList, ok := rdbmsInfoMap[mockName]
+	if !ok {
+		return []*irs.RDBMSInfo{}, nil
+	}
+
+	for _, info := range infoList {
+		refreshRDBMSStatus(mockName, info)
+	}
+	infoList = applyListLag(mockName, "ListRDBMS", "rdbms", infoList, func(info *irs.RDBMSInfo) irs.IID { return info.IId })
+	// cloning list of RDBMS
+	clonedInfoList := CloneRDBMSInfoList(infoList)
+	for _, info := range clonedInfoList {
+		if isFaultStuck(mockName, "rdbms", info.IId.SystemId) {
+			info.Status = irs.RDBMSCreating
+		}
+	}
+	return clonedInfoList, nil
+}
+
+func (rdbmsHandler *MockRDBMSHandler) GetRDBMS(iid irs.IID) (irs.RDBMSInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetRDBMS()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "GetRDBMS"); err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	mockName := rdbmsHandler.MockName
+	rdbmsMapLock.Lock()
+	defer rdbmsMapLock.Unlock()
+	infoList, ok := rdbmsInfoMap[mockName]
+	if !ok {
+		return irs.RDBMSInfo{}, fmt.Errorf("%s RDBMS does not exist!!", iid.NameId)
+	}
+
+	for _, info := range infoList {
+		if info.IId.NameId == iid.NameId {
+			refreshRDBMSStatus(mockName, info)
+			clonedInfo := CloneRDBMSInfo(*info)
+			if isFaultStuck(mockName, "rdbms", clonedInfo.IId.SystemId) {
+				clonedInfo.Status = irs.RDBMSCreating
+			}
+			return clonedInfo, nil
+		}
+	}
+
+	return irs.RDBMSInfo{}, fmt.Errorf("%s RDBMS does not exist!!", iid.NameId)
+}
+
+func (rdbmsHandler *MockRDBMSHandler) DeleteRDBMS(iid irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteRDBMS()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "DeleteRDBMS"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := rdbmsHandler.MockName
+
+	rdbmsMapLock.Lock()
+	defer rdbmsMapLock.Unlock()
+
+	infoList, ok := rdbmsInfoMap[mockName]
+	if !ok {
+		return false, fmt.Errorf("%s RDBMS does not exist!!", iid.NameId)
+	}
+
+	for idx, info := range infoList {
+		if info.IId.SystemId == iid.SystemId {
+			if info.DeletionProtection {
+				return false, fmt.Errorf("%s RDBMS cannot be deleted: DeletionProtection is enabled!!", iid.NameId)
+			}
+			infoList = append(infoList[:idx], infoList[idx+1:]...)
+			rdbmsInfoMap[mockName] = infoList
+			delete(rdbmsModifiedTimeMap[mockName], iid.SystemId)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s RDBMS does not exist!!", iid.NameId)
+}
+
+func (rdbmsHandler *MockRDBMSHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
+	mockName := rdbmsHandler.MockName
+	rdbmsMapLock.RLock()
+	defer rdbmsMapLock.RUnlock()
+	infoList, ok := rdbmsInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+	infoList = applyListLag(mockName, "ListIID", "rdbms", infoList, func(info *irs.RDBMSInfo) irs.IID { return info.IId })
+
+	iidList := []*irs.IID{}
+	for _, info := range infoList {
+		iidList = append(iidList, &irs.IID{NameId: info.IId.NameId, SystemId: info.IId.SystemId})
+	}
+	return iidList, nil
+}
+
+//================ Instance Control
+
+// ChangeSpec changes the DBSpec of the RDBMS.
+// The RDBMS stays in Creating status until RDBMSModifyingDuration has passed.
+func (rdbmsHandler *MockRDBMSHandler) ChangeSpec(iid irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ChangeSpec()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "ChangeSpec"); err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	mockName := rdbmsHandler.MockName
+
+	rdbmsMapLock.Lock()
+	defer rdbmsMapLock.Unlock()
+
+	info, err := getModifiableRDBMS(mockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	dbSpecHandler := MockDBSpecHandler{mockName}
+	specInfo, err := dbSpecHandler.GetDBSpec(info.DBEngine, newSpec)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+	if specInfo.HasNoSpecData() {
+		err := fmt.Errorf("%s DBSpec is not orderable!!", newSpec)
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+	if newSpec == info.DBSpec {
+		err := fmt.Errorf("%s RDBMS already has %s DBSpec!!", iid.NameId, newSpec)
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+	size, _ := strconv.ParseInt(info.StorageSize, 10, 64)
+	if size < specInfo.StorageSizeRangeGB.Min || size > specInfo.StorageSizeRangeGB.Max {
+		err := fmt.Errorf("StorageSize %dGB of %s RDBMS is out of range(%d-%dGB) for %s!!", size, iid.NameId,
+			specInfo.StorageSizeRangeGB.Min, specInfo.StorageSizeRangeGB.Max, newSpec)
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	info.DBSpec = newSpec
+	setRDBMSModifying(mockName, info)
+
+	return CloneRDBMSInfo(*info), nil
+}
+
+// ChangeStorageSize expands the storage of the RDBMS. Shrinking is not supported.
+// The RDBMS stays in Creating status until RDBMSModifyingDuration has passed.
+func (rdbmsHandler *MockRDBMSHandler) ChangeStorageSize(iid irs.IID, newSize string) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ChangeStorageSize()!")
+
+	if err := injectFault(rdbmsHandler.MockName, "ChangeStorageSize"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	mockName := rdbmsHandler.MockName
+
+	rdbmsMapLock.Lock()
+	defer rdbmsMapLock.Unlock()
+
+	info, err := getModifiableRDBMS(mockName, iid)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	size, err := strconv.ParseInt(newSize, 10, 64)
+	if err != nil {
+		err := fmt.Errorf("invalid StorageSize '%s': %v", newSize, err)
+		cblogger.Error(err)
+		return false, err
+	}
+	curSize, _ := strconv.ParseInt(info.StorageSize, 10, 64)
+	if size <= curSize {
+		err := fmt.Errorf("StorageSize can only be expanded!! Current: %dGB, Requested: %dGB", curSize, size)
+		cblogger.Error(err)
+		return false, err
+	}
+	dbSpecHandler := MockDBSpecHandler{mockName}
+	specInfo, err := dbSpecHandler.GetDBSpec(info.DBEngine, info.DBSpec)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+	if size > specInfo.StorageSizeRangeGB.Max {
+		err := fmt.Errorf("StorageSize %dGB is out of range(%d-%dGB) for %s!!", size,
+			specInfo.StorageSizeRangeGB.Min, specInfo.StorageSizeRangeGB.Max, info.DBSpec)
+		cblogger.Error(err)
+		return false, err
+	}
+
+	info.StorageSize = strconv.FormatInt(size, 10)
+	setRDBMSModifying(mockName, info)
+
+	return true, nil
+}
+
+// getModifiableRDBMS returns the RDBMS in the global Map if it is Available.
+// Caller must hold rdbmsMapLock.
+func getModifiableRDBMS(mockName string, iid irs.IID) (*irs.RDBMSInfo, error) {
+	for _, info := range rdbmsInfoMap[mockName] {
+		if info.IId.NameId == iid.NameId {
+			refreshRDBMSStatus(mockName, info)
+			if info.Status != irs.RDBMSAvailable || isFaultStuck(mockName, "rdbms", info.IId.SystemId) {
+				return nil, fmt.Errorf("%s RDBMS is not Available!! Current status: %s", iid.NameId, info.Status)
+			}
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%s RDBMS does not exist!!", iid.NameId)
+}
+
+// setRDBMSModifying starts the simulated change. Caller must hold rdbmsMapLock.
+func setRDBMSModifying(mockName string, info *irs.RDBMSInfo) {
+	if rdbmsModifiedTimeMap[mockName] == nil {
+		rdbmsModifiedTimeMap[mockName] = make(map[string]time.Time)
+	}
+	rdbmsModifiedTimeMap[mockName][info.IId.SystemId] = time.Now()
+	info.Status = irs.RDBMSCreating
+	if RDBMSModifyingDuration <= 0 {
+		refreshRDBMSStatus(mockName, info)
+	}
+}
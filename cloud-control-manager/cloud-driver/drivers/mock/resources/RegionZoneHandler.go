@@ -251,6 +251,11 @@ func (handler *MockRegionZoneHandler) ListRegionZone() ([]*irs.RegionZoneInfo, e
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListRegionZone()!")
 
+	if err := injectFault(handler.MockName, "ListRegionZone"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := handler.MockName
 
 	infoList, ok := regionZoneInfoMap[mockName]
@@ -268,6 +273,11 @@ func (handler *MockRegionZoneHandler) GetRegionZone(Name string) (irs.RegionZone
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetRegionZone()!")
 
+	if err := injectFault(handler.MockName, "GetRegionZone"); err != nil {
+		cblogger.Error(err)
+		return irs.RegionZoneInfo{}, err
+	}
+
 	infoList, err := handler.ListRegionZone()
 	if err != nil {
 		cblogger.Error(err)
@@ -288,6 +298,11 @@ func (handler *MockRegionZoneHandler) ListOrgRegion() (string, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListOrgRegion()!")
 
+	if err := injectFault(handler.MockName, "ListOrgRegion"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	// Convert prepareRegionZoneInfoList to JSON
 	jsonData, err := json.MarshalIndent(prepareRegionZoneInfoList, "", "  ")
 	if err != nil {
@@ -303,6 +318,11 @@ func (handler *MockRegionZoneHandler) ListOrgZone() (string, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListOrgZone()!")
 
+	if err := injectFault(handler.MockName, "ListOrgZone"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	for _, info := range prepareRegionZoneInfoList {
 		if (*info).Name == handler.Region.Region {
 			jsonData, err := json.MarshalIndent(info, "", "  ")
@@ -37,6 +37,17 @@ func (securityHandler *MockSecurityHandler) CreateSecurity(securityReqInfo irs.S
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateSecurity()!")
 
+	if err := injectFault(securityHandler.MockName, "CreateSecurity"); err != nil {
+		cblogger.Error(err)
+		return irs.SecurityInfo{}, err
+	}
+
+	defer lockQuota(securityHandler.MockName)()
+	if err := checkQuota(securityHandler.MockName, "network", "security-groups", 1); err != nil {
+		cblogger.Error(err)
+		return irs.SecurityInfo{}, err
+	}
+
 	mockName := securityHandler.MockName
 	securityReqInfo.IId.SystemId = securityReqInfo.IId.NameId
 	securityReqInfo.VpcIID.SystemId = securityReqInfo.VpcIID.NameId
@@ -56,6 +67,11 @@ func (securityHandler *MockSecurityHandler) CreateSecurity(securityReqInfo irs.S
 	infoList = append(infoList, &securityInfo)
 	securityInfoMap[mockName] = infoList
 
+	if err := recordFaultCreation(mockName, "CreateSecurity", "sg", securityInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.SecurityInfo{}, err
+	}
+
 	return CloneSecurityInfo(securityInfo), nil
 }
 
@@ -95,6 +111,11 @@ func (securityHandler *MockSecurityHandler) ListSecurity() ([]*irs.SecurityInfo,
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListSecurity()!")
 
+	if err := injectFault(securityHandler.MockName, "ListSecurity"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := securityHandler.MockName
 	sgMapLock.RLock()
 	defer sgMapLock.RUnlock()
@@ -103,6 +124,7 @@ func (securityHandler *MockSecurityHandler) ListSecurity() ([]*irs.SecurityInfo,
 		return []*irs.SecurityInfo{}, nil
 	}
 
+	infoList = applyListLag(mockName, "ListSecurity", "sg", infoList, func(info *irs.SecurityInfo) irs.IID { return info.IId })
 	return CloneSecurityInfoList(infoList), nil
 }
 
@@ -110,6 +132,11 @@ func (securityHandler *MockSecurityHandler) GetSecurity(iid irs.IID) (irs.Securi
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetSecurity()!")
 
+	if err := injectFault(securityHandler.MockName, "GetSecurity"); err != nil {
+		cblogger.Error(err)
+		return irs.SecurityInfo{}, err
+	}
+
 	sgMapLock.RLock()
 	defer sgMapLock.RUnlock()
 
@@ -133,6 +160,11 @@ func (securityHandler *MockSecurityHandler) DeleteSecurity(iid irs.IID) (bool, e
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteSecurity()!")
 
+	if err := injectFault(securityHandler.MockName, "DeleteSecurity"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	sgMapLock.Lock()
 	defer sgMapLock.Unlock()
 
@@ -156,6 +188,11 @@ func (securityHandler *MockSecurityHandler) AddRules(sgIID irs.IID, securityRule
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AddRules()!")
 
+	if err := injectFault(securityHandler.MockName, "AddRules"); err != nil {
+		cblogger.Error(err)
+		return irs.SecurityInfo{}, err
+	}
+
 	sgMapLock.Lock()
 	defer sgMapLock.Unlock()
 
@@ -195,6 +232,11 @@ func (securityHandler *MockSecurityHandler) RemoveRules(sgIID irs.IID, securityR
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called RemoveRules()!")
 
+	if err := injectFault(securityHandler.MockName, "RemoveRules"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	sgMapLock.Lock()
 	defer sgMapLock.Unlock()
 
@@ -277,6 +319,11 @@ func (securityHandler *MockSecurityHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(securityHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := securityHandler.MockName
 	sgMapLock.RLock()
 	defer sgMapLock.RUnlock()
@@ -284,6 +331,7 @@ func (securityHandler *MockSecurityHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "sg", infoList, func(info *irs.SecurityInfo) irs.IID { return info.IId })
 
 	iidList := make([]*irs.IID, len(infoList))
 	for i, info := range infoList {
@@ -26,6 +26,11 @@ func (tagHandler *MockTagHandler) AddTag(resType irs.RSType, resIID irs.IID, tag
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AddTag()!")
 
+	if err := injectFault(tagHandler.MockName, "AddTag"); err != nil {
+		cblogger.Error(err)
+		return irs.KeyValue{}, err
+	}
+
 	mockName := tagHandler.MockName
 	resType = irs.RSType(strings.ToLower(string(resType)))
 
@@ -205,6 +210,11 @@ func (tagHandler *MockTagHandler) ListTag(resType irs.RSType, resIID irs.IID) ([
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListTag()!")
 
+	if err := injectFault(tagHandler.MockName, "ListTag"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := tagHandler.MockName
 	resType = irs.RSType(strings.ToLower(string(resType)))
 
@@ -373,6 +383,11 @@ func (tagHandler *MockTagHandler) GetTag(resType irs.RSType, resIID irs.IID, key
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetTag()!")
 
+	if err := injectFault(tagHandler.MockName, "GetTag"); err != nil {
+		cblogger.Error(err)
+		return irs.KeyValue{}, err
+	}
+
 	mockName := tagHandler.MockName
 	resType = irs.RSType(strings.ToLower(string(resType)))
 
@@ -577,6 +592,11 @@ func (tagHandler *MockTagHandler) RemoveTag(resType irs.RSType, resIID irs.IID,
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called RemoveTag()!")
 
+	if err := injectFault(tagHandler.MockName, "RemoveTag"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	mockName := tagHandler.MockName
 	resType = irs.RSType(strings.ToLower(string(resType)))
 
@@ -790,6 +810,11 @@ func (tagHandler *MockTagHandler) FindTag(resType irs.RSType, keyword string) ([
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called FindTag()!")
 
+	if err := injectFault(tagHandler.MockName, "FindTag"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := tagHandler.MockName
 	resType = irs.RSType(strings.ToLower(string(resType)))
 
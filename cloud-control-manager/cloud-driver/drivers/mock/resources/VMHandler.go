@@ -12,6 +12,7 @@ package resources
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -35,10 +36,24 @@ func init() {
 
 var vmMapLock = new(sync.RWMutex)
 
+// public IP auto-assigned to every mock VM, replaced by an associated PublicIP on the primary NIC
+const mockVMDefaultPublicIP = "4.3.2.1"
+
 func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called StartVM()!")
 
+	if err := injectFault(vmHandler.MockName, "StartVM"); err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	defer lockQuota(vmHandler.MockName)()
+	if err := checkQuota(vmHandler.MockName, "compute", "vm-instances", 1); err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
 	mockName := vmHandler.MockName
 	vmReqInfo.IId.SystemId = vmReqInfo.IId.NameId
 
@@ -167,11 +182,9 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 		VMUserId:     vmReqInfo.VMUserId,
 		VMUserPasswd: vmReqInfo.VMUserPasswd,
 
-		NICs: []irs.VMNICInfo{{IId: irs.IID{NameId: "mockni0", SystemId: "mockni0"}}},
-		PublicIP:         "4.3.2.1",
-		PublicDNS:        vmReqInfo.IId.NameId + ".spider.barista.com",
-		PrivateIP:        "1.2.3.4",
-		PrivateDNS:       vmReqInfo.IId.NameId + ".spider.barista.com",
+		PublicIP:   mockVMDefaultPublicIP,
+		PublicDNS:  vmReqInfo.IId.NameId + ".spider.barista.com",
+		PrivateDNS: vmReqInfo.IId.NameId + ".spider.barista.com",
 
 		VMBootDisk:  "/dev/sda1",
 		VMBlockDisk: "/dev/sda1",
@@ -182,9 +195,14 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 
 		DataDiskIIDs: validatedDiskIIDs,
 
+		LifecycleType: irs.OnDemandVM,
+
 		TagList:      vmReqInfo.TagList,
 		KeyValueList: nil,
 	}
+	if vmReqInfo.PurchaseOption.LifecycleType == irs.SpotVM {
+		vmInfo.LifecycleType = irs.SpotVM
+	}
 
 	// attach disks
 	for _, diskIID := range validatedDiskIIDs {
@@ -195,6 +213,15 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 		}
 	}
 
+	// primary NIC creation
+	primaryNIC, err := createPrimaryNIC(mockName, vmReqInfo.IId, validatedVPCInfo.IId, *validatedSubnetInfo, validatedSgIIDs)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+	vmInfo.NICs = []irs.VMNICInfo{toVMNICInfo(primaryNIC)}
+	refreshVMNICInfo(&vmInfo)
+
 	vmMapLock.Lock()
 	defer vmMapLock.Unlock()
 
@@ -209,6 +236,11 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 	statusInfoList = append(statusInfoList, &vmStatusInfo)
 	vmStatusInfoMap[mockName] = statusInfoList
 
+	if err := recordFaultCreation(mockName, "StartVM", "vm", vmInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
 	return vmInfo, nil
 }
 
@@ -216,6 +248,11 @@ func (vmHandler *MockVMHandler) SuspendVM(iid irs.IID) (irs.VMStatus, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called SuspendVM()!")
 
+	if err := injectFault(vmHandler.MockName, "SuspendVM"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.Lock()
@@ -248,6 +285,11 @@ func (vmHandler *MockVMHandler) ResumeVM(iid irs.IID) (irs.VMStatus, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ResumeVM()!")
 
+	if err := injectFault(vmHandler.MockName, "ResumeVM"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.Lock()
@@ -281,6 +323,11 @@ func (vmHandler *MockVMHandler) RebootVM(iid irs.IID) (irs.VMStatus, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called RebootVM()!")
 
+	if err := injectFault(vmHandler.MockName, "RebootVM"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.Lock()
@@ -319,8 +366,16 @@ func (vmHandler *MockVMHandler) TerminateVM(iid irs.IID) (irs.VMStatus, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called TerminateVM()!")
 
+	if err := injectFault(vmHandler.MockName, "TerminateVM"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	mockName := vmHandler.MockName
 
+	// delete the primary NIC and detach the secondary NICs
+	releaseVMNICs(mockName, iid)
+
 	vmMapLock.Lock()
 	defer vmMapLock.Unlock()
 
@@ -356,10 +411,50 @@ func (vmHandler *MockVMHandler) TerminateVM(iid irs.IID) (irs.VMStatus, error) {
 	return irs.Terminating, nil
 }
 
+func (vmHandler *MockVMHandler) ChangeVMSpec(iid irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ChangeVMSpec()!")
+
+	if err := injectFault(vmHandler.MockName, "ChangeVMSpec"); err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	mockName := vmHandler.MockName
+
+	// spec validation
+	vmSpecHandler := MockVMSpecHandler{mockName}
+	validatedSpecInfo, err := vmSpecHandler.GetVMSpec(vmSpecName)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	vmMapLock.Lock()
+	defer vmMapLock.Unlock()
+
+	// the status is kept because the mock VM is modified without stop and start
+	for _, info := range vmInfoMap[mockName] {
+		if (*info).IId.NameId == iid.NameId {
+			info.VMSpecName = validatedSpecInfo.Name
+			return CloneVMInfo(*info), nil
+		}
+	}
+
+	errMSG := iid.NameId + " vm iid does not exist!!"
+	cblogger.Error(errMSG)
+	return irs.VMInfo{}, fmt.Errorf(errMSG)
+}
+
 func (vmHandler *MockVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListVMStatus()!")
 
+	if err := injectFault(vmHandler.MockName, "ListVMStatus"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.RLock()
@@ -369,8 +464,16 @@ func (vmHandler *MockVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	if !ok {
 		return []*irs.VMStatusInfo{}, nil
 	}
+	infoList = applyListLag(mockName, "ListVMStatus", "vm", infoList, func(info *irs.VMStatusInfo) irs.IID { return info.IId })
+
 	// cloning list of VM Status
-	return CloneVMStatusInfoList(infoList), nil
+	statusInfoList := CloneVMStatusInfoList(infoList)
+	for _, statusInfo := range statusInfoList {
+		if isFaultStuck(mockName, "vm", statusInfo.IId.SystemId) {
+			statusInfo.VmStatus = irs.Creating
+		}
+	}
+	return statusInfoList, nil
 }
 
 func CloneVMStatusInfoList(srcInfoList []*irs.VMStatusInfo) []*irs.VMStatusInfo {
@@ -404,6 +507,11 @@ func (vmHandler *MockVMHandler) GetVMStatus(iid irs.IID) (irs.VMStatus, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetVMStatus()!")
 
+	if err := injectFault(vmHandler.MockName, "GetVMStatus"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.RLock()
@@ -417,6 +525,9 @@ func (vmHandler *MockVMHandler) GetVMStatus(iid irs.IID) (irs.VMStatus, error) {
 
 	for _, info := range infoList {
 		if (*info).IId.NameId == iid.NameId {
+			if isFaultStuck(mockName, "vm", (*info).IId.SystemId) {
+				return irs.Creating, nil
+			}
 			return (*info).VmStatus, nil
 		}
 	}
@@ -432,6 +543,11 @@ func (vmHandler *MockVMHandler) ListVM() ([]*irs.VMInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListVM()!")
 
+	if err := injectFault(vmHandler.MockName, "ListVM"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.RLock()
@@ -442,8 +558,23 @@ func (vmHandler *MockVMHandler) ListVM() ([]*irs.VMInfo, error) {
 		return []*irs.VMInfo{}, nil
 	}
 
+	infoList = applyListLag(mockName, "ListVM", "vm", infoList, func(info *irs.VMInfo) irs.IID { return info.IId })
+
 	// cloning list of VM
-	return CloneVMInfoList(infoList), nil
+	vmInfoList := CloneVMInfoList(infoList)
+	for _, vmInfo := range vmInfoList {
+		hideStuckVMInfo(mockName, vmInfo)
+	}
+	return vmInfoList, nil
+}
+
+// hideStuckVMInfo clears the public address of a VM stuck in Creating,
+// because CSPs assign it after the VM is running.
+func hideStuckVMInfo(mockName string, vmInfo *irs.VMInfo) {
+	if isFaultStuck(mockName, "vm", vmInfo.IId.SystemId) {
+		vmInfo.PublicIP = ""
+		vmInfo.PublicDNS = ""
+	}
 }
 
 func CloneVMInfoList(srcInfoList []*irs.VMInfo) []*irs.VMInfo {
@@ -519,10 +650,13 @@ func CloneVMInfo(srcInfo irs.VMInfo) irs.VMInfo {
 
 		VMUserId:         srcInfo.VMUserId,
 		VMUserPasswd:     srcInfo.VMUserPasswd,
-		NICs: srcInfo.NICs,
+		NICs:             cloneVMNICInfoList(srcInfo.NICs),
+		NetworkInterface: srcInfo.NetworkInterface,
 		PublicIP:         srcInfo.PublicIP,
+		PublicIPs:        append([]string{}, srcInfo.PublicIPs...),
 		PublicDNS:        srcInfo.PublicDNS,
 		PrivateIP:        srcInfo.PrivateIP,
+		PrivateIPs:       append([]string{}, srcInfo.PrivateIPs...),
 		PrivateDNS:       srcInfo.PrivateDNS,
 
 		SSHAccessPoint: srcInfo.SSHAccessPoint,
@@ -534,6 +668,17 @@ func CloneVMInfo(srcInfo irs.VMInfo) irs.VMInfo {
 	return clonedInfo
 }
 
+func cloneVMNICInfoList(srcInfoList []irs.VMNICInfo) []irs.VMNICInfo {
+	clonedInfoList := []irs.VMNICInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := srcInfo
+		clonedInfo.PrivateIPs = append([]string{}, srcInfo.PrivateIPs...)
+		clonedInfo.PublicIPs = append([]string{}, srcInfo.PublicIPs...)
+		clonedInfoList = append(clonedInfoList, clonedInfo)
+	}
+	return clonedInfoList
+}
+
 func cloneIIDArray(srcIIDArray []irs.IID) []irs.IID {
 	clonedIIDs := []irs.IID{}
 	for _, iid := range srcIIDArray {
@@ -546,6 +691,11 @@ func (vmHandler *MockVMHandler) GetVM(iid irs.IID) (irs.VMInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetVM()!")
 
+	if err := injectFault(vmHandler.MockName, "GetVM"); err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
 	mockName := vmHandler.MockName
 
 	vmMapLock.RLock()
@@ -562,7 +712,9 @@ func (vmHandler *MockVMHandler) GetVM(iid irs.IID) (irs.VMInfo, error) {
 
 	for _, info := range infoList {
 		if (*info).IId.NameId == iid.NameId {
-			return CloneVMInfo(*info), nil
+			vmInfo := CloneVMInfo(*info)
+			hideStuckVMInfo(mockName, &vmInfo)
+			return vmInfo, nil
 		}
 	}
 
@@ -627,10 +779,97 @@ func diskDetach(mockName string, iid irs.IID, diskIID irs.IID) (bool, error) {
 	return false, fmt.Errorf(errMSG)
 }
 
+// refreshVMNICInfo recomputes the convenience fields of VMInfo from its NICs.
+func refreshVMNICInfo(info *irs.VMInfo) {
+	sort.Slice(info.NICs, func(i, j int) bool { return info.NICs[i].DeviceIndex < info.NICs[j].DeviceIndex })
+
+	info.PrivateIPs = []string{}
+	info.PublicIPs = []string{}
+	info.PublicIP = mockVMDefaultPublicIP
+	for _, nic := range info.NICs {
+		info.PrivateIPs = append(info.PrivateIPs, nic.PrivateIPs...)
+		for _, ip := range nic.PublicIPs {
+			if ip != "" {
+				info.PublicIPs = append(info.PublicIPs, ip)
+			}
+		}
+		if nic.DeviceIndex == 0 {
+			info.NetworkInterface = nic.IId.SystemId
+			if len(nic.PrivateIPs) > 0 {
+				info.PrivateIP = nic.PrivateIPs[0]
+			}
+			if len(nic.PublicIPs) > 0 && nic.PublicIPs[0] != "" {
+				info.PublicIP = nic.PublicIPs[0]
+			}
+		}
+	}
+}
+
+// nicAttach adds or updates a NIC in VMInfo.NICs
+func nicAttach(mockName string, iid irs.IID, nicInfo irs.VMNICInfo) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called nicAttach()!")
+
+	vmMapLock.Lock()
+	defer vmMapLock.Unlock()
+
+	for _, info := range vmInfoMap[mockName] {
+		if (*info).IId.SystemId == iid.SystemId {
+			replaced := false
+			for idx, oneNIC := range info.NICs {
+				if oneNIC.IId.SystemId == nicInfo.IId.SystemId {
+					info.NICs[idx] = nicInfo
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				info.NICs = append(info.NICs, nicInfo)
+			}
+			refreshVMNICInfo(info)
+			return true, nil
+		}
+	}
+
+	errMSG := iid.NameId + " vm iid does not exist!!"
+	cblogger.Error(errMSG)
+	return false, fmt.Errorf(errMSG)
+}
+
+// nicDetach removes a NIC from VMInfo.NICs
+func nicDetach(mockName string, iid irs.IID, nicIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called nicDetach()!")
+
+	vmMapLock.Lock()
+	defer vmMapLock.Unlock()
+
+	for _, info := range vmInfoMap[mockName] {
+		if (*info).IId.SystemId == iid.SystemId {
+			for idx, oneNIC := range info.NICs {
+				if oneNIC.IId.SystemId == nicIID.SystemId {
+					info.NICs = append(info.NICs[:idx], info.NICs[idx+1:]...)
+					refreshVMNICInfo(info)
+					return true, nil
+				}
+			}
+		}
+	}
+
+	errMSG := iid.NameId + " vm iid does not exist!!"
+	cblogger.Error(errMSG)
+	return false, fmt.Errorf(errMSG)
+}
+
 func (vmHandler *MockVMHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("AWS Driver: called ListIID()!")
 
+	if err := injectFault(vmHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	// get VM list
 	vmList, err := vmHandler.ListVM()
 	if err != nil {
@@ -53,6 +53,11 @@ func (vmSpecHandler *MockVMSpecHandler) ListVMSpec() ([]*irs.VMSpecInfo, error)
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListVMSpec()!")
 
+	if err := injectFault(vmSpecHandler.MockName, "ListVMSpec"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := vmSpecHandler.MockName
 
 	infoList, ok := vmSpecInfoMap[mockName]
@@ -70,6 +75,11 @@ func (vmSpecHandler *MockVMSpecHandler) GetVMSpec(Name string) (irs.VMSpecInfo,
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetVMSpec()!")
 
+	if err := injectFault(vmSpecHandler.MockName, "GetVMSpec"); err != nil {
+		cblogger.Error(err)
+		return irs.VMSpecInfo{}, err
+	}
+
 	infoList, err := vmSpecHandler.ListVMSpec()
 	if err != nil {
 		cblogger.Error(err)
@@ -89,6 +99,11 @@ func (vmSpecHandler *MockVMSpecHandler) ListOrgVMSpec() (string, error) { // ret
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListOrgVMSpec()!")
 
+	if err := injectFault(vmSpecHandler.MockName, "ListOrgVMSpec"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	// Convert prepareVMSpecInfoList to JSON
 	jsonData, err := json.MarshalIndent(prepareVMSpecInfoList, "", "  ")
 	if err != nil {
@@ -103,6 +118,11 @@ func (vmSpecHandler *MockVMSpecHandler) GetOrgVMSpec(Name string) (string, error
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetOrgVMSpec()!")
 
+	if err := injectFault(vmSpecHandler.MockName, "GetOrgVMSpec"); err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
 	for _, info := range prepareVMSpecInfoList {
 		if (*info).Name == Name {
 			jsonData, err := json.MarshalIndent(info, "", "  ")
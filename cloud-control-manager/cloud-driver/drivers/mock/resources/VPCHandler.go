@@ -26,6 +26,22 @@ func (vpcHandler *MockVPCHandler) CreateVPC(vpcReqInfo irs.VPCReqInfo) (irs.VPCI
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateVPC()!")
 
+	if err := injectFault(vpcHandler.MockName, "CreateVPC"); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
+	defer lockQuota(vpcHandler.MockName)()
+	if err := checkQuota(vpcHandler.MockName, "network", "vpcs", 1); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
+	if err := checkQuota(vpcHandler.MockName, "network", "subnets", len(vpcReqInfo.SubnetInfoList)); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
 	mockName := vpcHandler.MockName
 	vpcReqInfo.IId.SystemId = vpcReqInfo.IId.NameId
 
@@ -48,6 +64,11 @@ func (vpcHandler *MockVPCHandler) CreateVPC(vpcReqInfo irs.VPCReqInfo) (irs.VPCI
 	defer vpcMapLock.Unlock()
 	vpcInfoMap[mockName] = append(vpcInfoMap[mockName], &vpcInfo)
 
+	if err := recordFaultCreation(mockName, "CreateVPC", "vpc", vpcInfo.IId.SystemId); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
 	return CloneVPCInfo(vpcInfo), nil
 }
 
@@ -55,6 +76,11 @@ func (vpcHandler *MockVPCHandler) ListVPC() ([]*irs.VPCInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListVPC()!")
 
+	if err := injectFault(vpcHandler.MockName, "ListVPC"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := vpcHandler.MockName
 	vpcMapLock.RLock()
 	defer vpcMapLock.RUnlock()
@@ -63,6 +89,7 @@ func (vpcHandler *MockVPCHandler) ListVPC() ([]*irs.VPCInfo, error) {
 		return []*irs.VPCInfo{}, nil
 	}
 
+	infoList = applyListLag(mockName, "ListVPC", "vpc", infoList, func(info *irs.VPCInfo) irs.IID { return info.IId })
 	return CloneVPCInfoList(infoList), nil
 }
 
@@ -111,6 +138,11 @@ func (vpcHandler *MockVPCHandler) GetVPC(iid irs.IID) (irs.VPCInfo, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called GetVPC()!")
 
+	if err := injectFault(vpcHandler.MockName, "GetVPC"); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
 	vpcMapLock.RLock()
 	defer vpcMapLock.RUnlock()
 	mockName := vpcHandler.MockName
@@ -132,6 +164,11 @@ func (vpcHandler *MockVPCHandler) DeleteVPC(iid irs.IID) (bool, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called DeleteVPC()!")
 
+	if err := injectFault(vpcHandler.MockName, "DeleteVPC"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	vpcMapLock.Lock()
 	defer vpcMapLock.Unlock()
 
@@ -154,6 +191,17 @@ func (vpcHandler *MockVPCHandler) AddSubnet(iid irs.IID, subnetInfo irs.SubnetIn
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AddSubnet()!")
 
+	if err := injectFault(vpcHandler.MockName, "AddSubnet"); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
+	defer lockQuota(vpcHandler.MockName)()
+	if err := checkQuota(vpcHandler.MockName, "network", "subnets", 1); err != nil {
+		cblogger.Error(err)
+		return irs.VPCInfo{}, err
+	}
+
 	vpcMapLock.Lock()
 	defer vpcMapLock.Unlock()
 
@@ -178,6 +226,11 @@ func (vpcHandler *MockVPCHandler) RemoveSubnet(iid irs.IID, subnetIID irs.IID) (
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called RemoveSubnet()!")
 
+	if err := injectFault(vpcHandler.MockName, "RemoveSubnet"); err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
 	vpcMapLock.Lock()
 	defer vpcMapLock.Unlock()
 
@@ -205,6 +258,11 @@ func (vpcHandler *MockVPCHandler) ListIID() ([]*irs.IID, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called ListIID()!")
 
+	if err := injectFault(vpcHandler.MockName, "ListIID"); err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+
 	mockName := vpcHandler.MockName
 	vpcMapLock.RLock()
 	defer vpcMapLock.RUnlock()
@@ -212,6 +270,7 @@ func (vpcHandler *MockVPCHandler) ListIID() ([]*irs.IID, error) {
 	if !ok {
 		return []*irs.IID{}, nil
 	}
+	infoList = applyListLag(mockName, "ListIID", "vpc", infoList, func(info *irs.VPCInfo) irs.IID { return info.IId })
 
 	iidList := make([]*irs.IID, len(infoList))
 	for i, info := range infoList {
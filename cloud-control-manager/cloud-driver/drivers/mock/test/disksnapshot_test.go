@@ -0,0 +1,103 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var diskSnapshotHandler irs.DiskSnapshotHandler
+var snapshotDiskHandler irs.DiskHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-DiskSnapshot", // separate name to avoid conflicts with other tests' data
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	diskSnapshotHandler, _ = cloudConn.CreateDiskSnapshotHandler()
+	snapshotDiskHandler, _ = cloudConn.CreateDiskHandler()
+}
+
+func TestDiskSnapshotLifecycle(t *testing.T) {
+	diskInfo, err := snapshotDiskHandler.CreateDisk(irs.DiskInfo{IId: irs.IID{NameId: "mock-snap-disk"}, DiskSize: "100"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// create
+	snapshotInfo, err := diskSnapshotHandler.CreateSnapshot(irs.DiskSnapshotInfo{
+		IId:        irs.IID{NameId: "mock-snap-01"},
+		SourceDisk: diskInfo.IId,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if snapshotInfo.Status != irs.DiskSnapshotAvailable || snapshotInfo.DiskSize != "100" || snapshotInfo.SourceDisk.SystemId != diskInfo.IId.SystemId {
+		t.Errorf("unexpected snapshot: %#v", snapshotInfo)
+	}
+	if _, err := diskSnapshotHandler.CreateSnapshot(irs.DiskSnapshotInfo{IId: irs.IID{NameId: "mock-snap-01"}, SourceDisk: diskInfo.IId}); err == nil {
+		t.Error("duplicated snapshot name should be rejected")
+	}
+	if _, err := diskSnapshotHandler.CreateSnapshot(irs.DiskSnapshotInfo{IId: irs.IID{NameId: "mock-snap-02"}, SourceDisk: irs.IID{NameId: "no-disk"}}); err == nil {
+		t.Error("snapshot of an unknown disk should be rejected")
+	}
+
+	// list and get
+	infoList, err := diskSnapshotHandler.ListSnapshot()
+	if err != nil || len(infoList) != 1 {
+		t.Fatalf("ListSnapshot() should return one snapshot: %v, %v", infoList, err)
+	}
+	if _, err := diskSnapshotHandler.GetSnapshot(snapshotInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+
+	// restore to a new Disk
+	if _, err := diskSnapshotHandler.CreateDiskFromSnapshot(snapshotInfo.IId, irs.DiskInfo{IId: irs.IID{NameId: "mock-snap-small"}, DiskSize: "50"}); err == nil {
+		t.Error("disk smaller than the snapshot should be rejected")
+	}
+	newDiskInfo, err := diskSnapshotHandler.CreateDiskFromSnapshot(snapshotInfo.IId, irs.DiskInfo{IId: irs.IID{NameId: "mock-snap-restored"}, DiskSize: "default"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if newDiskInfo.DiskSize != "100" || newDiskInfo.Status != irs.DiskAvailable {
+		t.Errorf("unexpected restored disk: %#v", newDiskInfo)
+	}
+	if _, err := snapshotDiskHandler.GetDisk(newDiskInfo.IId); err != nil {
+		t.Errorf("restored disk should be managed by the DiskHandler: %v", err)
+	}
+
+	// the snapshot is independent of the source Disk
+	if _, err := snapshotDiskHandler.DeleteDisk(diskInfo.IId); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := diskSnapshotHandler.GetSnapshot(snapshotInfo.IId); err != nil {
+		t.Errorf("snapshot should remain after the source disk is deleted: %v", err)
+	}
+
+	// delete
+	if ok, err := diskSnapshotHandler.DeleteSnapshot(snapshotInfo.IId); err != nil || !ok {
+		t.Fatalf("DeleteSnapshot() failed: %v, %v", ok, err)
+	}
+	if _, err := diskSnapshotHandler.GetSnapshot(snapshotInfo.IId); err == nil {
+		t.Error("deleted snapshot should not exist")
+	}
+	snapshotDiskHandler.DeleteDisk(newDiskInfo.IId)
+}
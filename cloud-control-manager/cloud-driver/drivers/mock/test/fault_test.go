@@ -0,0 +1,233 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	mkrs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+const faultTestMockName = "MockDriver-Fault" // separate name to avoid conflicts with other tests' data
+
+var faultVPCHandler irs.VPCHandler
+var faultKeyPairHandler irs.KeyPairHandler
+var faultDiskHandler irs.DiskHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: faultTestMockName,
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	faultVPCHandler, _ = cloudConn.CreateVPCHandler()
+	faultKeyPairHandler, _ = cloudConn.CreateKeyPairHandler()
+	faultDiskHandler, _ = cloudConn.CreateDiskHandler()
+}
+
+func setFaultSpec(t *testing.T, spec string) {
+	if err := mkrs.SetFaultSpec(faultTestMockName, spec); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestFaultSpec(t *testing.T) {
+	spec := "StartVM:delay=2s,error=0.3;CreateRDBMS:stuck=true;CreateDisk:stuck=1m0s,partial=0.5;ListVM:lag=5s"
+	rules, err := mkrs.ParseFaultSpec(spec)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if rule := rules["StartVM"]; rule.Delay != 2*time.Second || rule.ErrorRate != 0.3 {
+		t.Errorf("unexpected StartVM rule: %#v", rule)
+	}
+	if rule := rules["CreateDisk"]; !rule.Stuck || rule.StuckTime != time.Minute || rule.PartialRate != 0.5 {
+		t.Errorf("unexpected CreateDisk rule: %#v", rule)
+	}
+	if rule := rules["ListVM"]; rule.ListLag != 5*time.Second {
+		t.Errorf("unexpected ListVM rule: %#v", rule)
+	}
+
+	expected := "CreateDisk:partial=0.5,stuck=1m0s;CreateRDBMS:stuck=true;ListVM:lag=5s;StartVM:delay=2s,error=0.3"
+	if formatted := mkrs.FormatFaultSpec(rules); formatted != expected {
+		t.Errorf("formatted spec is %s, expected %s", formatted, expected)
+	}
+
+	for _, badSpec := range []string{"StartVM", "StartVM:delay", "StartVM:delay=2x", "StartVM:error=1.5", "StartVM:stuck=maybe", "StartVM:unknown=1"} {
+		if _, err := mkrs.ParseFaultSpec(badSpec); err == nil {
+			t.Errorf("bad spec '%s' should be rejected", badSpec)
+		}
+	}
+}
+
+func TestFaultError(t *testing.T) {
+	defer mkrs.ClearFault(faultTestMockName)
+
+	setFaultSpec(t, "CreateVPC:error=1")
+	_, err := faultVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-fault-vpc-01"}, IPv4_CIDR: "10.60.0.0/16"})
+	if !errors.Is(err, mkrs.ErrInjectedFault) {
+		t.Errorf("CreateVPC should fail with %v, but got %v", mkrs.ErrInjectedFault, err)
+	}
+	if _, err := faultVPCHandler.GetVPC(irs.IID{NameId: "mock-fault-vpc-01"}); err == nil {
+		t.Error("VPC should not be created by the failed CreateVPC")
+	}
+
+	// the operation rule takes precedence over the rule of all operations
+	setFaultSpec(t, "*:error=1;ListKey:delay=50ms")
+	if _, err := faultVPCHandler.ListVPC(); !errors.Is(err, mkrs.ErrInjectedFault) {
+		t.Errorf("ListVPC should fail by the rule of all operations, but got %v", err)
+	}
+	start := time.Now()
+	if _, err := faultKeyPairHandler.ListKey(); err != nil {
+		t.Error(err.Error())
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("ListKey should be delayed 50ms, but it took %v", elapsed)
+	}
+}
+
+func TestFaultPartial(t *testing.T) {
+	defer mkrs.ClearFault(faultTestMockName)
+
+	setFaultSpec(t, "CreateKey:partial=1")
+	_, err := faultKeyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: "mock-fault-key-01"}})
+	if !errors.Is(err, mkrs.ErrInjectedFault) {
+		t.Errorf("CreateKey should fail with %v, but got %v", mkrs.ErrInjectedFault, err)
+	}
+	// the key is created although the call failed
+	if _, err := faultKeyPairHandler.GetKey(irs.IID{NameId: "mock-fault-key-01", SystemId: "mock-fault-key-01"}); err != nil {
+		t.Errorf("KeyPair should be left by the partial failure: %v", err)
+	}
+	faultKeyPairHandler.DeleteKey(irs.IID{NameId: "mock-fault-key-01", SystemId: "mock-fault-key-01"})
+}
+
+func TestFaultStuck(t *testing.T) {
+	defer mkrs.ClearFault(faultTestMockName)
+
+	setFaultSpec(t, "CreateDisk:stuck=true")
+	diskInfo, err := faultDiskHandler.CreateDisk(irs.DiskInfo{IId: irs.IID{NameId: "mock-fault-disk-01"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if diskInfo, _ := faultDiskHandler.GetDisk(diskInfo.IId); diskInfo.Status != irs.DiskCreating {
+		t.Errorf("Disk should be stuck in Creating, but it is %s", diskInfo.Status)
+	}
+	// clear releases the stuck resources
+	mkrs.ClearFault(faultTestMockName)
+	if diskInfo, _ := faultDiskHandler.GetDisk(diskInfo.IId); diskInfo.Status != irs.DiskAvailable {
+		t.Errorf("Disk should be Available after the clear, but it is %s", diskInfo.Status)
+	}
+
+	setFaultSpec(t, "CreateDisk:stuck=100ms")
+	diskInfo, err = faultDiskHandler.CreateDisk(irs.DiskInfo{IId: irs.IID{NameId: "mock-fault-disk-02"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	diskList, _ := faultDiskHandler.ListDisk()
+	for _, info := range diskList {
+		if info.IId.NameId == "mock-fault-disk-02" && info.Status != irs.DiskCreating {
+			t.Errorf("Disk should be stuck in Creating, but it is %s", info.Status)
+		}
+	}
+	time.Sleep(150 * time.Millisecond)
+	if diskInfo, _ := faultDiskHandler.GetDisk(diskInfo.IId); diskInfo.Status != irs.DiskAvailable {
+		t.Errorf("Disk should be Available after the stuck time, but it is %s", diskInfo.Status)
+	}
+}
+
+func TestFaultListLag(t *testing.T) {
+	defer mkrs.ClearFault(faultTestMockName)
+
+	setFaultSpec(t, "ListVPC:lag=200ms")
+	vpcInfo, err := faultVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-fault-vpc-02"}, IPv4_CIDR: "10.61.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer faultVPCHandler.DeleteVPC(vpcInfo.IId)
+
+	containsVPC := func() bool {
+		vpcList, err := faultVPCHandler.ListVPC()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, info := range vpcList {
+			if info.IId.NameId == "mock-fault-vpc-02" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if containsVPC() {
+		t.Error("ListVPC should not show the VPC within the lag")
+	}
+	if _, err := faultVPCHandler.GetVPC(vpcInfo.IId); err != nil {
+		t.Errorf("GetVPC should not be affected by the list lag: %v", err)
+	}
+	time.Sleep(250 * time.Millisecond)
+	if !containsVPC() {
+		t.Error("ListVPC should show the VPC after the lag")
+	}
+}
+
+func TestFaultCredential(t *testing.T) {
+	// the credential's rules are applied only at the first connection of the MockName
+	mockName := fmt.Sprintf("MockDriver-Fault-Cred-%d", time.Now().UnixNano())
+	defer mkrs.ClearFault(mockName)
+
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: mockName, MockFault: "CreateKey:error=1"},
+	}
+	cloudConn, err := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if spec := mkrs.GetFaultSpec(mockName); spec != "CreateKey:error=1" {
+		t.Errorf("fault spec of the credential is not applied: %s", spec)
+	}
+	keyPairHandler, _ := cloudConn.CreateKeyPairHandler()
+	if _, err := keyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: "mock-fault-key-02"}}); !errors.Is(err, mkrs.ErrInjectedFault) {
+		t.Errorf("CreateKey should fail with %v, but got %v", mkrs.ErrInjectedFault, err)
+	}
+
+	// the rules set by the admin API are not overwritten by the next connection
+	if err := mkrs.SetFaultSpec(mockName, "ListVPC:delay=1ms"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := (&mockdrv.MockDriver{}).ConnectCloud(connInfo); err != nil {
+		t.Fatal(err.Error())
+	}
+	if spec := mkrs.GetFaultSpec(mockName); spec != "ListVPC:delay=1ms" {
+		t.Errorf("fault spec of the admin API is overwritten: %s", spec)
+	}
+	mkrs.ClearFault(mockName)
+	if _, err := (&mockdrv.MockDriver{}).ConnectCloud(connInfo); err != nil {
+		t.Fatal(err.Error())
+	}
+	if spec := mkrs.GetFaultSpec(mockName); spec != "" {
+		t.Errorf("fault spec cleared by the admin API is restored: %s", spec)
+	}
+
+	connInfo.CredentialInfo.MockFault = "CreateKey:error=2"
+	if _, err := (&mockdrv.MockDriver{}).ConnectCloud(connInfo); err == nil {
+		t.Error("bad fault spec of the credential should be rejected")
+	}
+}
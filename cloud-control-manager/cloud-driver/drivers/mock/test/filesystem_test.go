@@ -0,0 +1,270 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var fileSystemHandler irs.FileSystemHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-FileSystem", // separate name to avoid conflicts with other tests' data
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{Region: "mock-region", Zone: "mock-zone-a"},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	fileSystemHandler, _ = cloudConn.CreateFileSystemHandler()
+
+	vpcHandler, _ := cloudConn.CreateVPCHandler()
+	vpcHandler.CreateVPC(irs.VPCReqInfo{
+		IId:       irs.IID{NameId: "mock-fs-vpc"},
+		IPv4_CIDR: "10.20.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{
+			{IId: irs.IID{NameId: "mock-fs-subnet-01"}, IPv4_CIDR: "10.20.1.0/24"},
+			{IId: irs.IID{NameId: "mock-fs-subnet-02"}, IPv4_CIDR: "10.20.2.0/24"},
+		},
+	})
+	vpcHandler.CreateVPC(irs.VPCReqInfo{
+		IId:            irs.IID{NameId: "mock-fs-vpc-other"},
+		IPv4_CIDR:      "10.30.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{NameId: "mock-fs-subnet-other"}, IPv4_CIDR: "10.30.1.0/24"}},
+	})
+}
+
+func TestFileSystemLifecycle(t *testing.T) {
+	metaInfo, err := fileSystemHandler.GetMetaInfo()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !metaInfo.SupportsCapacity || len(metaInfo.SupportsNFSVersion) == 0 {
+		t.Errorf("unexpected meta info: %#v", metaInfo)
+	}
+
+	// create with defaults
+	fsInfo, err := fileSystemHandler.CreateFileSystem(irs.FileSystemInfo{
+		IId:              irs.IID{NameId: "mock-fs-01"},
+		VpcIID:           irs.IID{NameId: "mock-fs-vpc"},
+		AccessSubnetList: []irs.IID{{NameId: "mock-fs-subnet-01"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if fsInfo.Status != irs.FileSystemAvailable || fsInfo.FileSystemType != irs.RegionType || fsInfo.NFSVersion != "4.1" {
+		t.Errorf("unexpected file system: %#v", fsInfo)
+	}
+	if len(fsInfo.MountTargetList) != 1 || fsInfo.MountTargetList[0].Endpoint == "" {
+		t.Errorf("unexpected mount targets: %#v", fsInfo.MountTargetList)
+	}
+	if fsInfo.BackupSchedule.Schedule.Hour != "5" {
+		t.Errorf("default backup schedule is not set: %#v", fsInfo.BackupSchedule)
+	}
+
+	// zone type uses the connection's zone
+	zoneFsInfo, err := fileSystemHandler.CreateFileSystem(irs.FileSystemInfo{
+		IId:             irs.IID{NameId: "mock-fs-02"},
+		VpcIID:          irs.IID{NameId: "mock-fs-vpc"},
+		FileSystemType:  irs.ZoneType,
+		CapacityGB:      2048,
+		PerformanceInfo: map[string]string{"Tier": "PREMIUM"},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if zoneFsInfo.Zone != "mock-zone-a" {
+		t.Errorf("Zone is not %s. It is %s.", "mock-zone-a", zoneFsInfo.Zone)
+	}
+
+	// access subnets
+	if _, err := fileSystemHandler.AddAccessSubnet(fsInfo.IId, irs.IID{NameId: "mock-fs-subnet-02"}); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := fileSystemHandler.AddAccessSubnet(fsInfo.IId, irs.IID{NameId: "mock-fs-subnet-02"}); err == nil {
+		t.Error("duplicated access subnet should be rejected")
+	}
+	if _, err := fileSystemHandler.AddAccessSubnet(fsInfo.IId, irs.IID{NameId: "mock-fs-subnet-other"}); err == nil {
+		t.Error("subnet of another VPC should be rejected")
+	}
+	subnetList, err := fileSystemHandler.ListAccessSubnet(fsInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(subnetList) != 2 {
+		t.Errorf("The number of access subnets is not %d. It is %d.", 2, len(subnetList))
+	}
+	if _, err := fileSystemHandler.RemoveAccessSubnet(fsInfo.IId, irs.IID{NameId: "mock-fs-subnet-01"}); err != nil {
+		t.Error(err.Error())
+	}
+	fsInfo, _ = fileSystemHandler.GetFileSystem(fsInfo.IId)
+	if len(fsInfo.AccessSubnetList) != 1 || len(fsInfo.MountTargetList) != 1 {
+		t.Errorf("access subnet or mount target is not removed: %#v", fsInfo)
+	}
+
+	// delete all
+	for _, iid := range []irs.IID{fsInfo.IId, zoneFsInfo.IId} {
+		ret, err := fileSystemHandler.DeleteFileSystem(iid)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if !ret {
+			t.Errorf("Return is not True!! %s", iid.NameId)
+		}
+	}
+	iidList, err := fileSystemHandler.ListIID()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(iidList) > 0 {
+		t.Errorf("The number of IIDs is not %d. It is %d.", 0, len(iidList))
+	}
+}
+
+func TestFileSystemCreateValidation(t *testing.T) {
+	testCases := []irs.FileSystemInfo{
+		// unknown VPC
+		{IId: irs.IID{NameId: "bad-fs-01"}, VpcIID: irs.IID{NameId: "no-vpc"}},
+		// subnet of another VPC
+		{IId: irs.IID{NameId: "bad-fs-02"}, VpcIID: irs.IID{NameId: "mock-fs-vpc"}, AccessSubnetList: []irs.IID{{NameId: "mock-fs-subnet-other"}}},
+		// unsupported NFS version
+		{IId: irs.IID{NameId: "bad-fs-03"}, VpcIID: irs.IID{NameId: "mock-fs-vpc"}, NFSVersion: "2.0"},
+		// capacity out of range
+		{IId: irs.IID{NameId: "bad-fs-04"}, VpcIID: irs.IID{NameId: "mock-fs-vpc"}, CapacityGB: 10},
+		// unknown tier
+		{IId: irs.IID{NameId: "bad-fs-05"}, VpcIID: irs.IID{NameId: "mock-fs-vpc"}, PerformanceInfo: map[string]string{"Tier": "GOLD"}},
+		// bad cron
+		{IId: irs.IID{NameId: "bad-fs-06"}, VpcIID: irs.IID{NameId: "mock-fs-vpc"},
+			BackupSchedule: irs.FileSystemBackupInfo{Schedule: irs.CronSchedule{Minute: "60", Hour: "*", DayOfMonth: "*", Month: "*", DayOfWeek: "*"}}},
+	}
+	for _, reqInfo := range testCases {
+		if _, err := fileSystemHandler.CreateFileSystem(reqInfo); err == nil {
+			t.Errorf("%s should be rejected", reqInfo.IId.NameId)
+		}
+	}
+}
+
+func TestFileSystemBackup(t *testing.T) {
+	fsInfo, err := fileSystemHandler.CreateFileSystem(irs.FileSystemInfo{
+		IId:    irs.IID{NameId: "mock-fs-backup"},
+		VpcIID: irs.IID{NameId: "mock-fs-vpc"},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// scheduled & on-demand backups
+	schedule := irs.CronSchedule{Minute: "30", Hour: "*/6", DayOfMonth: "*", Month: "*", DayOfWeek: "1-5"}
+	scheduledInfo, err := fileSystemHandler.ScheduleBackup(irs.FileSystemBackupInfo{FileSystemIID: fsInfo.IId.SystemId, Schedule: schedule})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if scheduledInfo.BackupID == "" || scheduledInfo.CreationTime.IsZero() {
+		t.Errorf("backup has no ID or CreationTime: %#v", scheduledInfo)
+	}
+	fsInfo, _ = fileSystemHandler.GetFileSystem(fsInfo.IId)
+	if fsInfo.BackupSchedule.Schedule != schedule {
+		t.Errorf("backup schedule is not updated: %#v", fsInfo.BackupSchedule)
+	}
+	if _, err := fileSystemHandler.ScheduleBackup(irs.FileSystemBackupInfo{FileSystemIID: fsInfo.IId.SystemId,
+		Schedule: irs.CronSchedule{Minute: "0", Hour: "24", DayOfMonth: "*", Month: "*", DayOfWeek: "*"}}); err == nil {
+		t.Error("invalid cron schedule should be rejected")
+	}
+
+	onDemandInfo, err := fileSystemHandler.OnDemandBackup(fsInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if onDemandInfo.BackupID == scheduledInfo.BackupID {
+		t.Errorf("backup ID %s is duplicated", onDemandInfo.BackupID)
+	}
+
+	backupList, err := fileSystemHandler.ListBackup(fsInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(backupList) != 2 {
+		t.Errorf("The number of backups is not %d. It is %d.", 2, len(backupList))
+	}
+	if _, err := fileSystemHandler.GetBackup(fsInfo.IId, onDemandInfo.BackupID); err != nil {
+		t.Error(err.Error())
+	}
+
+	// delete
+	if _, err := fileSystemHandler.DeleteBackup(fsInfo.IId, onDemandInfo.BackupID); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := fileSystemHandler.GetBackup(fsInfo.IId, onDemandInfo.BackupID); err == nil {
+		t.Error("deleted backup should not be found")
+	}
+	if _, err := fileSystemHandler.DeleteFileSystem(fsInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := fileSystemHandler.ListBackup(fsInfo.IId); err == nil {
+		t.Error("backups of a deleted file system should not be listed")
+	}
+}
+
+func TestFileSystemRestoreBackup(t *testing.T) {
+	fsInfo, err := fileSystemHandler.CreateFileSystem(irs.FileSystemInfo{
+		IId:              irs.IID{NameId: "mock-fs-restore-src"},
+		VpcIID:           irs.IID{NameId: "mock-fs-vpc"},
+		AccessSubnetList: []irs.IID{{NameId: "mock-fs-subnet-02"}},
+		PerformanceInfo:  map[string]string{"Tier": "PREMIUM"},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer fileSystemHandler.DeleteFileSystem(fsInfo.IId)
+
+	backupInfo, err := fileSystemHandler.OnDemandBackup(fsInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	backupIID := irs.IID{NameId: backupInfo.BackupID, SystemId: backupInfo.BackupID}
+	restoredInfo, err := fileSystemHandler.RestoreBackup(fsInfo.IId, backupIID, irs.IID{NameId: "mock-fs-restored"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer fileSystemHandler.DeleteFileSystem(restoredInfo.IId)
+
+	if restoredInfo.IId.NameId != "mock-fs-restored" || restoredInfo.Status != irs.FileSystemAvailable {
+		t.Errorf("unexpected restored file system: %#v", restoredInfo)
+	}
+	if restoredInfo.VpcIID.SystemId != fsInfo.VpcIID.SystemId || restoredInfo.PerformanceInfo["Tier"] != "PREMIUM" {
+		t.Errorf("restored file system does not have the configuration of the source: %#v", restoredInfo)
+	}
+	if len(restoredInfo.MountTargetList) != 1 || restoredInfo.MountTargetList[0].Endpoint == fsInfo.MountTargetList[0].Endpoint {
+		t.Errorf("unexpected mount targets of the restored file system: %#v", restoredInfo.MountTargetList)
+	}
+	if _, err := fileSystemHandler.GetFileSystem(restoredInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+
+	// errors
+	if _, err := fileSystemHandler.RestoreBackup(fsInfo.IId, backupIID, irs.IID{NameId: "mock-fs-restored"}); err == nil {
+		t.Error("restore to an existing file system name should be rejected")
+	}
+	if _, err := fileSystemHandler.RestoreBackup(fsInfo.IId, irs.IID{SystemId: "no-backup"}, irs.IID{NameId: "mock-fs-restored-02"}); err == nil {
+		t.Error("restore from an unknown backup should be rejected")
+	}
+	if _, err := fileSystemHandler.RestoreBackup(fsInfo.IId, backupIID, irs.IID{}); err == nil {
+		t.Error("restore without the new file system name should be rejected")
+	}
+}
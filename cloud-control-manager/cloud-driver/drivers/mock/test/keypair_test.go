@@ -110,3 +110,47 @@ func TestKeyPairDeleteGet(t *testing.T) {
 	}
 }
 
+
+func TestKeyPairImport(t *testing.T) {
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-KeyImport", // separate name to avoid conflicts with the KeyPairs above
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{CredentialInfo: cred})
+	importHandler, _ := cloudConn.CreateKeyPairHandler()
+
+	publicKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGUOnOEyJwbKfrc/HTZpCTJMqgCETVXx4yXkp1IZ1EeI user@laptop"
+	info, err := importHandler.ImportKey(irs.IID{NameId: "mock-imported-key", SystemId: ""}, publicKey)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.PrivateKey != "" {
+		t.Error("The PrivateKey of an imported KeyPair should be empty.")
+	}
+	if info.PublicKey != "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGUOnOEyJwbKfrc/HTZpCTJMqgCETVXx4yXkp1IZ1EeI" {
+		t.Errorf("PublicKey %s is not the imported one.", info.PublicKey)
+	}
+	if info.Fingerprint == "" {
+		t.Error("The Fingerprint of an imported KeyPair should be set.")
+	}
+
+	// Get returns the same KeyPair
+	getInfo, err := importHandler.GetKey(info.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if getInfo.Fingerprint != info.Fingerprint {
+		t.Errorf("Fingerprint %s is not same %s", getInfo.Fingerprint, info.Fingerprint)
+	}
+
+	// invalid key and duplicated name
+	if _, err := importHandler.ImportKey(irs.IID{NameId: "mock-invalid-key"}, "ssh-rsa not-a-key"); err == nil {
+		t.Error("An invalid public key should be rejected.")
+	}
+	if _, err := importHandler.ImportKey(irs.IID{NameId: "mock-imported-key"}, publicKey); err == nil {
+		t.Error("A duplicated KeyPair name should be rejected.")
+	}
+
+	if ret, err := importHandler.DeleteKey(info.IId); err != nil || !ret {
+		t.Errorf("DeleteKey() failed: %v, %v", ret, err)
+	}
+}
@@ -0,0 +1,154 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	mkrs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var monitoringHandler irs.MonitoringHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-Monitoring", // separate name to avoid conflicts with other tests' data
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	monitoringHandler, _ = cloudConn.CreateMonitoringHandler()
+
+	imageHandler, _ := cloudConn.CreateImageHandler()
+	imageHandler.CreateImage(irs.ImageReqInfo{IId: irs.IID{NameId: "mock-mon-img"}})
+	vpcHandler, _ := cloudConn.CreateVPCHandler()
+	vpcHandler.CreateVPC(irs.VPCReqInfo{
+		IId:            irs.IID{NameId: "mock-mon-vpc"},
+		IPv4_CIDR:      "10.40.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{NameId: "mock-mon-subnet"}, IPv4_CIDR: "10.40.1.0/24"}},
+	})
+	securityHandler, _ := cloudConn.CreateSecurityHandler()
+	securityHandler.CreateSecurity(irs.SecurityReqInfo{IId: irs.IID{NameId: "mock-mon-sg"}, VpcIID: irs.IID{NameId: "mock-mon-vpc"},
+		SecurityRules: &[]irs.SecurityRuleInfo{}})
+	keyPairHandler, _ := cloudConn.CreateKeyPairHandler()
+	keyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: "mock-mon-keypair"}})
+
+	vmHandler, _ := cloudConn.CreateVMHandler()
+	vmHandler.StartVM(irs.VMReqInfo{
+		IId:               irs.IID{NameId: "mock-mon-vm"},
+		ImageIID:          irs.IID{NameId: "mock-mon-img"},
+		VpcIID:            irs.IID{NameId: "mock-mon-vpc"},
+		SubnetIID:         irs.IID{NameId: "mock-mon-subnet"},
+		SecurityGroupIIDs: []irs.IID{{NameId: "mock-mon-sg"}},
+		VMSpecName:        "mock-vmspec-01",
+		KeyPairIID:        irs.IID{NameId: "mock-mon-keypair"},
+	})
+
+	clusterHandler, _ := cloudConn.CreateClusterHandler()
+	clusterHandler.CreateCluster(irs.ClusterInfo{
+		IId:           irs.IID{NameId: "mock-mon-cluster"},
+		NodeGroupList: []irs.NodeGroupInfo{{IId: irs.IID{NameId: "mock-mon-ng"}, DesiredNodeSize: 2}},
+	})
+}
+
+func TestVMMetricData(t *testing.T) {
+	vmIID := irs.IID{NameId: "mock-mon-vm", SystemId: "mock-mon-vm"}
+
+	for _, metricType := range []irs.MetricType{irs.CPUUsage, irs.MemoryUsage, irs.DiskRead, irs.DiskWrite,
+		irs.DiskReadOps, irs.DiskWriteOps, irs.NetworkIn, irs.NetworkOut} {
+		metricData, err := monitoringHandler.GetVMMetricData(irs.VMMonitoringReqInfo{
+			VMIID: vmIID, MetricType: metricType, IntervalMinute: "5", TimeBeforeHour: "2"})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(metricData.TimestampValues) != 24 {
+			t.Errorf("The number of %s values is not %d. It is %d.", metricType, 24, len(metricData.TimestampValues))
+		}
+		for idx, tv := range metricData.TimestampValues {
+			value, err := strconv.ParseFloat(tv.Value, 64)
+			if err != nil || value < 0 {
+				t.Errorf("invalid %s value: %s", metricType, tv.Value)
+			}
+			if (metricType == irs.CPUUsage || metricType == irs.MemoryUsage) && value > 100 {
+				t.Errorf("%s value %s is over 100 percent", metricType, tv.Value)
+			}
+			if idx > 0 && !tv.Timestamp.After(metricData.TimestampValues[idx-1].Timestamp) {
+				t.Errorf("%s timestamps are not ascending", metricType)
+			}
+		}
+	}
+
+	// errors
+	if _, err := monitoringHandler.GetVMMetricData(irs.VMMonitoringReqInfo{VMIID: irs.IID{NameId: "no-vm"}, MetricType: irs.CPUUsage}); err == nil {
+		t.Error("metric of an unknown VM should be rejected")
+	}
+	if _, err := monitoringHandler.GetVMMetricData(irs.VMMonitoringReqInfo{VMIID: vmIID, MetricType: irs.Unknown}); err == nil {
+		t.Error("unknown metric type should be rejected")
+	}
+	if _, err := monitoringHandler.GetVMMetricData(irs.VMMonitoringReqInfo{VMIID: vmIID, MetricType: irs.CPUUsage,
+		IntervalMinute: "120", TimeBeforeHour: "1"}); err == nil {
+		t.Error("interval longer than the period should be rejected")
+	}
+}
+
+func TestMetricDataSeed(t *testing.T) {
+	reqInfo := irs.VMMonitoringReqInfo{VMIID: irs.IID{NameId: "mock-mon-vm", SystemId: "mock-mon-vm"},
+		MetricType: irs.CPUUsage, IntervalMinute: "60", TimeBeforeHour: "24"}
+
+	defer func() { mkrs.MetricSeed = 0 }()
+
+	mkrs.MetricSeed = 1
+	first, _ := monitoringHandler.GetVMMetricData(reqInfo)
+	second, _ := monitoringHandler.GetVMMetricData(reqInfo)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same seed should produce the same time series")
+	}
+
+	mkrs.MetricSeed = 2
+	third, _ := monitoringHandler.GetVMMetricData(reqInfo)
+	if reflect.DeepEqual(first, third) {
+		t.Error("a different seed should produce a different time series")
+	}
+}
+
+func TestClusterNodeMetricData(t *testing.T) {
+	reqInfo := irs.ClusterNodeMonitoringReqInfo{
+		ClusterIID:  irs.IID{NameId: "mock-mon-cluster", SystemId: "mock-mon-cluster"},
+		NodeGroupID: irs.IID{NameId: "mock-mon-ng", SystemId: "mock-mon-ng"},
+		NodeIID:     irs.IID{NameId: "mock-mon-ng-node-2"},
+		MetricType:  irs.NetworkIn,
+	}
+	metricData, err := monitoringHandler.GetClusterNodeMetricData(reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(metricData.TimestampValues) != 60 {
+		t.Errorf("The number of values is not %d. It is %d.", 60, len(metricData.TimestampValues))
+	}
+
+	reqInfo.NodeIID = irs.IID{NameId: "mock-mon-ng-node-3"}
+	if _, err := monitoringHandler.GetClusterNodeMetricData(reqInfo); err == nil {
+		t.Error("metric of an unknown node should be rejected")
+	}
+	reqInfo.NodeGroupID = irs.IID{NameId: "no-ng", SystemId: "no-ng"}
+	if _, err := monitoringHandler.GetClusterNodeMetricData(reqInfo); err == nil {
+		t.Error("metric of an unknown node group should be rejected")
+	}
+}
@@ -0,0 +1,242 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var nicHandler irs.NICHandler
+var publicIPHandler irs.PublicIPHandler
+var nicVMHandler irs.VMHandler
+
+const nicTestSubnetCIDR = "10.10.1.0/24"
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-NIC", // separate name to avoid conflicts with other tests' data
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	nicHandler, _ = cloudConn.CreateNICHandler()
+	publicIPHandler, _ = cloudConn.CreatePublicIPHandler()
+	nicVMHandler, _ = cloudConn.CreateVMHandler()
+
+	imageHandler, _ := cloudConn.CreateImageHandler()
+	imageHandler.CreateImage(irs.ImageReqInfo{IId: irs.IID{NameId: "mock-nic-img"}})
+
+	vpcHandler, _ := cloudConn.CreateVPCHandler()
+	vpcHandler.CreateVPC(irs.VPCReqInfo{
+		IId:            irs.IID{NameId: "mock-nic-vpc"},
+		IPv4_CIDR:      "10.10.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{NameId: "mock-nic-subnet"}, IPv4_CIDR: nicTestSubnetCIDR}},
+	})
+
+	securityHandler, _ := cloudConn.CreateSecurityHandler()
+	securityHandler.CreateSecurity(irs.SecurityReqInfo{
+		IId:           irs.IID{NameId: "mock-nic-sg"},
+		VpcIID:        irs.IID{NameId: "mock-nic-vpc"},
+		SecurityRules: &[]irs.SecurityRuleInfo{{FromPort: "22", ToPort: "22", IPProtocol: "tcp", Direction: "inbound"}},
+	})
+
+	keyPairHandler, _ := cloudConn.CreateKeyPairHandler()
+	keyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: "mock-nic-keypair"}})
+
+	nicVMHandler.StartVM(irs.VMReqInfo{
+		IId:               irs.IID{NameId: "mock-nic-vm"},
+		ImageIID:          irs.IID{NameId: "mock-nic-img"},
+		VpcIID:            irs.IID{NameId: "mock-nic-vpc"},
+		SubnetIID:         irs.IID{NameId: "mock-nic-subnet"},
+		SecurityGroupIIDs: []irs.IID{{NameId: "mock-nic-sg"}},
+		VMSpecName:        "mock-vmspec-01",
+		KeyPairIID:        irs.IID{NameId: "mock-nic-keypair"},
+	})
+}
+
+func TestNICLifecycle(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR(nicTestSubnetCIDR)
+	vmIID := irs.IID{NameId: "mock-nic-vm", SystemId: "mock-nic-vm"}
+
+	// the VM has a primary NIC with an IP in the subnet
+	vmInfo, err := nicVMHandler.GetVM(vmIID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vmInfo.NICs) != 1 || vmInfo.NICs[0].DeviceIndex != 0 {
+		t.Fatalf("VM should have one primary NIC: %#v", vmInfo.NICs)
+	}
+	if !subnet.Contains(net.ParseIP(vmInfo.PrivateIP)) {
+		t.Errorf("VM PrivateIP %s is not in %s", vmInfo.PrivateIP, nicTestSubnetCIDR)
+	}
+	primaryNICIID := vmInfo.NICs[0].IId
+
+	// create
+	nicInfo, err := nicHandler.CreateNIC(irs.NICReqInfo{
+		IId:               irs.IID{NameId: "mock-nic-01"},
+		VpcIID:            irs.IID{NameId: "mock-nic-vpc"},
+		SubnetIID:         irs.IID{NameId: "mock-nic-subnet"},
+		SecurityGroupIIDs: []irs.IID{{NameId: "mock-nic-sg"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if nicInfo.Status != irs.NICAvailable {
+		t.Errorf("NIC status is not %s. It is %s.", irs.NICAvailable, nicInfo.Status)
+	}
+	if !subnet.Contains(net.ParseIP(nicInfo.PrivateIP)) || nicInfo.PrivateIP == vmInfo.PrivateIP {
+		t.Errorf("NIC PrivateIP %s is not a free IP in %s", nicInfo.PrivateIP, nicTestSubnetCIDR)
+	}
+	if _, err := nicHandler.CreateNIC(irs.NICReqInfo{IId: irs.IID{NameId: "mock-nic-bad"},
+		VpcIID: irs.IID{NameId: "mock-nic-vpc"}, SubnetIID: irs.IID{NameId: "no-subnet"}}); err == nil {
+		t.Error("NIC in an unknown subnet should be rejected")
+	}
+
+	// script is not available before attachment
+	if _, err := nicHandler.GetNICOSConfigScript(nicInfo.IId); err == nil {
+		t.Error("OS config script of a detached NIC should be rejected")
+	}
+
+	// attach
+	nicInfo, err = nicHandler.AttachNIC(nicInfo.IId, vmIID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if nicInfo.Status != irs.NICAttached || nicInfo.DeviceIndex != 1 || nicInfo.OwnerVM.NameId != vmIID.NameId {
+		t.Errorf("unexpected attached NIC: %#v", nicInfo)
+	}
+	vmInfo, _ = nicVMHandler.GetVM(vmIID)
+	if len(vmInfo.NICs) != 2 {
+		t.Errorf("The number of VM NICs is not %d. It is %d.", 2, len(vmInfo.NICs))
+	}
+
+	// secondary private IP
+	nicInfo, err = nicHandler.AddPrivateIP(nicInfo.IId, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(nicInfo.PrivateIPs) != 2 || !subnet.Contains(net.ParseIP(nicInfo.PrivateIPs[1])) {
+		t.Errorf("unexpected private IPs: %v", nicInfo.PrivateIPs)
+	}
+	if _, err := nicHandler.AddPrivateIP(nicInfo.IId, nicInfo.PrivateIPs[1]); err == nil {
+		t.Error("duplicated private IP should be rejected")
+	}
+	if _, err := nicHandler.RemovePrivateIP(nicInfo.IId, nicInfo.PrivateIP); err == nil {
+		t.Error("primary private IP should not be removed")
+	}
+	if _, err := nicHandler.RemovePrivateIP(nicInfo.IId, nicInfo.PrivateIPs[1]); err != nil {
+		t.Error(err.Error())
+	}
+
+	// OS config script
+	script, err := nicHandler.GetNICOSConfigScript(nicInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !strings.Contains(script, nicInfo.PrivateIP) {
+		t.Errorf("OS config script does not contain %s", nicInfo.PrivateIP)
+	}
+
+	// delete while attached, detach the primary NIC
+	if _, err := nicHandler.DeleteNIC(nicInfo.IId); err == nil {
+		t.Error("attached NIC should not be deleted")
+	}
+	if _, err := nicHandler.DetachNIC(primaryNICIID); err == nil {
+		t.Error("primary NIC should not be detached")
+	}
+
+	// detach & delete
+	if _, err := nicHandler.DetachNIC(nicInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+	vmInfo, _ = nicVMHandler.GetVM(vmIID)
+	if len(vmInfo.NICs) != 1 {
+		t.Errorf("The number of VM NICs is not %d. It is %d.", 1, len(vmInfo.NICs))
+	}
+	if _, err := nicHandler.DeleteNIC(nicInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestPublicIPLifecycle(t *testing.T) {
+	vmIID := irs.IID{NameId: "mock-nic-vm", SystemId: "mock-nic-vm"}
+
+	// create
+	pipInfo, err := publicIPHandler.CreatePublicIP(irs.PublicIPInfo{IId: irs.IID{NameId: "mock-pip-01"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if pipInfo.Status != irs.PublicIPAvailable || pipInfo.PublicIPAddress == "" {
+		t.Errorf("unexpected public IP: %#v", pipInfo)
+	}
+	pipInfo2, err := publicIPHandler.CreatePublicIP(irs.PublicIPInfo{IId: irs.IID{NameId: "mock-pip-02"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if pipInfo2.PublicIPAddress == pipInfo.PublicIPAddress {
+		t.Errorf("public IP address %s is duplicated", pipInfo.PublicIPAddress)
+	}
+
+	// associate with the VM's primary NIC
+	pipInfo, err = publicIPHandler.AssociatePublicIP(pipInfo.IId, vmIID, irs.IID{}, "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if pipInfo.Status != irs.PublicIPAssociated || pipInfo.OwnedVM.NameId != vmIID.NameId || pipInfo.OwnedPrivateIP == "" {
+		t.Errorf("unexpected associated public IP: %#v", pipInfo)
+	}
+	vmInfo, _ := nicVMHandler.GetVM(vmIID)
+	if vmInfo.PublicIP != pipInfo.PublicIPAddress {
+		t.Errorf("VM PublicIP is not %s. It is %s.", pipInfo.PublicIPAddress, vmInfo.PublicIP)
+	}
+
+	// one association per public IP, one public IP per private IP
+	if _, err := publicIPHandler.AssociatePublicIP(pipInfo.IId, vmIID, irs.IID{}, ""); err == nil {
+		t.Error("associated public IP should not be associated again")
+	}
+	if _, err := publicIPHandler.AssociatePublicIP(pipInfo2.IId, vmIID, irs.IID{}, ""); err == nil {
+		t.Error("private IP already mapped should be rejected")
+	}
+	if _, err := publicIPHandler.DeletePublicIP(pipInfo.IId); err == nil {
+		t.Error("associated public IP should not be deleted")
+	}
+
+	// disassociate & delete
+	if _, err := publicIPHandler.DisassociatePublicIP(pipInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+	vmInfo, _ = nicVMHandler.GetVM(vmIID)
+	if vmInfo.PublicIP == pipInfo.PublicIPAddress {
+		t.Errorf("VM PublicIP %s is not released", vmInfo.PublicIP)
+	}
+	for _, iid := range []irs.IID{pipInfo.IId, pipInfo2.IId} {
+		if _, err := publicIPHandler.DeletePublicIP(iid); err != nil {
+			t.Error(err.Error())
+		}
+	}
+	iidList, err := publicIPHandler.ListIID()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(iidList) > 0 {
+		t.Errorf("The number of IIDs is not %d. It is %d.", 0, len(iidList))
+	}
+}
@@ -64,7 +64,7 @@ func TestGetComputeInstancePriceInfo(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		jsonPriceInfo, err := handler.GetPriceInfo(productFamily, tc.regionName, tc.filterList)
+		jsonPriceInfo, err := handler.GetPriceInfo(productFamily, tc.regionName, tc.filterList, false)
 		if err != nil {
 			t.Errorf("GetPriceInfo returned an error: %v", err)
 		}
@@ -105,7 +105,7 @@ func TestGetStoragePriceInfo(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		jsonPriceInfo, err := handler.GetPriceInfo(productFamily, tc.regionName, tc.filterList)
+		jsonPriceInfo, err := handler.GetPriceInfo(productFamily, tc.regionName, tc.filterList, false)
 		if err != nil {
 			t.Errorf("GetPriceInfo returned an error: %v", err)
 		}
@@ -145,7 +145,7 @@ func TestGetNLBPriceInfo(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		jsonPriceInfo, err := handler.GetPriceInfo(productFamily, tc.regionName, tc.filterList)
+		jsonPriceInfo, err := handler.GetPriceInfo(productFamily, tc.regionName, tc.filterList, false)
 		if err != nil {
 			t.Errorf("GetPriceInfo returned an error: %v", err)
 		}
@@ -0,0 +1,206 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	mkrs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+const quotaTestMockName = "MockDriver-Quota" // separate name to avoid conflicts with other tests' data
+
+var quotaInfoHandler irs.QuotaInfoHandler
+var quotaVPCHandler irs.VPCHandler
+var quotaKeyPairHandler irs.KeyPairHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: quotaTestMockName,
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{Region: "mock-region"},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	quotaInfoHandler, _ = cloudConn.CreateQuotaInfoHandler()
+	quotaVPCHandler, _ = cloudConn.CreateVPCHandler()
+	quotaKeyPairHandler, _ = cloudConn.CreateKeyPairHandler()
+}
+
+func getQuota(t *testing.T, serviceType string, quotaName string) irs.Quota {
+	quotaInfo, err := quotaInfoHandler.GetQuotaInfo(serviceType)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, quota := range quotaInfo.Quotas {
+		if quota.QuotaName == quotaName {
+			return quota
+		}
+	}
+	t.Fatalf("%s quota does not exist in %s", quotaName, serviceType)
+	return irs.Quota{}
+}
+
+func TestQuotaServiceType(t *testing.T) {
+	serviceTypes, err := quotaInfoHandler.ListServiceType()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	for _, serviceType := range serviceTypes {
+		quotaInfo, err := quotaInfoHandler.GetQuotaInfo(serviceType)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if quotaInfo.Region != "mock-region" || len(quotaInfo.Quotas) == 0 {
+			t.Errorf("unexpected quota info of %s: %#v", serviceType, quotaInfo)
+		}
+	}
+	if _, err := quotaInfoHandler.GetQuotaInfo("no-service"); err == nil {
+		t.Error("unknown service type should be rejected")
+	}
+	if err := mkrs.SetQuotaLimit(quotaTestMockName, "compute", "no-quota", 1); err == nil {
+		t.Error("unknown quota item should be rejected")
+	}
+}
+
+func TestQuotaEnforcement(t *testing.T) {
+	defer mkrs.ResetQuotaLimit(quotaTestMockName)
+
+	if err := mkrs.SetQuotaLimit(quotaTestMockName, "network", "vpcs", 1); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := mkrs.SetQuotaLimit(quotaTestMockName, "network", "subnets", 2); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err := quotaVPCHandler.CreateVPC(irs.VPCReqInfo{
+		IId:            irs.IID{NameId: "mock-quota-vpc-01"},
+		IPv4_CIDR:      "10.50.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{NameId: "mock-quota-subnet-01"}, IPv4_CIDR: "10.50.1.0/24"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	quota := getQuota(t, "network", "vpcs")
+	if quota.Limit != "1" || quota.Used != "1" || quota.Available != "0" {
+		t.Errorf("unexpected vpcs quota: %#v", quota)
+	}
+
+	// vpc limit
+	_, err = quotaVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-quota-vpc-02"}, IPv4_CIDR: "10.51.0.0/16"})
+	if !errors.Is(err, mkrs.ErrQuotaExceeded) {
+		t.Errorf("VPC creation over the quota should fail with %v, but got %v", mkrs.ErrQuotaExceeded, err)
+	}
+
+	// subnet limit
+	vpcIID := irs.IID{NameId: "mock-quota-vpc-01", SystemId: "mock-quota-vpc-01"}
+	if _, err := quotaVPCHandler.AddSubnet(vpcIID, irs.SubnetInfo{IId: irs.IID{NameId: "mock-quota-subnet-02"}, IPv4_CIDR: "10.50.2.0/24"}); err != nil {
+		t.Error(err.Error())
+	}
+	_, err = quotaVPCHandler.AddSubnet(vpcIID, irs.SubnetInfo{IId: irs.IID{NameId: "mock-quota-subnet-03"}, IPv4_CIDR: "10.50.3.0/24"})
+	if !errors.Is(err, mkrs.ErrQuotaExceeded) {
+		t.Errorf("Subnet creation over the quota should fail with %v, but got %v", mkrs.ErrQuotaExceeded, err)
+	}
+
+	// deletion releases the quota
+	if _, err := quotaVPCHandler.DeleteVPC(vpcIID); err != nil {
+		t.Error(err.Error())
+	}
+	if quota := getQuota(t, "network", "vpcs"); quota.Used != "0" {
+		t.Errorf("vpcs quota is not released: %#v", quota)
+	}
+}
+
+func TestQuotaConcurrentCreation(t *testing.T) {
+	defer mkrs.ResetQuotaLimit(quotaTestMockName)
+
+	const limit = 3
+	if err := mkrs.SetQuotaLimit(quotaTestMockName, "compute", "keypairs", limit); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	created := []irs.IID{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			info, err := quotaKeyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: fmt.Sprintf("mock-quota-race-%02d", i)}})
+			if err != nil {
+				if !errors.Is(err, mkrs.ErrQuotaExceeded) {
+					t.Error(err.Error())
+				}
+				return
+			}
+			mutex.Lock()
+			created = append(created, info.IId)
+			mutex.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(created) != limit {
+		t.Errorf("%d KeyPairs are created over the quota(%d)", len(created), limit)
+	}
+	for _, iid := range created {
+		quotaKeyPairHandler.DeleteKey(iid)
+	}
+}
+
+func TestQuotaFile(t *testing.T) {
+	defer mkrs.ResetQuotaLimit(quotaTestMockName)
+
+	filePath := filepath.Join(t.TempDir(), "quota.yaml")
+	data := quotaTestMockName + ":\n  compute:\n    keypairs: 1\n"
+	if err := os.WriteFile(filePath, []byte(data), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := mkrs.LoadQuotaFile(filePath); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if quota := getQuota(t, "compute", "keypairs"); quota.Limit != "1" {
+		t.Errorf("keypairs quota is not loaded: %#v", quota)
+	}
+	// other items keep the default limits
+	if quota := getQuota(t, "compute", "vm-instances"); quota.Limit == "1" || quota.Limit == "NA" {
+		t.Errorf("vm-instances quota should be the default: %#v", quota)
+	}
+
+	if _, err := quotaKeyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: "mock-quota-key-01"}}); err != nil {
+		t.Error(err.Error())
+	}
+	_, err := quotaKeyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{NameId: "mock-quota-key-02"}})
+	if !errors.Is(err, mkrs.ErrQuotaExceeded) {
+		t.Errorf("KeyPair creation over the quota should fail with %v, but got %v", mkrs.ErrQuotaExceeded, err)
+	}
+
+	// bad file
+	if err := os.WriteFile(filePath, []byte("default:\n  compute:\n    no-quota: 1\n"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := mkrs.LoadQuotaFile(filePath); err == nil {
+		t.Error("unknown quota item in the file should be rejected")
+	}
+}
@@ -0,0 +1,257 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package mocktest
+
+import (
+	"testing"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	mkrs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var rdbmsHandler irs.RDBMSHandler
+var dbSpecHandler irs.DBSpecHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-RDBMS", // separate name to avoid conflicts with other tests' data
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	rdbmsHandler, _ = cloudConn.CreateRDBMSHandler()
+	dbSpecHandler, _ = cloudConn.CreateDBSpecHandler()
+
+	vpcHandler, _ := cloudConn.CreateVPCHandler()
+	vpcHandler.CreateVPC(irs.VPCReqInfo{
+		IId:            irs.IID{NameId: "mock-rdbms-vpc"},
+		IPv4_CIDR:      "10.0.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{NameId: "mock-rdbms-subnet"}, IPv4_CIDR: "10.0.1.0/24"}},
+	})
+}
+
+type RDBMSTestInfo struct {
+	Name     string
+	DBEngine string
+	Version  string
+	DBSpec   string
+}
+
+var rdbmsTestInfoList = []RDBMSTestInfo{
+	{"mock-rdbms-mysql", "mysql", "8.0", "mock-dbspec-01"},
+	{"mock-rdbms-mariadb", "MariaDB", "", "mock-dbspec-02"},
+	{"mock-rdbms-postgresql", "postgresql", "16", "mock-dbspec-03"},
+}
+
+func TestDBSpecList(t *testing.T) {
+	for _, engine := range []string{"mysql", "mariadb", "postgresql"} {
+		infoList, err := dbSpecHandler.ListDBSpec(engine)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if len(infoList) != 3 {
+			t.Errorf("The number of %s DBSpecs is not %d. It is %d.", engine, 3, len(infoList))
+		}
+	}
+
+	// spec without data is hidden from the list, but still returned by GetDBSpec
+	info, err := dbSpecHandler.GetDBSpec("mysql", "mock-dbspec-nodata")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !info.HasNoSpecData() || info.DataSource["VCpu"] != irs.DataSourceStatic {
+		t.Errorf("mock-dbspec-nodata should be marked as static without spec data: %#v", info)
+	}
+
+	if _, err := dbSpecHandler.ListDBSpec("oracle"); err == nil {
+		t.Error("oracle engine should not be supported")
+	}
+}
+
+func TestRDBMSMetaInfo(t *testing.T) {
+	metaInfo, err := rdbmsHandler.GetMetaInfo("PostgreSQL")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if metaInfo.DBEngine != "postgresql" || len(metaInfo.SupportedVersions) == 0 || len(metaInfo.DBSpecOptions) != 3 {
+		t.Errorf("unexpected meta info: %#v", metaInfo)
+	}
+}
+
+func TestRDBMSLifecycle(t *testing.T) {
+	mkrs.RDBMSCreatingDuration = 100 * time.Millisecond
+
+	// create
+	for _, info := range rdbmsTestInfoList {
+		reqInfo := irs.RDBMSInfo{
+			IId:             irs.IID{NameId: info.Name},
+			VpcIID:          irs.IID{NameId: "mock-rdbms-vpc"},
+			SubnetIIDs:      []irs.IID{{NameId: "mock-rdbms-subnet"}},
+			DBEngine:        info.DBEngine,
+			DBEngineVersion: info.Version,
+			DBSpec:          info.DBSpec,
+			MasterUserName:  "admin",
+		}
+		createdInfo, err := rdbmsHandler.CreateRDBMS(reqInfo)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if createdInfo.Status != irs.RDBMSCreating {
+			t.Errorf("%s status is not %s. It is %s.", info.Name, irs.RDBMSCreating, createdInfo.Status)
+		}
+		if createdInfo.Endpoint == "" || createdInfo.DBEngineVersion == "" {
+			t.Errorf("%s has no Endpoint or DBEngineVersion", info.Name)
+		}
+	}
+
+	// duplicated name
+	_, err := rdbmsHandler.CreateRDBMS(irs.RDBMSInfo{IId: irs.IID{NameId: rdbmsTestInfoList[0].Name}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"},
+		DBEngine: "mysql", DBSpec: "mock-dbspec-01", MasterUserName: "admin"})
+	if err == nil {
+		t.Error("duplicated RDBMS name should be rejected")
+	}
+
+	// Creating => Available
+	time.Sleep(200 * time.Millisecond)
+	infoList, err := rdbmsHandler.ListRDBMS()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) != len(rdbmsTestInfoList) {
+		t.Errorf("The number of Infos is not %d. It is %d.", len(rdbmsTestInfoList), len(infoList))
+	}
+	for _, info := range infoList {
+		if info.Status != irs.RDBMSAvailable {
+			t.Errorf("%s status is not %s. It is %s.", info.IId.NameId, irs.RDBMSAvailable, info.Status)
+		}
+	}
+
+	// delete all
+	for _, info := range infoList {
+		ret, err := rdbmsHandler.DeleteRDBMS(info.IId)
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if !ret {
+			t.Errorf("Return is not True!! %s", info.IId.NameId)
+		}
+	}
+	iidList, err := rdbmsHandler.ListIID()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(iidList) > 0 {
+		t.Errorf("The number of IIDs is not %d. It is %d.", 0, len(iidList))
+	}
+}
+
+func TestRDBMSCreateValidation(t *testing.T) {
+	testCases := []irs.RDBMSInfo{
+		// unknown version
+		{IId: irs.IID{NameId: "bad-01"}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"}, DBEngine: "mysql", DBEngineVersion: "3.0", DBSpec: "mock-dbspec-01", MasterUserName: "admin"},
+		// unknown spec
+		{IId: irs.IID{NameId: "bad-02"}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"}, DBEngine: "mysql", DBSpec: "no-spec", MasterUserName: "admin"},
+		// storage out of range
+		{IId: irs.IID{NameId: "bad-03"}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"}, DBEngine: "mysql", DBSpec: "mock-dbspec-01", StorageSize: "10", MasterUserName: "admin"},
+		// unknown VPC
+		{IId: irs.IID{NameId: "bad-04"}, VpcIID: irs.IID{NameId: "no-vpc"}, DBEngine: "mysql", DBSpec: "mock-dbspec-01", MasterUserName: "admin"},
+		// unknown subnet
+		{IId: irs.IID{NameId: "bad-05"}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"}, SubnetIIDs: []irs.IID{{NameId: "no-subnet"}}, DBEngine: "mysql", DBSpec: "mock-dbspec-01", MasterUserName: "admin"},
+	}
+	for _, reqInfo := range testCases {
+		if _, err := rdbmsHandler.CreateRDBMS(reqInfo); err == nil {
+			t.Errorf("%s should be rejected", reqInfo.IId.NameId)
+		}
+	}
+
+	// deletion protection
+	_, err := rdbmsHandler.CreateRDBMS(irs.RDBMSInfo{IId: irs.IID{NameId: "protected-01"}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"},
+		DBEngine: "mysql", DBSpec: "mock-dbspec-01", MasterUserName: "admin", DeletionProtection: true})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := rdbmsHandler.DeleteRDBMS(irs.IID{NameId: "protected-01", SystemId: "protected-01"}); err == nil {
+		t.Error("RDBMS with DeletionProtection should not be deleted")
+	}
+}
+
+func TestRDBMSChangeSpecAndStorage(t *testing.T) {
+	mkrs.RDBMSCreatingDuration = 100 * time.Millisecond
+	mkrs.RDBMSModifyingDuration = 100 * time.Millisecond
+
+	createdInfo, err := rdbmsHandler.CreateRDBMS(irs.RDBMSInfo{IId: irs.IID{NameId: "scale-01"}, VpcIID: irs.IID{NameId: "mock-rdbms-vpc"},
+		DBEngine: "mysql", DBSpec: "mock-dbspec-01", MasterUserName: "admin"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	iid := createdInfo.IId
+	defer rdbmsHandler.DeleteRDBMS(iid)
+
+	// not Available yet
+	if _, err := rdbmsHandler.ChangeSpec(iid, "mock-dbspec-02"); err == nil {
+		t.Error("RDBMS in Creating status should not be changed")
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	// change spec
+	if _, err := rdbmsHandler.ChangeSpec(iid, "mock-dbspec-01"); err == nil {
+		t.Error("the same DBSpec should be rejected")
+	}
+	if _, err := rdbmsHandler.ChangeSpec(iid, "mock-dbspec-nodata"); err == nil {
+		t.Error("DBSpec without spec data should be rejected")
+	}
+	if _, err := rdbmsHandler.ChangeSpec(iid, "mock-dbspec-03"); err == nil {
+		t.Error("DBSpec whose storage range does not cover the current storage should be rejected")
+	}
+	changedInfo, err := rdbmsHandler.ChangeSpec(iid, "mock-dbspec-02")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if changedInfo.Status != irs.RDBMSCreating {
+		t.Errorf("%s status is not %s. It is %s.", iid.NameId, irs.RDBMSCreating, changedInfo.Status)
+	}
+	if _, err := rdbmsHandler.ChangeStorageSize(iid, "100"); err == nil {
+		t.Error("RDBMS under change should not be changed again")
+	}
+	time.Sleep(200 * time.Millisecond)
+	info, err := rdbmsHandler.GetRDBMS(iid)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if info.Status != irs.RDBMSAvailable || info.DBSpec != "mock-dbspec-02" {
+		t.Errorf("unexpected status or DBSpec after change: %s, %s", info.Status, info.DBSpec)
+	}
+
+	// expand storage
+	for _, size := range []string{"10", "20", "9000", "abc"} {
+		if _, err := rdbmsHandler.ChangeStorageSize(iid, size); err == nil {
+			t.Errorf("StorageSize %s should be rejected", size)
+		}
+	}
+	if _, err := rdbmsHandler.ChangeStorageSize(iid, "100"); err != nil {
+		t.Error(err.Error())
+	}
+	time.Sleep(200 * time.Millisecond)
+	info, err = rdbmsHandler.GetRDBMS(iid)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if info.Status != irs.RDBMSAvailable || info.StorageSize != "100" {
+		t.Errorf("unexpected status or StorageSize after change: %s, %s", info.Status, info.StorageSize)
+	}
+}
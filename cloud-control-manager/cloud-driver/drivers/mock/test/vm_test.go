@@ -319,6 +319,43 @@ func TestVMRebootGet(t *testing.T) {
 
 }
 
+func TestVMChangeSpecGet(t *testing.T) {
+
+	iid := irs.IID{vmTestInfoList[0].IId, ""}
+
+	// change the spec
+	info, err := vmHandler.ChangeVMSpec(iid, "mock-vmspec-02")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if info.VMSpecName != "mock-vmspec-02" {
+		t.Errorf("VMSpecName is not mock-vmspec-02!! %s", info.VMSpecName)
+	}
+
+	// check the result of ChangeVMSpec Op
+	info, err = vmHandler.GetVM(iid)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if info.VMSpecName != "mock-vmspec-02" {
+		t.Errorf("VMSpecName is not mock-vmspec-02!! %s", info.VMSpecName)
+	}
+	ret, err := vmHandler.GetVMStatus(iid)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if ret != "Running" {
+		t.Errorf("Return is not Running!! %s", iid.NameId)
+	}
+
+	// not existing spec
+	_, err = vmHandler.ChangeVMSpec(iid, "mock-vmspec-99")
+	if err == nil {
+		t.Errorf("Not existing spec should be rejected!! %s", iid.NameId)
+	}
+
+}
+
 func TestVMTerminateGet(t *testing.T) {
 
 	// Get & check the Value
@@ -252,3 +252,7 @@ func (cloudConn *NcpVpcCloudConnection) CreatePublicIPHandler() (irs.PublicIPHan
 	}
 	return &handler, nil
 }
+
+func (cloudConn *NcpVpcCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: DiskSnapshotHandler not implemented yet")
+}
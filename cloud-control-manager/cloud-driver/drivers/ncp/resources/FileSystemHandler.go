@@ -481,3 +481,9 @@ func (filesystemHandler *NcpVpcFileSystemHandler) DeleteBackup(fsIID irs.IID, ba
 
 	return false, fmt.Errorf("backup deletion is not supported in OpenStack Manila")
 }
+
+func (filesystemHandler *NcpVpcFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	cblogger.Info("NCP VPC Driver: called RestoreBackup()")
+
+	return irs.FileSystemInfo{}, fmt.Errorf("backup restore is not supported in OpenStack Manila")
+}
@@ -136,6 +136,70 @@ func (keyPairHandler *NcpVpcKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPair
 	return keyPairInfo, nil
 }
 
+func (keyPairHandler *NcpVpcKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Info("NCP VPC cloud driver: called ImportKey()!!")
+
+	InitLog() // Caution!!
+	callLogInfo := GetCallLogScheme(keyPairHandler.RegionInfo.Zone, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+
+	cblogger.Infof("KeyPairName to Import : [%s]", keyIID.NameId)
+
+	// To Generate Hash
+	strList := []string{
+		keyPairHandler.CredentialInfo.ClientId,
+		keyPairHandler.CredentialInfo.ClientSecret,
+	}
+
+	hashString, err := keycommon.GenHash(strList)
+	if err != nil {
+		cblogger.Errorf("Failed to Generate Hash String : %v", err)
+		LoggingError(callLogInfo, err)
+		return irs.KeyPairInfo{}, err
+	}
+
+	keypairReq := vserver.ImportLoginKeyRequest{
+		RegionCode: ncloud.String(keyPairHandler.RegionInfo.Region),
+		KeyName:    ncloud.String(keyIID.NameId),
+		PublicKey:  ncloud.String(publicKey),
+	}
+	callLogStart := call.Start()
+	result, err := keyPairHandler.VMClient.V2Api.ImportLoginKey(&keypairReq)
+	if err != nil {
+		cblogger.Errorf("Failed to Import KeyPair: %s, %v.", keyIID.NameId, err)
+		LoggingError(callLogInfo, err)
+		return irs.KeyPairInfo{}, err
+	}
+	LoggingInfo(callLogInfo, callLogStart)
+	cblogger.Infof("(# result.ReturnMessage : %s ", ncloud.StringValue(result.ReturnMessage))
+
+	publicKey = strings.TrimSpace(publicKey) + " " + lnxUserName
+
+	// Save the publicKey to DB in other to use on VMHandler(Cloud-init)
+	addKeyErr := keycommon.AddKey("NCP", hashString, keyIID.NameId, publicKey)
+	if addKeyErr != nil {
+		cblogger.Errorf("Failed to Save the PublicKey to DB : %v", addKeyErr)
+		LoggingError(callLogInfo, addKeyErr)
+		return irs.KeyPairInfo{}, addKeyErr
+	}
+
+	resultKey, keyError := keyPairHandler.GetKey(keyIID)
+	if keyError != nil {
+		cblogger.Errorf("Failed to Get the KeyPair Info : %v", keyError)
+		LoggingError(callLogInfo, keyError)
+		return irs.KeyPairInfo{}, keyError
+	}
+
+	// NCP Key does not have SystemId, so the unique NameId value is also applied to the SystemId
+	keyPairInfo := irs.KeyPairInfo{
+		IId:         irs.IID{NameId: keyIID.NameId, SystemId: keyIID.NameId},
+		Fingerprint: resultKey.Fingerprint,
+		PublicKey:   publicKey,
+		VMUserID:    lnxUserName,
+	}
+
+	return keyPairInfo, nil
+}
+
 func (keyPairHandler *NcpVpcKeyPairHandler) GetKey(keyIID irs.IID) (irs.KeyPairInfo, error) {
 	cblogger.Info("NCP VPC cloud driver: called GetKey()!!")
 
@@ -130,6 +130,8 @@ func (handler *NcpVpcRDBMSHandler) getMysqlMetaInfo() (irs.RDBMSMetaInfo, error)
 		RequiresSubnet:                   true,
 		RequiresSecurityGroup:            false,
 		SupportsTag:                      false,
+		SupportsSpecChange:               false, // NCP SDK does not provide a spec change API for Cloud DB
+		SupportsStorageSizeChange:        false, // NCP Cloud DB storage is expanded automatically by the CSP
 	}
 	metaInfo.MarkStatic("StorageTypeOptions", "NCP G3 generation sets storage type (SSD) automatically; not user-selectable or queryable via API.")
 	metaInfo.MarkStatic("StorageSizeRangeGB", "NCP has no storage-size query API; range shown (10-6000GB) is a known approximation, not authoritative. No unit conversion is applied because the value is not derived from any CSP-reported unit.")
@@ -224,6 +226,8 @@ func (handler *NcpVpcRDBMSHandler) getPostgresqlMetaInfo() (irs.RDBMSMetaInfo, e
 		SupportsStorageSizeConfiguration: false,
 		RequiresSubnet:                   true,
 		RequiresSecurityGroup:            false,
+		SupportsSpecChange:               false, // NCP SDK does not provide a spec change API for Cloud DB
+		SupportsStorageSizeChange:        false, // NCP Cloud DB storage is expanded automatically by the CSP
 	}
 	metaInfo.MarkStatic("StorageTypeOptions", "NCP G3 generation sets storage type (SSD) automatically; not user-selectable or queryable via API.")
 	metaInfo.MarkStatic("StorageSizeRangeGB", "NCP has no storage-size query API; range shown (10-6000GB) is a known approximation, not authoritative. No unit conversion is applied because the value is not derived from any CSP-reported unit.")
@@ -688,6 +692,18 @@ func (handler *NcpVpcRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error) {
 	return true, nil
 }
 
+// ChangeSpec is not implemented yet in the NCP driver: the NCP SDK has no Cloud DB spec change API.
+// GetMetaInfo reports SupportsSpecChange=false.
+func (handler *NcpVpcRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	return irs.RDBMSInfo{}, errors.New("changing the RDBMS spec is not implemented yet in the NCP driver. See SupportsSpecChange in GetMetaInfo")
+}
+
+// ChangeStorageSize is not implemented yet in the NCP driver: NCP Cloud DB storage is expanded automatically.
+// GetMetaInfo reports SupportsStorageSizeChange=false.
+func (handler *NcpVpcRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	return false, errors.New("changing the RDBMS storage size is not implemented yet in the NCP driver. See SupportsStorageSizeChange in GetMetaInfo")
+}
+
 // ---- Helper functions ----
 
 // extractStorageSizeFromProductCode parses storage size (GB) from NCP product code.
@@ -851,6 +851,104 @@ func convertVMStatusString(vmStatus string) (irs.VMStatus, error) {
 	return irs.VMStatus(resultStatus), nil
 }
 
+func (vmHandler *NcpVpcVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Info("NCP VPC Cloud driver: called ChangeVMSpec()!!")
+	InitLog()
+	callLogInfo := GetCallLogScheme(vmHandler.RegionInfo.Zone, call.VM, vmIID.NameId, "ChangeVMSpec()")
+
+	if strings.EqualFold(vmIID.SystemId, "") {
+		newErr := fmt.Errorf("Invalid VM SystemId required")
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	vmStatus, err := vmHandler.GetVMStatus(vmIID)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Get the VM Status with the VM ID : [%s], [%v]", vmIID.SystemId, err)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	// The spec of a NCP VM can be changed only when the VM is stopped.
+	wasRunning := false
+	switch vmStatus {
+	case irs.Running:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmIID); err != nil {
+			newErr := fmt.Errorf("Failed to Suspend the VM to Change the Spec : [%v]", err)
+			cblogger.Error(newErr.Error())
+			LoggingError(callLogInfo, newErr)
+			return irs.VMInfo{}, newErr
+		}
+		if _, err := vmHandler.waitToBeSuspended(vmIID); err != nil {
+			cblogger.Error(err.Error())
+			LoggingError(callLogInfo, err)
+			return irs.VMInfo{}, err
+		}
+	case irs.Suspended:
+	default:
+		newErr := fmt.Errorf("The VM Spec can be Changed only when the VM is Running or Suspended. The VM is [%s]", vmStatus)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	req := vserver.ChangeServerInstanceSpecRequest{
+		RegionCode:       ncloud.String(vmHandler.RegionInfo.Region), // $$$ Caution!!
+		ServerInstanceNo: ncloud.String(vmIID.SystemId),
+		ServerSpecCode:   ncloud.String(vmSpecName),
+	}
+	callLogStart := call.Start()
+	_, err = vmHandler.VMClient.V2Api.ChangeServerInstanceSpec(&req)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Change the VM Spec : [%v]", err)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		if wasRunning {
+			vmHandler.ResumeVM(vmIID)
+		}
+		return irs.VMInfo{}, newErr
+	}
+	LoggingInfo(callLogInfo, callLogStart)
+
+	// Waiting for up to 600 seconds until the spec is changed and the VM is stopped again.
+	curRetryCnt := 0
+	maxRetryCnt := 120
+	for {
+		ncpVMInfo, err := vmHandler.getNcpVMInfo(vmIID.SystemId)
+		if err == nil && strings.EqualFold(ncloud.StringValue(ncpVMInfo.ServerSpecCode), vmSpecName) &&
+			strings.EqualFold(ncloud.StringValue(ncpVMInfo.ServerInstanceStatusName), "stopped") {
+			break
+		}
+		curRetryCnt++
+		if curRetryCnt > maxRetryCnt {
+			newErr := fmt.Errorf("Despite waiting for a long time, the VM Spec is not changed to [%s], so it is forcibly finished.", vmSpecName)
+			cblogger.Error(newErr.Error())
+			LoggingError(callLogInfo, newErr)
+			return irs.VMInfo{}, newErr
+		}
+		time.Sleep(time.Second * 5)
+	}
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmIID); err != nil {
+			newErr := fmt.Errorf("Failed to Resume the VM after Changing the Spec : [%v]", err)
+			cblogger.Error(newErr.Error())
+			LoggingError(callLogInfo, newErr)
+			return irs.VMInfo{}, newErr
+		}
+		if _, err := vmHandler.waitToGetVMInfo(vmIID); err != nil {
+			cblogger.Error(err.Error())
+			LoggingError(callLogInfo, err)
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmIID)
+}
+
 func (vmHandler *NcpVpcVMHandler) GetVMStatus(vmIID irs.IID) (irs.VMStatus, error) {
 	cblogger.Info("NCP VPC Cloud driver: called GetVMStatus()!")
 	InitLog()
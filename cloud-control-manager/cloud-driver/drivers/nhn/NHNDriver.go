@@ -62,6 +62,8 @@ func (NhnCloudDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 
 	drvCapabilityInfo.VPC_CIDR = true
 
+	drvCapabilityInfo.VM_USERDATA = true
+
 	drvCapabilityInfo.RDBMSHandler = true
 	drvCapabilityInfo.DBSpecHandler = true
 	drvCapabilityInfo.RDBMSMySQLHandler = true
@@ -69,6 +71,7 @@ func (NhnCloudDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false // not implemented
 	drvCapabilityInfo.PublicIPHandler = false
 	drvCapabilityInfo.NICHandler = false
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	return drvCapabilityInfo
 }
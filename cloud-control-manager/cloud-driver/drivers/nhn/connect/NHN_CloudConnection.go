@@ -211,3 +211,7 @@ func (cloudConn *NhnCloudConnection) CreatePublicIPHandler() (irs.PublicIPHandle
 	}
 	return &handler, nil
 }
+
+func (cloudConn *NhnCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: DiskSnapshotHandler not implemented yet")
+}
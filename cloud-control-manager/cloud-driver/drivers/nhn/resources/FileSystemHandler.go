@@ -661,3 +661,6 @@ func (nf *NhnCloudFileSystemHandler) GetBackup(fsIID irs.IID, backupID string) (
 func (nf *NhnCloudFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID string) (bool, error) {
 	return false, nil
 }
+func (nf *NhnCloudFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	return irs.FileSystemInfo{}, fmt.Errorf("backup restore is not supported in NHN Cloud NAS")
+}
@@ -76,6 +76,49 @@ func (keyPairHandler *NhnCloudKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPa
 	return *keyPairInfo, nil
 }
 
+func (keyPairHandler *NhnCloudKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Info("NHN Cloud Driver: called ImportKey()")
+	callLogInfo := getCallLogScheme(keyPairHandler.RegionInfo.Region, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+
+	if keyIID.NameId == "" {
+		newErr := fmt.Errorf("Invalid KeyPair NameId.")
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	exist, err := checkExistKey(keyPairHandler.VMClient, keyIID)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Import Key. err = %s", err)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+	if exist {
+		newErr := fmt.Errorf("Failed to Import Key. err = The Key name %s already exists", keyIID.NameId)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+
+	start := call.Start()
+	create0pts := keypairs.CreateOpts{
+		Name:      keyIID.NameId,
+		PublicKey: publicKey,
+	}
+	keyPair, err := keypairs.Create(keyPairHandler.VMClient, create0pts).Extract()
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Import Key. err = %s", err.Error())
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.KeyPairInfo{}, newErr
+	}
+	LoggingInfo(callLogInfo, start)
+
+	keyPairInfo := mappingKeypairInfo(*keyPair)
+	return *keyPairInfo, nil
+}
+
 func (keyPairHandler *NhnCloudKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	cblogger.Info("NHN Cloud Driver: called ListKey()")
 	callLogInfo := getCallLogScheme(keyPairHandler.RegionInfo.Region, call.VMKEYPAIR, "ListKey()", "ListKey()")
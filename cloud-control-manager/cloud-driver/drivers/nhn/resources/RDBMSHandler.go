@@ -335,7 +335,7 @@ func (handler *NhnCloudRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMeta
 
 	storageSizeRange := irs.StorageSizeRange{Min: 20, Max: 2048}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, false, "1-730", true, false, true, true, false)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, true, false, "1-730", true, false, true, true, false, true, true)
 	if err != nil {
 		LoggingError(callLogInfo, err)
 		return irs.RDBMSMetaInfo{}, err
@@ -992,6 +992,93 @@ func (handler *NhnCloudRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error)
 	return true, nil
 }
 
+// ChangeSpec changes the DB flavor of an NHN Cloud RDS instance (PUT /v3.0/db-instances/{id}).
+// NHN restarts the DB instance to apply the new flavor.
+func (handler *NhnCloudRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	cblogger.Info("NHN Cloud Driver: called ChangeSpec()")
+	callLogInfo := getCallLogScheme(handler.RegionInfo.Region, call.RDBMS, rdbmsIID.NameId, "PUT /v3.0/db-instances/{id}")
+	start := call.Start()
+
+	if err := handler.checkRDSCredentials(); err != nil {
+		LoggingError(callLogInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	endpointFn, dbInstanceId, err := handler.resolveInstanceEndpoint(ctx, rdbmsIID)
+	if err != nil {
+		LoggingError(callLogInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	flavorId, err := handler.resolveRDSFlavorIdWithEndpoint(ctx, endpointFn, newSpec)
+	if err != nil {
+		LoggingError(callLogInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	var result nhnRDSJobResponse
+	body := map[string]interface{}{"dbFlavorId": flavorId}
+	if err := handler.putRDSWithEndpoint(ctx, endpointFn, "/v3.0/db-instances/"+dbInstanceId, body, &result); err != nil {
+		newErr := fmt.Errorf("failed to change the flavor of NHN Cloud RDS instance '%s': %w", dbInstanceId, err)
+		LoggingError(callLogInfo, newErr)
+		return irs.RDBMSInfo{}, newErr
+	}
+	if err := checkRDSResponseHeader(result.Header); err != nil {
+		LoggingError(callLogInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+	LoggingInfo(callLogInfo, start)
+
+	return handler.GetRDBMS(irs.IID{NameId: rdbmsIID.NameId, SystemId: dbInstanceId})
+}
+
+// ChangeStorageSize expands the data storage(GB) of an NHN Cloud RDS instance (PUT /v3.0/db-instances/{id}/storage-info).
+// NHN Cloud RDS does not support shrinking the storage.
+func (handler *NhnCloudRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	cblogger.Info("NHN Cloud Driver: called ChangeStorageSize()")
+	callLogInfo := getCallLogScheme(handler.RegionInfo.Region, call.RDBMS, rdbmsIID.NameId, "PUT /v3.0/db-instances/{id}/storage-info")
+	start := call.Start()
+
+	storageSize, err := strconv.Atoi(newSize)
+	if err != nil {
+		newErr := fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		LoggingError(callLogInfo, newErr)
+		return false, newErr
+	}
+
+	if err := handler.checkRDSCredentials(); err != nil {
+		LoggingError(callLogInfo, err)
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	endpointFn, dbInstanceId, err := handler.resolveInstanceEndpoint(ctx, rdbmsIID)
+	if err != nil {
+		LoggingError(callLogInfo, err)
+		return false, err
+	}
+
+	var result nhnRDSJobResponse
+	body := map[string]interface{}{"storageSize": storageSize}
+	if err := handler.putRDSWithEndpoint(ctx, endpointFn, "/v3.0/db-instances/"+dbInstanceId+"/storage-info", body, &result); err != nil {
+		newErr := fmt.Errorf("failed to change the storage size of NHN Cloud RDS instance '%s': %w", dbInstanceId, err)
+		LoggingError(callLogInfo, newErr)
+		return false, newErr
+	}
+	if err := checkRDSResponseHeader(result.Header); err != nil {
+		LoggingError(callLogInfo, err)
+		return false, err
+	}
+	LoggingInfo(callLogInfo, start)
+
+	return true, nil
+}
+
 // ---- NHN native RDS API helper methods ────────────────────────────────────
 
 // postRDS sends a POST request to the NHN RDS for MySQL API.
@@ -1085,7 +1172,12 @@ func (handler *NhnCloudRDBMSHandler) fetchSubnetCidr(ctx context.Context, subnet
 
 // putRDS sends a PUT request to the NHN RDS for MySQL API.
 func (handler *NhnCloudRDBMSHandler) putRDS(ctx context.Context, path string, body interface{}, v interface{}) error {
-	endpoint, err := handler.rdsEndpoint()
+	return handler.putRDSWithEndpoint(ctx, handler.rdsEndpoint, path, body, v)
+}
+
+// putRDSWithEndpoint sends a PUT request to the NHN RDS API at the given endpoint.
+func (handler *NhnCloudRDBMSHandler) putRDSWithEndpoint(ctx context.Context, endpointFn func() (string, error), path string, body interface{}, v interface{}) error {
+	endpoint, err := endpointFn()
 	if err != nil {
 		return err
 	}
@@ -1100,7 +1192,7 @@ func (handler *NhnCloudRDBMSHandler) putRDS(ctx context.Context, path string, bo
 		return fmt.Errorf("failed to create NHN Cloud RDS PUT request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-TC-APP-KEY", handler.CredentialInfo.RDSMySQLAppKey)
+	req.Header.Set("X-TC-APP-KEY", handler.rdsEffectiveAppKey(endpoint))
 	req.Header.Set("X-TC-AUTHENTICATION-ID", handler.CredentialInfo.RDSUserAccessKey)
 	req.Header.Set("X-TC-AUTHENTICATION-SECRET", handler.CredentialInfo.RDSSecretAccessKey)
 
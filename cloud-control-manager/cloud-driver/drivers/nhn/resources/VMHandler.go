@@ -44,6 +44,7 @@ import (
 	//	images "github.com/cloud-barista/nhncloud-sdk-go/openstack/imageservice/v2/images" // imageservice/v2/images : For Visibility parameter
 
 	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+	cdcom "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
 	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 )
@@ -222,7 +223,7 @@ func (vmHandler *NhnCloudVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo
 		}
 		if isPublicWindowsImage {
 			var createErr error
-			initUserData, createErr = vmHandler.createWinInitUserData(vmReqInfo.VMUserPasswd)
+			initUserData, createErr = vmHandler.createWinInitUserData(vmReqInfo.VMUserPasswd, vmReqInfo.UserData)
 			if createErr != nil {
 				newErr := fmt.Errorf("Failed to Create Cloud-Init Script with the Password : [%v]", createErr)
 				cblogger.Error(newErr.Error())
@@ -231,7 +232,7 @@ func (vmHandler *NhnCloudVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo
 			}
 		} else {
 			var createErr error
-			initUserData, createErr = vmHandler.createLinuxInitUserData(vmReqInfo.ImageIID, keyPairId)
+			initUserData, createErr = vmHandler.createLinuxInitUserData(vmReqInfo.ImageIID, keyPairId, vmReqInfo.UserData)
 			if createErr != nil {
 				newErr := fmt.Errorf("Failed to Create Cloud-Init Script with the KeyPairId : [%v]", createErr)
 				cblogger.Error(newErr.Error())
@@ -268,7 +269,7 @@ func (vmHandler *NhnCloudVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo
 		}
 		if isMyWindowsImage {
 			var createErr error
-			initUserData, createErr = vmHandler.createWinInitUserData(vmReqInfo.VMUserPasswd)
+			initUserData, createErr = vmHandler.createWinInitUserData(vmReqInfo.VMUserPasswd, vmReqInfo.UserData)
 			if createErr != nil {
 				newErr := fmt.Errorf("Failed to Create Cloud-Init Script with the Password : [%v]", createErr)
 				cblogger.Error(newErr.Error())
@@ -277,7 +278,7 @@ func (vmHandler *NhnCloudVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo
 			}
 		} else {
 			var createErr error
-			initUserData, createErr = vmHandler.createLinuxInitUserData(vmReqInfo.ImageIID, keyPairId)
+			initUserData, createErr = vmHandler.createLinuxInitUserData(vmReqInfo.ImageIID, keyPairId, vmReqInfo.UserData)
 			if createErr != nil {
 				newErr := fmt.Errorf("Failed to Create Cloud-Init Script with the KeyPairId : [%v]", createErr)
 				cblogger.Error(newErr.Error())
@@ -752,6 +753,98 @@ func (vmHandler *NhnCloudVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, er
 	return irs.Terminating, nil
 }
 
+// ChangeVMSpec changes the instance type of the VM with the resize of the server.
+// NHN Cloud changes the instance type only of a stopped instance, so a running VM is stopped and started again.
+func (vmHandler *NhnCloudVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Info("NHN Cloud Driver: called ChangeVMSpec()")
+	callLogInfo := getCallLogScheme(vmHandler.RegionInfo.Region, call.VM, vmIID.SystemId, "ChangeVMSpec()")
+
+	vmSpecId, err := getVMSpecIdWithName(vmHandler.VMClient, vmSpecName)
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Get the VMSpec ID with the name [%s] : [%v]", vmSpecName, err)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	vm, vmStatus, err := vmHandler.getVMStatus(vmIID)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(callLogInfo, err)
+		return irs.VMInfo{}, err
+	}
+
+	wasRunning := false
+	switch vmStatus {
+	case irs.Running:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForServerStatus(vm.ID, "SHUTOFF"); err != nil {
+			LoggingError(callLogInfo, err)
+			return irs.VMInfo{}, err
+		}
+	case irs.Suspended:
+	default:
+		newErr := fmt.Errorf("The VM is %s, the spec can be changed only when it is Running or Suspended.", vmStatus)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		return irs.VMInfo{}, newErr
+	}
+
+	start := call.Start()
+	err = servers.Resize(vmHandler.VMClient, vm.ID, servers.ResizeOpts{FlavorRef: vmSpecId}).ExtractErr()
+	if err == nil {
+		err = vmHandler.waitForServerStatus(vm.ID, "VERIFY_RESIZE")
+	}
+	if err == nil {
+		err = servers.ConfirmResize(vmHandler.VMClient, vm.ID).ExtractErr()
+	}
+	if err == nil {
+		err = vmHandler.waitForServerStatus(vm.ID, "SHUTOFF")
+	}
+	if err != nil {
+		newErr := fmt.Errorf("Failed to Change the VMSpec of the VM!! : [%v] ", err)
+		cblogger.Error(newErr.Error())
+		LoggingError(callLogInfo, newErr)
+		if wasRunning {
+			// start the VM with the original instance type
+			vmHandler.ResumeVM(vmIID)
+		}
+		return irs.VMInfo{}, newErr
+	}
+	LoggingInfo(callLogInfo, start)
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForServerStatus(vm.ID, "ACTIVE"); err != nil {
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmIID)
+}
+
+// waitForServerStatus waits until the server is in the given status of NHN Cloud, ex) SHUTOFF, VERIFY_RESIZE, ACTIVE.
+func (vmHandler *NhnCloudVMHandler) waitForServerStatus(vmId string, status string) error {
+	maxRetryCnt := 120
+	for curRetryCnt := 0; curRetryCnt < maxRetryCnt; curRetryCnt++ {
+		nhnVM, err := vmHandler.getRawVM(irs.IID{SystemId: vmId})
+		if err != nil {
+			cblogger.Error(err)
+		} else if strings.EqualFold(nhnVM.Status, status) {
+			return nil
+		} else if strings.EqualFold(nhnVM.Status, "ERROR") {
+			return fmt.Errorf("The VM status is ERROR while waiting for [%s]", status)
+		}
+		time.Sleep(time.Second * 5)
+	}
+	return fmt.Errorf("Despite waiting for a long time(%d sec), the VM status is not %s, so it is forcibly finished.", maxRetryCnt*5, status)
+}
+
 func (vmHandler *NhnCloudVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	cblogger.Info("NHN Cloud Driver: called ListVMStatus()")
 	callLogInfo := getCallLogScheme(vmHandler.RegionInfo.Region, call.VM, "ListVMStatus()", "ListVMStatus()")
@@ -1365,7 +1458,7 @@ func (vmHandler *NhnCloudVMHandler) getOSPlatformWithImageID(imageId string) (ir
 	return irs.LINUX_UNIX, nil
 }
 
-func (vmHandler *NhnCloudVMHandler) createLinuxInitUserData(imageIID irs.IID, keyPairId string) (*string, error) {
+func (vmHandler *NhnCloudVMHandler) createLinuxInitUserData(imageIID irs.IID, keyPairId string, userData string) (*string, error) {
 	cblogger.Info("NHN Cloud driver: called createLinuxInitUserData()!!")
 
 	// Get KeyPair Info from NHN Cloud (to Get PublicKey info for cloud-init)
@@ -1390,15 +1483,26 @@ func (vmHandler *NhnCloudVMHandler) createLinuxInitUserData(imageIID irs.IID, ke
 	fileStr := string(fileData)
 	fileStr = strings.ReplaceAll(fileStr, "{{username}}", DefaultVMUserName)
 	fileStr = strings.ReplaceAll(fileStr, "{{public_key}}", keyPair.PublicKey)
+	fileStr, err = cdcom.MergeUserDataWithLimit("NHN", fileStr, userData) // UserData of the user runs after the cloud-init of the driver
+	if err != nil {
+		cblogger.Error(err.Error())
+		return nil, err
+	}
 	// cblogger.Info("\n# fileStr : ")
 	// spew.Dump(fileStr)
 
 	return &fileStr, nil
 }
 
-func (vmHandler *NhnCloudVMHandler) createWinInitUserData(passWord string) (*string, error) {
+func (vmHandler *NhnCloudVMHandler) createWinInitUserData(passWord string, userData string) (*string, error) {
 	cblogger.Info("NHN Cloud driver: called createWinInitUserData()!!")
 
+	if userData != "" {
+		newErr := fmt.Errorf("UserData is not supported for Windows images")
+		cblogger.Error(newErr.Error())
+		return nil, newErr
+	}
+
 	// Set cloud-init script
 	rootPath := os.Getenv("CBSPIDER_ROOT")
 	fileData, err := os.ReadFile(rootPath + WinCloudInitFilePath)
@@ -65,12 +65,15 @@ func (OpenStackDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 
 	drvCapabilityInfo.VPC_CIDR = false
 
+	drvCapabilityInfo.VM_USERDATA = true
+
 	drvCapabilityInfo.RDBMSHandler = true
 	drvCapabilityInfo.DBSpecHandler = true
 	drvCapabilityInfo.RDBMSMySQLHandler = true
 	drvCapabilityInfo.RDBMSMariaDBHandler = true
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false // implemented but not yet validated
 	drvCapabilityInfo.PublicIPHandler = true
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	return drvCapabilityInfo
 }
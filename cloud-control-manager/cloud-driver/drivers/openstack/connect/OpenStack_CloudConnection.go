@@ -258,3 +258,7 @@ func (cloudConn *OpenStackCloudConnection) CreatePublicIPHandler() (irs.PublicIP
 	}
 	return &handler, nil
 }
+
+func (cloudConn *OpenStackCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("OpenStack Driver: DiskSnapshotHandler not implemented yet")
+}
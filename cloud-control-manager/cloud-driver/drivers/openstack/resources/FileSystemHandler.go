@@ -906,3 +906,7 @@ func (filesystemHandler *OpenstackFileSystemHandler) GetBackup(fsIID irs.IID, ba
 func (filesystemHandler *OpenstackFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID string) (bool, error) {
 	return false, fmt.Errorf("backup deletion is not supported in OpenStack Manila")
 }
+
+func (filesystemHandler *OpenstackFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	return irs.FileSystemInfo{}, fmt.Errorf("backup restore is not supported in OpenStack Manila")
+}
@@ -85,6 +85,53 @@ func (keyPairHandler *OpenStackKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyP
 	return *keyPairInfo, nil
 }
 
+// ImportKey registers the user's public key with the PublicKey of keypairs.CreateOpts.
+func (keyPairHandler *OpenStackKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	// log HisCall
+	hiscallInfo := GetCallLogScheme(keyPairHandler.Client.IdentityEndpoint, call.VMKEYPAIR, keyIID.NameId, "ImportKey()")
+	start := call.Start()
+
+	// 0. Check keyIID
+	err := CheckKeyPairReqInfo(irs.KeyPairReqInfo{IId: keyIID})
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+
+	// 1. Check Exist
+	exist, err := CheckExistKey(keyPairHandler.Client, keyIID)
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	if exist {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = The Key name %s already exists", keyIID.NameId))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	// 2. Import KeyPair
+	create0pts := keypairs.CreateOpts{
+		Name:      keyIID.NameId,
+		PublicKey: publicKey,
+	}
+	keyPair, err := keypairs.Create(context.TODO(), keyPairHandler.Client, create0pts).Extract()
+	if err != nil {
+		importErr := errors.New(fmt.Sprintf("Failed to Import Key. err = %s", err.Error()))
+		cblogger.Error(importErr.Error())
+		LoggingError(hiscallInfo, importErr)
+		return irs.KeyPairInfo{}, importErr
+	}
+	LoggingInfo(hiscallInfo, start)
+	// 3. Set keyPairInfo
+	keyPairInfo := setterKeypair(*keyPair)
+	return *keyPairInfo, nil
+}
+
 func (keyPairHandler *OpenStackKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	hiscallInfo := GetCallLogScheme(keyPairHandler.Client.IdentityEndpoint, call.VMKEYPAIR, KeyPair, "ListKey()")
 	start := call.Start()
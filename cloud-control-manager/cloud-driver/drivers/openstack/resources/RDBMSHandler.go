@@ -86,7 +86,7 @@ func (handler *OpenStackRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMet
 		return irs.RDBMSMetaInfo{}, err
 	}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, false, true, true, false, false, "NA", false, false, true, true, false)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, false, true, true, false, false, "NA", false, false, true, true, false, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -583,6 +583,75 @@ func (handler *OpenStackRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error
 	return true, nil
 }
 
+// ChangeSpec resizes a Trove database instance to the flavor of newSpec.
+// Trove restarts the database to apply the new flavor.
+func (handler *OpenStackRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	hiscallInfo := GetCallLogScheme(handler.CredentialInfo.IdentityEndpoint, call.RDBMS, rdbmsIID.NameId, "instances.Resize()")
+	start := call.Start()
+
+	instanceID, err := handler.getInstanceID(rdbmsIID)
+	if err != nil {
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	flavorRef, err := handler.resolveFlavorRef(newSpec)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	result := instances.Resize(context.TODO(), handler.DBClient, instanceID, flavorRef)
+	if result.Err != nil {
+		cblogger.Error(result.Err)
+		LoggingError(hiscallInfo, result.Err)
+		return irs.RDBMSInfo{}, fmt.Errorf("failed to resize RDBMS instance '%s': %w", instanceID, result.Err)
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return handler.GetRDBMS(irs.IID{NameId: rdbmsIID.NameId, SystemId: instanceID})
+}
+
+// ChangeStorageSize expands the volume size(GB) of a Trove database instance.
+// Trove does not support shrinking the volume.
+func (handler *OpenStackRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	hiscallInfo := GetCallLogScheme(handler.CredentialInfo.IdentityEndpoint, call.RDBMS, rdbmsIID.NameId, "instances.ResizeVolume()")
+	start := call.Start()
+
+	size, err := strconv.Atoi(newSize)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	instanceID, err := handler.getInstanceID(rdbmsIID)
+	if err != nil {
+		LoggingError(hiscallInfo, err)
+		return false, err
+	}
+
+	result := instances.ResizeVolume(context.TODO(), handler.DBClient, instanceID, size)
+	if result.Err != nil {
+		cblogger.Error(result.Err)
+		LoggingError(hiscallInfo, result.Err)
+		return false, fmt.Errorf("failed to resize the volume of RDBMS instance '%s': %w", instanceID, result.Err)
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return true, nil
+}
+
+// getInstanceID returns the Trove instance ID of rdbmsIID, finding it by name if only NameId is given.
+func (handler *OpenStackRDBMSHandler) getInstanceID(rdbmsIID irs.IID) (string, error) {
+	if rdbmsIID.SystemId != "" {
+		return rdbmsIID.SystemId, nil
+	}
+	return handler.findInstanceIDByName(rdbmsIID.NameId)
+}
+
 // resolveFlavorRef resolves a VM spec name (e.g. "m1.small") or UUID to the
 // flavor UUID accepted by Trove's FlavorRef. In DevStack, Trove shares the
 // same Nova flavor catalog, so the IDs are identical to VM spec IDs.
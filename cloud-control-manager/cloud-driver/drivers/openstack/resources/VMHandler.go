@@ -190,6 +190,10 @@ func (vmHandler *OpenStackVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (startvm i
 		},
 		SecurityGroups: sgIdArr,
 	}
+	if vmReqInfo.UserData != "" {
+		// merged with the cloud-init of the driver for Linux, passed to cloudbase-init for Windows
+		serverCreateOpts.UserData = []byte(vmReqInfo.UserData)
+	}
 	if vmHandler.Region.TargetZone != "" {
 		serverCreateOpts.AvailabilityZone = vmHandler.Region.TargetZone
 	} else if vmHandler.Region.Zone != "" {
@@ -482,6 +486,52 @@ func (vmHandler *OpenStackVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, e
 	return irs.Terminated, nil
 }
 
+// ChangeVMSpec resizes the VM to the flavor of the spec.
+// Nova resizes a running or stopped server without stopping it, and the resize is confirmed in VERIFY_RESIZE status.
+func (vmHandler *OpenStackVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	// log HisCall
+	hiscallInfo := GetCallLogScheme(vmHandler.ComputeClient.IdentityEndpoint, call.VM, vmIID.NameId, "ChangeVMSpec()")
+	start := call.Start()
+
+	vmSpec, err := GetFlavorByName(vmHandler.ComputeClient, vmSpecName)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to Change VM Spec. err = failed to get vmspec, err : %s", err))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	server, err := vmHandler.getRawVM(vmIID)
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to Change VM Spec. err = %s", err))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Minute)
+	defer cancel()
+	err = servers.Resize(ctx, vmHandler.ComputeClient, server.ID, servers.ResizeOpts{FlavorRef: vmSpec.ID}).ExtractErr()
+	if err == nil {
+		err = servers.WaitForStatus(ctx, vmHandler.ComputeClient, server.ID, "VERIFY_RESIZE")
+	}
+	if err == nil {
+		err = servers.ConfirmResize(ctx, vmHandler.ComputeClient, server.ID).ExtractErr()
+	}
+	if err == nil {
+		// ACTIVE or SHUTOFF as before the resize
+		err = servers.WaitForStatus(ctx, vmHandler.ComputeClient, server.ID, server.Status)
+	}
+	if err != nil {
+		changeErr := errors.New(fmt.Sprintf("Failed to Change VM Spec. err = %s", err))
+		cblogger.Error(changeErr.Error())
+		LoggingError(hiscallInfo, changeErr)
+		return irs.VMInfo{}, changeErr
+	}
+	LoggingInfo(hiscallInfo, start)
+
+	return vmHandler.GetVM(vmIID)
+}
+
 func (vmHandler *OpenStackVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	// log HisCall
 	hiscallInfo := GetCallLogScheme(vmHandler.ComputeClient.IdentityEndpoint, call.VM, VM, "ListVMStatus()")
@@ -1258,7 +1308,11 @@ func linuxServerCreatOptConvertKeyPairWrapping(baseServerCreateOpt servers.Creat
 	fileStr = strings.ReplaceAll(fileStr, "{{username}}", SSHDefaultUser)
 	fileStr = strings.ReplaceAll(fileStr, "{{public_key}}", keyPair.PublicKey)
 
-	baseServerCreateOpt.UserData = []byte(fileStr)
+	userData, err := cdcom.MergeUserDataWithLimit("OPENSTACK", fileStr, string(baseServerCreateOpt.UserData))
+	if err != nil {
+		return keypairs.CreateOptsExt{}, err
+	}
+	baseServerCreateOpt.UserData = []byte(userData)
 	createOptsExt := keypairs.CreateOptsExt{
 		KeyName: keyPair.Name,
 	}
@@ -36,6 +36,7 @@ func (OracleDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 		KeyPairHandler:    true,
 		VMHandler:         true,
 		VPC_CIDR:          true,
+		VM_USERDATA:       true,
 		TagHandler:        true,
 		QuotaInfoHandler:  true,
 		DiskHandler:       true,
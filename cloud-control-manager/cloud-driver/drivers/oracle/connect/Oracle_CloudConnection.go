@@ -123,3 +123,7 @@ func (cloudConn *OracleConnection) CreateNICHandler() (irs.NICHandler, error) {
 func (cloudConn *OracleConnection) CreatePublicIPHandler() (irs.PublicIPHandler, error) {
 	return nil, errors.New("Oracle Driver: PublicIPHandler not implemented")
 }
+
+func (cloudConn *OracleConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("Oracle Driver: DiskSnapshotHandler not implemented yet")
+}
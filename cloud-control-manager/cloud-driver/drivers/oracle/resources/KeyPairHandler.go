@@ -32,6 +32,29 @@ func (handler *OracleKeyPairHandler) CreateKey(req irs.KeyPairReqInfo) (irs.KeyP
 	return irs.KeyPairInfo{IId: irs.IID{NameId: req.IId.NameId, SystemId: req.IId.NameId}, PrivateKey: string(privateKey), PublicKey: strings.TrimSpace(string(publicKey)), VMUserID: defaultVMUserID}, nil
 }
 
+// ImportKey saves the user's public key in the local key store like a generated KeyPair,
+// because OCI sets the public key to the metadata of an instance.
+func (handler *OracleKeyPairHandler) ImportKey(iid irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	if iid.NameId == "" {
+		return irs.KeyPairInfo{}, errors.New("invalid keypair name")
+	}
+	publicKey, fingerprint, err := keypair.ParsePublicKey(publicKey)
+	if err != nil {
+		return irs.KeyPairInfo{}, err
+	}
+	hash, err := handler.keyHash()
+	if err != nil {
+		return irs.KeyPairInfo{}, err
+	}
+	if _, err := keypair.GetKey(oracleProviderName, hash, iid.NameId); err == nil {
+		return irs.KeyPairInfo{}, errors.New("keypair " + iid.NameId + " already exists")
+	}
+	if err := keypair.ImportKey(oracleProviderName, hash, iid.NameId, publicKey); err != nil {
+		return irs.KeyPairInfo{}, err
+	}
+	return irs.KeyPairInfo{IId: irs.IID{NameId: iid.NameId, SystemId: iid.NameId}, Fingerprint: fingerprint, PublicKey: publicKey, VMUserID: defaultVMUserID}, nil
+}
+
 func (handler *OracleKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	hash, err := handler.keyHash()
 	if err != nil {
@@ -43,7 +66,7 @@ func (handler *OracleKeyPairHandler) ListKey() ([]*irs.KeyPairInfo, error) {
 	}
 	infos := make([]*irs.KeyPairInfo, 0, len(keys))
 	for _, key := range keys {
-		publicKey, _ := keypair.MakePublicKeyFromPrivateKey(key.Value)
+		publicKey := publicKeyOf(hash, key)
 		infos = append(infos, &irs.KeyPairInfo{IId: irs.IID{NameId: key.Key, SystemId: key.Key}, PrivateKey: key.Value, PublicKey: publicKey, VMUserID: defaultVMUserID})
 	}
 	return infos, nil
@@ -58,7 +81,7 @@ func (handler *OracleKeyPairHandler) GetKey(iid irs.IID) (irs.KeyPairInfo, error
 	if err != nil {
 		return irs.KeyPairInfo{}, err
 	}
-	publicKey, _ := keypair.MakePublicKeyFromPrivateKey(key.Value)
+	publicKey := publicKeyOf(hash, key)
 	return irs.KeyPairInfo{IId: irs.IID{NameId: key.Key, SystemId: key.Key}, PrivateKey: key.Value, PublicKey: publicKey, VMUserID: defaultVMUserID}, nil
 }
 
@@ -82,6 +105,19 @@ func (handler *OracleKeyPairHandler) ListIID() ([]*irs.IID, error) {
 	return iids, nil
 }
 
+// publicKeyOf returns the public key made from the private key, or the imported public key.
+func publicKeyOf(hash string, key *irs.KeyValue) string {
+	if key.Value != "" {
+		publicKey, _ := keypair.MakePublicKeyFromPrivateKey(key.Value)
+		return publicKey
+	}
+	imported, err := keypair.GetPublicKey(oracleProviderName, hash, key.Key)
+	if err != nil {
+		return ""
+	}
+	return imported.Value
+}
+
 func (handler *OracleKeyPairHandler) keyHash() (string, error) {
 	return keypair.GenHash([]string{handler.CredentialInfo.TenantId, handler.CredentialInfo.ClientId, handler.CredentialInfo.ProjectID, handler.Region.Region})
 }
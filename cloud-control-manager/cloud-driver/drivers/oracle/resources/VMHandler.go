@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	cdcom "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/common"
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
 	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	"github.com/oracle/oci-go-sdk/v65/common"
@@ -51,7 +52,7 @@ func (handler *OracleVMHandler) StartVM(req irs.VMReqInfo) (irs.VMInfo, error) {
 			nsgIDs = append(nsgIDs, sgIID.SystemId)
 		}
 	}
-	userData, err := cloudInitUserData(keyInfo.PublicKey)
+	userData, err := cloudInitUserData(keyInfo.PublicKey, req.UserData)
 	if err != nil {
 		return irs.VMInfo{}, err
 	}
@@ -167,6 +168,47 @@ func (handler *OracleVMHandler) TerminateVM(iid irs.IID) (irs.VMStatus, error) {
 	return irs.Terminating, nil
 }
 
+// ChangeVMSpec updates the shape of the instance. OCI reboots a running instance by itself.
+func (handler *OracleVMHandler) ChangeVMSpec(iid irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	instance, err := handler.getInstance(iid)
+	if err != nil {
+		return irs.VMInfo{}, err
+	}
+	state := instance.LifecycleState
+	if state != core.InstanceLifecycleStateRunning && state != core.InstanceLifecycleStateStopped {
+		return irs.VMInfo{}, fmt.Errorf("Oracle VM spec can be changed only when the instance is running or stopped: %s", state)
+	}
+	shape, err := handler.getShapeForImage(vmSpecName, stringValue(instance.ImageId))
+	if err != nil {
+		return irs.VMInfo{}, err
+	}
+	details := core.UpdateInstanceDetails{Shape: common.String(vmSpecName)}
+	shapeConfig, err := handler.launchShapeConfig(shape)
+	if err != nil {
+		return irs.VMInfo{}, err
+	}
+	if shapeConfig != nil {
+		details.ShapeConfig = &core.UpdateInstanceShapeConfigDetails{Ocpus: shapeConfig.Ocpus, MemoryInGBs: shapeConfig.MemoryInGBs}
+	}
+	_, err = handler.ComputeClient.UpdateInstance(handler.Ctx, core.UpdateInstanceRequest{InstanceId: instance.Id, UpdateInstanceDetails: details})
+	if err != nil {
+		return irs.VMInfo{}, statusErr("failed to change Oracle VM spec", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Minute)
+	for time.Now().Before(deadline) {
+		instance, err = handler.getInstance(irs.IID{SystemId: stringValue(instance.Id)})
+		if err != nil {
+			return irs.VMInfo{}, err
+		}
+		if stringValue(instance.Shape) == vmSpecName && instance.LifecycleState == state {
+			return handler.vmInfo(instance)
+		}
+		time.Sleep(10 * time.Second)
+	}
+	return irs.VMInfo{}, fmt.Errorf("timeout waiting for instance %s to change VM spec to %s", stringValue(instance.Id), vmSpecName)
+}
+
 func (handler *OracleVMHandler) ListVMStatus() ([]*irs.VMStatusInfo, error) {
 	instances, err := handler.listInstances()
 	if err != nil {
@@ -323,13 +365,17 @@ func (handler *OracleVMHandler) vmInfo(instance core.Instance) (irs.VMInfo, erro
 	return irs.VMInfo{IId: irs.IID{NameId: stringValue(instance.DisplayName), SystemId: stringValue(instance.Id)}, StartTime: timeValue(instance.TimeCreated), Region: irs.RegionInfo{Region: handler.Region.Region, Zone: stringValue(instance.AvailabilityDomain)}, ImageType: imageType, ImageIId: irs.IID{SystemId: imageID, NameId: imageID}, VMSpecName: stringValue(instance.Shape), VpcIID: irs.IID{SystemId: vcnID}, SubnetIID: irs.IID{SystemId: subnetID}, SecurityGroupIIds: nsgIIDs(vnic.NsgIds), KeyPairIId: irs.IID{NameId: keyPairName, SystemId: keyPairName}, RootDiskType: "default", RootDiskSize: "default", RootDeviceName: "boot", DataDiskIIDs: dataDisks, VMUserId: vmUserID, NICs: []irs.VMNICInfo{{IId: irs.IID{NameId: stringValue(vnic.Id), SystemId: stringValue(vnic.Id)}}}, PublicIP: stringValue(vnic.PublicIp), PrivateIP: stringValue(vnic.PrivateIp), Platform: platform, AccessPoint: accessPoint(stringValue(vnic.PublicIp)), TagList: tagList(instance.FreeformTags)}, nil
 }
 
-func cloudInitUserData(publicKey string) (string, error) {
+func cloudInitUserData(publicKey string, reqUserData string) (string, error) {
 	rootPath := os.Getenv("CBSPIDER_ROOT")
 	fileData, err := os.ReadFile(rootPath + oracleCloudInitPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read Oracle cloud-init template: %w", err)
 	}
 	userData := strings.ReplaceAll(string(fileData), cloudInitPublicKeyVar, strings.TrimSpace(publicKey))
+	userData, err = cdcom.MergeUserDataWithLimit("ORACLE", userData, reqUserData)
+	if err != nil {
+		return "", err
+	}
 	return base64.StdEncoding.EncodeToString([]byte(userData)), nil
 }
 
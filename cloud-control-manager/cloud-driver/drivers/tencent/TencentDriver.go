@@ -68,12 +68,15 @@ func (TencentDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 
 	drvCapabilityInfo.VPC_CIDR = true
 
+	drvCapabilityInfo.VM_USERDATA = true
+
 	drvCapabilityInfo.RDBMSHandler = true
 	drvCapabilityInfo.DBSpecHandler = true
 	drvCapabilityInfo.RDBMSMySQLHandler = true
 	drvCapabilityInfo.RDBMSMariaDBHandler = false    // not implemented: Tencent CDB API rejects mariadb
 	drvCapabilityInfo.RDBMSPostgreSQLHandler = false // not implemented
 	drvCapabilityInfo.PublicIPHandler = true
+	drvCapabilityInfo.DiskSnapshotHandler = false // not implemented yet
 
 	return drvCapabilityInfo
 }
@@ -219,3 +219,7 @@ func (cloudConn *TencentCloudConnection) CreatePublicIPHandler() (irs.PublicIPHa
 	handler := trs.TencentPublicIPHandler{Region: cloudConn.Region, VPCClient: cloudConn.VNetworkClient}
 	return &handler, nil
 }
+
+func (cloudConn *TencentCloudConnection) CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error) {
+	return nil, errors.New("Tencent Cloud Driver: DiskSnapshotHandler not implemented yet")
+}
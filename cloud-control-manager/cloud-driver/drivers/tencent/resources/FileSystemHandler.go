@@ -684,6 +684,14 @@ func (fsHandler *TencentFileSystemHandler) DeleteBackup(fsIID irs.IID, backupID
 	return false, errors.New("backups not supported by Tencent Cloud CFS")
 }
 
+// RestoreBackup creates a new file system from a backup
+func (fsHandler *TencentFileSystemHandler) RestoreBackup(fsIID irs.IID, backupIID irs.IID, newFsIID irs.IID) (irs.FileSystemInfo, error) {
+	cblogger.Info("Start RestoreBackup()")
+
+	// Tencent Cloud CFS doesn't support backups through API
+	return irs.FileSystemInfo{}, errors.New("backups not supported by Tencent Cloud CFS")
+}
+
 // Helper functions
 
 // describeCfsFileSystem retrieves file systems using pagination with optional filters
@@ -274,6 +274,53 @@ func (keyPairHandler *TencentKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPai
 	return keyPairInfo, nil
 }
 
+// ImportKey registers the user's public key as a Tencent KeyPair.
+func (keyPairHandler *TencentKeyPairHandler) ImportKey(keyIID irs.IID, publicKey string) (irs.KeyPairInfo, error) {
+	cblogger.Info(keyIID)
+
+	isExist, errExist := keyPairHandler.isExist(keyIID.NameId)
+	if errExist != nil {
+		cblogger.Error(errExist)
+		return irs.KeyPairInfo{}, errExist
+	}
+	if isExist {
+		return irs.KeyPairInfo{}, errors.New("A keyPair with the name " + keyIID.NameId + " already exists.")
+	}
+
+	// logger for HisCall
+	callogger := call.GetLogger("HISCALL")
+	callLogInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.TENCENT,
+		RegionZone:   keyPairHandler.Region.Zone,
+		ResourceType: call.VMKEYPAIR,
+		ResourceName: keyIID.NameId,
+		CloudOSAPI:   "ImportKeyPair()",
+		ElapsedTime:  "",
+		ErrorMSG:     "",
+	}
+
+	request := cvm.NewImportKeyPairRequest()
+	request.KeyName = common.StringPtr(keyIID.NameId)
+	request.ProjectId = common.Int64Ptr(0)
+	request.PublicKey = common.StringPtr(publicKey)
+
+	callLogStart := call.Start()
+	response, err := keyPairHandler.Client.ImportKeyPair(request)
+	callLogInfo.ElapsedTime = call.Elapsed(callLogStart)
+
+	if err != nil {
+		callLogInfo.ErrorMSG = err.Error()
+		callogger.Error(call.String(callLogInfo))
+
+		cblogger.Error(err)
+		return irs.KeyPairInfo{}, err
+	}
+	cblogger.Debug(response.ToJsonString())
+	callogger.Info(call.String(callLogInfo))
+
+	return keyPairHandler.GetKey(irs.IID{NameId: keyIID.NameId, SystemId: *response.Response.KeyId})
+}
+
 // cb-spider 정책상 이름 기반으로 중복 생성을 막아야 함.
 func (keyPairHandler *TencentKeyPairHandler) isExist(chkName string) (bool, error) {
 	cblogger.Debugf("chkName : %s", chkName)
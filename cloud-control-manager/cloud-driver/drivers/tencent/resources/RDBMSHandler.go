@@ -76,7 +76,7 @@ func (handler *TencentRDBMSHandler) GetMetaInfo(dbEngine string) (irs.RDBMSMetaI
 		return irs.RDBMSMetaInfo{}, fmt.Errorf("GetMetaInfo failed: %w", err)
 	}
 
-	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, false, true, "7-1830", true, false, true, true, true)
+	metaInfo, err := irs.BuildRDBMSMetaInfo(requestedEngine, supportedEngines, instanceSpecOptions, storageTypeOptions, storageSizeRange, true, true, true, false, true, "7-1830", true, false, true, true, true, true, true)
 	if err != nil {
 		return irs.RDBMSMetaInfo{}, err
 	}
@@ -816,6 +816,95 @@ func (handler *TencentRDBMSHandler) DeleteRDBMS(rdbmsIID irs.IID) (bool, error)
 	return false, fmt.Errorf("instance isolated but permanent delete (OfflineIsolatedInstances) failed after %d attempts: %w", maxOfflineAttempts, offlineErr)
 }
 
+// ChangeSpec changes the memory of a CDB instance with UpgradeDBInstance, keeping the current volume.
+// newSpec is the memory in MB or a Tencent VM spec, as in CreateRDBMS.
+func (handler *TencentRDBMSHandler) ChangeSpec(rdbmsIID irs.IID, newSpec string) (irs.RDBMSInfo, error) {
+	memory, err := handler.resolveMemoryMBFromSpec(newSpec)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+
+	inst, err := handler.describeCDBInstance(rdbmsIID.SystemId)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.RDBMSInfo{}, err
+	}
+	if inst.Volume == nil {
+		return irs.RDBMSInfo{}, fmt.Errorf("DB instance %s has no volume information", rdbmsIID.SystemId)
+	}
+
+	err = handler.upgradeDBInstance(rdbmsIID, memory, *inst.Volume)
+	if err != nil {
+		return irs.RDBMSInfo{}, err
+	}
+	return handler.GetRDBMS(rdbmsIID)
+}
+
+// ChangeStorageSize expands the volume(GB) of a CDB instance with UpgradeDBInstance, keeping the current memory.
+// Tencent CDB does not support shrinking the volume.
+func (handler *TencentRDBMSHandler) ChangeStorageSize(rdbmsIID irs.IID, newSize string) (bool, error) {
+	volume, err := strconv.ParseInt(newSize, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid storage size '%s': %w", newSize, err)
+		cblogger.Error(err)
+		return false, err
+	}
+
+	inst, err := handler.describeCDBInstance(rdbmsIID.SystemId)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+	if inst.Memory == nil {
+		return false, fmt.Errorf("DB instance %s has no memory information", rdbmsIID.SystemId)
+	}
+
+	err = handler.upgradeDBInstance(rdbmsIID, *inst.Memory, volume)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// upgradeDBInstance sets the memory(MB) and volume(GB) of a CDB instance.
+// The instance switches to the new configuration as soon as the upgrade is done.
+func (handler *TencentRDBMSHandler) upgradeDBInstance(rdbmsIID irs.IID, memory int64, volume int64) error {
+	hiscallInfo := GetCallLogScheme(handler.Region, call.RDBMS, rdbmsIID.NameId, "UpgradeDBInstance()")
+	start := call.Start()
+
+	request := cdb.NewUpgradeDBInstanceRequest()
+	request.InstanceId = &rdbmsIID.SystemId
+	request.Memory = &memory
+	request.Volume = &volume
+	request.WaitSwitch = common.Int64Ptr(0)
+
+	_, err := handler.Client.UpgradeDBInstance(request)
+	hiscallInfo.ElapsedTime = call.Elapsed(start)
+	if err != nil {
+		cblogger.Error(err)
+		LoggingError(hiscallInfo, err)
+		return err
+	}
+	calllogger.Info(call.String(hiscallInfo))
+	return nil
+}
+
+// describeCDBInstance returns the CDB instance info of instanceId.
+func (handler *TencentRDBMSHandler) describeCDBInstance(instanceId string) (*cdb.InstanceInfo, error) {
+	request := cdb.NewDescribeDBInstancesRequest()
+	request.InstanceIds = []*string{&instanceId}
+
+	response, err := handler.Client.DescribeDBInstances(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.Response == nil || len(response.Response.Items) == 0 {
+		return nil, fmt.Errorf("DB instance not found: %s", instanceId)
+	}
+	return response.Response.Items[0], nil
+}
+
 // ===== Helper Functions =====
 
 func (handler *TencentRDBMSHandler) convertToRDBMSInfo(inst *cdb.InstanceInfo) irs.RDBMSInfo {
@@ -373,6 +373,11 @@ func (vmHandler *TencentVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo,
 	userData := string(fileDataCloudInit)
 	//userData = strings.ReplaceAll(userData, "{{username}}", CBDefaultVmUserName)
 	//userData = strings.ReplaceAll(userData, "{{public_key}}", keyPairInfo.PublicKey)
+	userData, err = cdcom.MergeUserDataWithLimit("TENCENT", userData, vmReqInfo.UserData)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
 	userDataBase64 := base64.StdEncoding.EncodeToString([]byte(userData))
 	cblogger.Debugf("cloud-init data : [%s]", userDataBase64)
 	request.UserData = common.StringPtr(userDataBase64)
@@ -624,6 +629,105 @@ func (vmHandler *TencentVMHandler) TerminateVM(vmIID irs.IID) (irs.VMStatus, err
 	return irs.VMStatus("Terminating"), nil
 }
 
+// ChangeVMSpec changes the instance type of the VM.
+// CVM changes the instance type only of a stopped instance, so a running VM is stopped and started again.
+func (vmHandler *TencentVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger.Infof("vmNameId : [%s], vmSpecName : [%s]", vmIID.SystemId, vmSpecName)
+
+	status, err := vmHandler.GetVMStatus(vmIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	wasRunning := false
+	switch status {
+	case irs.Running:
+		wasRunning = true
+		if _, err := vmHandler.SuspendVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if err := vmHandler.waitForChange(vmIID, irs.Suspended, nil); err != nil {
+			return irs.VMInfo{}, err
+		}
+	case irs.Suspended:
+	default:
+		err := errors.New("VM " + vmIID.SystemId + " is " + string(status) + ", the spec can be changed only when it is running or suspended")
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	callogger := call.GetLogger("HISCALL")
+	callLogInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.TENCENT,
+		RegionZone:   vmHandler.Region.Zone,
+		ResourceType: call.VM,
+		ResourceName: vmIID.SystemId,
+		CloudOSAPI:   "ResetInstancesType()",
+		ElapsedTime:  "",
+		ErrorMSG:     "",
+	}
+
+	request := cvm.NewResetInstancesTypeRequest()
+	request.InstanceIds = common.StringPtrs([]string{vmIID.SystemId})
+	request.InstanceType = common.StringPtr(vmSpecName)
+
+	callLogStart := call.Start()
+	response, err := vmHandler.Client.ResetInstancesType(request)
+	callLogInfo.ElapsedTime = call.Elapsed(callLogStart)
+	if err != nil {
+		callLogInfo.ErrorMSG = err.Error()
+		callogger.Error(call.String(callLogInfo))
+		cblogger.Error(err)
+		if wasRunning {
+			// start the VM with the original instance type
+			vmHandler.ResumeVM(vmIID)
+		}
+		return irs.VMInfo{}, err
+	}
+	callogger.Info(call.String(callLogInfo))
+	cblogger.Debug(response.ToJsonString())
+
+	// the instance type is changed asynchronously
+	if err := vmHandler.waitForChange(vmIID, irs.Suspended, func(vmInfo irs.VMInfo) bool {
+		return vmInfo.VMSpecName == vmSpecName
+	}); err != nil {
+		return irs.VMInfo{}, err
+	}
+
+	if wasRunning {
+		if _, err := vmHandler.ResumeVM(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+		if _, err := vmHandler.WaitForRun(vmIID); err != nil {
+			return irs.VMInfo{}, err
+		}
+	}
+
+	return vmHandler.GetVM(vmIID)
+}
+
+// waitForChange waits until the VM is in the waitStatus and done(vmInfo) is true, if done is not nil.
+func (vmHandler *TencentVMHandler) waitForChange(vmIID irs.IID, waitStatus irs.VMStatus, done func(vmInfo irs.VMInfo) bool) error {
+	maxRetryCnt := 300
+	for curRetryCnt := 0; curRetryCnt < maxRetryCnt; curRetryCnt++ {
+		curStatus, err := vmHandler.GetVMStatus(vmIID)
+		if err != nil {
+			cblogger.Error(err.Error())
+		}
+		if curStatus == waitStatus {
+			if done == nil {
+				return nil
+			}
+			if vmInfo, err := vmHandler.GetVM(vmIID); err == nil && done(vmInfo) {
+				return nil
+			}
+		}
+		time.Sleep(time.Second * 1)
+	}
+	return errors.New("You waited a long time, but the status of the VM did not change to [" + string(waitStatus) + "] and it is interrupted.")
+}
+
 func (vmHandler *TencentVMHandler) GetVM(vmIID irs.IID) (irs.VMInfo, error) {
 	cblogger.Infof("vmNameId : [%s]", vmIID.SystemId)
 
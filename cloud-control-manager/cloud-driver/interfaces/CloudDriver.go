@@ -43,6 +43,7 @@ type DriverCapabilityInfo struct {
 	RDBMSPostgreSQLHandler bool // support: true, do not support: false
 	PublicIPHandler        bool // support: true, do not support: false
 	NICHandler             bool // support: true, do not support: false
+	DiskSnapshotHandler    bool // support: true, do not support: false
 
 	TagHandler bool // support: true, do not support: false
 	// ex) {ires.VPC, ires.SUBNET, ires.SG, ires.KEY, ires.VM, ires.NLB, ires.DISK, ires.MYIMAGE, ires.CLUSTER}
@@ -52,6 +53,8 @@ type DriverCapabilityInfo struct {
 	VPC_CIDR     bool // support: true, do not support: false
 	EMULATED_VPC bool // support: true, do not support: false
 	SINGLE_VPC   bool // support: true, do not support: false
+	VM_USERDATA  bool // support: true, do not support: false, UserData of VMReqInfo
+	SPOT_VM      bool // support: true, do not support: false, Spot PurchaseOption of VMReqInfo
 
 	// reserved for future use
 	// VNicHandler     bool // support: true, do not support: false
@@ -77,6 +80,7 @@ type CredentialInfo struct {
 	Host             string // Docker
 	APIVersion       string // Docker
 	MockName         string // Mock
+	MockFault        string // Mock, fault injection rules
 	ApiKey           string // Ibm
 	ConnectionName   string // MINI
 	ClusterId        string // Cloudit
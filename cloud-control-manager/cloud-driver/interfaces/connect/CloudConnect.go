@@ -49,6 +49,8 @@ type CloudConnection interface {
 
 	CreateNICHandler() (irs.NICHandler, error)
 
+	CreateDiskSnapshotHandler() (irs.DiskSnapshotHandler, error)
+
 	IsConnected() (bool, error)
 	Close() error
 }
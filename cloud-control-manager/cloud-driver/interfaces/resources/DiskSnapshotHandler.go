@@ -0,0 +1,54 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2026.10.
+
+package resources
+
+import "time"
+
+// -------- Const
+type DiskSnapshotStatus string
+
+const (
+	DiskSnapshotCreating  DiskSnapshotStatus = "Creating"
+	DiskSnapshotAvailable DiskSnapshotStatus = "Available"
+	DiskSnapshotDeleting  DiskSnapshotStatus = "Deleting"
+	DiskSnapshotError     DiskSnapshotStatus = "Error"
+)
+
+// -------- Info Structure
+// DiskSnapshotInfo represents the information of a snapshot of a single Disk.
+type DiskSnapshotInfo struct {
+	IId        IID `json:"IId" validate:"required"`        // {NameId, SystemId}
+	SourceDisk IID `json:"SourceDisk" validate:"required"` // The Disk of the snapshot
+
+	DiskSize string `json:"DiskSize" validate:"required" example:"100"` // Size of the source Disk (unit is GB)
+
+	Status DiskSnapshotStatus `json:"Status" validate:"required" example:"Available"` // Creating | Available | Deleting | Error
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"required"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// -------- DiskSnapshot API
+type DiskSnapshotHandler interface {
+
+	//------ DiskSnapshot Management
+	ListIID() ([]*IID, error)
+	CreateSnapshot(snapshotReqInfo DiskSnapshotInfo) (DiskSnapshotInfo, error)
+	ListSnapshot() ([]*DiskSnapshotInfo, error)
+	GetSnapshot(snapshotIID IID) (DiskSnapshotInfo, error)
+	DeleteSnapshot(snapshotIID IID) (bool, error)
+
+	//------ Restore to a new Disk
+	// CreateDiskFromSnapshot creates a new Disk with the data of the snapshot.
+	// DiskType and DiskSize of the diskReqInfo can be "default", and DiskSize can not be smaller than the snapshot.
+	CreateDiskFromSnapshot(snapshotIID IID, diskReqInfo DiskInfo) (DiskInfo, error)
+}
@@ -98,6 +98,7 @@ type FileSystemBackupInfo struct {
 
 	// for response only, not for request
 	BackupID     string     `json:"BackupID" validate:"required"`
+	BackupName   string     `json:"BackupName,omitempty"` // The user's backup name, set by CB-Spider
 	CreationTime time.Time  `json:"CreationTime"`
 	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
 }
@@ -135,7 +136,5 @@ type FileSystemHandler interface {
 	ListBackup(fsIID IID) ([]FileSystemBackupInfo, error)
 	GetBackup(fsIID IID, backupID string) (FileSystemBackupInfo, error)
 	DeleteBackup(fsIID IID, backupID string) (bool, error)
-
-	// TBD
-	// RestoreBackup(fsIID IID, backupIID IID, newFsIID IID) (FileSystemInfo, error)
+	RestoreBackup(fsIID IID, backupIID IID, newFsIID IID) (FileSystemInfo, error) // Create a new file system(newFsIID.NameId) from the backup; backupIID.SystemId is the BackupID
 }
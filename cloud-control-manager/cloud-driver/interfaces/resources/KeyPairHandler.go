@@ -31,6 +31,9 @@ type KeyPairInfo struct {
 
 type KeyPairHandler interface {
 	CreateKey(keyPairReqInfo KeyPairReqInfo) (KeyPairInfo, error)
+	// ImportKey registers a user's public key(OpenSSH format) as a KeyPair.
+	// The PrivateKey of the imported KeyPair is not known, so it is always empty.
+	ImportKey(keyIID IID, publicKey string) (KeyPairInfo, error)
 	ListKey() ([]*KeyPairInfo, error)
 	GetKey(keyIID IID) (KeyPairInfo, error)
 	DeleteKey(keyIID IID) (bool, error)
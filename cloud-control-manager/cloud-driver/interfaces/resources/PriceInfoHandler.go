@@ -43,8 +43,9 @@ type ProductInfo struct {
 
 // PriceInfo represents the pricing details for a product.
 type PriceInfo struct {
-	OnDemand     OnDemand    `json:"OnDemand" validate:"required" description:"Ondemand pricing details"`  // Ondemand pricing details
-	CSPPriceInfo interface{} `json:"CSPPriceInfo" validate:"required" description:"Additional price info"` // Additional price information specific to CSP
+	OnDemand     OnDemand    `json:"OnDemand" validate:"required" description:"Ondemand pricing details"`                         // Ondemand pricing details
+	Spot         *Spot       `json:"Spot,omitempty" validate:"omitempty" description:"Spot pricing details, if published by CSP"` // Spot pricing details
+	CSPPriceInfo interface{} `json:"CSPPriceInfo" validate:"required" description:"Additional price info"`                        // Additional price information specific to CSP
 }
 
 // OnDemand represents the OnDemand pricing details.
@@ -56,6 +57,15 @@ type OnDemand struct {
 	Description string `json:"Description,omitempty" example:"Pricing for t2.micro"` // Description of the pricing policy
 }
 
+// Spot represents the current Spot pricing details, which change with the spare capacity of CSP.
+type Spot struct {
+	PricingId   string `json:"PricingId" validate:"required" example:"spot-t2.micro"`           // ID of the pricing policy
+	Unit        string `json:"Unit" validate:"required" example:"Hour"`                         // Unit of the pricing (e.g., per hour)
+	Currency    string `json:"Currency" validate:"required" example:"USD"`                      // Currency of the pricing
+	Price       string `json:"Price" validate:"required" example:"0.0035"`                      // Price in the specified currency per unit
+	Description string `json:"Description,omitempty" example:"Lowest Spot price in us-east-1a"` // Description of the pricing policy
+}
+
 type PriceInfoHandler interface {
 	ListProductFamily(regionName string) ([]string, error)
 	GetPriceInfo(productFamily string, regionName string, filterList []KeyValue, simpleVMSpecInfo bool) (string, error) // return string: json format
@@ -67,6 +67,9 @@ type RDBMSMetaInfo struct {
 
 	SupportsTag bool `json:"SupportsTag"` // true if tagging is supported for RDBMS resources on this CSP
 
+	SupportsSpecChange        bool `json:"SupportsSpecChange"`        // true if the DBSpec of a running RDBMS can be changed(ChangeSpec)
+	SupportsStorageSizeChange bool `json:"SupportsStorageSizeChange"` // true if the StorageSize of a running RDBMS can be expanded(ChangeStorageSize)
+
 	// DataSource records, per field name (e.g. "StorageTypeOptions", "StorageSizeRangeGB",
 	// or "StorageSizeRangeGB.Min"/"StorageSizeRangeGB.Max" for a partially-static range),
 	// whether that field's value above was obtained live from the CSP API ("API") or is
@@ -105,7 +108,7 @@ func NormalizeRDBMSEngine(dbEngine string) (string, error) {
 	}
 }
 
-func BuildRDBMSMetaInfo(dbEngine string, supportedEngines map[string][]string, dbSpecOptions map[string][]string, storageTypeOptions map[string][]string, storageSizeRange StorageSizeRange, supportsHighAvailability, supportsBackup, supportsPublicAccess, supportsDeletionProtection, supportsEncryption bool, backupRetentionRange string, requiresSubnet, requiresSecurityGroup, supportsStorageTypeSelection, supportsStorageSizeConfiguration bool, supportsTag bool) (RDBMSMetaInfo, error) {
+func BuildRDBMSMetaInfo(dbEngine string, supportedEngines map[string][]string, dbSpecOptions map[string][]string, storageTypeOptions map[string][]string, storageSizeRange StorageSizeRange, supportsHighAvailability, supportsBackup, supportsPublicAccess, supportsDeletionProtection, supportsEncryption bool, backupRetentionRange string, requiresSubnet, requiresSecurityGroup, supportsStorageTypeSelection, supportsStorageSizeConfiguration bool, supportsTag bool, supportsSpecChange, supportsStorageSizeChange bool) (RDBMSMetaInfo, error) {
 	normalizedEngine, err := NormalizeRDBMSEngine(dbEngine)
 	if err != nil {
 		return RDBMSMetaInfo{}, err
@@ -135,6 +138,8 @@ func BuildRDBMSMetaInfo(dbEngine string, supportedEngines map[string][]string, d
 		RequiresSubnet:                   requiresSubnet,
 		RequiresSecurityGroup:            requiresSecurityGroup,
 		SupportsTag:                      supportsTag,
+		SupportsSpecChange:               supportsSpecChange,
+		SupportsStorageSizeChange:        supportsStorageSizeChange,
 	}, nil
 }
 
@@ -247,7 +252,8 @@ type RDBMSHandler interface {
 	GetRDBMS(rdbmsIID IID) (RDBMSInfo, error)
 	DeleteRDBMS(rdbmsIID IID) (bool, error)
 
-	//------ Instance Control (TBD: support will be decided later)
-	// ChangeSpec(rdbmsIID IID, newSpec string) (RDBMSInfo, error)        // Change instance class/spec
-	// ChangeStorageSize(rdbmsIID IID, newSize string) (bool, error)      // Expand storage
+	//------ Instance Control
+	// The change is applied asynchronously by the CSP; the RDBMS returns to Available status when it is done.
+	ChangeSpec(rdbmsIID IID, newSpec string) (RDBMSInfo, error)   // Change instance class/spec
+	ChangeStorageSize(rdbmsIID IID, newSize string) (bool, error) // Expand storage, newSize in GB
 }
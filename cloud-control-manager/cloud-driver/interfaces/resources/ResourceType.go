@@ -37,6 +37,8 @@ const (
 	RDBMS    RSType = "rdbms"
 	PUBLICIP RSType = "publicip"
 	NIC      RSType = "nic"
+
+	DISKSNAPSHOT RSType = "disksnapshot"
 )
 
 func RSTypeString(rsType RSType) string {
@@ -73,6 +75,8 @@ func RSTypeString(rsType RSType) string {
 		return "Public IP"
 	case NIC:
 		return "Network Interface Card"
+	case DISKSNAPSHOT:
+		return "Disk Snapshot"
 	default:
 		return string(rsType) + " is not supported Resource!!"
 
@@ -116,6 +120,8 @@ func StringToRSType(str string) (RSType, error) {
 		return PUBLICIP, nil
 	case "nic":
 		return NIC, nil
+	case "disksnapshot":
+		return DISKSNAPSHOT, nil
 	default:
 		return "", fmt.Errorf("%s is not a valid resource type", str)
 	}
@@ -41,6 +41,26 @@ const (
 	WINDOWS    Platform = "WINDOWS"
 )
 
+type VMLifecycleType string
+
+const (
+	OnDemandVM VMLifecycleType = "OnDemand"
+	SpotVM     VMLifecycleType = "Spot" // spare capacity, can be interrupted by the CSP
+)
+
+type SpotInterruptionBehavior string
+
+const (
+	SpotTerminate SpotInterruptionBehavior = "Terminate"
+	SpotStop      SpotInterruptionBehavior = "Stop"
+)
+
+type VMPurchaseOption struct {
+	LifecycleType        VMLifecycleType          // OnDemand | Spot, default: OnDemand
+	MaxPrice             string                   // Spot only, max price per hour in the currency of CSP, ex) "0.05", "": up to the OnDemand price
+	InterruptionBehavior SpotInterruptionBehavior // Spot only, Terminate | Stop, default: Terminate
+}
+
 type VMReqInfo struct {
 	IId IID // {NameId, SystemId}
 
@@ -62,6 +82,11 @@ type VMReqInfo struct {
 	VMUserPasswd string
 	WindowsType  bool
 
+	UserData         string // run at the first boot, ex) "#cloud-config ..." or "#!/bin/bash ...", "": none
+	UserDataEncoding string // "", "plain": UserData is plain text, "base64": UserData is decoded by Spider, drivers get plain text
+
+	PurchaseOption VMPurchaseOption
+
 	TagList []KeyValue
 }
 
@@ -138,6 +163,8 @@ type VMInfo struct {
 
 	Platform Platform `json:"Platform" validate:"required" example:"LINUX"` // LINUX | WINDOWS
 
+	LifecycleType VMLifecycleType `json:"LifecycleType,omitempty" validate:"omitempty" example:"OnDemand"` // OnDemand | Spot, "": not reported by the driver
+
 	SSHAccessPoint string `json:"SSHAccessPoint,omitempty" validate:"omitempty" example:"10.2.3.2:22"` // Deprecated
 	AccessPoint    string `json:"AccessPoint" validate:"required" example:"1.2.3.4:22"`                // 10.2.3.2:22, 123.456.789.123:432
 
@@ -155,6 +182,10 @@ type VMHandler interface {
 	RebootVM(vmIID IID) (VMStatus, error)
 	TerminateVM(vmIID IID) (VMStatus, error)
 
+	// ChangeVMSpec changes the VMSpec of the VM, stopping and starting the VM if the CSP requires it.
+	// The VM is returned in its original status, Running or Suspended.
+	ChangeVMSpec(vmIID IID, vmSpecName string) (VMInfo, error)
+
 	ListVMStatus() ([]*VMStatusInfo, error)
 	GetVMStatus(vmIID IID) (VMStatus, error)
 
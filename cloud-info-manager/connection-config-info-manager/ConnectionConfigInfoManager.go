@@ -42,7 +42,7 @@ func init() {
 	if err != nil {
 		panic("Failed to Connect to Database")
 	}
-	db.AutoMigrate(&ConnectionConfigInfo{})
+	infostore.AutoMigrate(db, &ConnectionConfigInfo{})
 	infostore.Close(db)
 }
 
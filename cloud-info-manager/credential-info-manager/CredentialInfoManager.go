@@ -49,7 +49,8 @@ func init() {
 	if err != nil {
 		panic("failed to connect database")
 	}
-	db.AutoMigrate(&CredentialInfo{})
+	infostore.AutoMigrate(db, &CredentialInfo{})
+	infostore.AutoMigrateSecurity(db, &EncryptionKeyVersionInfo{})
 	infostore.Close(db)
 }
 
@@ -254,6 +255,44 @@ func UnRegisterCredential(credentialName string) (bool, error) {
 	return result, nil
 }
 
+// RotateCredentialEncryption re-encrypts all credentials with the active key.
+// It returns the number of the re-encrypted credentials.
+func RotateCredentialEncryption() (int, error) {
+	cblog.Info("call RotateCredentialEncryption()")
+
+	var credentialInfoList []*CredentialInfo
+	if err := infostore.List(&credentialInfoList); err != nil {
+		cblog.Error(err)
+		return 0, err
+	}
+
+	count := 0
+	for _, info := range credentialInfoList {
+		changed := false
+		for i, kv := range info.KeyValueInfoList {
+			encValue, reEncrypted, err := ReEncryptValue(kv.Value)
+			if err != nil {
+				err = fmt.Errorf("failed to re-encrypt Credential '%s': %v", info.CredentialName, err)
+				cblog.Error(err)
+				return count, err
+			}
+			if reEncrypted {
+				info.KeyValueInfoList[i].Value = encValue
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := infostore.Insert(info); err != nil {
+			cblog.Error(err)
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
 //----------------
 
 func checkParams(credentialName string, providerName string, keyValueInfoList []icdrs.KeyValue) error {
@@ -285,14 +324,14 @@ func checkParams(credentialName string, providerName string, keyValueInfoList []
 }
 
 // #######################################################################
-// @todo get from Env file. by powerkim, 2020.06.01.
+// built-in key of the key version "0", see KeyManager.go for the configured keys.
 var SPIDER_KEY = []byte("cloud-barista-cb-spider-cloud-ba") // 32 bytes
 //#######################################################################
 
 func encryptKeyValueList(keyValueInfoList []icdrs.KeyValue) error {
 
 	for i, kv := range keyValueInfoList {
-		encString, err := Encrypt(SPIDER_KEY, []byte(kv.Value))
+		encString, err := EncryptValue(kv.Value)
 		if err != nil {
 			return err
 		}
@@ -305,7 +344,7 @@ func encryptKeyValueList(keyValueInfoList []icdrs.KeyValue) error {
 func decryptKeyValueList(keyValueInfoList []icdrs.KeyValue) error {
 
 	for i, kv := range keyValueInfoList {
-		decString, err := Decrypt(SPIDER_KEY, []byte(kv.Value))
+		decString, err := DecryptValue(kv.Value)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,908 @@
+// Cloud Credential Info. Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// Key Manager — versioned encryption keys of the stored secrets.
+// A key provider gives the active key and the old keys by version:
+//   - env:  SPIDER_KEY(active), SPIDER_KEY_VERSION, SPIDER_KEY_PREVIOUS("version:key,...")
+//   - file: a local key ring file, SPIDER_KEY_FILE(default: $CBSPIDER_ROOT/conf/spider-keyring.json)
+//   - other providers registered with RegisterKeyProvider(), selected by SPIDER_KEY_PROVIDER
+// Without any provider, the built-in SPIDER_KEY is used as the key version "0".
+//
+// The key versions are registered in the MetaDB(without the keys) and the active version is
+// decided there, so that the Spider servers sharing a MetaDB encrypt with the same key version.
+//
+// by CB-Spider Team, 2026.10.
+
+package credentialinfomanager
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+	"gorm.io/gorm"
+)
+
+const (
+	LEGACY_KEY_VERSION   = "0"     // version of the built-in SPIDER_KEY
+	encryptedValuePrefix = "spk:"  // "spk:<version>:<base64>", a value without the prefix is of the version "0"
+	encryptedDataPrefix  = "spkd:" // "spkd:<version>:<nonce prefix><chunks>", see NewEncryptReader
+	keySize              = 32      // AES-256
+)
+
+// KeyProvider gives the encryption keys by version.
+type KeyProvider interface {
+	// Name returns the name of the provider, ex) "env", "file".
+	Name() string
+	// ActiveKey returns the version and the key used to encrypt new values.
+	ActiveKey() (string, []byte, error)
+	// Key returns the key of the version to decrypt values.
+	Key(version string) ([]byte, error)
+	// Versions returns all key versions of the provider.
+	Versions() []string
+}
+
+// KeyRotator is a KeyProvider which can create a new key version by itself.
+type KeyRotator interface {
+	KeyProvider
+	// NewKeyVersion creates a new key of the version and makes it active.
+	NewKeyVersion(version string) error
+}
+
+// ExternalKeyProvider is a KeyProvider whose keys are kept out of this server, ex) given by the operator or by a KMS.
+// The data kept out of this server, ex) the remote MetaDB backups, is encrypted only with an external key,
+// so it can be decrypted after this server is lost.
+type ExternalKeyProvider interface {
+	KeyProvider
+	// External returns true if the keys are kept out of this server.
+	External() bool
+}
+
+// EncryptionKeyVersionInfo is a key version registered in the MetaDB by the Spider servers.
+// The key itself is not stored, only a check value to find a different key of the same version.
+type EncryptionKeyVersionInfo struct {
+	Version     string    `gorm:"primaryKey" json:"Version" example:"2"`
+	Provider    string    `json:"Provider" example:"file"`
+	KeyCheck    string    `json:"KeyCheck"` // base64 of HMAC-SHA256(key, "cb-spider-key-check")
+	Active      bool      `json:"Active"`   // the key version to encrypt new values
+	CreatedTime time.Time `json:"CreatedTime"`
+}
+
+func (EncryptionKeyVersionInfo) TableName() string {
+	return "encryption_key_version_infos"
+}
+
+var keyProviderFactories = map[string]func() (KeyProvider, error){
+	"env":  newEnvKeyProvider,
+	"file": newFileKeyProvider,
+}
+
+var (
+	keyProviderLock = new(sync.RWMutex)
+	keyProvider     KeyProvider
+)
+
+// RegisterKeyProvider registers a key provider, ex) a KMS client, to be selected by SPIDER_KEY_PROVIDER.
+func RegisterKeyProvider(name string, factory func() (KeyProvider, error)) {
+	keyProviderLock.Lock()
+	defer keyProviderLock.Unlock()
+	keyProviderFactories[name] = factory
+}
+
+// getKeyProvider returns the key provider of SPIDER_KEY_PROVIDER, or of the env which is set.
+func getKeyProvider() (KeyProvider, error) {
+	keyProviderLock.RLock()
+	provider := keyProvider
+	keyProviderLock.RUnlock()
+	if provider != nil {
+		return provider, nil
+	}
+
+	keyProviderLock.Lock()
+	defer keyProviderLock.Unlock()
+	if keyProvider != nil {
+		return keyProvider, nil
+	}
+
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("SPIDER_KEY_PROVIDER")))
+	if name == "" {
+		switch {
+		case os.Getenv("SPIDER_KEY") != "":
+			name = "env"
+		case os.Getenv("SPIDER_KEY_FILE") != "":
+			name = "file"
+		default:
+			cblog.Warn("no encryption key is configured, the built-in key is used. Set SPIDER_KEY or SPIDER_KEY_FILE.")
+			keyProvider = &legacyKeyProvider{}
+			return keyProvider, nil
+		}
+	}
+	factory, ok := keyProviderFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown SPIDER_KEY_PROVIDER: %s", name)
+	}
+	provider, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the %s key provider: %v", name, err)
+	}
+	if err := registerKeyVersions(provider); err != nil {
+		return nil, fmt.Errorf("failed to register the key versions of the %s key provider: %v", name, err)
+	}
+	keyProvider = provider
+	return keyProvider, nil
+}
+
+// getKeyCheck returns the check value of the key, which does not reveal the key.
+func getKeyCheck(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("cb-spider-key-check"))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// listKeyVersionInfo returns the key versions registered in the MetaDB.
+func listKeyVersionInfo() ([]*EncryptionKeyVersionInfo, error) {
+	var infoList []*EncryptionKeyVersionInfo
+	if err := infostore.List(&infoList); err != nil {
+		return nil, err
+	}
+	return infoList, nil
+}
+
+// registerKeyVersions registers the key versions of the provider in the MetaDB.
+// A version registered with a different key is an error, the servers must have the same key of a version.
+// The active version of the provider becomes the active one of the MetaDB, if it is a new version
+// (ex: SPIDER_KEY_VERSION is changed) or no version is active yet.
+func registerKeyVersions(provider KeyProvider) error {
+	activeVersion, _, err := provider.ActiveKey()
+	if err != nil {
+		return err
+	}
+
+	db, err := infostore.Open()
+	if err != nil {
+		return err
+	}
+	defer infostore.Close(db)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var infoList []*EncryptionKeyVersionInfo
+		if err := tx.Find(&infoList).Error; err != nil {
+			return err
+		}
+		registered := map[string]*EncryptionKeyVersionInfo{}
+		hasActive := false
+		for _, info := range infoList {
+			registered[info.Version] = info
+			hasActive = hasActive || info.Active
+		}
+
+		newActive := false
+		for _, version := range provider.Versions() {
+			key, err := provider.Key(version)
+			if err != nil {
+				return err
+			}
+			keyCheck := getKeyCheck(key)
+			if info, ok := registered[version]; ok {
+				if info.KeyCheck != keyCheck {
+					return fmt.Errorf("the key of the version '%s' is different from the one registered in the MetaDB by another server", version)
+				}
+				continue
+			}
+			info := &EncryptionKeyVersionInfo{Version: version, Provider: provider.Name(), KeyCheck: keyCheck, CreatedTime: time.Now()}
+			if err := tx.Create(info).Error; err != nil {
+				return err
+			}
+			newActive = newActive || version == activeVersion
+		}
+
+		if newActive || !hasActive {
+			return setActiveKeyVersion(tx, activeVersion)
+		}
+		return nil
+	})
+}
+
+// setActiveKeyVersion makes the version the only active one in the MetaDB.
+func setActiveKeyVersion(tx *gorm.DB, version string) error {
+	if err := tx.Model(&EncryptionKeyVersionInfo{}).Where("active = ?", true).Update("active", false).Error; err != nil {
+		return err
+	}
+	return tx.Model(&EncryptionKeyVersionInfo{}).Where("version = ?", version).Update("active", true).Error
+}
+
+// getActiveKey returns the active key version of the MetaDB and its key from the provider.
+// The built-in key is not registered, it is the same in all servers.
+func getActiveKey(provider KeyProvider) (string, []byte, error) {
+	if _, ok := provider.(*legacyKeyProvider); ok {
+		return provider.ActiveKey()
+	}
+
+	infoList, err := listKeyVersionInfo()
+	if err != nil {
+		return "", nil, err
+	}
+	for _, info := range infoList {
+		if !info.Active {
+			continue
+		}
+		key, err := provider.Key(info.Version)
+		if err != nil {
+			return "", nil, fmt.Errorf("the active key version '%s' of the MetaDB is not available in the %s key provider of this server: %v",
+				info.Version, provider.Name(), err)
+		}
+		return info.Version, key, nil
+	}
+	return provider.ActiveKey()
+}
+
+// ReloadKeyProvider drops the loaded key provider, it is loaded again with the current env when used.
+func ReloadKeyProvider() {
+	keyProviderLock.Lock()
+	defer keyProviderLock.Unlock()
+	keyProvider = nil
+}
+
+// parseKey accepts a 32-byte key or a base64 encoded 32-byte key.
+func parseKey(keyString string) ([]byte, error) {
+	if len(keyString) == keySize {
+		return []byte(keyString), nil
+	}
+	key, err := base64.StdEncoding.DecodeString(keyString)
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("the key should be %d bytes or base64 of %d bytes", keySize, keySize)
+	}
+	return key, nil
+}
+
+// EncryptValue encrypts the value with the active key and records the key version in it.
+func EncryptValue(value string) (string, error) {
+	provider, err := getKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	version, key, err := getActiveKey(provider)
+	if err != nil {
+		return "", err
+	}
+	encString, err := Encrypt(key, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	if version == LEGACY_KEY_VERSION {
+		// keep the old format, which can be read by older servers
+		return encString, nil
+	}
+	return encryptedValuePrefix + version + ":" + encString, nil
+}
+
+// DecryptValue decrypts the value with the key of the version recorded in it.
+func DecryptValue(value string) (string, error) {
+	version, encString := splitEncryptedValue(value)
+	key, err := getVersionKey(version)
+	if err != nil {
+		return "", err
+	}
+	return Decrypt(key, []byte(encString))
+}
+
+// getVersionKey returns the key of the version, "0" is the built-in key.
+func getVersionKey(version string) ([]byte, error) {
+	if version == LEGACY_KEY_VERSION {
+		return SPIDER_KEY, nil
+	}
+	provider, err := getKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+	return provider.Key(version)
+}
+
+// GetValueKeyVersion returns the key version of the encrypted value.
+func GetValueKeyVersion(value string) string {
+	version, _ := splitEncryptedValue(value)
+	return version
+}
+
+func splitEncryptedValue(value string) (string, string) {
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return LEGACY_KEY_VERSION, value
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, encryptedValuePrefix), ":", 2)
+	if len(parts) != 2 {
+		return LEGACY_KEY_VERSION, value
+	}
+	return parts[0], parts[1]
+}
+
+// ReEncryptValue re-encrypts the value with the active key.
+// It returns false if the value is already encrypted with the active key.
+func ReEncryptValue(value string) (string, bool, error) {
+	provider, err := getKeyProvider()
+	if err != nil {
+		return "", false, err
+	}
+	activeVersion, _, err := getActiveKey(provider)
+	if err != nil {
+		return "", false, err
+	}
+	if GetValueKeyVersion(value) == activeVersion {
+		return value, false, nil
+	}
+	plain, err := DecryptValue(value)
+	if err != nil {
+		return "", false, err
+	}
+	encValue, err := EncryptValue(plain)
+	if err != nil {
+		return "", false, err
+	}
+	return encValue, true, nil
+}
+
+// CheckExternalKey returns an error if the active key is not of an ExternalKeyProvider,
+// ex) the key ring file stored in this server, or the built-in key.
+func CheckExternalKey() error {
+	provider, err := getKeyProvider()
+	if err != nil {
+		return err
+	}
+	if external, ok := provider.(ExternalKeyProvider); ok && external.External() {
+		return nil
+	}
+	return fmt.Errorf("the keys of the key provider '%s' are kept in this server, an external key is required (SPIDER_KEY or a KMS key provider)",
+		provider.Name())
+}
+
+// dataChunkSize is the size of the plain chunks of an encrypted stream, see NewEncryptReader.
+const dataChunkSize = 64 * 1024
+
+// NewEncryptReader encrypts large data, ex) a backup file, with the active key as a stream.
+// size is the exact size of src, and the size of the encrypted stream is returned.
+// The stream is "spkd:<version>:" + nonce prefix + chunks of (flag, length, AES-GCM sealed chunk),
+// each chunk is authenticated with its sequence number and the last chunk is marked, so a truncated stream is detected.
+func NewEncryptReader(src io.Reader, size int64) (io.Reader, int64, error) {
+	provider, err := getKeyProvider()
+	if err != nil {
+		return nil, 0, err
+	}
+	version, key, err := getActiveKey(provider)
+	if err != nil {
+		return nil, 0, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	header := []byte(encryptedDataPrefix + version + ":")
+	noncePrefix := make([]byte, gcm.NonceSize()-4)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, 0, err
+	}
+	chunkCount := (size + dataChunkSize - 1) / dataChunkSize
+	if chunkCount == 0 {
+		chunkCount = 1
+	}
+	encSize := int64(len(header)+len(noncePrefix)) + chunkCount*int64(5+gcm.Overhead()) + size
+
+	reader := &encryptReader{src: src, remain: size, gcm: gcm, header: header, noncePrefix: noncePrefix}
+	reader.buf = append(append([]byte{}, header...), noncePrefix...)
+	return reader, encSize, nil
+}
+
+type encryptReader struct {
+	src         io.Reader
+	remain      int64 // plain bytes to read from src
+	gcm         cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	seq         uint32
+	buf         []byte // encrypted bytes not read yet
+	done        bool   // the last chunk is sealed
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		chunkSize := int64(dataChunkSize)
+		if r.remain < chunkSize {
+			chunkSize = r.remain
+		}
+		chunk := make([]byte, chunkSize)
+		if _, err := io.ReadFull(r.src, chunk); err != nil {
+			return 0, fmt.Errorf("failed to read the data to encrypt: %v", err)
+		}
+		r.remain -= chunkSize
+		flag := byte(0)
+		if r.remain == 0 {
+			flag = 1
+			r.done = true
+		}
+		sealed := r.gcm.Seal(nil, chunkNonce(r.noncePrefix, r.seq), chunk, append(append([]byte{}, r.header...), flag))
+		r.seq++
+		r.buf = append([]byte{flag}, binary.BigEndian.AppendUint32(nil, uint32(len(sealed)))...)
+		r.buf = append(r.buf, sealed...)
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// chunkNonce returns the nonce of the chunk of the sequence number.
+func chunkNonce(noncePrefix []byte, seq uint32) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte{}, noncePrefix...), seq)
+}
+
+// NewDecryptReader decrypts the stream encrypted by NewEncryptReader with the key of the version recorded in it.
+// A Read returns an error if a chunk is tampered, or if the stream is truncated or has trailing data.
+func NewDecryptReader(src io.Reader) (io.Reader, error) {
+	reader := bufio.NewReader(src)
+	prefix := make([]byte, len(encryptedDataPrefix))
+	if _, err := io.ReadFull(reader, prefix); err != nil || string(prefix) != encryptedDataPrefix {
+		return nil, fmt.Errorf("not an encrypted data of CB-Spider")
+	}
+	version, err := reader.ReadString(':')
+	if err != nil || len(version) > 64 {
+		return nil, fmt.Errorf("no key version in the encrypted data")
+	}
+	version = strings.TrimSuffix(version, ":")
+
+	key, err := getVersionKey(version)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	noncePrefix := make([]byte, gcm.NonceSize()-4)
+	if _, err := io.ReadFull(reader, noncePrefix); err != nil {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+	return &decryptReader{src: reader, gcm: gcm, version: version,
+		header: []byte(encryptedDataPrefix + version + ":"), noncePrefix: noncePrefix}, nil
+}
+
+type decryptReader struct {
+	src         *bufio.Reader
+	gcm         cipher.AEAD
+	version     string
+	header      []byte
+	noncePrefix []byte
+	seq         uint32
+	buf         []byte // decrypted bytes not read yet
+	done        bool   // the last chunk is opened
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			if _, err := r.src.ReadByte(); err != io.EOF {
+				return 0, fmt.Errorf("encrypted data has trailing bytes after the last chunk")
+			}
+			return 0, io.EOF
+		}
+		chunkHeader := make([]byte, 5)
+		if _, err := io.ReadFull(r.src, chunkHeader); err != nil {
+			return 0, fmt.Errorf("encrypted data is truncated: %v", err)
+		}
+		flag := chunkHeader[0]
+		sealedSize := binary.BigEndian.Uint32(chunkHeader[1:])
+		if flag > 1 || sealedSize < uint32(r.gcm.Overhead()) || sealedSize > uint32(dataChunkSize+r.gcm.Overhead()) {
+			return 0, fmt.Errorf("invalid chunk in the encrypted data")
+		}
+		sealed := make([]byte, sealedSize)
+		if _, err := io.ReadFull(r.src, sealed); err != nil {
+			return 0, fmt.Errorf("encrypted data is truncated: %v", err)
+		}
+		chunk, err := r.gcm.Open(nil, chunkNonce(r.noncePrefix, r.seq), sealed, append(append([]byte{}, r.header...), flag))
+		if err != nil {
+			return 0, fmt.Errorf("failed to decrypt the data with the key version '%s': %v", r.version, err)
+		}
+		r.seq++
+		r.buf = chunk
+		r.done = flag == 1
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptionKeyInfo represents the status of the encryption keys.
+type EncryptionKeyInfo struct {
+	Provider        string   `json:"Provider" example:"file"`
+	ActiveVersion   string   `json:"ActiveVersion" example:"2"`             // active version of the MetaDB
+	Versions        []string `json:"Versions" example:"0,1,2"`              // "0" is the built-in key
+	MissingVersions []string `json:"MissingVersions,omitempty" example:"3"` // versions of the MetaDB not available in this server
+	Rotatable       bool     `json:"Rotatable" example:"true"`              // the provider can create a new key version
+}
+
+// GetEncryptionKeyInfo returns the status of the encryption keys. The keys are not included.
+func GetEncryptionKeyInfo() (*EncryptionKeyInfo, error) {
+	provider, err := getKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+	activeVersion, _, err := provider.ActiveKey()
+	if err != nil {
+		return nil, err
+	}
+	versions := provider.Versions()
+	var missingVersions []string
+	if _, ok := provider.(*legacyKeyProvider); !ok {
+		infoList, err := listKeyVersionInfo()
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infoList {
+			if info.Active {
+				activeVersion = info.Version
+			}
+			if !containsString(versions, info.Version) {
+				missingVersions = append(missingVersions, info.Version)
+			}
+		}
+	}
+	if !containsString(versions, LEGACY_KEY_VERSION) {
+		versions = append([]string{LEGACY_KEY_VERSION}, versions...)
+	}
+	_, rotatable := provider.(KeyRotator)
+	return &EncryptionKeyInfo{
+		Provider:        provider.Name(),
+		ActiveVersion:   activeVersion,
+		Versions:        versions,
+		MissingVersions: missingVersions,
+		Rotatable:       rotatable,
+	}, nil
+}
+
+// NewKeyVersion creates a new key version, if the provider supports it, and makes it active in the MetaDB.
+// The new version is greater than all versions of the MetaDB, so it does not collide with a version
+// created by another server. The caller should hold the distributed lock of the key rotation.
+func NewKeyVersion() (string, error) {
+	provider, err := getKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	rotator, ok := provider.(KeyRotator)
+	if !ok {
+		return "", fmt.Errorf("the %s key provider cannot create a new key version, change its active key and restart the server", provider.Name())
+	}
+
+	infoList, err := listKeyVersionInfo()
+	if err != nil {
+		return "", err
+	}
+	maxVersion := 0
+	for _, version := range provider.Versions() {
+		if n, err := strconv.Atoi(version); err == nil && n > maxVersion {
+			maxVersion = n
+		}
+	}
+	for _, info := range infoList {
+		if n, err := strconv.Atoi(info.Version); err == nil && n > maxVersion {
+			maxVersion = n
+		}
+	}
+	newVersion := strconv.Itoa(maxVersion + 1)
+
+	if err := rotator.NewKeyVersion(newVersion); err != nil {
+		return "", err
+	}
+	if err := registerKeyVersions(provider); err != nil {
+		return "", err
+	}
+	return newVersion, nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ================ legacy provider: the built-in key only
+
+type legacyKeyProvider struct{}
+
+func (*legacyKeyProvider) Name() string { return "builtin" }
+
+func (*legacyKeyProvider) ActiveKey() (string, []byte, error) {
+	return LEGACY_KEY_VERSION, SPIDER_KEY, nil
+}
+
+func (*legacyKeyProvider) Key(version string) ([]byte, error) {
+	if version != LEGACY_KEY_VERSION {
+		return nil, fmt.Errorf("encryption key version '%s' is not available", version)
+	}
+	return SPIDER_KEY, nil
+}
+
+func (*legacyKeyProvider) Versions() []string { return []string{LEGACY_KEY_VERSION} }
+
+// ================ env provider
+
+type envKeyProvider struct {
+	activeVersion string
+	keys          map[string][]byte
+}
+
+func newEnvKeyProvider() (KeyProvider, error) {
+	activeKey, err := parseKey(os.Getenv("SPIDER_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("SPIDER_KEY: %v", err)
+	}
+	activeVersion := strings.TrimSpace(os.Getenv("SPIDER_KEY_VERSION"))
+	if activeVersion == "" {
+		activeVersion = "1"
+	}
+	if err := checkKeyVersion(activeVersion); err != nil {
+		return nil, fmt.Errorf("SPIDER_KEY_VERSION: %v", err)
+	}
+
+	provider := &envKeyProvider{activeVersion: activeVersion, keys: map[string][]byte{activeVersion: activeKey}}
+	for _, item := range strings.Split(os.Getenv("SPIDER_KEY_PREVIOUS"), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("SPIDER_KEY_PREVIOUS should be 'version:key,...'")
+		}
+		if err := checkKeyVersion(parts[0]); err != nil {
+			return nil, fmt.Errorf("SPIDER_KEY_PREVIOUS: %v", err)
+		}
+		key, err := parseKey(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("SPIDER_KEY_PREVIOUS of version '%s': %v", parts[0], err)
+		}
+		if _, ok := provider.keys[parts[0]]; !ok {
+			provider.keys[parts[0]] = key
+		}
+	}
+	return provider, nil
+}
+
+func (*envKeyProvider) Name() string { return "env" }
+
+// External returns true, the keys are given by the operator.
+func (*envKeyProvider) External() bool { return true }
+
+func (p *envKeyProvider) ActiveKey() (string, []byte, error) {
+	return p.activeVersion, p.keys[p.activeVersion], nil
+}
+
+func (p *envKeyProvider) Key(version string) ([]byte, error) {
+	key, ok := p.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("encryption key version '%s' is not available, set it in SPIDER_KEY_PREVIOUS", version)
+	}
+	return key, nil
+}
+
+func (p *envKeyProvider) Versions() []string {
+	return sortedVersions(p.keys)
+}
+
+// checkKeyVersion checks the key version, which is a part of the encrypted values.
+func checkKeyVersion(version string) error {
+	if version == "" || version == LEGACY_KEY_VERSION || strings.ContainsAny(version, ":, ") {
+		return fmt.Errorf("invalid key version '%s', it should not be empty, '0' or have ':', ',' or spaces", version)
+	}
+	return nil
+}
+
+func sortedVersions(keys map[string][]byte) []string {
+	versions := make([]string, 0, len(keys))
+	for version := range keys {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		ni, errI := strconv.Atoi(versions[i])
+		nj, errJ := strconv.Atoi(versions[j])
+		if errI == nil && errJ == nil {
+			return ni < nj
+		}
+		return versions[i] < versions[j]
+	})
+	return versions
+}
+
+// ================ file provider: a local key ring, usable offline
+
+// keyRingFile is the format of the key ring file.
+type keyRingFile struct {
+	ActiveVersion string            `json:"ActiveVersion"`
+	Keys          map[string]string `json:"Keys"` // version: base64 key
+}
+
+type fileKeyProvider struct {
+	lock          sync.RWMutex
+	path          string
+	activeVersion string
+	keys          map[string][]byte
+}
+
+func getKeyRingFilePath() string {
+	if path := os.Getenv("SPIDER_KEY_FILE"); path != "" {
+		return path
+	}
+	return filepath.Join(os.Getenv("CBSPIDER_ROOT"), "conf", "spider-keyring.json")
+}
+
+func newFileKeyProvider() (KeyProvider, error) {
+	provider := &fileKeyProvider{path: getKeyRingFilePath(), keys: map[string][]byte{}}
+
+	activeVersion, keys, err := readKeyRingFile(provider.path)
+	if os.IsNotExist(err) {
+		// a new key ring is created only for a MetaDB without key versions,
+		// otherwise the key ring of the other servers should be given to this server.
+		infoList, err := listKeyVersionInfo()
+		if err != nil {
+			return nil, err
+		}
+		if len(infoList) > 0 {
+			return nil, fmt.Errorf("%s does not exist, but the MetaDB has key versions of other servers. Copy their key ring file to it", provider.path)
+		}
+		if err := provider.NewKeyVersion("1"); err != nil {
+			return nil, err
+		}
+		cblog.Infof("a new key ring is created: %s", provider.path)
+		return provider, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	provider.activeVersion = activeVersion
+	provider.keys = keys
+	return provider, nil
+}
+
+// readKeyRingFile returns the active version and the keys of the key ring file.
+func readKeyRingFile(path string) (string, map[string][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var ring keyRingFile
+	if err := json.Unmarshal(data, &ring); err != nil {
+		return "", nil, fmt.Errorf("%s: %v", path, err)
+	}
+	keys := map[string][]byte{}
+	for version, keyString := range ring.Keys {
+		if err := checkKeyVersion(version); err != nil {
+			return "", nil, fmt.Errorf("%s: %v", path, err)
+		}
+		key, err := parseKey(keyString)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: key version '%s': %v", path, version, err)
+		}
+		keys[version] = key
+	}
+	if _, ok := keys[ring.ActiveVersion]; !ok {
+		return "", nil, fmt.Errorf("%s: the active key version '%s' does not exist", path, ring.ActiveVersion)
+	}
+	return ring.ActiveVersion, keys, nil
+}
+
+func (*fileKeyProvider) Name() string { return "file" }
+
+func (p *fileKeyProvider) ActiveKey() (string, []byte, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.activeVersion, p.keys[p.activeVersion], nil
+}
+
+// Key returns the key of the version. The key ring file is read again if the version is not loaded,
+// because another server may have added it to a shared key ring file.
+func (p *fileKeyProvider) Key(version string) ([]byte, error) {
+	p.lock.RLock()
+	key, ok := p.keys[version]
+	p.lock.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, keys, err := readKeyRingFile(p.path); err == nil {
+		for v, k := range keys {
+			if _, ok := p.keys[v]; !ok {
+				p.keys[v] = k
+			}
+		}
+	}
+	key, ok = p.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("encryption key version '%s' does not exist in %s", version, p.path)
+	}
+	return key, nil
+}
+
+func (p *fileKeyProvider) Versions() []string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return sortedVersions(p.keys)
+}
+
+// NewKeyVersion adds a new random key of the version to the key ring file and makes it active.
+func (p *fileKeyProvider) NewKeyVersion(newVersion string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := checkKeyVersion(newVersion); err != nil {
+		return err
+	}
+	if _, ok := p.keys[newVersion]; ok {
+		return fmt.Errorf("encryption key version '%s' already exists in %s", newVersion, p.path)
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	ring := keyRingFile{ActiveVersion: newVersion, Keys: map[string]string{}}
+	for version, k := range p.keys {
+		ring.Keys[version] = base64.StdEncoding.EncodeToString(k)
+	}
+	ring.Keys[newVersion] = base64.StdEncoding.EncodeToString(key)
+	if err := writeKeyRingFile(p.path, &ring); err != nil {
+		return err
+	}
+
+	p.keys[newVersion] = key
+	p.activeVersion = newVersion
+	return nil
+}
+
+// writeKeyRingFile replaces the key ring file atomically, readable only by the owner.
+func writeKeyRingFile(path string, ring *keyRingFile) error {
+	data, err := json.MarshalIndent(ring, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
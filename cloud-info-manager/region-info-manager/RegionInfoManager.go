@@ -46,7 +46,7 @@ func init() {
 	if err != nil {
 		panic("failed to connect database")
 	}
-	db.AutoMigrate(&RegionInfo{})
+	infostore.AutoMigrate(db, &RegionInfo{})
 	infostore.Close(db)
 }
 
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -180,6 +181,59 @@ func Ping() error {
 	return nil
 }
 
+// tableModels are the models of the MetaDB tables, which are copied by the backup restore and the MetaDB migration.
+var tableModels []interface{}
+var tableModelsLock sync.Mutex
+
+// AutoMigrate migrates the tables of the models, and registers them as the MetaDB tables.
+func AutoMigrate(db *gorm.DB, models ...interface{}) error {
+	tableModelsLock.Lock()
+	for _, model := range models {
+		registered := false
+		for _, tableModel := range tableModels {
+			if reflect.TypeOf(tableModel) == reflect.TypeOf(model) {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			tableModels = append(tableModels, model)
+		}
+	}
+	tableModelsLock.Unlock()
+
+	return db.AutoMigrate(models...)
+}
+
+// AutoMigrateSecurity migrates and registers the tables of the security and audit models, ex) users, tokens and audit logs.
+// Their rows are never replaced: the backup restore keeps the current rows, and the MetaDB migration merges the rows.
+func AutoMigrateSecurity(db *gorm.DB, models ...interface{}) error {
+	tableModelsLock.Lock()
+	for _, model := range models {
+		securityModels[reflect.TypeOf(model)] = true
+	}
+	tableModelsLock.Unlock()
+
+	return AutoMigrate(db, models...)
+}
+
+// securityModels are the types of the models registered by AutoMigrateSecurity.
+var securityModels = map[reflect.Type]bool{}
+
+// getTableModels returns the models of the MetaDB tables.
+func getTableModels() []interface{} {
+	tableModelsLock.Lock()
+	defer tableModelsLock.Unlock()
+	return append([]interface{}{}, tableModels...)
+}
+
+// isSecurityModel returns true if the model is registered by AutoMigrateSecurity.
+func isSecurityModel(model interface{}) bool {
+	tableModelsLock.Lock()
+	defer tableModelsLock.Unlock()
+	return securityModels[reflect.TypeOf(model)]
+}
+
 // KVList represents a list of key-value pairs.
 // @Description A list of key-value pairs, where each entry is a key and its associated value.
 type KVList []icdrs.KeyValue
@@ -47,6 +47,11 @@ func backupSQLiteMetaDB(backupDir string) (string, error) {
 	timestamp := time.Now().Format(BACKUP_TIME_FORMAT)
 	backupFileName := BACKUP_FILE_PREFIX + timestamp + SQLITE_BACKUP_FILE_SUFFIX
 	backupFilePath := filepath.Join(backupDir, backupFileName)
+	// e.g., a backup before a restore in the same second as the scheduled backup
+	for n := 1; fileExists(backupFilePath); n++ {
+		backupFileName = fmt.Sprintf("%s%s_%d%s", BACKUP_FILE_PREFIX, timestamp, n, SQLITE_BACKUP_FILE_SUFFIX)
+		backupFilePath = filepath.Join(backupDir, backupFileName)
+	}
 
 	// Open a direct connection to the source DB for VACUUM INTO
 	srcDB, err := sql.Open("sqlite", DB_FILE_PATH+"?_busy_timeout=60000")
@@ -120,3 +125,9 @@ func listBackupFiles(backupDir string) ([]string, error) {
 
 	return backups, nil
 }
+
+// fileExists returns true if the file exists.
+func fileExists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
@@ -21,6 +21,16 @@ type BackupConfig struct {
 	Interval  time.Duration // Backup interval (default: 6h)
 	BackupDir string        // Backup directory path (default: $CBSPIDER_ROOT/meta_db/backups)
 	MaxCount  int           // Maximum number of backup files to retain (default: 10)
+
+	// Optional remote backup target in an object storage, registered as an S3 bucket of a connection.
+	S3ConnectionName string // Connection config of the bucket
+	S3BucketName     string // Bucket name registered in Spider
+	S3Prefix         string // Prefix of the backup objects (default: cb-spider/metadb-backups/)
+}
+
+// RemoteEnabled returns true if the remote backup target is set.
+func (cfg BackupConfig) RemoteEnabled() bool {
+	return cfg.S3ConnectionName != "" && cfg.S3BucketName != ""
 }
 
 // Default values for backup configuration
@@ -29,6 +39,7 @@ const (
 	DEFAULT_BACKUP_INTERVAL  = 6 * time.Hour
 	DEFAULT_BACKUP_MAX_COUNT = 10
 	DEFAULT_BACKUP_DIR_NAME  = "backups"
+	DEFAULT_BACKUP_S3_PREFIX = "cb-spider/metadb-backups/"
 )
 
 // LoadBackupConfig loads backup configuration from environment variables.
@@ -40,6 +51,7 @@ func LoadBackupConfig() BackupConfig {
 		Interval:  DEFAULT_BACKUP_INTERVAL,
 		BackupDir: os.Getenv("CBSPIDER_ROOT") + "/meta_db/" + DEFAULT_BACKUP_DIR_NAME,
 		MaxCount:  DEFAULT_BACKUP_MAX_COUNT,
+		S3Prefix:  DEFAULT_BACKUP_S3_PREFIX,
 	}
 
 	// External PostgreSQL MetaDB is user-managed, so Spider backup scheduler is disabled.
@@ -91,5 +103,19 @@ func LoadBackupConfig() BackupConfig {
 		}
 	}
 
+	// SPIDER_BACKUP_S3_CONNECTION, SPIDER_BACKUP_S3_BUCKET, SPIDER_BACKUP_S3_PREFIX
+	cfg.S3ConnectionName = strings.TrimSpace(os.Getenv("SPIDER_BACKUP_S3_CONNECTION"))
+	cfg.S3BucketName = strings.TrimSpace(os.Getenv("SPIDER_BACKUP_S3_BUCKET"))
+	if (cfg.S3ConnectionName == "") != (cfg.S3BucketName == "") {
+		cblog.Warn("[MSB] Both SPIDER_BACKUP_S3_CONNECTION and SPIDER_BACKUP_S3_BUCKET are required for the remote backup, remote backup is disabled.")
+		cfg.S3ConnectionName, cfg.S3BucketName = "", ""
+	}
+	if v := strings.TrimSpace(os.Getenv("SPIDER_BACKUP_S3_PREFIX")); v != "" {
+		cfg.S3Prefix = strings.TrimPrefix(v, "/")
+		if !strings.HasSuffix(cfg.S3Prefix, "/") {
+			cfg.S3Prefix += "/"
+		}
+	}
+
 	return cfg
 }
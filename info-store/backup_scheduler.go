@@ -10,7 +10,9 @@ package infostore
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -26,6 +28,10 @@ func StartBackupScheduler(ctx context.Context, cfg BackupConfig) {
 
 	cblog.Infof("[MSB] Meta DB Backup Scheduler started. interval=%v, maxCount=%d, dir=%s",
 		cfg.Interval, cfg.MaxCount, cfg.BackupDir)
+	if cfg.RemoteEnabled() {
+		cblog.Infof("[MSB] Meta DB backups are uploaded to the bucket '%s' of '%s' with the prefix '%s'",
+			cfg.S3BucketName, cfg.S3ConnectionName, cfg.S3Prefix)
+	}
 
 	go func() {
 		// Perform an immediate backup on startup
@@ -77,4 +83,39 @@ func performBackup(cfg BackupConfig) {
 	if err := RotateBackups(cfg.BackupDir, cfg.MaxCount); err != nil {
 		cblog.Errorf("[MSB] Backup rotation failed: %v", err)
 	}
+
+	// Upload the backup to the remote target, with the same retention
+	if cfg.RemoteEnabled() {
+		if err := UploadBackup(cfg, backupPath); err != nil {
+			cblog.Errorf("[MSB] Remote backup failed: %v", err)
+		}
+	}
+}
+
+// RemoteBackupHandler uploads a backup file to the remote target and removes the oldest remote backups over cfg.MaxCount.
+type RemoteBackupHandler func(cfg BackupConfig, backupPath string) error
+
+var remoteBackupHandler RemoteBackupHandler
+
+// SetRemoteBackupHandler sets the handler of the remote backup, which is implemented over the S3 API of Spider.
+func SetRemoteBackupHandler(handler RemoteBackupHandler) {
+	remoteBackupHandler = handler
+}
+
+// UploadBackup uploads the backup file to the remote target of cfg.
+func UploadBackup(cfg BackupConfig, backupPath string) error {
+	if !cfg.RemoteEnabled() {
+		return fmt.Errorf("remote backup target is not set (SPIDER_BACKUP_S3_CONNECTION, SPIDER_BACKUP_S3_BUCKET)")
+	}
+	if remoteBackupHandler == nil {
+		return fmt.Errorf("remote backup is not available")
+	}
+
+	startTime := time.Now()
+	if err := remoteBackupHandler(cfg, backupPath); err != nil {
+		return err
+	}
+	cblog.Infof("[MSB] Meta DB backup is uploaded to the bucket '%s': %s (took %v)",
+		cfg.S3BucketName, filepath.Base(backupPath), time.Since(startTime))
+	return nil
 }
@@ -0,0 +1,220 @@
+// Meta DB Restore Logic for CB-Spider
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2026.10.
+
+package infostore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupFileInfo represents a MetaDB backup file.
+type BackupFileInfo struct {
+	FileName    string    `json:"FileName" example:"cb-spider_backup_20261018_120000.db"`
+	Size        int64     `json:"Size" example:"1048576"` // bytes
+	CreatedTime time.Time `json:"CreatedTime" example:"2026-10-18T12:00:00Z"`
+}
+
+// BackupValidationInfo represents the result of a backup file validation.
+type BackupValidationInfo struct {
+	FileName       string         `json:"FileName" example:"cb-spider_backup_20261018_120000.db"`
+	Valid          bool           `json:"Valid" example:"true"`                             // the backup can be restored
+	IntegrityCheck string         `json:"IntegrityCheck,omitempty" example:"ok"`            // result of the SQLite integrity check
+	Tables         []TableRowInfo `json:"Tables"`                                           // MetaDB tables in the backup with the number of rows
+	MissingTables  []string       `json:"MissingTables,omitempty"`                          // MetaDB tables which are not in the backup
+	Error          string         `json:"Error,omitempty" example:"file is not a database"` // reason of an invalid backup
+}
+
+// ListBackups returns the backup files in the backupDir, newest first.
+func ListBackups(backupDir string) ([]*BackupFileInfo, error) {
+	backups, err := listBackupFiles(backupDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup files: %w", err)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	backupList := []*BackupFileInfo{}
+	for _, name := range backups {
+		backupInfo, err := GetBackupFileInfo(backupDir, name)
+		if err != nil {
+			cblog.Warnf("[MSB] %v", err)
+			continue
+		}
+		backupList = append(backupList, backupInfo)
+	}
+	return backupList, nil
+}
+
+// GetBackupFileInfo returns the info of the backup file in the backupDir.
+func GetBackupFileInfo(backupDir string, fileName string) (*BackupFileInfo, error) {
+	filePath, err := getBackupFilePath(backupDir, fileName)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &BackupFileInfo{
+		FileName:    fileName,
+		Size:        stat.Size(),
+		CreatedTime: stat.ModTime(),
+	}, nil
+}
+
+// CheckBackupFileName returns an error if the fileName is not a name of the backup files.
+func CheckBackupFileName(fileName string) error {
+	if fileName != filepath.Base(fileName) || !strings.HasPrefix(fileName, BACKUP_FILE_PREFIX) ||
+		!strings.HasSuffix(fileName, SQLITE_BACKUP_FILE_SUFFIX) {
+		return fmt.Errorf("invalid backup file name '%s' (ex: %s%s%s)", fileName,
+			BACKUP_FILE_PREFIX, "20261018_120000", SQLITE_BACKUP_FILE_SUFFIX)
+	}
+	return nil
+}
+
+// getBackupFilePath returns the path of the backup file, only a backup file in the backupDir is allowed.
+func getBackupFilePath(backupDir string, fileName string) (string, error) {
+	if err := CheckBackupFileName(fileName); err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(backupDir, fileName)
+	if _, err := os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("backup file '%s' does not exist", fileName)
+		}
+		return "", err
+	}
+	return filePath, nil
+}
+
+// SaveBackupFile writes the backup file, ex) downloaded from the remote target, into the backupDir.
+// The file is replaced atomically if it exists, and nothing is written if reading the data fails.
+func SaveBackupFile(backupDir string, fileName string, reader io.Reader) error {
+	if err := CheckBackupFileName(fileName); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory '%s': %w", backupDir, err)
+	}
+
+	tmpFile, err := os.CreateTemp(backupDir, "."+fileName+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := io.Copy(tmpFile, reader); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), filepath.Join(backupDir, fileName))
+}
+
+// ValidateBackup checks the integrity and the tables of the backup file.
+// An error is returned only if the file is not found. A broken file is reported as not valid.
+func ValidateBackup(backupDir string, fileName string) (*BackupValidationInfo, error) {
+	filePath, err := getBackupFilePath(backupDir, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &BackupValidationInfo{FileName: fileName, Tables: []TableRowInfo{}}
+	invalid := func(err error) (*BackupValidationInfo, error) {
+		validation.Error = err.Error()
+		return validation, nil
+	}
+
+	backupDB, err := openMetaDB(filePath, true)
+	if err != nil {
+		return invalid(err)
+	}
+	defer backupDB.close()
+
+	var checkResults []string
+	if err := backupDB.db.Raw("PRAGMA integrity_check").Scan(&checkResults).Error; err != nil {
+		return invalid(err)
+	}
+	validation.IntegrityCheck = strings.Join(checkResults, "; ")
+	if validation.IntegrityCheck != "ok" {
+		return invalid(fmt.Errorf("integrity check failed"))
+	}
+
+	for _, model := range getTableModels() {
+		table, err := getTableName(backupDB.db, model)
+		if err != nil {
+			return invalid(err)
+		}
+		if !backupDB.db.Migrator().HasTable(table) {
+			validation.MissingTables = append(validation.MissingTables, table)
+			continue
+		}
+		var count int64
+		if err := backupDB.db.Table(table).Count(&count).Error; err != nil {
+			return invalid(fmt.Errorf("failed to read the table '%s': %w", table, err))
+		}
+		validation.Tables = append(validation.Tables, TableRowInfo{Table: table, Rows: count})
+	}
+	if len(validation.Tables) == 0 {
+		return invalid(fmt.Errorf("no CB-Spider MetaDB table in the backup"))
+	}
+
+	validation.Valid = true
+	return validation, nil
+}
+
+// RestoreMetaDB replaces the tables of the MetaDB with the tables of the backup file.
+// The backup is validated first, and the current SQLite MetaDB is backed up before the restore.
+// Tables which are not in the backup are kept as they are,
+// and the security and audit tables are kept too, a backup must not bring back revoked tokens or deleted users.
+func RestoreMetaDB(backupDir string, fileName string) (*MetaDBCopyResult, error) {
+	validation, err := ValidateBackup(backupDir, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if !validation.Valid {
+		return nil, fmt.Errorf("backup file '%s' is not valid: %s", fileName, validation.Error)
+	}
+
+	safetyBackup := ""
+	if IsSQLite() {
+		backupPath, err := BackupMetaDB(backupDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up the current MetaDB before the restore: %w", err)
+		}
+		safetyBackup = filepath.Base(backupPath)
+		cblog.Infof("[MSB] Current MetaDB is backed up before the restore: %s", backupPath)
+	}
+
+	backupDB, err := openMetaDB(filepath.Join(backupDir, fileName), true)
+	if err != nil {
+		return nil, err
+	}
+	defer backupDB.close()
+
+	currentDB, err := openMetaDB("", false)
+	if err != nil {
+		return nil, err
+	}
+
+	cblog.Infof("[MSB] Restoring the MetaDB from the backup: %s", fileName)
+	result, err := copyTables(backupDB, currentDB, true, false)
+	if err != nil {
+		cblog.Errorf("[MSB] MetaDB restore failed: %v", err)
+		return nil, err
+	}
+	result.SafetyBackup = safetyBackup
+	cblog.Infof("[MSB] MetaDB restore completed: %d tables (took %s)", len(result.Tables), result.ElapsedTime)
+	return result, nil
+}